@@ -302,11 +302,14 @@ type AllocationMetric struct {
 	DimensionExhausted map[string]int
 	QuotaExhausted     []string
 	ResourcesExhausted map[string]*Resources
+	GangUnsatisfiable  string
+	PreemptionBlocked  map[string]int
 	// Deprecated, replaced with ScoreMetaData
 	Scores            map[string]float64
 	AllocationTime    time.Duration
 	CoalescedFailures int
 	ScoreMetaData     []*NodeScoreMeta
+	NodeExplanations  map[string]*NodeExplanation
 }
 
 // NodeScoreMeta is used to serialize node scoring metadata
@@ -315,6 +318,30 @@ type NodeScoreMeta struct {
 	NodeID    string
 	Scores    map[string]float64
 	NormScore float64
+	Labels    map[string]string
+}
+
+// NodeExplanation records what happened to a node visited by the scheduler
+// while placing an allocation, when a job plan was asked to explain its
+// placements.
+type NodeExplanation struct {
+	NodeID    string
+	NodeName  string
+	Filtered  string
+	Exhausted string
+	Scores    map[string]float64
+	NormScore float64
+	Labels    map[string]string
+}
+
+// PlacementExplanation explains why an allocation of a job plan was placed
+// on its node.
+type PlacementExplanation struct {
+	AllocName string
+	TaskGroup string
+	NodeID    string
+	NodeName  string
+	Metrics   *AllocationMetric
 }
 
 // Stub returns a list stub for the allocation
@@ -432,10 +459,11 @@ type AllocatedResources struct {
 }
 
 type AllocatedTaskResources struct {
-	Cpu      AllocatedCpuResources
-	Memory   AllocatedMemoryResources
-	Networks []*NetworkResource
-	Devices  []*AllocatedDeviceResource
+	Cpu             AllocatedCpuResources
+	Memory          AllocatedMemoryResources
+	Networks        []*NetworkResource
+	Devices         []*AllocatedDeviceResource
+	CustomResources map[string]uint64
 }
 
 type AllocatedSharedResources struct {
@@ -588,6 +616,14 @@ type DesiredTransition struct {
 	// MigrateDisablePlacement is used to indicate that this allocation
 	// should not be placed during migration.
 	MigrateDisablePlacement *bool
+
+	// Rebalance is used to indicate that the migration was requested by the
+	// rebalancer.
+	Rebalance *bool
+
+	// RebalanceTargetNodeID is the node the rebalancer prefers for the
+	// migrated allocation.
+	RebalanceTargetNodeID string
 }
 
 // ShouldMigrate returns whether the transition object dictates a migration.
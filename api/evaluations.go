@@ -88,11 +88,12 @@ func (e *Evaluations) Allocations(evalID string, q *QueryOptions) ([]*Allocation
 }
 
 const (
-	EvalStatusBlocked   = "blocked"
-	EvalStatusPending   = "pending"
-	EvalStatusComplete  = "complete"
-	EvalStatusFailed    = "failed"
-	EvalStatusCancelled = "canceled"
+	EvalStatusBlocked           = "blocked"
+	EvalStatusDependencyBlocked = "dependency-blocked"
+	EvalStatusPending           = "pending"
+	EvalStatusComplete          = "complete"
+	EvalStatusFailed            = "failed"
+	EvalStatusCancelled         = "canceled"
 )
 
 // Evaluation is used to serialize an evaluation.
@@ -121,6 +122,7 @@ type Evaluation struct {
 	EscapedComputedClass bool
 	QuotaLimitReached    string
 	AnnotatePlan         bool
+	ExplainPlan          bool
 	QueuedAllocations    map[string]int
 	SnapshotIndex        uint64
 	CreateIndex          uint64
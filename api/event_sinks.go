@@ -0,0 +1,167 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+const (
+	// EventSinkWebhook is the type of sinks that POST events to an HTTP
+	// endpoint.
+	EventSinkWebhook = "webhook"
+
+	// EventSinkFile is the type of sinks that append events as newline
+	// delimited JSON to a file on the leader.
+	EventSinkFile = "file"
+)
+
+// EventSinks is used to access the durable event sink endpoints.
+type EventSinks struct {
+	client *Client
+}
+
+// EventSinks returns a handle on the durable event sink endpoints.
+func (c *Client) EventSinks() *EventSinks {
+	return &EventSinks{client: c}
+}
+
+// List is used to list all event sinks.
+func (e *EventSinks) List(q *QueryOptions) ([]*EventSink, *QueryMeta, error) {
+	var resp []*EventSink
+	qm, err := e.client.query("/v1/event/sinks", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// PrefixList is used to list event sinks that match a given ID prefix.
+func (e *EventSinks) PrefixList(prefix string, q *QueryOptions) ([]*EventSink, *QueryMeta, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	q.Prefix = prefix
+	return e.List(q)
+}
+
+// Info is used to fetch a specific event sink.
+func (e *EventSinks) Info(id string, q *QueryOptions) (*EventSink, *QueryMeta, error) {
+	if id == "" {
+		return nil, nil, errors.New("missing event sink ID")
+	}
+
+	var resp EventSink
+	qm, err := e.client.query("/v1/event/sink/"+url.PathEscape(id), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Status is used to fetch the delivery status of an event sink from the
+// leader.
+func (e *EventSinks) Status(id string, q *QueryOptions) (*EventSinkStatus, *QueryMeta, error) {
+	if id == "" {
+		return nil, nil, errors.New("missing event sink ID")
+	}
+
+	var resp EventSinkStatus
+	qm, err := e.client.query("/v1/event/sink/"+url.PathEscape(id)+"/status", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Statuses is used to fetch the delivery status of all event sinks from the
+// leader.
+func (e *EventSinks) Statuses(q *QueryOptions) ([]*EventSinkStatus, *QueryMeta, error) {
+	var resp []*EventSinkStatus
+	qm, err := e.client.query("/v1/event/sinks/status", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Register is used to create or update an event sink. Updating a sink keeps
+// its delivery progress.
+func (e *EventSinks) Register(sink *EventSink, w *WriteOptions) (*WriteMeta, error) {
+	if sink == nil {
+		return nil, errors.New("missing event sink")
+	}
+	if sink.ID == "" {
+		return nil, errors.New("missing event sink ID")
+	}
+
+	wm, err := e.client.put("/v1/event/sinks", sink, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Deregister is used to delete an event sink, which stops the delivery of
+// events to it.
+func (e *EventSinks) Deregister(id string, w *WriteOptions) (*WriteMeta, error) {
+	if id == "" {
+		return nil, errors.New("missing event sink ID")
+	}
+
+	wm, err := e.client.delete("/v1/event/sink/"+url.PathEscape(id), nil, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// EventSink is a durable consumer of the event stream managed by the leader.
+// Events matching its topics and namespace are delivered at least once, in
+// index order, and delivery resumes after a leader election. Events evicted
+// from the event buffer before they were delivered are reported in the
+// status of the sink.
+type EventSink struct {
+	ID        string
+	Type      string
+	Topics    map[Topic][]string
+	Namespace string
+
+	// Filter is an optional go-bexpr expression evaluated against each
+	// event. Events that don't match it, or whose payload lacks a field it
+	// selects, are not delivered.
+	Filter string
+
+	// Address is the URL events are POSTed to by webhook sinks.
+	Address string
+
+	// Path is the absolute path of the file events are appended to by file
+	// sinks, on whichever server is the leader. It must be within the
+	// event_sink_file_dir directory of the servers.
+	Path string
+
+	// LatestIndex is the index of the last event checkpointed as delivered.
+	LatestIndex uint64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// EventSinkStatus is the delivery status of an event sink, as reported by the
+// leader.
+type EventSinkStatus struct {
+	ID              string
+	Type            string
+	Status          string
+	DeliveredIndex  uint64
+	CheckpointIndex uint64
+	LatestIndex     uint64
+	Lag             uint64
+	LastError       string
+	LastDelivery    time.Time
+	MissedFromIndex uint64
+	MissedToIndex   uint64
+}
@@ -21,6 +21,7 @@ const (
 	TopicNode       Topic = "Node"
 	TopicNodePool   Topic = "NodePool"
 	TopicService    Topic = "Service"
+	TopicVariables  Topic = "Variables"
 	TopicAll        Topic = "*"
 )
 
@@ -120,6 +121,17 @@ func (e *Event) Service() (*ServiceRegistration, error) {
 	return out.Service, nil
 }
 
+// Variable returns the metadata of a variable from a given event payload. If
+// the Event Topic is Variables this will return valid VariableMetadata. The
+// items of the variable are never included in events.
+func (e *Event) Variable() (*VariableMetadata, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.Variable, nil
+}
+
 type eventPayload struct {
 	Allocation *Allocation          `mapstructure:"Allocation"`
 	Deployment *Deployment          `mapstructure:"Deployment"`
@@ -128,6 +140,7 @@ type eventPayload struct {
 	Node       *Node                `mapstructure:"Node"`
 	NodePool   *NodePool            `mapstructure:"NodePool"`
 	Service    *ServiceRegistration `mapstructure:"Service"`
+	Variable   *VariableMetadata    `mapstructure:"Variable"`
 }
 
 func (e *Event) decodePayload() (*eventPayload, error) {
@@ -166,7 +179,12 @@ func (c *Client) EventStream() *EventStream {
 }
 
 // Stream establishes a new subscription to Nomad's event stream and streams
-// results back to the returned channel.
+// results back to the returned channel. The Filter of the query options is a
+// go-bexpr expression evaluated by the server against each event, including
+// its Payload, and only the matching events are streamed. Events whose
+// Payload lacks a field the expression selects are dropped rather than
+// rejected. Allocation events don't include their job, so they can only be
+// filtered on fields of the allocation such as its JobID.
 //
 // Events stop being emitted once the Events.Err field is non-nil.
 func (e *EventStream) Stream(ctx context.Context, topics map[Topic][]string, index uint64, q *QueryOptions) (<-chan *Events, error) {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"testing"
 	"time"
@@ -184,6 +185,69 @@ func TestEvent_Stream_Err_InvalidQueryParam(t *testing.T) {
 	must.ErrorContains(t, err, "Invalid key value pair")
 }
 
+func TestEvent_Stream_Filter(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	// register jobs to generate events
+	jobs := c.Jobs()
+	other := testJob()
+	other.ID = pointerOf("other")
+	_, _, err := jobs.Register(other, nil)
+	must.NoError(t, err)
+
+	job := testJob()
+	_, _, err = jobs.Register(job, nil)
+	must.NoError(t, err)
+
+	// build event stream request
+	events := c.EventStream()
+	q := &QueryOptions{
+		Filter: fmt.Sprintf("Payload.Job.ID == %q", *job.ID),
+	}
+	topics := map[Topic][]string{
+		TopicJob: {"*"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streamCh, err := events.Stream(ctx, topics, 0, q)
+	must.NoError(t, err)
+
+	select {
+	case event := <-streamCh:
+		must.NoError(t, event.Err)
+		must.Len(t, 1, event.Events)
+		must.Eq(t, *job.ID, event.Events[0].Key)
+	case <-time.After(5 * time.Second):
+		must.Unreachable(t, must.Sprint("failed waiting for event stream event"))
+	}
+}
+
+func TestEvent_Stream_Err_InvalidFilter(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	events := c.EventStream()
+	q := &QueryOptions{
+		Filter: "Payload.Job.ID ==",
+	}
+	topics := map[Topic][]string{
+		TopicJob: {"*"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := events.Stream(ctx, topics, 0, q)
+	must.ErrorContains(t, err, "Invalid filter")
+}
+
 func TestEvent_Stream_CloseCtx(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -31,6 +31,10 @@ const (
 	// on all clients.
 	JobTypeSysbatch = "sysbatch"
 
+	// JobTypeWorkflow indicates a short-lived process whose task groups run
+	// in the order set by their after parameter.
+	JobTypeWorkflow = "workflow"
+
 	// JobDefaultPriority is the default priority if not specified.
 	JobDefaultPriority = 50
 
@@ -490,6 +494,7 @@ func (j *Jobs) PeriodicForce(jobID string, q *WriteOptions) (string, *WriteMeta,
 type PlanOptions struct {
 	Diff           bool
 	PolicyOverride bool
+	Explain        bool
 }
 
 func (j *Jobs) Plan(job *Job, diff bool, q *WriteOptions) (*JobPlanResponse, *WriteMeta, error) {
@@ -512,6 +517,7 @@ func (j *Jobs) PlanOpts(job *Job, opts *PlanOptions, q *WriteOptions) (*JobPlanR
 	if opts != nil {
 		req.Diff = opts.Diff
 		req.PolicyOverride = opts.PolicyOverride
+		req.Explain = opts.Explain
 	}
 
 	var resp JobPlanResponse
@@ -531,6 +537,26 @@ func (j *Jobs) Summary(jobID string, q *QueryOptions) (*JobSummary, *QueryMeta,
 	return &resp, qm, nil
 }
 
+// WorkflowSteps is used to query the status of the steps of a workflow job.
+func (j *Jobs) WorkflowSteps(jobID string, q *QueryOptions) ([]*WorkflowStep, *QueryMeta, error) {
+	var resp []*WorkflowStep
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/steps", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// WorkflowStep is the status of a step of a workflow job, computed by the
+// servers from the allocations of the current version of the job.
+type WorkflowStep struct {
+	Name     string
+	After    []string
+	Count    int
+	Complete int
+	Status   string
+}
+
 // DispatchOptions is used to pass through job dispatch parameters
 type DispatchOptions struct {
 	JobID            string
@@ -977,6 +1003,85 @@ func (p *PeriodicConfig) GetLocation() (*time.Location, error) {
 	return time.LoadLocation(*p.TimeZone)
 }
 
+// ScheduleWindow restricts the placement of the allocations of a batch or
+// sysbatch job to recurring time windows.
+type ScheduleWindow struct {
+	Cron     *string        `hcl:"cron,optional"`
+	Duration *time.Duration `hcl:"duration,optional"`
+	TimeZone *string        `mapstructure:"time_zone" hcl:"time_zone,optional"`
+}
+
+func (w *ScheduleWindow) Canonicalize() {
+	if w.Cron == nil {
+		w.Cron = pointerOf("")
+	}
+	if w.Duration == nil {
+		w.Duration = pointerOf(time.Duration(0))
+	}
+	if w.TimeZone == nil || *w.TimeZone == "" {
+		w.TimeZone = pointerOf("UTC")
+	}
+}
+
+// Next returns when the window that contains or follows the given time opens
+// and closes. The window is open at the given time if opens is not after it.
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/schedule_window.go
+// and should be kept in sync.
+func (w *ScheduleWindow) Next(from time.Time) (opens, closes time.Time, err error) {
+	location, err := w.GetLocation()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	var cron string
+	if w.Cron != nil {
+		cron = *w.Cron
+	}
+	var duration time.Duration
+	if w.Duration != nil {
+		duration = *w.Duration
+	}
+
+	opens, err = cronParseNext(from.In(location).Add(-duration), cron)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if opens.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf("cron expression %q never matches", cron)
+	}
+	return opens, opens.Add(duration), nil
+}
+
+func (w *ScheduleWindow) GetLocation() (*time.Location, error) {
+	if w.TimeZone == nil || *w.TimeZone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(*w.TimeZone)
+}
+
+const (
+	JobDependencyStatusComplete = "complete"
+	JobDependencyStatusFailed   = "failed"
+	JobDependencyStatusDead     = "dead"
+)
+
+// JobDependency holds the evaluations of a batch or sysbatch job until
+// another job in the same namespace reaches a terminal status.
+type JobDependency struct {
+	JobID  *string `mapstructure:"job_id" hcl:"job_id,optional"`
+	Status *string `hcl:"status,optional"`
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.JobID == nil {
+		d.JobID = pointerOf("")
+	}
+	if d.Status == nil || *d.Status == "" {
+		d.Status = pointerOf(JobDependencyStatusComplete)
+	}
+}
+
 // ParameterizedJobConfig is used to configure the parameterized job.
 type ParameterizedJobConfig struct {
 	Payload      string   `hcl:"payload,optional"`
@@ -1120,10 +1225,15 @@ type Job struct {
 	Update           *UpdateStrategy         `hcl:"update,block"`
 	Multiregion      *Multiregion            `hcl:"multiregion,block"`
 	Spreads          []*Spread               `hcl:"spread,block"`
+	Tolerations      []*Toleration           `hcl:"toleration,block"`
+	JobAffinities    []*JobAffinity          `mapstructure:"job_affinity" hcl:"job_affinity,block"`
 	Periodic         *PeriodicConfig         `hcl:"periodic,block"`
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
+	ScheduleWindow   *ScheduleWindow         `mapstructure:"schedule_window" hcl:"schedule_window,block"`
+	DependsOn        []*JobDependency        `mapstructure:"depends_on" hcl:"depends_on,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
 	Migrate          *MigrateStrategy        `hcl:"migrate,block"`
+	DisruptionBudget *DisruptionBudget       `mapstructure:"disruption_budget" hcl:"disruption_budget,block"`
 	Meta             map[string]string       `hcl:"meta,block"`
 	UI               *JobUIConfig            `hcl:"ui,block"`
 
@@ -1227,6 +1337,12 @@ func (j *Job) Canonicalize() {
 	if j.Periodic != nil {
 		j.Periodic.Canonicalize()
 	}
+	if j.ScheduleWindow != nil {
+		j.ScheduleWindow.Canonicalize()
+	}
+	for _, d := range j.DependsOn {
+		d.Canonicalize()
+	}
 	if j.Update != nil {
 		j.Update.Canonicalize()
 	} else if *j.Type == JobTypeService || *j.Type == JobTypeSystem {
@@ -1246,6 +1362,12 @@ func (j *Job) Canonicalize() {
 	for _, a := range j.Affinities {
 		a.Canonicalize()
 	}
+	for _, t := range j.Tolerations {
+		t.Canonicalize()
+	}
+	for _, a := range j.JobAffinities {
+		a.Canonicalize()
+	}
 
 	if j.UI != nil {
 		j.UI.Canonicalize()
@@ -1530,6 +1652,7 @@ type JobPlanRequest struct {
 	Job            *Job
 	Diff           bool
 	PolicyOverride bool
+	Explain        bool
 	WriteRequest
 }
 
@@ -1539,6 +1662,7 @@ type JobPlanResponse struct {
 	Diff               *JobDiff
 	Annotations        *PlanAnnotations
 	FailedTGAllocs     map[string]*AllocationMetric
+	Placements         []*PlacementExplanation
 	NextPeriodicLaunch time.Time
 
 	// Warnings contains any warnings about the given job. These may include
@@ -1588,6 +1712,17 @@ type ObjectDiff struct {
 type PlanAnnotations struct {
 	DesiredTGUpdates map[string]*DesiredUpdates
 	PreemptedAllocs  []*AllocationListStub
+	SpreadViolations []*SpreadViolation
+}
+
+// SpreadViolation describes a spread with a max_skew whose allocations are
+// skewed beyond the maximum.
+type SpreadViolation struct {
+	TaskGroup string
+	Attribute string
+	MaxSkew   int
+	Skew      int
+	Counts    map[string]uint64
 }
 
 type DesiredUpdates struct {
@@ -6,6 +6,7 @@ package api
 import (
 	"fmt"
 	"sort"
+	"time"
 )
 
 // Namespaces is used to query the namespace endpoints.
@@ -70,16 +71,17 @@ func (n *Namespaces) Delete(namespace string, q *WriteOptions) (*WriteMeta, erro
 
 // Namespace is used to serialize a namespace.
 type Namespace struct {
-	Name                  string
-	Description           string
-	Quota                 string
-	Capabilities          *NamespaceCapabilities          `hcl:"capabilities,block"`
-	NodePoolConfiguration *NamespaceNodePoolConfiguration `hcl:"node_pool_config,block"`
-	VaultConfiguration    *NamespaceVaultConfiguration    `hcl:"vault,block"`
-	ConsulConfiguration   *NamespaceConsulConfiguration   `hcl:"consul,block"`
-	Meta                  map[string]string
-	CreateIndex           uint64
-	ModifyIndex           uint64
+	Name                   string
+	Description            string
+	Quota                  string
+	Capabilities           *NamespaceCapabilities           `hcl:"capabilities,block"`
+	NodePoolConfiguration  *NamespaceNodePoolConfiguration  `hcl:"node_pool_config,block"`
+	VaultConfiguration     *NamespaceVaultConfiguration     `hcl:"vault,block"`
+	ConsulConfiguration    *NamespaceConsulConfiguration    `hcl:"consul,block"`
+	VariablesConfiguration *NamespaceVariablesConfiguration `hcl:"variables,block"`
+	Meta                   map[string]string
+	CreateIndex            uint64
+	ModifyIndex            uint64
 }
 
 // NamespaceCapabilities represents a set of capabilities allowed for this
@@ -167,3 +169,17 @@ type NamespacedID struct {
 	// ID is the ID of the namespaced object (e.g. Job ID)
 	ID string
 }
+
+// NamespaceVariablesConfiguration stores configuration about the history kept
+// for the variables in a namespace.
+type NamespaceVariablesConfiguration struct {
+	// HistoryLimit is the number of prior versions kept for each variable. A
+	// value of zero uses the server default and a negative value disables the
+	// history.
+	HistoryLimit int `hcl:"history_limit"`
+
+	// HistoryRetention is how long a prior version is kept after it has been
+	// replaced. A value of zero keeps prior versions until they exceed the
+	// HistoryLimit.
+	HistoryRetention time.Duration `hcl:"history_retention"`
+}
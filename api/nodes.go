@@ -431,6 +431,79 @@ func (n *Nodes) ToggleEligibility(nodeID string, eligible bool, q *WriteOptions)
 	return &resp, nil
 }
 
+const (
+	// TaintEffectNoSchedule prevents allocations that don't tolerate the
+	// taint from being placed on the node.
+	TaintEffectNoSchedule = "NoSchedule"
+
+	// TaintEffectPreferNoSchedule makes the scheduler avoid the node for
+	// allocations that don't tolerate the taint.
+	TaintEffectPreferNoSchedule = "PreferNoSchedule"
+
+	// TaintEffectNoExecute prevents allocations that don't tolerate the taint
+	// from being placed on the node, and migrates the ones running on it.
+	TaintEffectNoExecute = "NoExecute"
+)
+
+// NodeTaint marks a node so that only the allocations that tolerate it are
+// placed or kept on the node.
+type NodeTaint struct {
+	Key    string
+	Value  string
+	Effect string
+}
+
+// String returns the taint in the key=value:Effect form.
+func (t *NodeTaint) String() string {
+	if t.Value == "" {
+		return fmt.Sprintf("%s:%s", t.Key, t.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+}
+
+// NodeUtilization is the actual utilization of a node last published by its
+// client. Percentages are between 0 and 100. UpdatedAt is in seconds since
+// the Unix epoch.
+type NodeUtilization struct {
+	CPUPercent     float64
+	MemoryPercent  float64
+	CPUPressure    float64
+	MemoryPressure float64
+	IOPressure     float64
+	UpdatedAt      int64
+}
+
+// NodeUpdateTaintsRequest is used to replace the taints of a node.
+type NodeUpdateTaintsRequest struct {
+	NodeID string
+	Taints []*NodeTaint
+}
+
+// NodeTaintsUpdateResponse is used to respond to a node taints update
+type NodeTaintsUpdateResponse struct {
+	NodeModifyIndex uint64
+	EvalIDs         []string
+	EvalCreateIndex uint64
+	WriteMeta
+}
+
+// UpdateTaints is used to replace the taints of the node. Allocations that
+// don't tolerate the NoExecute taints of the node are migrated.
+func (n *Nodes) UpdateTaints(nodeID string, taints []*NodeTaint, q *WriteOptions) (*NodeTaintsUpdateResponse, error) {
+	req := &NodeUpdateTaintsRequest{
+		NodeID: nodeID,
+		Taints: taints,
+	}
+
+	var resp NodeTaintsUpdateResponse
+	wm, err := n.client.put("/v1/node/"+nodeID+"/taints", req, &resp, q)
+	if err != nil {
+		return nil, err
+	}
+	resp.WriteMeta = *wm
+	return &resp, nil
+}
+
 // Allocations is used to return the allocations associated with a node.
 func (n *Nodes) Allocations(nodeID string, q *QueryOptions) ([]*Allocation, *QueryMeta, error) {
 	var resp []*Allocation
@@ -561,6 +634,8 @@ type Node struct {
 	Meta                  map[string]string
 	NodeClass             string
 	NodePool              string
+	Taints                []*NodeTaint
+	Utilization           *NodeUtilization
 	CgroupParent          string
 	Drain                 bool
 	DrainStrategy         *DrainStrategy
@@ -590,6 +665,15 @@ type NodeResources struct {
 
 	MinDynamicPort int
 	MaxDynamicPort int
+
+	CustomResources []*NodeCustomResource
+}
+
+// NodeCustomResource is a named countable resource of a node declared in the
+// client configuration.
+type NodeCustomResource struct {
+	Name  string
+	Count uint64
 }
 
 type NodeCpuResources struct {
@@ -794,10 +878,20 @@ type HostStats struct {
 	DiskStats        []*HostDiskStats
 	AllocDirStats    *HostDiskStats
 	DeviceStats      []*DeviceGroupStats
+	Pressure         *HostPressureStats
 	Uptime           uint64
 	CPUTicksConsumed float64
 }
 
+// HostPressureStats is the pressure stall information of the host: the
+// percentage of time over the last 10 seconds during which tasks were stalled
+// waiting on each resource.
+type HostPressureStats struct {
+	CPU    float64
+	Memory float64
+	IO     float64
+}
+
 type HostMemoryStats struct {
 	Total     uint64
 	Available uint64
@@ -185,6 +185,18 @@ type SchedulerConfiguration struct {
 	// until the configuration is updated and written to the Nomad servers.
 	PauseEvalBroker bool
 
+	// FairShareConfig specifies whether the evaluation broker dequeues
+	// evaluations of equal priority fairly across namespaces.
+	FairShareConfig FairShareConfig
+
+	// RebalanceConfig specifies whether running service allocations are
+	// periodically migrated to pack them onto fewer nodes.
+	RebalanceConfig RebalanceConfig
+
+	// LoadAwareConfig specifies how the load-aware scheduler algorithm uses
+	// the actual utilization published by clients.
+	LoadAwareConfig LoadAwareConfig
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -214,8 +226,9 @@ type SchedulerSetConfigurationResponse struct {
 type SchedulerAlgorithm string
 
 const (
-	SchedulerAlgorithmBinpack SchedulerAlgorithm = "binpack"
-	SchedulerAlgorithmSpread  SchedulerAlgorithm = "spread"
+	SchedulerAlgorithmBinpack   SchedulerAlgorithm = "binpack"
+	SchedulerAlgorithmSpread    SchedulerAlgorithm = "spread"
+	SchedulerAlgorithmLoadAware SchedulerAlgorithm = "load-aware"
 )
 
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
@@ -226,6 +239,65 @@ type PreemptionConfig struct {
 	ServiceSchedulerEnabled  bool
 }
 
+// FairShareConfig specifies whether evaluations of equal priority are
+// dequeued in proportion to the weight of their namespace. Namespaces without
+// a weight have a weight of 1.
+type FairShareConfig struct {
+	Enabled          bool
+	NamespaceWeights map[string]int
+}
+
+// RebalanceConfig specifies whether the rebalancer migrates running service
+// allocations from lightly used nodes onto nodes where they pack more
+// tightly. MaxMigrationsPerHour defaults to 10 when zero.
+type RebalanceConfig struct {
+	Enabled              bool
+	DryRun               bool
+	MaxMigrationsPerHour int
+}
+
+// LoadAwareConfig specifies how the load-aware scheduler algorithm uses the
+// actual utilization published by clients. UsageWeight and PressureThreshold
+// are percentages, defaulting to 50 and 25 when zero. Jobs with a priority at
+// or below BestEffortPriority, defaulting to 30 when zero, are best-effort and
+// aren't placed on nodes under pressure.
+type LoadAwareConfig struct {
+	UsageWeight        int
+	PressureThreshold  int
+	BestEffortPriority int
+}
+
+// RebalanceReport describes the migrations the rebalancer would make.
+type RebalanceReport struct {
+	Migrations           []*RebalanceMigration
+	MigrationsUsed       int
+	MaxMigrationsPerHour int
+}
+
+// RebalanceMigration is a single allocation the rebalancer would move off its
+// node, along with the bin packing scores of its current and target nodes.
+type RebalanceMigration struct {
+	AllocID         string
+	Namespace       string
+	JobID           string
+	TaskGroup       string
+	NodeID          string
+	TargetNodeID    string
+	NodeScore       float64
+	TargetNodeScore float64
+}
+
+// SchedulerRebalanceReport is used to query the migrations the rebalancer
+// would make against the current cluster state, without making them.
+func (op *Operator) SchedulerRebalanceReport(q *QueryOptions) (*RebalanceReport, *QueryMeta, error) {
+	var resp RebalanceReport
+	qm, err := op.c.query("/v1/operator/scheduler/rebalance", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // SchedulerGetConfiguration is used to query the current Scheduler configuration.
 func (op *Operator) SchedulerGetConfiguration(q *QueryOptions) (*SchedulerConfigurationResponse, *QueryMeta, error) {
 	var resp SchedulerConfigurationResponse
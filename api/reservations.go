@@ -0,0 +1,182 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// Reservations is used to access capacity reservation endpoints.
+type Reservations struct {
+	client *Client
+}
+
+// Reservations returns a handle on the capacity reservation endpoints.
+func (c *Client) Reservations() *Reservations {
+	return &Reservations{client: c}
+}
+
+// List is used to list all capacity reservations with their usage.
+func (r *Reservations) List(q *QueryOptions) ([]*Reservation, *QueryMeta, error) {
+	var resp []*Reservation
+	qm, err := r.client.query("/v1/reservations", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// PrefixList is used to list capacity reservations that match a given
+// prefix.
+func (r *Reservations) PrefixList(prefix string, q *QueryOptions) ([]*Reservation, *QueryMeta, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	q.Prefix = prefix
+	return r.List(q)
+}
+
+// Info is used to fetch a specific capacity reservation with its usage.
+func (r *Reservations) Info(name string, q *QueryOptions) (*Reservation, *QueryMeta, error) {
+	if name == "" {
+		return nil, nil, errors.New("missing reservation name")
+	}
+
+	var resp Reservation
+	qm, err := r.client.query("/v1/reservation/"+url.PathEscape(name), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Register is used to create or update a capacity reservation. Registering
+// an existing reservation renews its TTL.
+func (r *Reservations) Register(res *Reservation, w *WriteOptions) (*WriteMeta, error) {
+	if res == nil {
+		return nil, errors.New("missing reservation")
+	}
+	if res.Name == "" {
+		return nil, errors.New("missing reservation name")
+	}
+
+	wm, err := r.client.put("/v1/reservations", res, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Delete is used to delete a capacity reservation, releasing the capacity it
+// holds.
+func (r *Reservations) Delete(name string, w *WriteOptions) (*WriteMeta, error) {
+	if name == "" {
+		return nil, errors.New("missing reservation name")
+	}
+
+	wm, err := r.client.delete("/v1/reservation/"+url.PathEscape(name), nil, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Reservation holds capacity of a node pool for future jobs. Only the jobs
+// selected by its consumers can use the reserved capacity, until the
+// reservation expires after its TTL.
+type Reservation struct {
+	Name        string                `hcl:"name,label"`
+	Description string                `hcl:"description,optional"`
+	NodePool    string                `hcl:"node_pool,optional"`
+	Resources   *ReservationResources `hcl:"resources,block"`
+	Consumers   *ReservationConsumers `hcl:"consumers,block"`
+	TTL         time.Duration         `hcl:"ttl,optional"`
+
+	// ExpiresAt, Placements and Usage are set by the server.
+	ExpiresAt  time.Time
+	Placements map[string]*ReservationPlacement
+	Usage      *ReservationUsage
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// MarshalJSON implements the json.Marshaler interface and allows
+// Reservation.TTL to be marshaled as a duration string.
+func (r *Reservation) MarshalJSON() ([]byte, error) {
+	type Alias Reservation
+	exported := &struct {
+		TTL string
+		*Alias
+	}{
+		TTL:   r.TTL.String(),
+		Alias: (*Alias)(r),
+	}
+	if r.TTL == 0 {
+		exported.TTL = ""
+	}
+	return json.Marshal(exported)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface and allows
+// Reservation.TTL to be unmarshalled from a duration string.
+func (r *Reservation) UnmarshalJSON(data []byte) (err error) {
+	type Alias Reservation
+	aux := &struct {
+		TTL any
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+
+	if err = json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	switch v := aux.TTL.(type) {
+	case string:
+		if v != "" {
+			if r.TTL, err = time.ParseDuration(v); err != nil {
+				return err
+			}
+		}
+	case float64:
+		r.TTL = time.Duration(v)
+	}
+	return nil
+}
+
+// ReservationResources is the capacity of a reservation. Cores are converted
+// to the CPU shares of the nodes they are placed on.
+type ReservationResources struct {
+	CPU      int `hcl:"cpu,optional"`
+	Cores    int `hcl:"cores,optional"`
+	MemoryMB int `hcl:"memory,optional"`
+}
+
+// ReservationConsumers selects the jobs allowed to use the capacity of a
+// reservation. A job is a consumer if its ID or namespace is listed, or if
+// its priority is at least MinPriority.
+type ReservationConsumers struct {
+	Namespaces  []string `hcl:"namespaces,optional"`
+	Jobs        []string `hcl:"jobs,optional"`
+	MinPriority int      `hcl:"min_priority,optional"`
+}
+
+// ReservationPlacement is the capacity of a reservation held on a node.
+type ReservationPlacement struct {
+	CPU      int64
+	MemoryMB int64
+}
+
+// ReservationUsage is the capacity of a reservation across its node pool,
+// and how much of it consumers use.
+type ReservationUsage struct {
+	ReservedCPU      int64
+	ReservedMemoryMB int64
+	UsedCPU          int64
+	UsedMemoryMB     int64
+}
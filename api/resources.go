@@ -21,6 +21,10 @@ type Resources struct {
 	NUMA        *NUMAResource      `hcl:"numa,block"`
 	SecretsMB   *int               `mapstructure:"secrets" hcl:"secrets,optional"`
 
+	// CustomResources are the custom resources declared by clients that the
+	// task requests.
+	CustomResources []*RequestedCustomResource `hcl:"custom_resource,block"`
+
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
 	// 0.10 and is only being kept to allow any references to be removed before
@@ -53,6 +57,9 @@ func (r *Resources) Canonicalize() {
 	for _, d := range r.Devices {
 		d.Canonicalize()
 	}
+	for _, c := range r.CustomResources {
+		c.Canonicalize()
+	}
 
 	r.NUMA.Canonicalize()
 }
@@ -108,6 +115,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.SecretsMB != nil {
 		r.SecretsMB = other.SecretsMB
 	}
+	if len(other.CustomResources) != 0 {
+		r.CustomResources = other.CustomResources
+	}
 }
 
 // NUMAResource contains the NUMA affinity request for scheduling purposes.
@@ -330,3 +340,19 @@ func (d *RequestedDevice) Canonicalize() {
 		a.Canonicalize()
 	}
 }
+
+// RequestedCustomResource is used to request units of a custom resource
+// declared in the client configuration, such as license seats.
+type RequestedCustomResource struct {
+	// Name is the name of the custom resource.
+	Name string `hcl:",label"`
+
+	// Count is the number of units requested.
+	Count *uint64 `hcl:"count,optional"`
+}
+
+func (c *RequestedCustomResource) Canonicalize() {
+	if c.Count == nil {
+		c.Count = pointerOf(uint64(1))
+	}
+}
@@ -154,6 +154,60 @@ func (ds *DisconnectStrategy) Canonicalize() {
 	}
 }
 
+// Gang configures all-or-nothing placement for a task group. Task groups of
+// a job sharing the same gang name are only placed when all of their
+// allocations fit.
+type Gang struct {
+	// Name identifies the gang. Defaults to the task group name.
+	Name *string `mapstructure:"name" hcl:"name,optional"`
+
+	// Timeout is how long the gang may wait for placement before the
+	// evaluation and any active deployment are failed. Zero waits forever.
+	Timeout *time.Duration `mapstructure:"timeout" hcl:"timeout,optional"`
+}
+
+func (g *Gang) Canonicalize(tg *TaskGroup) {
+	if g.Name == nil || *g.Name == "" {
+		g.Name = pointerOf(*tg.Name)
+	}
+
+	if g.Timeout == nil {
+		g.Timeout = pointerOf(time.Duration(0))
+	}
+}
+
+// IndexedBatch configures a batch task group to run a fixed number of indexed
+// completions. Each allocation index must complete successfully once, at most
+// Parallelism allocations run at once, and failed indexes are rescheduled up
+// to BackoffLimitPerIndex times. The attempts of the reschedule policy of the
+// task group default to, and must match, the backoff limit.
+type IndexedBatch struct {
+	// Completions is the number of indexes that must complete. Defaults to
+	// the count of the task group, and the count defaults to it.
+	Completions *int `mapstructure:"completions" hcl:"completions,optional"`
+
+	// Parallelism is the maximum number of allocations running at once.
+	// Defaults to Completions.
+	Parallelism *int `mapstructure:"parallelism" hcl:"parallelism,optional"`
+
+	// BackoffLimitPerIndex is the number of times a failed index is
+	// rescheduled before it is marked failed. Defaults to 1, like the
+	// attempts of the default batch reschedule policy.
+	BackoffLimitPerIndex *int `mapstructure:"backoff_limit_per_index" hcl:"backoff_limit_per_index,optional"`
+}
+
+func (b *IndexedBatch) Canonicalize(tg *TaskGroup) {
+	if b.Completions == nil {
+		b.Completions = pointerOf(*tg.Count)
+	}
+	if b.Parallelism == nil || *b.Parallelism == 0 {
+		b.Parallelism = pointerOf(*b.Completions)
+	}
+	if b.BackoffLimitPerIndex == nil {
+		b.BackoffLimitPerIndex = pointerOf(1)
+	}
+}
+
 // Reschedule configures how Tasks are rescheduled  when they crash or fail.
 type ReschedulePolicy struct {
 	// Attempts limits the number of rescheduling attempts that can occur in an interval.
@@ -249,6 +303,51 @@ func (a *Affinity) Canonicalize() {
 	}
 }
 
+const (
+	// TolerationOperatorEqual tolerates taints with the same key and value.
+	TolerationOperatorEqual = "Equal"
+
+	// TolerationOperatorExists tolerates taints with the same key, whatever
+	// their value.
+	TolerationOperatorExists = "Exists"
+)
+
+// Toleration allows allocations to be placed or kept on nodes with matching
+// taints.
+type Toleration struct {
+	Key      string `hcl:"key,optional"`
+	Operator string `hcl:"operator,optional"`
+	Value    string `hcl:"value,optional"`
+	Effect   string `hcl:"effect,optional"`
+}
+
+func (t *Toleration) Canonicalize() {
+	if t.Operator == "" {
+		t.Operator = TolerationOperatorEqual
+	}
+}
+
+// JobAffinity places allocations relative to the allocations of another job,
+// in the same topology domain as them or away from them for anti-affinity.
+type JobAffinity struct {
+	Namespace   string `hcl:"namespace,optional"`
+	JobID       string `mapstructure:"job_id" hcl:"job_id"`
+	TaskGroup   string `mapstructure:"task_group" hcl:"task_group,optional"`
+	TopologyKey string `mapstructure:"topology_key" hcl:"topology_key,optional"`
+	Anti        bool   `hcl:"anti,optional"`
+	Required    bool   `hcl:"required,optional"`
+	Weight      *int8  `hcl:"weight,optional"`
+}
+
+func (a *JobAffinity) Canonicalize() {
+	if a.TopologyKey == "" {
+		a.TopologyKey = "${node.unique.id}"
+	}
+	if a.Weight == nil && !a.Required {
+		a.Weight = pointerOf(int8(50))
+	}
+}
+
 func NewDefaultDisconnectStrategy() *DisconnectStrategy {
 	return &DisconnectStrategy{
 		LostAfter: pointerOf(0 * time.Minute),
@@ -272,7 +371,7 @@ func NewDefaultReschedulePolicy(jobType string) *ReschedulePolicy {
 			Attempts: pointerOf(0),
 			Interval: pointerOf(time.Duration(0)),
 		}
-	case "batch":
+	case "batch", "workflow":
 		// This needs to be in sync with DefaultBatchJobReschedulePolicy
 		// in nomad/structs/structs.go
 		dp = &ReschedulePolicy{
@@ -324,11 +423,18 @@ func (r *ReschedulePolicy) String() string {
 
 // Spread is used to serialize task group allocation spread preferences
 type Spread struct {
-	Attribute    string          `hcl:"attribute,optional"`
-	Weight       *int8           `hcl:"weight,optional"`
-	SpreadTarget []*SpreadTarget `hcl:"target,block"`
+	Attribute         string          `hcl:"attribute,optional"`
+	Weight            *int8           `hcl:"weight,optional"`
+	SpreadTarget      []*SpreadTarget `hcl:"target,block"`
+	MaxSkew           int             `mapstructure:"max_skew" hcl:"max_skew,optional"`
+	WhenUnsatisfiable string          `mapstructure:"when_unsatisfiable" hcl:"when_unsatisfiable,optional"`
 }
 
+const (
+	SpreadWhenUnsatisfiableBlock          = "block"
+	SpreadWhenUnsatisfiableScheduleAnyway = "schedule_anyway"
+)
+
 // SpreadTarget is used to serialize target allocation spread percentages
 type SpreadTarget struct {
 	Value   string `hcl:",label"`
@@ -354,6 +460,9 @@ func (s *Spread) Canonicalize() {
 	if s.Weight == nil {
 		s.Weight = pointerOf(int8(50))
 	}
+	if s.MaxSkew > 0 && s.WhenUnsatisfiable == "" {
+		s.WhenUnsatisfiable = SpreadWhenUnsatisfiableBlock
+	}
 }
 
 // EphemeralDisk is an ephemeral disk object
@@ -444,6 +553,44 @@ func (m *MigrateStrategy) Copy() *MigrateStrategy {
 	return nm
 }
 
+// DisruptionBudget limits how many allocations of a task group voluntary
+// disruptions, such as node drains, preemption and job restarts, can make
+// unavailable at once across the cluster.
+type DisruptionBudget struct {
+	MinHealthy     *int `mapstructure:"min_healthy" hcl:"min_healthy,optional"`
+	MaxUnavailable *int `mapstructure:"max_unavailable" hcl:"max_unavailable,optional"`
+}
+
+func (b *DisruptionBudget) Canonicalize() {
+	if b == nil {
+		return
+	}
+	if b.MinHealthy == nil {
+		b.MinHealthy = pointerOf(0)
+	}
+	if b.MaxUnavailable == nil {
+		b.MaxUnavailable = pointerOf(0)
+	}
+}
+
+func (b *DisruptionBudget) Merge(o *DisruptionBudget) {
+	if o.MinHealthy != nil {
+		b.MinHealthy = o.MinHealthy
+	}
+	if o.MaxUnavailable != nil {
+		b.MaxUnavailable = o.MaxUnavailable
+	}
+}
+
+func (b *DisruptionBudget) Copy() *DisruptionBudget {
+	if b == nil {
+		return nil
+	}
+	nb := new(DisruptionBudget)
+	*nb = *b
+	return nb
+}
+
 // VolumeRequest is a representation of a storage volume that a TaskGroup wishes to use.
 type VolumeRequest struct {
 	Name           string           `hcl:"name,label"`
@@ -496,13 +643,19 @@ type TaskGroup struct {
 	Affinities       []*Affinity               `hcl:"affinity,block"`
 	Tasks            []*Task                   `hcl:"task,block"`
 	Spreads          []*Spread                 `hcl:"spread,block"`
+	Tolerations      []*Toleration             `hcl:"toleration,block"`
+	JobAffinities    []*JobAffinity            `mapstructure:"job_affinity" hcl:"job_affinity,block"`
 	Volumes          map[string]*VolumeRequest `hcl:"volume,block"`
 	RestartPolicy    *RestartPolicy            `hcl:"restart,block"`
 	Disconnect       *DisconnectStrategy       `hcl:"disconnect,block"`
+	Gang             *Gang                     `hcl:"gang,block"`
+	After            []string                  `hcl:"after,optional"`
+	IndexedBatch     *IndexedBatch             `mapstructure:"indexed_batch" hcl:"indexed_batch,block"`
 	ReschedulePolicy *ReschedulePolicy         `hcl:"reschedule,block"`
 	EphemeralDisk    *EphemeralDisk            `hcl:"ephemeral_disk,block"`
 	Update           *UpdateStrategy           `hcl:"update,block"`
 	Migrate          *MigrateStrategy          `hcl:"migrate,block"`
+	DisruptionBudget *DisruptionBudget         `mapstructure:"disruption_budget" hcl:"disruption_budget,block"`
 	Networks         []*NetworkResource        `hcl:"network,block"`
 	Meta             map[string]string         `hcl:"meta,block"`
 	Services         []*Service                `hcl:"service,block"`
@@ -532,12 +685,17 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	}
 
 	if g.Count == nil {
-		if g.Scaling != nil && g.Scaling.Min != nil {
+		if g.IndexedBatch != nil && g.IndexedBatch.Completions != nil {
+			g.Count = pointerOf(*g.IndexedBatch.Completions)
+		} else if g.Scaling != nil && g.Scaling.Min != nil {
 			g.Count = pointerOf(int(*g.Scaling.Min))
 		} else {
 			g.Count = pointerOf(1)
 		}
 	}
+	if g.IndexedBatch != nil {
+		g.IndexedBatch.Canonicalize(g)
+	}
 	if g.Scaling != nil {
 		g.Scaling.Canonicalize(*g.Count)
 	}
@@ -578,6 +736,14 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		jobReschedule := job.Reschedule.Copy()
 		g.ReschedulePolicy = jobReschedule
 	}
+	if g.IndexedBatch != nil {
+		if g.ReschedulePolicy == nil {
+			g.ReschedulePolicy = &ReschedulePolicy{}
+		}
+		if g.ReschedulePolicy.Attempts == nil {
+			g.ReschedulePolicy.Attempts = pointerOf(*g.IndexedBatch.BackoffLimitPerIndex)
+		}
+	}
 	if g.ReschedulePolicy == nil && *job.Type != JobTypeSysbatch && *job.Type != JobTypeSystem {
 		g.ReschedulePolicy = NewDefaultReschedulePolicy(*job.Type)
 	}
@@ -593,6 +759,16 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		g.Migrate = jobMigrate
 	}
 
+	// Merge the disruption budget from the job
+	if jb, tb := job.DisruptionBudget != nil, g.DisruptionBudget != nil; jb && tb {
+		jobBudget := job.DisruptionBudget.Copy()
+		jobBudget.Merge(g.DisruptionBudget)
+		g.DisruptionBudget = jobBudget
+	} else if jb {
+		g.DisruptionBudget = job.DisruptionBudget.Copy()
+	}
+	g.DisruptionBudget.Canonicalize()
+
 	// Merge with default reschedule policy
 	if g.Migrate == nil && *job.Type == "service" {
 		g.Migrate = &MigrateStrategy{}
@@ -624,6 +800,12 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	for _, a := range g.Affinities {
 		a.Canonicalize()
 	}
+	for _, t := range g.Tolerations {
+		t.Canonicalize()
+	}
+	for _, a := range g.JobAffinities {
+		a.Canonicalize()
+	}
 	for _, n := range g.Networks {
 		n.Canonicalize()
 	}
@@ -634,6 +816,10 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	if g.Disconnect != nil {
 		g.Disconnect.Canonicalize()
 	}
+
+	if g.Gang != nil {
+		g.Gang.Canonicalize(g)
+	}
 }
 
 // These needs to be in sync with DefaultServiceJobRestartPolicy in
@@ -557,6 +557,57 @@ func TestTaskGroup_Canonicalize_Update(t *testing.T) {
 	must.Nil(t, tg.Update)
 }
 
+func TestTaskGroup_Canonicalize_IndexedBatch(t *testing.T) {
+	testutil.Parallel(t)
+
+	job := &Job{
+		ID:   pointerOf("test"),
+		Type: pointerOf(JobTypeBatch),
+	}
+	job.Canonicalize()
+
+	// Completions default to the count
+	tg := &TaskGroup{
+		Name:         pointerOf("foo"),
+		Count:        pointerOf(8),
+		IndexedBatch: &IndexedBatch{},
+	}
+	tg.Canonicalize(job)
+	must.Eq(t, &IndexedBatch{
+		Completions:          pointerOf(8),
+		Parallelism:          pointerOf(8),
+		BackoffLimitPerIndex: pointerOf(1),
+	}, tg.IndexedBatch)
+
+	// The count defaults to the completions
+	tg = &TaskGroup{
+		Name: pointerOf("foo"),
+		IndexedBatch: &IndexedBatch{
+			Completions:          pointerOf(4),
+			Parallelism:          pointerOf(2),
+			BackoffLimitPerIndex: pointerOf(3),
+		},
+	}
+	tg.Canonicalize(job)
+	must.Eq(t, 4, *tg.Count)
+	must.Eq(t, 2, *tg.IndexedBatch.Parallelism)
+
+	// The reschedule attempts default to the backoff limit
+	must.Eq(t, 3, *tg.ReschedulePolicy.Attempts)
+	must.Eq(t, *NewDefaultReschedulePolicy(JobTypeBatch).Delay, *tg.ReschedulePolicy.Delay)
+
+	// An explicit count or reschedule policy is left as submitted
+	tg = &TaskGroup{
+		Name:             pointerOf("foo"),
+		Count:            pointerOf(2),
+		ReschedulePolicy: &ReschedulePolicy{Attempts: pointerOf(5)},
+		IndexedBatch:     &IndexedBatch{Completions: pointerOf(4)},
+	}
+	tg.Canonicalize(job)
+	must.Eq(t, 2, *tg.Count)
+	must.Eq(t, 5, *tg.ReschedulePolicy.Attempts)
+}
+
 func TestTaskGroup_Canonicalize_Scaling(t *testing.T) {
 	testutil.Parallel(t)
 
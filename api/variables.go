@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 )
 
 const (
@@ -22,6 +23,10 @@ var (
 	// ErrVariablePathNotFound is returned when trying to read a variable that
 	// does not exist.
 	ErrVariablePathNotFound = errors.New("variable not found")
+
+	// ErrVariableVersionNotFound is returned when trying to read a version of
+	// a variable that does not exist.
+	ErrVariableVersionNotFound = errors.New("variable version not found")
 )
 
 // Variables is used to access variables.
@@ -180,6 +185,64 @@ func (vars *Variables) GetVariableItems(path string, qo *QueryOptions) (Variable
 	return v.Items, qm, nil
 }
 
+// ReadVersion is used to query a single version of a variable by path and
+// version, as returned by History. This will error if the version is not
+// found.
+func (vars *Variables) ReadVersion(path string, version uint64, qo *QueryOptions) (*Variable, *QueryMeta, error) {
+	path = cleanPathString(path)
+	v, qm, err := vars.readInternal(fmt.Sprintf("/v1/var/%s?version=%d", path, version), qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v == nil {
+		return nil, qm, ErrVariableVersionNotFound
+	}
+	return v, qm, nil
+}
+
+// History is used to list the versions of a variable, newest first. The
+// current version of the variable, if it exists, is always the first one.
+func (vars *Variables) History(path string, qo *QueryOptions) ([]*VariableVersionMetadata, *QueryMeta, error) {
+	path = cleanPathString(path)
+	var resp []*VariableVersionMetadata
+	qm, err := vars.client.query("/v1/var/"+path+"?history", &resp, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Rollback is used to write a prior version of a variable as its current
+// version. The write fails with an ErrCASConflict if the variable is modified
+// concurrently.
+func (vars *Variables) Rollback(path string, version uint64, qo *WriteOptions) (*Variable, *WriteMeta, error) {
+	var q *QueryOptions
+	if qo != nil {
+		q = &QueryOptions{
+			Region:    qo.Region,
+			Namespace: qo.Namespace,
+			AuthToken: qo.AuthToken,
+			Headers:   qo.Headers,
+		}
+	}
+
+	prior, _, err := vars.ReadVersion(path, version, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	current, _, err := vars.Peek(path, q)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := NewVariable(path)
+	v.Items = prior.Items
+	if current != nil {
+		v.ModifyIndex = current.ModifyIndex
+	}
+	return vars.CheckedUpdate(v, qo)
+}
+
 // RenewLock renews the lease for the lock on the given variable. It has to be called
 // before the lock's TTL expires or the lock will be automatically released after the
 // delay period.
@@ -381,6 +444,15 @@ type Variable struct {
 
 	// Lock holds the information about the variable lock if its being used.
 	Lock *VariableLock `hcl:",lock,optional" json:",omitempty"`
+
+	// TTL is an optional duration after which the variable expires and is
+	// deleted. The expire time is set from the TTL each time the variable is
+	// written.
+	TTL time.Duration `hcl:"ttl,optional" json:",omitempty"`
+
+	// ExpireTime is the unix nano of the time at which the variable expires.
+	// It is set by the server and is zero for variables that never expire.
+	ExpireTime int64 `hcl:"expire_time,optional" json:",omitempty"`
 }
 
 // VariableMetadata specifies the metadata for a variable and
@@ -406,6 +478,35 @@ type VariableMetadata struct {
 
 	// Lock holds the information about the variable lock if its being used.
 	Lock *VariableLock `hcl:",lock,optional" json:",omitempty"`
+
+	// TTL is an optional duration after which the variable expires and is
+	// deleted. The expire time is set from the TTL each time the variable is
+	// written.
+	TTL time.Duration `hcl:"ttl,optional" json:",omitempty"`
+
+	// ExpireTime is the unix nano of the time at which the variable expires.
+	// It is set by the server and is zero for variables that never expire.
+	ExpireTime int64 `hcl:"expire_time,optional" json:",omitempty"`
+}
+
+// VariableVersionMetadata specifies the metadata for a version of a variable
+// and is used as the history list object. The version number of a variable is
+// its ModifyIndex.
+type VariableVersionMetadata struct {
+	VariableMetadata
+
+	// ArchiveIndex is the index at which this version was replaced or
+	// deleted. It is zero for the current version of the variable.
+	ArchiveIndex uint64
+
+	// ArchiveTime is the unix nano of the time this version was replaced
+	// or deleted.
+	ArchiveTime int64
+}
+
+// IsCurrent returns whether this is the current version of the variable.
+func (v *VariableVersionMetadata) IsCurrent() bool {
+	return v.ArchiveIndex == 0
 }
 
 type VariableLock struct {
@@ -456,6 +557,8 @@ func (v *Variable) Metadata() *VariableMetadata {
 		ModifyIndex: v.ModifyIndex,
 		CreateTime:  v.CreateTime,
 		ModifyTime:  v.ModifyTime,
+		TTL:         v.TTL,
+		ExpireTime:  v.ExpireTime,
 	}
 }
 
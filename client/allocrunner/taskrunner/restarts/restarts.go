@@ -26,8 +26,8 @@ const (
 func NewRestartTracker(policy *structs.RestartPolicy, jobType string, tlc *structs.TaskLifecycleConfig) *RestartTracker {
 	onSuccess := true
 
-	// Batch & SysBatch jobs should not restart if they exit successfully
-	if jobType == structs.JobTypeBatch || jobType == structs.JobTypeSysBatch {
+	// Batch, SysBatch & Workflow jobs should not restart if they exit successfully
+	if jobType == structs.JobTypeBatch || jobType == structs.JobTypeSysBatch || jobType == structs.JobTypeWorkflow {
 		onSuccess = false
 	}
 
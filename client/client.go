@@ -1879,6 +1879,9 @@ func (c *Client) registerAndHeartbeat() {
 	// Start watching for emitting node events
 	go c.watchNodeEvents()
 
+	// Start publishing the utilization of the node
+	go c.watchNodeUtilization()
+
 	// Setup the heartbeat timer, for the initial registration
 	// we want to do this quickly. We want to do it extra quickly
 	// in development mode.
@@ -335,6 +335,10 @@ type Config struct {
 	// Volume plugins may ignore this suggestion, but we provide this default.
 	HostVolumesDir string
 
+	// CustomResources are the named countable resources of the node declared
+	// in the client configuration.
+	CustomResources []*structs.NodeCustomResource
+
 	// HostVolumePluginDir is the directory with dynamic host volume plugins.
 	HostVolumePluginDir string
 
@@ -895,6 +899,7 @@ func (c *Config) Copy() *Config {
 	nc.Servers = slices.Clone(nc.Servers)
 	nc.Options = maps.Clone(nc.Options)
 	nc.HostVolumes = structs.CopyMapStringClientHostVolumeConfig(nc.HostVolumes)
+	nc.CustomResources = structs.CopySliceNodeCustomResources(nc.CustomResources)
 	nc.ConsulConfigs = helper.DeepCopyMap(c.ConsulConfigs)
 	nc.VaultConfigs = helper.DeepCopyMap(c.VaultConfigs)
 	nc.TemplateConfig = c.TemplateConfig.Copy()
@@ -0,0 +1,42 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package fingerprint
+
+import (
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// CustomResourcesFingerprint is used to fingerprint the custom resources
+// declared in the client configuration.
+type CustomResourcesFingerprint struct {
+	StaticFingerprinter
+	logger log.Logger
+}
+
+// NewCustomResourcesFingerprint is used to create a custom resources
+// fingerprint
+func NewCustomResourcesFingerprint(logger log.Logger) Fingerprint {
+	f := &CustomResourcesFingerprint{
+		logger: logger.Named("custom_resources"),
+	}
+	return f
+}
+
+func (f *CustomResourcesFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintResponse) error {
+	resources := req.Config.CustomResources
+	if len(resources) == 0 {
+		return nil
+	}
+	if err := structs.ValidateNodeCustomResources(resources); err != nil {
+		f.logger.Warn("invalid custom resources", "error", err)
+		return err
+	}
+
+	resp.NodeResources = &structs.NodeResources{
+		CustomResources: structs.CopySliceNodeCustomResources(resources),
+	}
+	resp.Detected = true
+	return nil
+}
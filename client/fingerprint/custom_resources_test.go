@@ -0,0 +1,43 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package fingerprint
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestCustomResourcesFingerprint(t *testing.T) {
+	ci.Parallel(t)
+
+	f := NewCustomResourcesFingerprint(testlog.HCLogger(t))
+	node := &structs.Node{Attributes: make(map[string]string)}
+
+	// Nothing is detected without custom resources
+	request := &FingerprintRequest{Config: &config.Config{}, Node: node}
+	var response FingerprintResponse
+	must.NoError(t, f.Fingerprint(request, &response))
+	must.False(t, response.Detected)
+	must.Nil(t, response.NodeResources)
+
+	resources := []*structs.NodeCustomResource{
+		{Name: "license_seat", Count: 4},
+		{Name: "nfs-slot", Count: 32},
+	}
+	request.Config.CustomResources = resources
+	response = FingerprintResponse{}
+	must.NoError(t, f.Fingerprint(request, &response))
+	must.True(t, response.Detected)
+	must.Eq(t, resources, response.NodeResources.CustomResources)
+
+	// Invalid custom resources are rejected
+	request.Config.CustomResources = []*structs.NodeCustomResource{{Name: "license seat"}}
+	response = FingerprintResponse{}
+	must.Error(t, f.Fingerprint(request, &response))
+}
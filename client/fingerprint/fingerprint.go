@@ -36,6 +36,7 @@ var (
 		"consul":              NewConsulFingerprint,
 		"cni":                 NewCNIFingerprint, // networks
 		"cpu":                 NewCPUFingerprint,
+		"custom_resources":    NewCustomResourcesFingerprint,
 		"host":                NewHostFingerprint,
 		"landlock":            NewLandlockFingerprint,
 		"memory":              NewMemoryFingerprint,
@@ -24,6 +24,7 @@ type HostStats struct {
 	DiskStats        []*DiskStats
 	AllocDirStats    *DiskStats
 	DeviceStats      []*DeviceGroupStats
+	Pressure         *PressureStats
 	Uptime           uint64
 	Timestamp        int64
 	CPUTicksConsumed float64
@@ -159,6 +160,13 @@ func (h *HostStatsCollector) collectLocked() error {
 	deviceStats := h.collectDeviceGroupStats()
 	hs.DeviceStats = deviceStats
 
+	// Collect pressure stall information
+	pressure, err := h.collectPressureStats()
+	if err != nil {
+		h.logger.Error("failed to collect pressure stats", "error", err)
+	}
+	hs.Pressure = pressure
+
 	// Update the collected status object.
 	h.hostStats = hs
 
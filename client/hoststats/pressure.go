@@ -0,0 +1,51 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PressureStats represents the pressure stall information (PSI) of the host:
+// the percentage of time, averaged over the last 10 seconds, during which at
+// least one task was stalled waiting on the resource.
+type PressureStats struct {
+	CPU    float64
+	Memory float64
+	IO     float64
+}
+
+// parsePressure parses the "some" avg10 value from the content of a file of
+// /proc/pressure, such as:
+//
+//	some avg10=1.53 avg60=0.87 avg300=0.23 total=19385841
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func parsePressure(r io.Reader) (float64, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "some" {
+			continue
+		}
+		for _, field := range fields[1:] {
+			value, ok := strings.CutPrefix(field, "avg10=")
+			if !ok {
+				continue
+			}
+			avg, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid pressure value %q: %w", value, err)
+			}
+			return avg, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("missing some avg10 pressure value")
+}
@@ -0,0 +1,12 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package hoststats
+
+// collectPressureStats returns nil stats as pressure stall information is
+// only available on Linux.
+func (h *HostStatsCollector) collectPressureStats() (*PressureStats, error) {
+	return nil, nil
+}
@@ -0,0 +1,41 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package hoststats
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// pressureDir is the directory exposing the pressure stall information of
+// the host. It is missing on kernels without PSI support.
+const pressureDir = "/proc/pressure"
+
+// collectPressureStats reads the pressure stall information of the host. It
+// returns nil stats if the kernel doesn't support PSI.
+func (h *HostStatsCollector) collectPressureStats() (*PressureStats, error) {
+	if _, err := os.Stat(pressureDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var stats PressureStats
+	for resource, value := range map[string]*float64{
+		"cpu":    &stats.CPU,
+		"memory": &stats.Memory,
+		"io":     &stats.IO,
+	} {
+		f, err := os.Open(filepath.Join(pressureDir, resource))
+		if err != nil {
+			return nil, err
+		}
+		*value, err = parsePressure(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &stats, nil
+}
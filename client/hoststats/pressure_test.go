@@ -0,0 +1,28 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestParsePressure(t *testing.T) {
+	ci.Parallel(t)
+
+	avg, err := parsePressure(strings.NewReader(`some avg10=1.53 avg60=0.87 avg300=0.23 total=19385841
+full avg10=0.50 avg60=0.00 avg300=0.00 total=0
+`))
+	must.NoError(t, err)
+	must.Eq(t, 1.53, avg)
+
+	_, err = parsePressure(strings.NewReader("full avg10=0.50 avg60=0.00 avg300=0.00 total=0\n"))
+	must.ErrorContains(t, err, "missing some avg10 pressure value")
+
+	_, err = parsePressure(strings.NewReader("some avg10=high\n"))
+	must.ErrorContains(t, err, `invalid pressure value "high"`)
+}
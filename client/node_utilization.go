@@ -0,0 +1,86 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/client/hoststats"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// nodeUtilizationIntv is how often the client publishes the utilization
+	// of its node. The servers only write it to raft when it changed
+	// significantly, so the interval mostly bounds the RPC rate.
+	nodeUtilizationIntv = 30 * time.Second
+)
+
+// watchNodeUtilization periodically publishes the utilization of the node,
+// computed from the latest host stats, for the load-aware scheduler
+// algorithm.
+func (c *Client) watchNodeUtilization() {
+	timer := time.NewTimer(helper.RandomStagger(nodeUtilizationIntv))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			util := nodeUtilization(c.hostStatsCollector.Stats())
+			if util != nil {
+				if err := c.submitNodeUtilization(util); err != nil {
+					c.logger.Debug("error submitting node utilization", "error", err)
+				}
+			}
+			timer.Reset(nodeUtilizationIntv)
+		case <-c.shutdownCh:
+			return
+		}
+	}
+}
+
+// submitNodeUtilization is used to publish the utilization of the node.
+func (c *Client) submitNodeUtilization(util *structs.NodeUtilization) error {
+	req := structs.NodeUpdateUtilizationRequest{
+		NodeID:      c.NodeID(),
+		Utilization: util,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.Region(),
+			AuthToken: c.nodeAuthToken(),
+		},
+	}
+	var resp structs.GenericResponse
+	return c.RPC("Node.UpdateUtilization", &req, &resp)
+}
+
+// nodeUtilization computes the utilization of the node from the host stats.
+// It returns nil if the CPU and memory stats haven't been collected yet.
+func nodeUtilization(stats *hoststats.HostStats) *structs.NodeUtilization {
+	if stats == nil || stats.Memory == nil || stats.Memory.Total == 0 || len(stats.CPU) == 0 {
+		return nil
+	}
+
+	util := &structs.NodeUtilization{}
+	for _, cpu := range stats.CPU {
+		util.CPUPercent += cpu.TotalPercent
+	}
+	util.CPUPercent = clampPercent(util.CPUPercent / float64(len(stats.CPU)))
+
+	used := stats.Memory.Total - min(stats.Memory.Available, stats.Memory.Total)
+	util.MemoryPercent = clampPercent(float64(used) / float64(stats.Memory.Total) * 100)
+
+	if stats.Pressure != nil {
+		util.CPUPressure = clampPercent(stats.Pressure.CPU)
+		util.MemoryPressure = clampPercent(stats.Pressure.Memory)
+		util.IOPressure = clampPercent(stats.Pressure.IO)
+	}
+	return util
+}
+
+// clampPercent bounds a percentage computed from host stats between 0 and
+// 100, since sampling can slightly overshoot.
+func clampPercent(value float64) float64 {
+	return min(max(value, 0), 100)
+}
@@ -0,0 +1,41 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/hoststats"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestClient_nodeUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Nil(t, nodeUtilization(nil))
+	must.Nil(t, nodeUtilization(&hoststats.HostStats{}))
+
+	stats := &hoststats.HostStats{
+		Memory: &hoststats.MemoryStats{Total: 1000, Available: 250},
+		CPU: []*hoststats.CPUStats{
+			{CPU: "cpu0", TotalPercent: 20},
+			{CPU: "cpu1", TotalPercent: 60.5},
+		},
+	}
+	must.Eq(t, &structs.NodeUtilization{
+		CPUPercent:    40.25,
+		MemoryPercent: 75,
+	}, nodeUtilization(stats))
+
+	stats.Pressure = &hoststats.PressureStats{CPU: 1.5, Memory: 12, IO: 100.2}
+	must.Eq(t, &structs.NodeUtilization{
+		CPUPercent:     40.25,
+		MemoryPercent:  75,
+		CPUPressure:    1.5,
+		MemoryPressure: 12,
+		IOPressure:     100,
+	}, nodeUtilization(stats))
+}
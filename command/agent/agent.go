@@ -119,6 +119,10 @@ type Agent struct {
 	// instances of the plugins.
 	pluginSingletonLoader loader.PluginCatalog
 
+	// scoringPlugins are the scoring plugin instances launched for the
+	// server. They are killed when the agent shuts down.
+	scoringPlugins []loader.PluginInstance
+
 	shutdown     bool
 	shutdownCh   chan struct{}
 	shutdownLock sync.Mutex
@@ -309,6 +313,12 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.EventBufferSize = int64(*agentConfig.Server.EventBufferSize)
 	}
+	if dir := agentConfig.Server.EventSinkFileDir; dir != "" {
+		if !filepath.IsAbs(dir) {
+			return nil, fmt.Errorf("Invalid Config, event_sink_file_dir must be an absolute path")
+		}
+		conf.EventSinkFileDir = dir
+	}
 	if agentConfig.Autopilot != nil {
 		if agentConfig.Autopilot.CleanupDeadServers != nil {
 			conf.AutopilotConfig.CleanupDeadServers = *agentConfig.Autopilot.CleanupDeadServers
@@ -973,6 +983,11 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 		hvMap[v.Name] = v
 	}
 	conf.HostVolumes = hvMap
+	customResources, err := agentConfig.Client.NodeCustomResources()
+	if err != nil {
+		return nil, fmt.Errorf("invalid custom resources: %v", err)
+	}
+	conf.CustomResources = customResources
 
 	// Setup the node
 	conf.Node = new(structs.Node)
@@ -981,6 +996,11 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.Node.Meta = agentConfig.Client.Meta
 	conf.Node.NodeClass = agentConfig.Client.NodeClass
 	conf.Node.NodePool = agentConfig.Client.NodePool
+	taints, err := agentConfig.Client.NodeTaints()
+	if err != nil {
+		return nil, fmt.Errorf("invalid node taints: %v", err)
+	}
+	conf.Node.Taints = taints
 
 	// Set up the HTTP advertise address
 	conf.Node.HTTPAddr = agentConfig.AdvertiseAddrs.HTTP
@@ -1162,6 +1182,11 @@ func (a *Agent) setupServer() error {
 		return fmt.Errorf("failed to configure keyring: %v", err)
 	}
 
+	// Launch the scoring plugins used by the schedulers
+	if err := a.setupScoringPlugins(conf); err != nil {
+		return fmt.Errorf("failed to setup scoring plugins: %v", err)
+	}
+
 	// Create the server
 	server, err := nomad.NewServer(conf,
 		a.consulCatalog,           // self service discovery
@@ -1498,6 +1523,9 @@ func (a *Agent) Shutdown() error {
 			a.logger.Error("server shutdown failed", "error", err)
 		}
 	}
+	for _, instance := range a.scoringPlugins {
+		instance.Kill()
+	}
 
 	if err := a.consulServices.Shutdown(); err != nil {
 		a.logger.Error("shutting down Consul client failed", "error", err)
@@ -655,6 +655,28 @@ func TestAgent_ServerConfig_RaftSnapshotThreshold(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_EventSinkFileDir(t *testing.T) {
+	ci.Parallel(t)
+
+	conf := DevConfig(nil)
+	must.NoError(t, conf.normalizeAddrs())
+
+	// File sinks are disabled by default
+	nc, err := convertServerConfig(conf)
+	must.NoError(t, err)
+	must.Eq(t, "", nc.EventSinkFileDir)
+
+	conf.Server.EventSinkFileDir = "sinks"
+	_, err = convertServerConfig(conf)
+	must.ErrorContains(t, err, "event_sink_file_dir must be an absolute path")
+
+	dir := t.TempDir()
+	conf.Server.EventSinkFileDir = dir
+	nc, err = convertServerConfig(conf)
+	must.NoError(t, err)
+	must.Eq(t, dir, nc.EventSinkFileDir)
+}
+
 func TestAgent_ServerConfig_RaftProtocol_3(t *testing.T) {
 	ci.Parallel(t)
 
@@ -430,6 +430,16 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		}
 	}
 
+	if _, err := config.Client.NodeTaints(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid node taints: %v", err))
+		return false
+	}
+
+	if _, err := config.Client.NodeCustomResources(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid custom resources: %v", err))
+		return false
+	}
+
 	for _, consul := range config.Consuls {
 		if err := structs.ValidateConsulClusterName(consul.Name); err != nil {
 			c.Ui.Error(fmt.Sprintf("Invalid Consul configuration: %v", err))
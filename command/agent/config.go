@@ -270,6 +270,11 @@ type ClientConfig struct {
 	// pool is created and replicated.
 	NodePool string `hcl:"node_pool"`
 
+	// Taints are set on the node when it first registers, in the
+	// key=value:Effect form. Afterwards they are managed with the node taint
+	// command.
+	Taints []string `hcl:"taints"`
+
 	// Options is used for configuration of nomad internals,
 	// like fingerprinters and drivers. The format is:
 	//
@@ -381,6 +386,10 @@ type ClientConfig struct {
 	// available to jobs running on this node.
 	HostVolumes []*structs.ClientHostVolumeConfig `hcl:"host_volume"`
 
+	// CustomResources are named countable resources of the node, such as
+	// license seats, that tasks can request in their resources block.
+	CustomResources []*CustomResourceConfig `hcl:"custom_resource"`
+
 	// CNIPath is the path to search for CNI plugins, multiple paths can be
 	// specified colon delimited
 	CNIPath string `hcl:"cni_path"`
@@ -451,6 +460,58 @@ type ClientConfig struct {
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
 
+// NodeTaints parses and validates the taints of the client configuration.
+func (c *ClientConfig) NodeTaints() ([]*structs.NodeTaint, error) {
+	if len(c.Taints) == 0 {
+		return nil, nil
+	}
+	taints := make([]*structs.NodeTaint, len(c.Taints))
+	for i, s := range c.Taints {
+		taint, err := structs.ParseNodeTaint(s)
+		if err != nil {
+			return nil, err
+		}
+		taints[i] = taint
+	}
+	if err := structs.ValidateNodeTaints(taints); err != nil {
+		return nil, err
+	}
+	return taints, nil
+}
+
+// NodeCustomResources converts and validates the custom resources of the
+// client configuration.
+func (c *ClientConfig) NodeCustomResources() ([]*structs.NodeCustomResource, error) {
+	if len(c.CustomResources) == 0 {
+		return nil, nil
+	}
+	resources := make([]*structs.NodeCustomResource, len(c.CustomResources))
+	for i, r := range c.CustomResources {
+		if r.Count <= 0 {
+			return nil, fmt.Errorf("Custom resource %q count must be greater than 0", r.Name)
+		}
+		resources[i] = &structs.NodeCustomResource{Name: r.Name, Count: uint64(r.Count)}
+	}
+	if err := structs.ValidateNodeCustomResources(resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// CustomResourceConfig declares a named countable resource of the node.
+type CustomResourceConfig struct {
+	Name  string `hcl:",key"`
+	Count int    `hcl:"count"`
+}
+
+func (c *CustomResourceConfig) Copy() *CustomResourceConfig {
+	if c == nil {
+		return nil
+	}
+	nc := *c
+	return &nc
+}
+
 func (c *ClientConfig) Copy() *ClientConfig {
 	if c == nil {
 		return c
@@ -466,6 +527,8 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc.TemplateConfig = c.TemplateConfig.Copy()
 	nc.ServerJoin = c.ServerJoin.Copy()
 	nc.HostVolumes = helper.CopySlice(c.HostVolumes)
+	nc.CustomResources = helper.CopySlice(c.CustomResources)
+	nc.Taints = slices.Clone(c.Taints)
 	nc.HostNetworks = helper.CopySlice(c.HostNetworks)
 	nc.NomadServiceDiscovery = pointer.Copy(c.NomadServiceDiscovery)
 	nc.Artifact = c.Artifact.Copy()
@@ -720,6 +783,10 @@ type ServerConfig struct {
 	// for the EventBufferSize is 1.
 	EventBufferSize *int `hcl:"event_buffer_size"`
 
+	// EventSinkFileDir is the directory file event sinks can write to. File
+	// sinks are disabled unless it is set.
+	EventSinkFileDir string `hcl:"event_sink_file_dir"`
+
 	// LicensePath is the path to search for an enterprise license.
 	LicensePath string `hcl:"license_path"`
 
@@ -2723,6 +2790,10 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.EventBufferSize = b.EventBufferSize
 	}
 
+	if b.EventSinkFileDir != "" {
+		result.EventSinkFileDir = b.EventSinkFileDir
+	}
+
 	result.JobMaxSourceSize = pointer.Merge(s.JobMaxSourceSize, b.JobMaxSourceSize)
 
 	if b.PlanRejectionTracker != nil {
@@ -2836,6 +2907,9 @@ func (c *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.NodePool != "" {
 		result.NodePool = b.NodePool
 	}
+	if len(b.Taints) != 0 {
+		result.Taints = slices.Clone(b.Taints)
+	}
 	if b.NetworkInterface != "" {
 		result.NetworkInterface = b.NetworkInterface
 	}
@@ -2957,6 +3031,10 @@ func (c *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.HostVolumes = structs.HostVolumeSliceMerge(c.HostVolumes, b.HostVolumes)
 	}
 
+	if len(b.CustomResources) != 0 {
+		result.CustomResources = helper.CopySlice(b.CustomResources)
+	}
+
 	if b.CNIPath != "" {
 		result.CNIPath = b.CNIPath
 	}
@@ -330,6 +330,12 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "host_volume")
 	}
 
+	// Remove CustomResource extra keys
+	for _, cr := range c.Client.CustomResources {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, cr.Name)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "custom_resource")
+	}
+
 	// Remove HostNetwork extra keys
 	for _, hn := range c.Client.HostNetworks {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, hn.Name)
@@ -358,7 +364,7 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "server")
 	}
 
-	for _, k := range []string{"preemption_config"} {
+	for _, k := range []string{"preemption_config", "fair_share_config", "namespace_weights", "rebalance_config", "load_aware_config"} {
 		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, k)
 	}
 
@@ -52,6 +52,7 @@ var basicConfig = &Config{
 		AllocMountsDir: "/tmp/mounts",
 		Servers:        []string{"a.b.c:80", "127.0.0.1:1234"},
 		NodeClass:      "linux-medium-64bit",
+		Taints:         []string{"dedicated=gpu:NoSchedule"},
 		ServerJoin: &ServerJoin{
 			RetryJoin:        []string{"1.1.1.1", "2.2.2.2"},
 			RetryInterval:    time.Duration(15) * time.Second,
@@ -95,6 +96,9 @@ var basicConfig = &Config{
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
+		CustomResources: []*CustomResourceConfig{
+			{Name: "license_seat", Count: 4},
+		},
 		CNIPath:                 "/tmp/cni_path",
 		BridgeNetworkName:       "custom_bridge_name",
 		BridgeNetworkSubnet:     "custom_bridge_subnet",
@@ -146,6 +150,7 @@ var basicConfig = &Config{
 		EncryptKey:                "abc",
 		EnableEventBroker:         pointer.Of(false),
 		EventBufferSize:           pointer.Of(200),
+		EventSinkFileDir:          "/opt/nomad/sinks",
 		PlanRejectionTracker: &PlanRejectionTracker{
 			Enabled:       pointer.Of(true),
 			NodeThreshold: 100,
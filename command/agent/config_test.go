@@ -381,6 +381,7 @@ func TestConfig_Merge(t *testing.T) {
 			UpgradeVersion:         "bar",
 			EnableEventBroker:      pointer.Of(true),
 			EventBufferSize:        pointer.Of(100),
+			EventSinkFileDir:       "/opt/nomad/sinks",
 			PlanRejectionTracker: &PlanRejectionTracker{
 				Enabled:       pointer.Of(true),
 				NodeThreshold: 100,
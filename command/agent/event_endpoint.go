@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/pkg/ioutils"
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"golang.org/x/sync/errgroup"
@@ -74,6 +75,14 @@ func (s *HTTPServer) EventStream(resp http.ResponseWriter, req *http.Request) (i
 	// Set region, namespace and authtoken to args
 	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
 
+	// Reject an invalid filter before the response is started, since the
+	// error from the stream would otherwise follow a 200 status.
+	if args.Filter != "" {
+		if _, err := bexpr.CreateEvaluator(args.Filter); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Invalid filter: %v", err))
+		}
+	}
+
 	// Determine the RPC handler to use to find a server
 	var handler structs.StreamingRpcHandler
 	var handlerErr error
@@ -124,6 +124,76 @@ func TestEventStream_NamespaceQuery(t *testing.T) {
 	})
 }
 
+func TestEventStream_Filter(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		filter := url.QueryEscape(`Payload.ID == "456"`)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/v1/event/stream?filter="+filter, nil)
+		require.Nil(t, err)
+		resp := httptest.NewRecorder()
+
+		respErrCh := make(chan error)
+		go func() {
+			_, err = s.Server.EventStream(resp, req)
+			respErrCh <- err
+			assert.NoError(t, err)
+		}()
+
+		pub, err := s.Agent.server.State().EventBroker()
+		require.NoError(t, err)
+
+		badID := uuid.Generate()
+		pub.Publish(&structs.Events{Index: 100, Events: []structs.Event{{Payload: testEvent{ID: badID}}}})
+		pub.Publish(&structs.Events{Index: 101, Events: []structs.Event{{Payload: testEvent{ID: "456"}}}})
+
+		testutil.WaitForResult(func() (bool, error) {
+			got := resp.Body.String()
+			want := `"ID":"456"`
+			if strings.Contains(got, badID) {
+				return false, fmt.Errorf("expected non matching event to be filtered, got:%v", got)
+			}
+			if strings.Contains(got, want) {
+				return true, nil
+			}
+
+			return false, fmt.Errorf("missing expected json, got: %v, want: %v", got, want)
+		}, func(err error) {
+			require.Fail(t, err.Error())
+		})
+
+		cancel()
+		select {
+		case err := <-respErrCh:
+			require.Nil(t, err)
+		case <-time.After(1 * time.Second):
+			require.Fail(t, "waiting for request cancellation")
+		}
+	})
+}
+
+func TestEventStream_Filter_Invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		filter := url.QueryEscape(`Payload.ID ==`)
+		req, err := http.NewRequest(http.MethodGet, "/v1/event/stream?filter="+filter, nil)
+		require.Nil(t, err)
+		resp := httptest.NewRecorder()
+
+		_, err = s.Server.EventStream(resp, req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Invalid filter")
+
+		codedErr, ok := err.(HTTPCodedError)
+		require.True(t, ok)
+		require.Equal(t, http.StatusBadRequest, codedErr.Code())
+	})
+}
+
 func TestEventStream_QueryParse(t *testing.T) {
 	ci.Parallel(t)
 
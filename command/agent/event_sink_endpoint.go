@@ -0,0 +1,160 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) EventSinksRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.eventSinkList(resp, req)
+	case http.MethodPut, http.MethodPost:
+		return s.eventSinkRegister(resp, req, "")
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) EventSinksStatusRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+	return s.eventSinkStatus(resp, req, "")
+}
+
+func (s *HTTPServer) EventSinkSpecificRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/v1/event/sink/")
+	switch {
+	case strings.HasSuffix(path, "/status"):
+		if req.Method != http.MethodGet {
+			return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+		}
+		id := strings.TrimSuffix(path, "/status")
+		if len(id) == 0 {
+			return nil, CodedError(http.StatusBadRequest, "Missing event sink ID")
+		}
+		return s.eventSinkStatus(resp, req, id)
+	case len(path) == 0:
+		return nil, CodedError(http.StatusBadRequest, "Missing event sink ID")
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		return s.eventSinkQuery(resp, req, path)
+	case http.MethodPut, http.MethodPost:
+		return s.eventSinkRegister(resp, req, path)
+	case http.MethodDelete:
+		return s.eventSinkDeregister(resp, req, path)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) eventSinkList(resp http.ResponseWriter, req *http.Request) (any, error) {
+	args := structs.EventSinkListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.EventSinkListResponse
+	if err := s.agent.RPC("EventSink.List", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Sinks == nil {
+		out.Sinks = make([]*structs.EventSink, 0)
+	}
+	return out.Sinks, nil
+}
+
+func (s *HTTPServer) eventSinkQuery(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	args := structs.EventSinkSpecificRequest{
+		ID: id,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.EventSinkResponse
+	if err := s.agent.RPC("EventSink.GetSink", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Sink == nil {
+		return nil, CodedError(http.StatusNotFound, "event sink not found")
+	}
+	return out.Sink, nil
+}
+
+func (s *HTTPServer) eventSinkStatus(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	args := structs.EventSinkStatusRequest{
+		ID: id,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.EventSinkStatusResponse
+	if err := s.agent.RPC("EventSink.Status", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if id == "" {
+		if out.Statuses == nil {
+			out.Statuses = make([]*structs.EventSinkStatus, 0)
+		}
+		return out.Statuses, nil
+	}
+	if len(out.Statuses) == 0 {
+		return nil, CodedError(http.StatusNotFound, "event sink not found")
+	}
+	return out.Statuses[0], nil
+}
+
+func (s *HTTPServer) eventSinkRegister(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	var sink structs.EventSink
+	if err := decodeBody(req, &sink); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	if id != "" && sink.ID != id {
+		return nil, CodedError(http.StatusBadRequest, "Event sink ID does not match request path")
+	}
+
+	args := structs.EventSinkRegisterRequest{
+		Sink: &sink,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("EventSink.Register", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+func (s *HTTPServer) eventSinkDeregister(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	args := structs.EventSinkDeregisterRequest{
+		IDs: []string{id},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("EventSink.Deregister", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
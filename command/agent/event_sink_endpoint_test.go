@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_EventSink_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		sink := mock.EventSink()
+
+		// Register the sink.
+		req, err := http.NewRequest(http.MethodPut, "/v1/event/sinks", encodeReq(sink))
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.EventSinksRequest(respW, req)
+		must.NoError(t, err)
+		must.NotEq(t, "", respW.Header().Get("X-Nomad-Index"))
+
+		// List the sinks.
+		req, err = http.NewRequest(http.MethodGet, "/v1/event/sinks", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err := s.Server.EventSinksRequest(respW, req)
+		must.NoError(t, err)
+		must.SliceLen(t, 1, obj.([]*structs.EventSink))
+
+		// Read the sink.
+		req, err = http.NewRequest(http.MethodGet, "/v1/event/sink/"+sink.ID, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.EventSinkSpecificRequest(respW, req)
+		must.NoError(t, err)
+		must.True(t, sink.SameConfig(obj.(*structs.EventSink)))
+
+		// Read the status of the sink, and of all sinks.
+		req, err = http.NewRequest(http.MethodGet, "/v1/event/sink/"+sink.ID+"/status", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.EventSinkSpecificRequest(respW, req)
+		must.NoError(t, err)
+		must.Eq(t, sink.ID, obj.(*structs.EventSinkStatus).ID)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/event/sinks/status", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.EventSinksStatusRequest(respW, req)
+		must.NoError(t, err)
+		must.SliceLen(t, 1, obj.([]*structs.EventSinkStatus))
+
+		// Deregister the sink.
+		req, err = http.NewRequest(http.MethodDelete, "/v1/event/sink/"+sink.ID, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.EventSinkSpecificRequest(respW, req)
+		must.NoError(t, err)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/event/sink/"+sink.ID, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.EventSinkSpecificRequest(respW, req)
+		must.ErrorContains(t, err, "event sink not found")
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/event/sink/"+sink.ID+"/status", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.EventSinkSpecificRequest(respW, req)
+		must.ErrorContains(t, err, "event sink not found")
+	})
+}
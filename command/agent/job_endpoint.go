@@ -87,6 +87,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/summary"):
 		jobID := strings.TrimSuffix(path, "/summary")
 		return s.jobSummaryRequest(resp, req, jobID)
+	case strings.HasSuffix(path, "/steps"):
+		jobID := strings.TrimSuffix(path, "/steps")
+		return s.jobWorkflowSteps(resp, req, jobID)
 	case strings.HasSuffix(path, "/dispatch"):
 		jobID := strings.TrimSuffix(path, "/dispatch")
 		return s.jobDispatchRequest(resp, req, jobID)
@@ -195,6 +198,7 @@ func (s *HTTPServer) jobPlan(resp http.ResponseWriter, req *http.Request,
 		Job:            sJob,
 		Diff:           args.Diff,
 		PolicyOverride: args.PolicyOverride,
+		Explain:        args.Explain,
 		WriteRequest:   *writeReq,
 	}
 
@@ -885,6 +889,29 @@ func (s *HTTPServer) jobSummaryRequest(resp http.ResponseWriter, req *http.Reque
 	return out.JobSummary, nil
 }
 
+func (s *HTTPServer) jobWorkflowSteps(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+	args := structs.JobWorkflowStepsRequest{
+		JobID: jobID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobWorkflowStepsResponse
+	if err := s.agent.RPC("Job.WorkflowSteps", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Steps == nil {
+		return nil, CodedError(404, "job not found")
+	}
+	return out.Steps, nil
+}
+
 func (s *HTTPServer) jobDispatchRequest(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -1151,6 +1178,8 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		VaultNamespace: *job.VaultNamespace,
 		Constraints:    ApiConstraintsToStructs(job.Constraints),
 		Affinities:     ApiAffinitiesToStructs(job.Affinities),
+		Tolerations:    ApiTolerationsToStructs(job.Tolerations),
+		JobAffinities:  ApiJobAffinitiesToStructs(job.JobAffinities),
 		UI:             ApiJobUIConfigToStructs(job.UI),
 		VersionTag:     ApiJobVersionTagToStructs(job.VersionTag),
 	}
@@ -1193,6 +1222,24 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		}
 	}
 
+	if job.ScheduleWindow != nil {
+		j.ScheduleWindow = &structs.ScheduleWindow{
+			Cron:     *job.ScheduleWindow.Cron,
+			Duration: *job.ScheduleWindow.Duration,
+			TimeZone: *job.ScheduleWindow.TimeZone,
+		}
+	}
+
+	if l := len(job.DependsOn); l != 0 {
+		j.DependsOn = make([]*structs.JobDependency, l)
+		for i, d := range job.DependsOn {
+			j.DependsOn[i] = &structs.JobDependency{
+				JobID:  *d.JobID,
+				Status: *d.Status,
+			}
+		}
+	}
+
 	if job.ParameterizedJob != nil {
 		j.ParameterizedJob = &structs.ParameterizedJobConfig{
 			Payload:      job.ParameterizedJob.Payload,
@@ -1237,6 +1284,8 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	tg.Meta = taskGroup.Meta
 	tg.Constraints = ApiConstraintsToStructs(taskGroup.Constraints)
 	tg.Affinities = ApiAffinitiesToStructs(taskGroup.Affinities)
+	tg.Tolerations = ApiTolerationsToStructs(taskGroup.Tolerations)
+	tg.JobAffinities = ApiJobAffinitiesToStructs(taskGroup.JobAffinities)
 	tg.Networks = ApiNetworkResourceToStructs(taskGroup.Networks)
 	tg.Services = ApiServicesToStructs(taskGroup.Services, true)
 	tg.Consul = apiConsulToStructs(taskGroup.Consul)
@@ -1279,6 +1328,30 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		}
 	}
 
+	if taskGroup.Gang != nil {
+		tg.Gang = &structs.Gang{
+			Name:    *taskGroup.Gang.Name,
+			Timeout: *taskGroup.Gang.Timeout,
+		}
+	}
+
+	tg.After = slices.Clone(taskGroup.After)
+
+	if taskGroup.IndexedBatch != nil {
+		tg.IndexedBatch = &structs.IndexedBatch{
+			Completions:          *taskGroup.IndexedBatch.Completions,
+			Parallelism:          *taskGroup.IndexedBatch.Parallelism,
+			BackoffLimitPerIndex: *taskGroup.IndexedBatch.BackoffLimitPerIndex,
+		}
+	}
+
+	if taskGroup.DisruptionBudget != nil {
+		tg.DisruptionBudget = &structs.DisruptionBudget{
+			MinHealthy:     *taskGroup.DisruptionBudget.MinHealthy,
+			MaxUnavailable: *taskGroup.DisruptionBudget.MaxUnavailable,
+		}
+	}
+
 	if taskGroup.Migrate != nil {
 		tg.Migrate = &structs.MigrateStrategy{
 			MaxParallel:     *taskGroup.Migrate.MaxParallel,
@@ -1636,6 +1709,20 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		}
 	}
 
+	if len(in.CustomResources) > 0 {
+		out.CustomResources = make(structs.ResourceCustomResources, 0, len(in.CustomResources))
+		for _, c := range in.CustomResources {
+			count := uint64(1)
+			if c.Count != nil {
+				count = *c.Count
+			}
+			out.CustomResources = append(out.CustomResources, &structs.RequestedCustomResource{
+				Name:  c.Name,
+				Count: count,
+			})
+		}
+	}
+
 	if in.NUMA != nil {
 		out.NUMA = &structs.NUMA{
 			Affinity: in.NUMA.Affinity,
@@ -2263,6 +2350,47 @@ func ApiAffinitiesToStructs(in []*api.Affinity) []*structs.Affinity {
 	return out
 }
 
+func ApiTolerationsToStructs(in []*api.Toleration) []*structs.Toleration {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*structs.Toleration, len(in))
+	for i, t := range in {
+		out[i] = &structs.Toleration{
+			Key:      t.Key,
+			Operator: t.Operator,
+			Value:    t.Value,
+			Effect:   t.Effect,
+		}
+	}
+
+	return out
+}
+
+func ApiJobAffinitiesToStructs(in []*api.JobAffinity) []*structs.JobAffinity {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*structs.JobAffinity, len(in))
+	for i, a := range in {
+		out[i] = &structs.JobAffinity{
+			Namespace:   a.Namespace,
+			JobID:       a.JobID,
+			TaskGroup:   a.TaskGroup,
+			TopologyKey: a.TopologyKey,
+			Anti:        a.Anti,
+			Required:    a.Required,
+		}
+		if a.Weight != nil {
+			out[i].Weight = *a.Weight
+		}
+	}
+
+	return out
+}
+
 func ApiJobUIConfigToStructs(jobUI *api.JobUIConfig) *structs.JobUIConfig {
 	if jobUI == nil {
 		return nil
@@ -2312,6 +2440,8 @@ func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
 	ret.Weight = *a1.Weight
+	ret.MaxSkew = a1.MaxSkew
+	ret.WhenUnsatisfiable = a1.WhenUnsatisfiable
 	if a1.SpreadTarget != nil {
 		ret.SpreadTarget = make([]*structs.SpreadTarget, len(a1.SpreadTarget))
 		for i, st := range a1.SpreadTarget {
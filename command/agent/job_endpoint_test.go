@@ -2747,6 +2747,14 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			ProhibitOverlap: pointer.Of(true),
 			TimeZone:        pointer.Of("test zone"),
 		},
+		ScheduleWindow: &api.ScheduleWindow{
+			Cron:     pointer.Of("0 22 * * *"),
+			Duration: pointer.Of(8 * time.Hour),
+			TimeZone: pointer.Of("test zone"),
+		},
+		DependsOn: []*api.JobDependency{
+			{JobID: pointer.Of("upstream"), Status: pointer.Of("failed")},
+		},
 		ParameterizedJob: &api.ParameterizedJobConfig{
 			Payload:      "payload",
 			MetaRequired: []string{"a", "b"},
@@ -3184,6 +3192,14 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			ProhibitOverlap: true,
 			TimeZone:        "test zone",
 		},
+		ScheduleWindow: &structs.ScheduleWindow{
+			Cron:     "0 22 * * *",
+			Duration: 8 * time.Hour,
+			TimeZone: "test zone",
+		},
+		DependsOn: []*structs.JobDependency{
+			{JobID: "upstream", Status: "failed"},
+		},
 		ParameterizedJob: &structs.ParameterizedJobConfig{
 			Payload:      "payload",
 			MetaRequired: []string{"a", "b"},
@@ -297,6 +297,27 @@ func (s *HTTPServer) schedulerGetConfig(resp http.ResponseWriter, req *http.Requ
 	return reply, nil
 }
 
+// OperatorSchedulerRebalance is used to report the migrations the rebalancer
+// would make, without making them.
+func (s *HTTPServer) OperatorSchedulerRebalance(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.SchedulerRebalanceReportResponse
+	if err := s.agent.RPC("Operator.SchedulerRebalanceReport", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply.Report, nil
+}
+
 func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var args structs.SchedulerSetConfigRequest
 	s.parseWriteRequest(req, &args.WriteRequest)
@@ -317,6 +338,20 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 			BatchSchedulerEnabled:    conf.PreemptionConfig.BatchSchedulerEnabled,
 			ServiceSchedulerEnabled:  conf.PreemptionConfig.ServiceSchedulerEnabled,
 		},
+		FairShareConfig: structs.FairShareConfig{
+			Enabled:          conf.FairShareConfig.Enabled,
+			NamespaceWeights: conf.FairShareConfig.NamespaceWeights,
+		},
+		RebalanceConfig: structs.RebalanceConfig{
+			Enabled:              conf.RebalanceConfig.Enabled,
+			DryRun:               conf.RebalanceConfig.DryRun,
+			MaxMigrationsPerHour: conf.RebalanceConfig.MaxMigrationsPerHour,
+		},
+		LoadAwareConfig: structs.LoadAwareConfig{
+			UsageWeight:        conf.LoadAwareConfig.UsageWeight,
+			PressureThreshold:  conf.LoadAwareConfig.PressureThreshold,
+			BestEffortPriority: conf.LoadAwareConfig.BestEffortPriority,
+		},
 	}
 
 	if err := args.Config.Validate(); err != nil {
@@ -9,10 +9,18 @@ import (
 	"github.com/hashicorp/nomad/helper/pluginutils/catalog"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pluginutils/singleton"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/scoring"
 )
 
-// setupPlugins is used to setup the plugin loaders.
+// setupPlugins is used to setup the plugin loaders. It is safe to call more
+// than once, as both the server and the client may need the loaders.
 func (a *Agent) setupPlugins() error {
+	if a.pluginLoader != nil {
+		return nil
+	}
+
 	// Get our internal plugins
 	internal, err := a.internalPluginConfigs()
 	if err != nil {
@@ -45,6 +53,38 @@ func (a *Agent) setupPlugins() error {
 	return nil
 }
 
+// setupScoringPlugins launches the scoring plugins found by the plugin loader
+// and adds them to the server config.
+func (a *Agent) setupScoringPlugins(conf *nomad.Config) error {
+	// Scoring plugins are always external, so the loaders are only needed
+	// when plugins are configured.
+	if len(a.config.Plugins) == 0 {
+		return nil
+	}
+	if err := a.setupPlugins(); err != nil {
+		return err
+	}
+
+	for _, info := range a.pluginLoader.Catalog()[base.PluginTypeScoring] {
+		instance, err := a.pluginLoader.Dispense(info.Name, base.PluginTypeScoring, nil, a.logger)
+		if err != nil {
+			return fmt.Errorf("failed to launch scoring plugin %q: %v", info.Name, err)
+		}
+		a.scoringPlugins = append(a.scoringPlugins, instance)
+
+		impl, ok := instance.Plugin().(scoring.ScoringPlugin)
+		if !ok {
+			return fmt.Errorf("plugin %q does not implement the scoring plugin interface", info.Name)
+		}
+		if conf.ScoringPlugins == nil {
+			conf.ScoringPlugins = make(map[string]scoring.ScoringPlugin)
+		}
+		conf.ScoringPlugins[info.Name] = impl
+	}
+
+	return nil
+}
+
 func (a *Agent) internalPluginConfigs() (map[loader.PluginID]*loader.InternalPluginConfig, error) {
 	// Get the registered plugins
 	catalog := catalog.Catalog()
@@ -0,0 +1,53 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestApiQuotaSpecToStructs(t *testing.T) {
+	ci.Parallel(t)
+
+	spec := &api.QuotaSpec{
+		Name:        "web",
+		Description: "web quota",
+		Limits: []*api.QuotaLimit{{
+			Region: "global",
+			RegionLimit: &api.QuotaResources{
+				CPU:      pointer.Of(2000),
+				MemoryMB: pointer.Of(1024),
+			},
+		}},
+	}
+
+	out, err := ApiQuotaSpecToStructs(spec)
+	must.NoError(t, err)
+	must.Eq(t, &structs.QuotaSpec{
+		Name:        "web",
+		Description: "web quota",
+		Limits: []*structs.QuotaLimit{{
+			Region:      "global",
+			RegionLimit: &structs.QuotaResources{CPU: 2000, MemoryMB: 1024},
+		}},
+	}, out)
+
+	// Dimensions that aren't enforced are rejected.
+	spec.Limits[0].RegionLimit.Devices = []*api.RequestedDevice{{Name: "nvidia/gpu"}}
+	spec.Limits[0].RegionLimit.Storage = &api.QuotaStorageResources{VariablesMB: 10}
+	_, err = ApiQuotaSpecToStructs(spec)
+	must.EqError(t, err, `quota limit for region "global": unsupported dimensions: device, storage`)
+
+	spec.Limits[0].RegionLimit.Devices = nil
+	spec.Limits[0].RegionLimit.Storage = nil
+	spec.Limits[0].VariablesLimit = pointer.Of(10)
+	_, err = ApiQuotaSpecToStructs(spec)
+	must.ErrorContains(t, err, "variables_limit is not supported")
+}
@@ -48,6 +48,7 @@ client {
   alloc_mounts_dir = "/tmp/mounts"
   servers          = ["a.b.c:80", "127.0.0.1:1234"]
   node_class       = "linux-medium-64bit"
+  taints           = ["dedicated=gpu:NoSchedule"]
 
   meta {
     foo = "bar"
@@ -103,6 +104,10 @@ client {
     path = "/tmp"
   }
 
+  custom_resource "license_seat" {
+    count = 4
+  }
+
   cni_path                   = "/tmp/cni_path"
   bridge_network_name        = "custom_bridge_name"
   bridge_network_subnet      = "custom_bridge_subnet"
@@ -148,6 +153,7 @@ server {
   raft_multiplier               = 4
   enable_event_broker           = false
   event_buffer_size             = 200
+  event_sink_file_dir           = "/opt/nomad/sinks"
   job_default_priority          = 100
   job_max_priority              = 200
   job_max_count                 = 1000
@@ -17,6 +17,9 @@ import (
 var (
 	renewLockQueryParam = "lock-renew"
 
+	historyQueryParam = "history"
+	versionQueryParam = "version"
+
 	acquireLockQueryParam = string(structs.VarOpLockAcquire)
 	releaseLockQueryParam = string(structs.VarOpLockRelease)
 )
@@ -52,6 +55,9 @@ func (s *HTTPServer) VariableSpecificRequest(resp http.ResponseWriter, req *http
 
 	switch req.Method {
 	case http.MethodGet:
+		if _, ok := req.URL.Query()[historyQueryParam]; ok {
+			return s.variableHistoryQuery(resp, req, path)
+		}
 		return s.variableQuery(resp, req, path)
 	case http.MethodPut, http.MethodPost:
 		urlParams := req.URL.Query()
@@ -150,6 +156,14 @@ func (s *HTTPServer) variableQuery(resp http.ResponseWriter, req *http.Request,
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, CodedError(http.StatusBadRequest, "failed to parse parameters")
 	}
+	if vq := req.URL.Query().Get(versionQueryParam); vq != "" {
+		version, err := strconv.ParseUint(vq, 10, 64)
+		if err != nil {
+			return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("can not parse version: %v", err))
+		}
+		args.Version = version
+	}
+
 	var out structs.VariablesReadResponse
 	if err := s.agent.RPC(structs.VariablesReadRPCMethod, &args, &out); err != nil {
 		return nil, err
@@ -158,11 +172,36 @@ func (s *HTTPServer) variableQuery(resp http.ResponseWriter, req *http.Request,
 	setMeta(resp, &out.QueryMeta)
 
 	if out.Data == nil {
+		if args.Version != 0 {
+			return nil, CodedError(http.StatusNotFound, "variable version not found")
+		}
 		return nil, CodedError(http.StatusNotFound, "variable not found")
 	}
 	return out.Data, nil
 }
 
+func (s *HTTPServer) variableHistoryQuery(resp http.ResponseWriter, req *http.Request,
+	path string) (interface{}, error) {
+	args := structs.VariablesHistoryRequest{
+		Path: path,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, CodedError(http.StatusBadRequest, "failed to parse parameters")
+	}
+
+	var out structs.VariablesHistoryResponse
+	if err := s.agent.RPC(structs.VariablesHistoryRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+
+	if out.Versions == nil {
+		out.Versions = make([]*structs.VariableVersionMetadata, 0)
+	}
+	return out.Versions, nil
+}
+
 func (s *HTTPServer) variableUpsert(resp http.ResponseWriter, req *http.Request,
 	path string) (interface{}, error) {
 
@@ -129,7 +129,7 @@ func (c *AllocStopCommand) Run(args []string) int {
 
 	opts := &api.QueryOptions{Params: map[string]string{}}
 
-	if alloc.Stub().JobType == "batch" {
+	if jobType := alloc.Stub().JobType; jobType == api.JobTypeBatch || jobType == api.JobTypeWorkflow {
 		opts.Params["reschedule"] = "true"
 	}
 
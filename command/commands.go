@@ -403,6 +403,31 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"event": func() (cli.Command, error) {
+			return &EventCommand{
+				Meta: meta,
+			}, nil
+		},
+		"event sink": func() (cli.Command, error) {
+			return &EventSinkCommand{
+				Meta: meta,
+			}, nil
+		},
+		"event sink deregister": func() (cli.Command, error) {
+			return &EventSinkDeregisterCommand{
+				Meta: meta,
+			}, nil
+		},
+		"event sink register": func() (cli.Command, error) {
+			return &EventSinkRegisterCommand{
+				Meta: meta,
+			}, nil
+		},
+		"event sink status": func() (cli.Command, error) {
+			return &EventSinkStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 		"exec": func() (cli.Command, error) {
 			return &AllocExecCommand{
 				Meta: meta,
@@ -691,6 +716,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"node taint": func() (cli.Command, error) {
+			return &NodeTaintCommand{
+				Meta: meta,
+			}, nil
+		},
 		"node pool": func() (cli.Command, error) {
 			return &NodePoolCommand{
 				Meta: meta,
@@ -869,11 +899,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator scheduler rebalance-report": func() (cli.Command, error) {
+			return &OperatorSchedulerRebalanceReport{
+				Meta: meta,
+			}, nil
+		},
 		"operator scheduler set-config": func() (cli.Command, error) {
 			return &OperatorSchedulerSetConfig{
 				Meta: meta,
 			}, nil
 		},
+		"operator scheduler simulate": func() (cli.Command, error) {
+			return &OperatorSchedulerSimulateCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator root": func() (cli.Command, error) {
 			return &OperatorRootCommand{
 				Meta: meta,
@@ -1025,6 +1065,31 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"reservation": func() (cli.Command, error) {
+			return &ReservationCommand{
+				Meta: meta,
+			}, nil
+		},
+		"reservation apply": func() (cli.Command, error) {
+			return &ReservationApplyCommand{
+				Meta: meta,
+			}, nil
+		},
+		"reservation delete": func() (cli.Command, error) {
+			return &ReservationDeleteCommand{
+				Meta: meta,
+			}, nil
+		},
+		"reservation list": func() (cli.Command, error) {
+			return &ReservationListCommand{
+				Meta: meta,
+			}, nil
+		},
+		"reservation status": func() (cli.Command, error) {
+			return &ReservationStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 
 		"run": func() (cli.Command, error) {
 			return &JobRunCommand{
@@ -1263,6 +1328,16 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"var history": func() (cli.Command, error) {
+			return &VarHistoryCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var rollback": func() (cli.Command, error) {
+			return &VarRollbackCommand{
+				Meta: meta,
+			}, nil
+		},
 		"version": func() (cli.Command, error) {
 			return &VersionCommand{
 				Version: version.GetVersion(),
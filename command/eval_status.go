@@ -273,6 +273,11 @@ func (c *EvalStatusCommand) formatEvalStatus(eval *api.Evaluation, placedAllocs
 			allocsOut := formatPreemptedAllocListStubs(eval.PlanAnnotations.PreemptedAllocs, length)
 			c.Ui.Output(allocsOut)
 		}
+
+		if len(eval.PlanAnnotations.SpreadViolations) > 0 {
+			c.Ui.Output(c.Colorize().Color("\n[bold]Spread Violations[reset]"))
+			c.Ui.Output(formatSpreadViolations(eval.PlanAnnotations.SpreadViolations))
+		}
 	}
 	if len(placedAllocs) > 0 {
 		c.Ui.Output(c.Colorize().Color("\n[bold]Placed Allocations[reset]"))
@@ -365,6 +370,28 @@ func formatPreemptedAllocListStubs(stubs []*api.AllocationListStub, uuidLength i
 	return formatList(allocs)
 }
 
+// formatSpreadViolations produces a table with one row per spread whose skew
+// exceeds its max_skew, with the number of allocations per attribute value.
+func formatSpreadViolations(violations []*api.SpreadViolation) string {
+	rows := make([]string, 0, len(violations)+1)
+	rows = append(rows, "Task Group|Attribute|Max Skew|Skew|Allocations")
+	for _, v := range violations {
+		values := make([]string, 0, len(v.Counts))
+		for value := range v.Counts {
+			values = append(values, value)
+		}
+		slices.Sort(values)
+
+		counts := make([]string, 0, len(values))
+		for _, value := range values {
+			counts = append(counts, fmt.Sprintf("%s=%d", value, v.Counts[value]))
+		}
+		rows = append(rows, fmt.Sprintf("%s|%s|%d|%d|%s",
+			v.TaskGroup, v.Attribute, v.MaxSkew, v.Skew, strings.Join(counts, ", ")))
+	}
+	return formatList(rows)
+}
+
 // formatPlanAnnotations produces a table with one row per task group where the
 // columns are all the changes (ignore, place, stop, etc.) plus all the non-zero
 // causes of those changes (migrate, canary, reschedule, etc)
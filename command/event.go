@@ -0,0 +1,45 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+
+	"github.com/hashicorp/cli"
+)
+
+type EventCommand struct {
+	Meta
+}
+
+func (c *EventCommand) Name() string {
+	return "event"
+}
+
+func (c *EventCommand) Synopsis() string {
+	return "Interact with the event stream"
+}
+
+func (c *EventCommand) Help() string {
+	helpText := `
+Usage: nomad event <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with the event stream.
+
+  Register a durable event sink:
+
+    $ nomad event sink register <path>
+
+  Display the delivery status of the event sinks:
+
+    $ nomad event sink status
+
+  Please refer to individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EventCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
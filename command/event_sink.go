@@ -0,0 +1,119 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type EventSinkCommand struct {
+	Meta
+}
+
+func (c *EventSinkCommand) Name() string {
+	return "event sink"
+}
+
+func (c *EventSinkCommand) Synopsis() string {
+	return "Interact with durable event sinks"
+}
+
+func (c *EventSinkCommand) Help() string {
+	helpText := `
+Usage: nomad event sink <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with durable event sinks.
+  The leader delivers the events matching the topics, namespace and optional
+  filter expression of each sink at least once, in index order, either by
+  POSTing them to a webhook or by appending them to a newline delimited JSON
+  file. The index of the last delivered event is checkpointed in raft, so a
+  new leader resumes delivery where the previous one stopped. Events evicted
+  from the event buffer before they were delivered are reported as missed by
+  "nomad event sink status".
+
+  Create or update a sink:
+
+    $ nomad event sink register <path>
+
+  Display the delivery status of all sinks:
+
+    $ nomad event sink status
+
+  Display the delivery status of a sink:
+
+    $ nomad event sink status <id>
+
+  Delete a sink:
+
+    $ nomad event sink deregister <id>
+
+  Please refer to individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EventSinkCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// formatEventSinkDestination returns the address or path events are
+// delivered to.
+func formatEventSinkDestination(sink *api.EventSink) string {
+	if sink.Type == api.EventSinkFile {
+		return sink.Path
+	}
+	return sink.Address
+}
+
+// formatEventSinkTopics returns the topics and keys of a sink, sorted by
+// topic.
+func formatEventSinkTopics(topics map[api.Topic][]string) string {
+	out := make([]string, 0, len(topics))
+	for topic, keys := range topics {
+		out = append(out, fmt.Sprintf("%s[%s]", topic, strings.Join(keys, ",")))
+	}
+	sort.Strings(out)
+	return strings.Join(out, " ")
+}
+
+func formatEventSinkStatusList(statuses []*api.EventSinkStatus) string {
+	out := make([]string, len(statuses)+1)
+	out[0] = "ID|Type|Status|Delivered Index|Lag"
+	for i, status := range statuses {
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%d|%d",
+			status.ID,
+			status.Type,
+			status.Status,
+			status.DeliveredIndex,
+			status.Lag,
+		)
+	}
+	return formatList(out)
+}
+
+func eventSinkPredictor(factory ApiClientFactory) complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := factory()
+		if err != nil {
+			return nil
+		}
+
+		sinks, _, err := client.EventSinks().PrefixList(a.Last, nil)
+		if err != nil {
+			return nil
+		}
+
+		ids := make([]string, len(sinks))
+		for i, sink := range sinks {
+			ids[i] = sink.ID
+		}
+		return ids
+	})
+}
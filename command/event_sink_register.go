@@ -0,0 +1,220 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/hcl"
+	"github.com/posener/complete"
+)
+
+type EventSinkRegisterCommand struct {
+	Meta
+}
+
+func (c *EventSinkRegisterCommand) Name() string {
+	return "event sink register"
+}
+
+func (c *EventSinkRegisterCommand) Synopsis() string {
+	return "Create or update a durable event sink"
+}
+
+func (c *EventSinkRegisterCommand) Help() string {
+	helpText := `
+Usage: nomad event sink register [options] <input>
+
+  Register is used to create or update a durable event sink from an HCL or
+  JSON specification. A new sink receives the events that follow its
+  registration, while updating a sink keeps its delivery progress. If the
+  input is "-", the specification is read from stdin.
+
+  Webhook sinks receive each batch of events as a JSON POST request, and any
+  response other than a 2xx is retried. File sinks append each batch of events
+  as a line of JSON to a file on whichever server is the leader. File sinks are
+  only accepted if the servers set the event_sink_file_dir option, and their
+  path must be within that directory. Events may be delivered again after a
+  leader election, and can be deduplicated using their index.
+
+  If ACLs are enabled, this command requires a management token.
+
+  An example specification:
+
+    sink "audit" {
+      type      = "webhook"
+      address   = "https://audit.example.com/nomad"
+      namespace = "*"
+      filter    = "Type == \"JobRegistered\""
+
+      topics = {
+        Job        = ["*"]
+        Deployment = ["*"]
+      }
+    }
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Register Options:
+
+  -filter
+    Specifies an expression used to filter the events delivered to the sink,
+    overriding the filter of the specification. Events whose payload doesn't
+    contain a field the expression selects are not delivered.
+
+  -json
+    Parse the input as JSON.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EventSinkRegisterCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-filter": complete.PredictAnything,
+			"-json":   complete.PredictNothing,
+		})
+}
+
+func (c *EventSinkRegisterCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.hcl"),
+		complete.PredictFiles("*.json"),
+	)
+}
+
+func (c *EventSinkRegisterCommand) Run(args []string) int {
+	var jsonInput bool
+	var filter string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&jsonInput, "json", false, "")
+	flags.StringVar(&filter, "filter", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we only have one argument.
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <input>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Read input content.
+	path := args[0]
+	var content []byte
+	var err error
+	switch path {
+	case "-":
+		content, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read stdin: %v", err))
+			return 1
+		}
+		// Set .hcl extension so the decoder doesn't fail.
+		if !jsonInput {
+			path = "stdin.nomad.hcl"
+		}
+	default:
+		content, err = os.ReadFile(path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read file %q: %v", path, err))
+			return 1
+		}
+	}
+
+	sink, err := parseEventSinkSpec(content, path, jsonInput)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse input content: %v", err))
+		return 1
+	}
+	if filter != "" {
+		sink.Filter = filter
+	}
+
+	// Make API request.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	_, err = client.EventSinks().Register(sink, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error registering event sink: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully registered event sink %q!", sink.ID))
+	return 0
+}
+
+type eventSinkSpec struct {
+	Sink *eventSinkBlock `hcl:"sink,block"`
+}
+
+// eventSinkBlock is the HCL form of an event sink. The topics are decoded with
+// string keys, since HCL can't decode map keys into api.Topic.
+type eventSinkBlock struct {
+	ID        string              `hcl:"id,label"`
+	Type      string              `hcl:"type"`
+	Address   string              `hcl:"address,optional"`
+	Path      string              `hcl:"path,optional"`
+	Namespace string              `hcl:"namespace,optional"`
+	Filter    string              `hcl:"filter,optional"`
+	Topics    map[string][]string `hcl:"topics,optional"`
+}
+
+// parseEventSinkSpec parses an event sink specification in HCL, or in JSON if
+// jsonInput is set.
+func parseEventSinkSpec(content []byte, path string, jsonInput bool) (*api.EventSink, error) {
+	if jsonInput {
+		var sink *api.EventSink
+		if err := json.Unmarshal(content, &sink); err != nil {
+			return nil, err
+		}
+		if sink == nil {
+			return nil, errors.New("missing sink")
+		}
+		return sink, nil
+	}
+
+	var spec eventSinkSpec
+	hclParser := hcl.NewParser()
+	if hclDiags := hclParser.Parse(content, &spec, path); hclDiags.HasErrors() {
+		return nil, hclDiags
+	}
+	if spec.Sink == nil {
+		return nil, errors.New("missing sink")
+	}
+
+	block := spec.Sink
+	sink := &api.EventSink{
+		ID:        block.ID,
+		Type:      block.Type,
+		Address:   block.Address,
+		Path:      block.Path,
+		Namespace: block.Namespace,
+		Filter:    block.Filter,
+	}
+	if block.Topics != nil {
+		sink.Topics = make(map[api.Topic][]string, len(block.Topics))
+		for topic, keys := range block.Topics {
+			sink.Topics[api.Topic(topic)] = keys
+		}
+	}
+	return sink, nil
+}
@@ -0,0 +1,66 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestEventSinkRegisterCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &EventSinkRegisterCommand{}
+}
+
+func TestParseEventSinkSpec(t *testing.T) {
+	ci.Parallel(t)
+
+	expected := &api.EventSink{
+		ID:        "audit",
+		Type:      api.EventSinkWebhook,
+		Address:   "https://audit.example.com/nomad",
+		Namespace: "*",
+		Filter:    `Type == "JobRegistered"`,
+		Topics: map[api.Topic][]string{
+			api.TopicJob:        {"*"},
+			api.TopicDeployment: {"web", "api"},
+		},
+	}
+
+	hclSpec := `
+sink "audit" {
+  type      = "webhook"
+  address   = "https://audit.example.com/nomad"
+  namespace = "*"
+  filter    = "Type == \"JobRegistered\""
+
+  topics = {
+    Job        = ["*"]
+    Deployment = ["web", "api"]
+  }
+}
+`
+	sink, err := parseEventSinkSpec([]byte(hclSpec), "audit.nomad.hcl", false)
+	must.NoError(t, err)
+	must.Eq(t, expected, sink)
+
+	jsonSpec := `{
+  "ID": "audit",
+  "Type": "webhook",
+  "Address": "https://audit.example.com/nomad",
+  "Namespace": "*",
+  "Filter": "Type == \"JobRegistered\"",
+  "Topics": {"Job": ["*"], "Deployment": ["web", "api"]}
+}`
+	sink, err = parseEventSinkSpec([]byte(jsonSpec), "audit.json", true)
+	must.NoError(t, err)
+	must.Eq(t, expected, sink)
+
+	_, err = parseEventSinkSpec([]byte(`reservation "audit" {}`), "audit.nomad.hcl", false)
+	must.Error(t, err)
+}
@@ -0,0 +1,173 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type EventSinkStatusCommand struct {
+	Meta
+}
+
+func (c *EventSinkStatusCommand) Name() string {
+	return "event sink status"
+}
+
+func (c *EventSinkStatusCommand) Synopsis() string {
+	return "Display the delivery status of durable event sinks"
+}
+
+func (c *EventSinkStatusCommand) Help() string {
+	helpText := `
+Usage: nomad event sink status [options] [<id>]
+
+  Status is used to view the delivery status of the durable event sinks, as
+  reported by the leader. If no ID is given, the status of every sink is
+  listed. The lag of a sink is the number of raft indexes its delivery trails
+  the leader by, and is zero once every available event was delivered.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Status Options:
+
+  -json
+    Output the delivery status in JSON format.
+
+  -t
+    Format and display the delivery status using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *EventSinkStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *EventSinkStatusCommand) AutocompleteArgs() complete.Predictor {
+	return eventSinkPredictor(c.Client)
+}
+
+func (c *EventSinkStatusCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got zero or one argument.
+	args = flags.Args()
+	if len(args) > 1 {
+		c.Ui.Error("This command takes either no arguments or one: <id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if len(args) == 0 {
+		statuses, _, err := client.EventSinks().Statuses(nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying event sinks: %s", err))
+			return 1
+		}
+
+		if json || tmpl != "" {
+			out, err := Format(json, tmpl, statuses)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error formatting output: %s", err))
+				return 1
+			}
+			c.Ui.Output(out)
+			return 0
+		}
+
+		if len(statuses) == 0 {
+			c.Ui.Output("No event sinks found")
+			return 0
+		}
+		c.Ui.Output(formatEventSinkStatusList(statuses))
+		return 0
+	}
+
+	sink, _, err := client.EventSinks().Info(args[0], nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying event sink: %s", err))
+		return 1
+	}
+	status, _, err := client.EventSinks().Status(args[0], nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying event sink status: %s", err))
+		return 1
+	}
+
+	if json || tmpl != "" {
+		out, err := Format(json, tmpl, status)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting output: %s", err))
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatEventSinkStatus(sink, status))
+	return 0
+}
+
+func formatEventSinkStatus(sink *api.EventSink, status *api.EventSinkStatus) string {
+	lastDelivery := "never"
+	if !status.LastDelivery.IsZero() {
+		lastDelivery = formatTime(status.LastDelivery)
+	}
+	basic := []string{
+		fmt.Sprintf("ID|%s", sink.ID),
+		fmt.Sprintf("Type|%s", sink.Type),
+		fmt.Sprintf("Destination|%s", formatEventSinkDestination(sink)),
+		fmt.Sprintf("Namespace|%s", sink.Namespace),
+		fmt.Sprintf("Topics|%s", formatEventSinkTopics(sink.Topics)),
+	}
+	if sink.Filter != "" {
+		basic = append(basic, fmt.Sprintf("Filter|%s", sink.Filter))
+	}
+	basic = append(basic,
+		fmt.Sprintf("Status|%s", status.Status),
+		fmt.Sprintf("Delivered Index|%d", status.DeliveredIndex),
+		fmt.Sprintf("Checkpoint Index|%d", status.CheckpointIndex),
+		fmt.Sprintf("Latest Index|%d", status.LatestIndex),
+		fmt.Sprintf("Lag|%d", status.Lag),
+		fmt.Sprintf("Last Delivery|%s", lastDelivery),
+	)
+	if status.MissedToIndex != 0 {
+		basic = append(basic, fmt.Sprintf("Missed Events|indexes %d to %d",
+			status.MissedFromIndex, status.MissedToIndex))
+	}
+	if status.LastError != "" {
+		basic = append(basic, fmt.Sprintf("Last Error|%s", status.LastError))
+	}
+	return formatKV(basic)
+}
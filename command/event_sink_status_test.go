@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestEventSinkStatusCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &EventSinkStatusCommand{}
+}
+
+func TestFormatEventSinkStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	sink := &api.EventSink{
+		ID:        "archive",
+		Type:      api.EventSinkFile,
+		Path:      "/var/lib/nomad/events.ndjson",
+		Namespace: "*",
+		Filter:    `Payload.Node.Status == "down"`,
+		Topics: map[api.Topic][]string{
+			api.TopicNode: {"*"},
+			api.TopicJob:  {"web"},
+		},
+	}
+	status := &api.EventSinkStatus{
+		ID:              "archive",
+		Type:            api.EventSinkFile,
+		Status:          "failing",
+		DeliveredIndex:  120,
+		CheckpointIndex: 100,
+		LatestIndex:     150,
+		Lag:             30,
+		LastError:       "disk full",
+	}
+
+	out := formatEventSinkStatus(sink, status)
+	must.StrContains(t, out, "Destination      = /var/lib/nomad/events.ndjson")
+	must.StrContains(t, out, "Topics           = Job[web] Node[*]")
+	must.StrContains(t, out, `Filter           = Payload.Node.Status == "down"`)
+	must.StrContains(t, out, "Checkpoint Index = 100")
+	must.StrContains(t, out, "Lag              = 30")
+	must.StrContains(t, out, "Last Delivery    = never")
+	must.StrContains(t, out, "Last Error       = disk full")
+
+	list := formatEventSinkStatusList([]*api.EventSinkStatus{status})
+	must.StrContains(t, list, "ID       Type  Status   Delivered Index  Lag")
+	must.RegexMatch(t, regexp.MustCompile(`archive\s+file\s+failing\s+120\s+30`), list)
+}
@@ -34,6 +34,11 @@ potentially invalid.`
 type JobPlanCommand struct {
 	Meta
 	JobGetter
+
+	// explain and explainNode control the output of the explanations of the
+	// placements, optionally limited to a single node.
+	explain     bool
+	explainNode string
 }
 
 func (c *JobPlanCommand) Help() string {
@@ -80,6 +85,15 @@ Plan Options:
     Determines whether the diff between the remote job and planned job is shown.
     Defaults to true.
 
+  -explain
+    Records and displays what happened to every node the scheduler visited
+    while placing each allocation: the constraint or check that filtered the
+    node, the resource it was exhausted on, or its score.
+
+  -node
+    Limits the output of -explain to the node with the given ID prefix or
+    name, including the full breakdown of its score.
+
   -json
     Parses the job file as JSON. If the outer object has a Job field, such as
     from "nomad job inspect" or "nomad run -output", the value of the field is
@@ -117,6 +131,8 @@ func (c *JobPlanCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-diff":            complete.PredictNothing,
+			"-explain":         complete.PredictNothing,
+			"-node":            complete.PredictAnything,
 			"-policy-override": complete.PredictNothing,
 			"-verbose":         complete.PredictNothing,
 			"-json":            complete.PredictNothing,
@@ -143,6 +159,8 @@ func (c *JobPlanCommand) Run(args []string) int {
 	flagSet := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flagSet.Usage = func() { c.Ui.Output(c.Help()) }
 	flagSet.BoolVar(&diff, "diff", true, "")
+	flagSet.BoolVar(&c.explain, "explain", false, "")
+	flagSet.StringVar(&c.explainNode, "node", "", "")
 	flagSet.BoolVar(&policyOverride, "policy-override", false, "")
 	flagSet.BoolVar(&verbose, "verbose", false, "")
 	flagSet.BoolVar(&c.JobGetter.JSON, "json", false, "")
@@ -168,6 +186,12 @@ func (c *JobPlanCommand) Run(args []string) int {
 		return 255
 	}
 
+	if c.explainNode != "" && !c.explain {
+		c.Ui.Error("The -node flag requires -explain")
+		c.Ui.Error(commandErrorText(c))
+		return 255
+	}
+
 	path := args[0]
 	// Get Job struct from Jobfile
 	_, job, err := c.JobGetter.Get(path)
@@ -206,6 +230,7 @@ func (c *JobPlanCommand) Run(args []string) int {
 	if policyOverride {
 		opts.PolicyOverride = true
 	}
+	opts.Explain = c.explain
 
 	if job.IsMultiregion() {
 		return c.multiregionPlan(client, job, opts, diff, verbose)
@@ -282,6 +307,13 @@ func (c *JobPlanCommand) outputPlannedJob(job *api.Job, resp *api.JobPlanRespons
 	c.Ui.Output(c.Colorize().Color(formatDryRun(resp, job, c.Colorize())))
 	c.Ui.Output("")
 
+	// Print the explanations of the placements if requested
+	if c.explain {
+		c.Ui.Output(c.Colorize().Color("[bold]Placement explanations:[reset]"))
+		c.Ui.Output(c.Colorize().Color(formatPlanExplanations(resp, c.explainNode, verbose)))
+		c.Ui.Output("")
+	}
+
 	// Print any warnings if there are any
 	if resp.Warnings != "" {
 		c.Ui.Output(
@@ -436,6 +468,130 @@ func formatDryRun(resp *api.JobPlanResponse, job *api.Job, colorize *colorstring
 	return out
 }
 
+// formatPlanExplanations produces a string explaining what happened to the
+// nodes visited by the scheduler for every placement and placement failure of
+// the plan. If node is set, only the explanation of the node with that ID
+// prefix or name is shown, with the full breakdown of its score.
+func formatPlanExplanations(resp *api.JobPlanResponse, node string, verbose bool) string {
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	var out []string
+	explain := func(header, placedOn string, metrics *api.AllocationMetric) {
+		out = append(out, header)
+		if metrics == nil || metrics.NodeExplanations == nil {
+			out = append(out, "  No explanation was recorded")
+			return
+		}
+		if node != "" {
+			out = append(out, formatNodeExplanation(metrics, node, placedOn))
+			return
+		}
+
+		exps := sortedNodeExplanations(metrics.NodeExplanations, placedOn)
+		rows := make([]string, 0, len(exps)+1)
+		rows = append(rows, "Node ID|Node Name|Outcome")
+		for _, exp := range exps {
+			rows = append(rows, fmt.Sprintf("%s|%s|%s",
+				limit(exp.NodeID, length), exp.NodeName, nodeExplanationOutcome(exp, placedOn)))
+		}
+		out = append(out, formatList(rows))
+	}
+
+	for _, placement := range resp.Placements {
+		explain(fmt.Sprintf("[bold]Allocation %q placed on node %q (%s)[reset]",
+			placement.AllocName, limit(placement.NodeID, length), placement.NodeName),
+			placement.NodeID, placement.Metrics)
+	}
+	for _, tg := range sortedTaskGroupFromMetrics(resp.FailedTGAllocs) {
+		explain(fmt.Sprintf("[bold]Task Group %q failed to place allocations[reset]", tg),
+			"", resp.FailedTGAllocs[tg])
+	}
+
+	if len(out) == 0 {
+		return "No allocations placed"
+	}
+	return strings.Join(out, "\n")
+}
+
+// formatNodeExplanation produces the explanation of the node with the given
+// ID prefix or name, including the breakdown of its score.
+func formatNodeExplanation(metrics *api.AllocationMetric, node, placedOn string) string {
+	var exp *api.NodeExplanation
+	for _, candidate := range sortedNodeExplanations(metrics.NodeExplanations, placedOn) {
+		if strings.HasPrefix(candidate.NodeID, node) || candidate.NodeName == node {
+			exp = candidate
+			break
+		}
+	}
+	if exp == nil {
+		return "  Node was not visited: it is not ready, not in the job's datacenters\n" +
+			"  or node pool, or the scheduler found enough feasible nodes before it"
+	}
+
+	out := fmt.Sprintf("  %s", nodeExplanationOutcome(exp, placedOn))
+	if len(exp.Scores) == 0 {
+		return out
+	}
+
+	names := make([]string, 0, len(exp.Scores))
+	for name := range exp.Scores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	rows := make([]string, 0, len(names)+2)
+	rows = append(rows, "Scorer|Score|Details")
+	for _, name := range names {
+		rows = append(rows, fmt.Sprintf("%s|%.3g|%s", name, exp.Scores[name], exp.Labels[name]))
+	}
+	rows = append(rows, fmt.Sprintf("final score|%.3g|", exp.NormScore))
+	return out + "\n" + formatList(rows)
+}
+
+// nodeExplanationOutcome returns a short description of what happened to the
+// node while placing an allocation on the given node.
+func nodeExplanationOutcome(exp *api.NodeExplanation, placedOn string) string {
+	switch {
+	case exp.Filtered != "":
+		return fmt.Sprintf("filtered by %q", exp.Filtered)
+	case exp.Exhausted != "":
+		return fmt.Sprintf("exhausted %q", exp.Exhausted)
+	case exp.NodeID == placedOn:
+		return fmt.Sprintf("selected with score %.3g", exp.NormScore)
+	default:
+		return fmt.Sprintf("not selected with score %.3g", exp.NormScore)
+	}
+}
+
+// sortedNodeExplanations returns the node explanations with the selected node
+// first, followed by the scored nodes by decreasing score, and the rejected
+// nodes by ID.
+func sortedNodeExplanations(exps map[string]*api.NodeExplanation, placedOn string) []*api.NodeExplanation {
+	rejected := func(exp *api.NodeExplanation) bool {
+		return exp.Filtered != "" || exp.Exhausted != ""
+	}
+	sorted := make([]*api.NodeExplanation, 0, len(exps))
+	for _, exp := range exps {
+		sorted = append(sorted, exp)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch {
+		case (a.NodeID == placedOn) != (b.NodeID == placedOn):
+			return a.NodeID == placedOn
+		case rejected(a) != rejected(b):
+			return !rejected(a)
+		case !rejected(a) && a.NormScore != b.NormScore:
+			return a.NormScore > b.NormScore
+		default:
+			return a.NodeID < b.NodeID
+		}
+	})
+	return sorted
+}
+
 // formatJobDiff produces an annotated diff of the job. If verbose mode is
 // set, added or deleted task groups and tasks are expanded.
 func formatJobDiff(job *api.JobDiff, verbose bool) string {
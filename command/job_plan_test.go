@@ -306,3 +306,60 @@ func TestPlanCommand_JSON(t *testing.T) {
 	must.Eq(t, 255, code)
 	must.StrContains(t, ui.ErrorWriter.String(), "Error during plan: Put")
 }
+
+func TestPlanCommand_FormatPlanExplanations(t *testing.T) {
+	ci.Parallel(t)
+
+	resp := &api.JobPlanResponse{
+		Placements: []*api.PlacementExplanation{{
+			AllocName: "example.web[0]",
+			TaskGroup: "web",
+			NodeID:    "11111111-aaaa",
+			NodeName:  "linux-1",
+			Metrics: &api.AllocationMetric{
+				NodeExplanations: map[string]*api.NodeExplanation{
+					"11111111-aaaa": {
+						NodeID:    "11111111-aaaa",
+						NodeName:  "linux-1",
+						Scores:    map[string]float64{"binpack": 0.5, "job-anti-affinity": 0},
+						NormScore: 0.25,
+					},
+					"22222222-bbbb": {
+						NodeID:   "22222222-bbbb",
+						NodeName: "windows-1",
+						Filtered: "${attr.kernel.name} = linux",
+					},
+					"33333333-cccc": {
+						NodeID:    "33333333-cccc",
+						NodeName:  "linux-2",
+						Exhausted: "memory",
+					},
+				},
+			},
+		}},
+		FailedTGAllocs: map[string]*api.AllocationMetric{
+			"db": {},
+		},
+	}
+
+	out := formatPlanExplanations(resp, "", false)
+	must.StrContains(t, out, `Allocation "example.web[0]" placed on node "11111111" (linux-1)`)
+	must.StrContains(t, out, "selected with score 0.25")
+	must.StrContains(t, out, `filtered by "${attr.kernel.name} = linux"`)
+	must.StrContains(t, out, `exhausted "memory"`)
+	must.StrContains(t, out, `Task Group "db" failed to place allocations`)
+	must.StrContains(t, out, "No explanation was recorded")
+
+	// A single node shows its score breakdown
+	out = formatPlanExplanations(resp, "linux-1", false)
+	must.StrContains(t, out, "selected with score 0.25")
+	must.StrContains(t, out, "binpack")
+	must.StrContains(t, out, "final score")
+	must.StrNotContains(t, out, "windows-1")
+
+	out = formatPlanExplanations(resp, "2222", false)
+	must.StrContains(t, out, `filtered by "${attr.kernel.name} = linux"`)
+
+	out = formatPlanExplanations(resp, "44444444", false)
+	must.StrContains(t, out, "Node was not visited")
+}
@@ -41,6 +41,10 @@ const (
 	// jobRestartOnErrorAks is the special token used to indicate that the
 	// command should ask user for confirmation when a batch has errors.
 	jobRestartOnErrorAsk = "ask"
+
+	// jobRestartBudgetWaitDefault is the default time to wait for the
+	// disruption budget of a group to allow restarting an allocation.
+	jobRestartBudgetWaitDefault = 10 * time.Minute
 )
 
 var (
@@ -88,6 +92,7 @@ type JobRestartCommand struct {
 	batchSizePercent bool
 	batchWait        time.Duration
 	batchWaitAsk     bool
+	budgetWait       time.Duration
 	groups           *set.Set[string]
 	jobID            string
 	noShutdownDelay  bool
@@ -125,6 +130,12 @@ Usage: nomad job restart [options] <job>
   batch. It is also possible to specify additional time to wait between
   batches.
 
+  Restarts honor the disruption budget of each group. Batches end early when
+  restarting more allocations would leave a group with fewer healthy
+  allocations than its budget requires, and the command waits for the group
+  to recover before restarting its next allocation, up to the time set by
+  '-budget-wait'.
+
   You may restart in-place or migrated allocations. When restarting in-place,
   the command may target specific tasks in the allocations, restart only tasks
   that are currently running, or restart all tasks, even the ones that have
@@ -168,6 +179,11 @@ Restart Options:
     is a time duration all remaining batches will use this new value. Defaults
     to 0.
 
+  -budget-wait=<duration>
+    Maximum time to wait for the disruption budget of a group to allow
+    restarting its next allocation. The restart fails if the group does not
+    recover in time. Defaults to 10m.
+
   -group=<group-name>
     Only restart allocations for the given group. Can be specified multiple
     times. If no group is set all allocations for the job are restarted.
@@ -222,6 +238,7 @@ func (c *JobRestartCommand) AutocompleteFlags() complete.Flags {
 			"-all-tasks":         complete.PredictNothing,
 			"-batch-size":        complete.PredictAnything,
 			"-batch-wait":        complete.PredictAnything,
+			"-budget-wait":       complete.PredictAnything,
 			"-no-shutdown-delay": complete.PredictNothing,
 			"-on-error":          complete.PredictSet(jobRestartOnErrorAsk, jobRestartOnErrorFail),
 			"-reschedule":        complete.PredictNothing,
@@ -281,7 +298,7 @@ func (c *JobRestartCommand) Run(args []string) int {
 	// Verify job type can be rescheduled.
 	if c.reschedule {
 		switch *job.Type {
-		case api.JobTypeBatch, api.JobTypeService, api.JobTypeSystem:
+		case api.JobTypeBatch, api.JobTypeService, api.JobTypeSystem, api.JobTypeWorkflow:
 		default:
 			c.Ui.Error(fmt.Sprintf("Jobs of type %q are not allowed to be rescheduled.", *job.Type))
 			return 1
@@ -345,8 +362,12 @@ func (c *JobRestartCommand) Run(args []string) int {
 	// restartErr accumulates the errors that happen in each batch.
 	var restartErr *multierror.Error
 
-	// Restart allocations in batches.
+	// Restart allocations in batches. restarting tracks the allocations of
+	// the current batch so they are not counted as healthy towards the
+	// disruption budget of their group.
 	batch := multierror.Group{}
+	batchNumber := 0
+	restarting := make(map[string]struct{})
 	for restartCount, alloc := range restartAllocs {
 		// Block and wait before each iteration if the command is handling an
 		// interrupt signal.
@@ -360,11 +381,18 @@ func (c *JobRestartCommand) Run(args []string) int {
 			break
 		}
 
-		// Print new batch header every time we restart a multiple of the batch
-		// size which indicates that we're starting a new batch.
-		// Skip batch header if batch size is one because it's redundant.
-		if restartCount%c.batchSize == 0 && c.batchSize > 1 {
-			batchNumber := restartCount/c.batchSize + 1
+		// Wait until the disruption budget of the allocation's group allows
+		// it to be restarted.
+		err = c.waitForDisruptionBudget(job, alloc.AllocationListStub, activeCh)
+		if err != nil {
+			restartErr = multierror.Append(restartErr, err)
+			break
+		}
+
+		// Print new batch header every time we start a new batch. Skip batch
+		// header if batch size is one because it's redundant.
+		if len(restarting) == 0 && c.batchSize > 1 {
+			batchNumber++
 			remaining := len(restartAllocs) - restartCount
 
 			c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
@@ -383,11 +411,30 @@ func (c *JobRestartCommand) Run(args []string) int {
 				return c.handleAlloc(allocStubWithJob)
 			}
 		}(alloc))
+		restarting[alloc.ID] = struct{}{}
 
 		// Check if we restarted enough allocations to complete a batch or if
 		// we restarted the last allocation.
-		batchComplete := (restartCount+1)%c.batchSize == 0
+		batchComplete := len(restarting) == c.batchSize
 		restartComplete := restartCount+1 == len(restartAllocs)
+
+		// Complete the batch early if restarting the next allocation along
+		// with this batch would violate the disruption budget of its group.
+		if !batchComplete && !restartComplete {
+			next := restartAllocs[restartCount+1]
+			reason, _, err := c.disruptionBudgetBlocked(job, next.AllocationListStub, restarting, nil)
+			if err != nil || reason != "" {
+				batchComplete = true
+			}
+			if reason != "" {
+				c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+					"[bold]==> %s: Completing batch early: %s[reset]",
+					formatTime(time.Now()),
+					reason,
+				)))
+			}
+		}
+
 		if batchComplete || restartComplete {
 
 			// Block and wait for the batch to finish. Handle the
@@ -467,6 +514,7 @@ func (c *JobRestartCommand) Run(args []string) int {
 
 			// Start a new batch.
 			batch = multierror.Group{}
+			restarting = make(map[string]struct{})
 		}
 	}
 
@@ -510,6 +558,7 @@ func (c *JobRestartCommand) parseAndValidate(args []string) (int, error) {
 	flags.BoolVar(&c.autoYes, "yes", false, "")
 	flags.StringVar(&batchSizeStr, "batch-size", "1", "")
 	flags.StringVar(&batchWaitStr, "batch-wait", "0s", "")
+	flags.DurationVar(&c.budgetWait, "budget-wait", jobRestartBudgetWaitDefault, "")
 	flags.StringVar(&c.onError, "on-error", jobRestartOnErrorAsk, "")
 	flags.BoolVar(&c.noShutdownDelay, "no-shutdown-delay", false, "")
 	flags.BoolVar(&c.reschedule, "reschedule", false, "")
@@ -579,6 +628,11 @@ func (c *JobRestartCommand) parseAndValidate(args []string) (int, error) {
 		}
 	}
 
+	// Validate -budget-wait.
+	if c.budgetWait <= 0 {
+		return 1, fmt.Errorf("Invalid -budget-wait value %q: must be greater than zero", c.budgetWait)
+	}
+
 	// Parse and validate -on-error.
 	switch c.onError {
 	case jobRestartOnErrorAsk:
@@ -710,6 +764,124 @@ func (c *JobRestartCommand) ensureNoActiveDeployment() error {
 	return nil
 }
 
+// waitForDisruptionBudget blocks until restarting the allocation keeps enough
+// healthy allocations in its group to satisfy the group's disruption budget,
+// or returns an error if the group doesn't recover within -budget-wait.
+//
+// activeCh is read before each query so the wait pauses while the command
+// handles an interrupt signal.
+func (c *JobRestartCommand) waitForDisruptionBudget(job *api.Job, alloc *api.AllocationListStub, activeCh chan any) error {
+	waiting := false
+	deadline := time.Now().Add(c.budgetWait)
+	q := &api.QueryOptions{}
+	for {
+		<-activeCh
+
+		reason, qm, err := c.disruptionBudgetBlocked(job, alloc, nil, q)
+		if err != nil {
+			return err
+		}
+		if reason == "" {
+			return nil
+		}
+
+		if !waiting {
+			c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+				"[bold]==> %s: Waiting to restart allocation %q: %s[reset]",
+				formatTime(time.Now()),
+				limit(alloc.ID, c.length),
+				reason,
+			)))
+			waiting = true
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("Timed out after %s waiting to restart allocation %q: %s",
+				c.budgetWait, limit(alloc.ID, c.length), reason)
+		}
+		q.WaitIndex = qm.LastIndex
+		q.WaitTime = remaining
+	}
+}
+
+// disruptionBudgetBlocked returns why the disruption budget of the
+// allocation's group blocks restarting it, or an empty string if the
+// allocation can be restarted. Allocations in restarting are already being
+// restarted and are not counted as healthy.
+func (c *JobRestartCommand) disruptionBudgetBlocked(
+	job *api.Job,
+	alloc *api.AllocationListStub,
+	restarting map[string]struct{},
+	q *api.QueryOptions,
+) (string, *api.QueryMeta, error) {
+	tg := job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.DisruptionBudget == nil {
+		return "", &api.QueryMeta{}, nil
+	}
+
+	stubs, qm, err := c.client.Jobs().Allocations(c.jobID, false, q)
+	if err != nil {
+		return "", nil, fmt.Errorf("Error retrieving allocations for job %q: %v", c.jobID, err)
+	}
+	return disruptionBudgetBlockedReason(tg, alloc, stubs, restarting), qm, nil
+}
+
+// disruptionBudgetBlockedReason returns why restarting the allocation would
+// leave its group with fewer healthy allocations than the group's disruption
+// budget requires, or an empty string if it wouldn't.
+func disruptionBudgetBlockedReason(
+	tg *api.TaskGroup,
+	alloc *api.AllocationListStub,
+	stubs []*api.AllocationListStub,
+	restarting map[string]struct{},
+) string {
+	if tg.DisruptionBudget == nil {
+		return ""
+	}
+
+	isHealthy := func(stub *api.AllocationListStub) bool {
+		if _, ok := restarting[stub.ID]; ok {
+			return false
+		}
+		if stub.DeploymentStatus != nil && stub.DeploymentStatus.Healthy != nil && !*stub.DeploymentStatus.Healthy {
+			return false
+		}
+		return stub.ClientStatus == api.AllocClientStatusRunning &&
+			stub.DesiredStatus == api.AllocDesiredStatusRun
+	}
+
+	// Restarting an allocation that is not healthy doesn't disrupt the group
+	// any further.
+	if !isHealthy(alloc) {
+		return ""
+	}
+
+	healthy := 0
+	for _, stub := range stubs {
+		if stub.TaskGroup == alloc.TaskGroup && isHealthy(stub) {
+			healthy++
+		}
+	}
+
+	minHealthy, maxUnavailable := 0, 0
+	if tg.DisruptionBudget.MinHealthy != nil {
+		minHealthy = *tg.DisruptionBudget.MinHealthy
+	}
+	if tg.DisruptionBudget.MaxUnavailable != nil {
+		maxUnavailable = *tg.DisruptionBudget.MaxUnavailable
+	}
+	if maxUnavailable > 0 && tg.Count != nil {
+		minHealthy = max(minHealthy, *tg.Count-maxUnavailable)
+	}
+
+	if healthy-1 >= minHealthy {
+		return ""
+	}
+	return fmt.Sprintf("disruption budget of group %q requires %d healthy allocations and %d are healthy",
+		alloc.TaskGroup, minHealthy, healthy)
+}
+
 // shouldRestartMultiregion blocks and waits for the user to confirm if the
 // restart of a multi-region job should proceed. Returns true if the answer is
 // positive.
@@ -147,6 +147,20 @@ func TestJobRestartCommand_parseAndValidate(t *testing.T) {
 			args:        []string{"-batch-wait", "10", "my-job"},
 			expectedErr: "Invalid -batch-wait value",
 		},
+		{
+			name: "budget wait",
+			args: []string{"-budget-wait", "30s", "my-job"},
+			expectedCmd: &JobRestartCommand{
+				jobID:      "my-job",
+				batchSize:  1,
+				budgetWait: 30 * time.Second,
+			},
+		},
+		{
+			name:        "budget wait zero",
+			args:        []string{"-budget-wait", "0s", "my-job"},
+			expectedErr: "Invalid -budget-wait value",
+		},
 		{
 			name: "on error fail",
 			args: []string{"-on-error", "fail", "my-job"},
@@ -214,6 +228,9 @@ func TestJobRestartCommand_parseAndValidate(t *testing.T) {
 				if tc.expectedCmd.tasks == nil {
 					tc.expectedCmd.tasks = set.New[string](0)
 				}
+				if tc.expectedCmd.budgetWait == 0 {
+					tc.expectedCmd.budgetWait = jobRestartBudgetWaitDefault
+				}
 				if tc.expectedCmd.onError == "" {
 					tc.expectedCmd.onError = jobRestartOnErrorAsk
 					tc.expectedCmd.autoYes = true
@@ -1129,6 +1146,39 @@ func TestJobRestartCommand_activeDeployment(t *testing.T) {
 	must.RegexMatch(t, regexp.MustCompile(`Deployment .+ is "running"`), ui.ErrorWriter.String())
 }
 
+func TestJobRestartCommand_disruptionBudgetTimeout(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	waitForNodes(t, client)
+
+	// Register a job whose disruption budget never allows a restart.
+	jobID := "test_job_restart_budget"
+	job := testJob(jobID)
+	job.Type = pointer.Of(api.JobTypeService)
+	job.TaskGroups[0].Tasks[0].Config["run_for"] = "1h"
+	job.TaskGroups[0].DisruptionBudget = &api.DisruptionBudget{MinHealthy: pointer.Of(1)}
+
+	_, _, err := client.Jobs().Register(job, nil)
+	must.NoError(t, err)
+	waitForJobAllocsStatus(t, client, jobID, api.AllocClientStatusRunning, "")
+
+	// Run job restart command and expect it to give up waiting.
+	ui := cli.NewMockUi()
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{
+		"-address", url,
+		"-on-error", jobRestartOnErrorFail,
+		"-budget-wait", "1s",
+		jobID,
+	})
+	must.One(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "Waiting to restart allocation")
+	must.StrContains(t, ui.ErrorWriter.String(), "Timed out after 1s waiting to restart allocation")
+}
+
 func TestJobRestartCommand_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1589,6 +1639,84 @@ func TestJobRestartCommand_filterAllocs(t *testing.T) {
 	}
 }
 
+func TestJobRestartCommand_disruptionBudgetBlockedReason(t *testing.T) {
+	ci.Parallel(t)
+
+	running := func(id string) *api.AllocationListStub {
+		return &api.AllocationListStub{
+			ID:            id,
+			TaskGroup:     "web",
+			ClientStatus:  api.AllocClientStatusRunning,
+			DesiredStatus: api.AllocDesiredStatusRun,
+		}
+	}
+	unhealthy := running("unhealthy")
+	unhealthy.DeploymentStatus = &api.AllocDeploymentStatus{Healthy: pointer.Of(false)}
+	pending := running("pending")
+	pending.ClientStatus = api.AllocClientStatusPending
+	other := running("other")
+	other.TaskGroup = "api"
+
+	stubs := []*api.AllocationListStub{
+		running("a1"), running("a2"), running("a3"), unhealthy, pending, other,
+	}
+
+	testCases := []struct {
+		name       string
+		budget     *api.DisruptionBudget
+		alloc      *api.AllocationListStub
+		restarting []string
+		blocked    bool
+	}{
+		{
+			name:  "no budget",
+			alloc: stubs[0],
+		},
+		{
+			name:   "min healthy allows",
+			budget: &api.DisruptionBudget{MinHealthy: pointer.Of(2)},
+			alloc:  stubs[0],
+		},
+		{
+			name:       "min healthy blocks with restarting allocs",
+			budget:     &api.DisruptionBudget{MinHealthy: pointer.Of(2)},
+			alloc:      stubs[0],
+			restarting: []string{"a2"},
+			blocked:    true,
+		},
+		{
+			name:    "max unavailable blocks",
+			budget:  &api.DisruptionBudget{MaxUnavailable: pointer.Of(2)},
+			alloc:   stubs[0],
+			blocked: true,
+		},
+		{
+			name:   "unhealthy alloc is never blocked",
+			budget: &api.DisruptionBudget{MinHealthy: pointer.Of(5)},
+			alloc:  unhealthy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tg := api.NewTaskGroup("web", 5)
+			tg.DisruptionBudget = tc.budget
+
+			restarting := make(map[string]struct{})
+			for _, id := range tc.restarting {
+				restarting[id] = struct{}{}
+			}
+
+			reason := disruptionBudgetBlockedReason(tg, tc.alloc, stubs, restarting)
+			if tc.blocked {
+				must.StrContains(t, reason, `disruption budget of group "web"`)
+			} else {
+				must.Eq(t, "", reason)
+			}
+		})
+	}
+}
+
 func TestJobRestartCommand_onErrorFail(t *testing.T) {
 	ci.Parallel(t)
 
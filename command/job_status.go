@@ -6,6 +6,7 @@ package command
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -263,6 +264,10 @@ func (c *JobStatusCommand) Run(args []string) int {
 		fmt.Sprintf("Parameterized|%v", parameterized),
 	}
 
+	if job.StatusDescription != nil && *job.StatusDescription != "" {
+		basic = append(basic, fmt.Sprintf("Status Description|%s", *job.StatusDescription))
+	}
+
 	if job.DispatchIdempotencyToken != nil && *job.DispatchIdempotencyToken != "" {
 		basic = append(basic, fmt.Sprintf("Idempotency Token|%v", *job.DispatchIdempotencyToken))
 	}
@@ -284,6 +289,23 @@ func (c *JobStatusCommand) Run(args []string) int {
 		}
 	}
 
+	if job.ScheduleWindow != nil && !*job.Stop {
+		location, err := job.ScheduleWindow.GetLocation()
+		if err == nil {
+			now := time.Now().In(location)
+			opens, closes, err := job.ScheduleWindow.Next(now)
+			if err == nil {
+				if opens.After(now) {
+					basic = append(basic, fmt.Sprintf("Next Schedule Window|%s (%s from now)",
+						formatTime(opens), formatTimeDifference(now, opens, time.Second)))
+				} else {
+					basic = append(basic, fmt.Sprintf("Next Schedule Window|open until %s (%s from now)",
+						formatTime(closes), formatTimeDifference(now, closes, time.Second)))
+				}
+			}
+		}
+	}
+
 	c.Ui.Output(formatKV(basic))
 
 	// Exit early
@@ -464,6 +486,35 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 		return err
 	}
 
+	// Output the steps of workflow jobs
+	if *job.Type == api.JobTypeWorkflow {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Workflow Steps[reset]"))
+		steps, _, err := client.Jobs().WorkflowSteps(*job.ID, &api.QueryOptions{Namespace: *job.Namespace})
+		if err != nil {
+			return fmt.Errorf("Error querying workflow steps: %s", err)
+		}
+		c.Ui.Output(formatList(formatWorkflowSteps(steps)))
+	}
+
+	// Output the status of each index of indexed batch task groups
+	for _, tg := range job.TaskGroups {
+		if tg.IndexedBatch == nil {
+			continue
+		}
+		c.Ui.Output(c.Colorize().Color(fmt.Sprintf("\n[bold]Indexed Batch %q[reset]", *tg.Name)))
+		summary, indexes := formatIndexedBatch(job, tg, jobAllocs)
+		c.Ui.Output(formatList(summary))
+		c.Ui.Output("")
+		c.Ui.Output(formatList(indexes))
+	}
+
+	// Output the jobs the job depends on
+	if len(job.DependsOn) != 0 {
+		if err := c.outputJobDependencies(client, job, jobEvals); err != nil {
+			return err
+		}
+	}
+
 	// Determine latest evaluation with failures whose follow up hasn't
 	// completed, this is done while formatting
 	var latestFailedPlacement *api.Evaluation
@@ -523,6 +574,144 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 	return nil
 }
 
+// formatWorkflowSteps returns the rows of the table of the steps of a workflow
+// job.
+func formatWorkflowSteps(steps []*api.WorkflowStep) []string {
+	rows := make([]string, 0, len(steps)+1)
+	rows = append(rows, "Step|After|Complete|Status")
+	for _, step := range steps {
+		after := "<none>"
+		if len(step.After) != 0 {
+			after = strings.Join(step.After, ",")
+		}
+		rows = append(rows, fmt.Sprintf("%s|%s|%d/%d|%s", step.Name, after, step.Complete, step.Count, step.Status))
+	}
+	return rows
+}
+
+// formatIndexedBatch returns the rows of the summary table of an indexed batch
+// task group and of the table of its indexes, with the status and number of
+// failures of each index computed from the allocations of the current version
+// of the job.
+func formatIndexedBatch(job *api.Job, tg *api.TaskGroup, allocs []*api.AllocationListStub) ([]string, []string) {
+	type counts struct{ complete, running, failed int }
+	byIndex := make([]counts, *tg.IndexedBatch.Completions)
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != *tg.Name || alloc.JobVersion != *job.Version {
+			continue
+		}
+		idx, ok := allocIndexFromName(alloc.Name)
+		if !ok || idx >= len(byIndex) {
+			continue
+		}
+		switch alloc.ClientStatus {
+		case api.AllocClientStatusComplete:
+			byIndex[idx].complete++
+		case api.AllocClientStatusFailed:
+			byIndex[idx].failed++
+		case api.AllocClientStatusPending, api.AllocClientStatusRunning, api.AllocClientStatusUnknown:
+			if alloc.DesiredStatus == api.AllocDesiredStatusRun {
+				byIndex[idx].running++
+			}
+		}
+	}
+
+	statusCounts := make(map[string]int)
+	indexes := make([]string, 0, len(byIndex)+1)
+	indexes = append(indexes, "Index|Status|Failures")
+	for i, c := range byIndex {
+		var status string
+		switch {
+		case c.complete > 0:
+			status = "complete"
+		case c.running > 0:
+			status = "running"
+		case c.failed > *tg.IndexedBatch.BackoffLimitPerIndex:
+			status = "failed"
+		default:
+			status = "pending"
+		}
+		statusCounts[status]++
+		indexes = append(indexes, fmt.Sprintf("%d|%s|%d", i, status, c.failed))
+	}
+
+	summary := []string{
+		"Completions|Parallelism|Backoff Limit Per Index|Complete|Running|Failed|Pending",
+		fmt.Sprintf("%d|%d|%d|%d|%d|%d|%d",
+			*tg.IndexedBatch.Completions, *tg.IndexedBatch.Parallelism,
+			*tg.IndexedBatch.BackoffLimitPerIndex, statusCounts["complete"],
+			statusCounts["running"], statusCounts["failed"], statusCounts["pending"]),
+	}
+	return summary, indexes
+}
+
+// allocIndexFromName returns the index of an allocation from its name, which
+// ends with the index between brackets.
+func allocIndexFromName(name string) (int, bool) {
+	start := strings.LastIndexByte(name, '[')
+	if start == -1 || !strings.HasSuffix(name, "]") {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(name[start+1 : len(name)-1])
+	return idx, err == nil
+}
+
+// outputJobDependencies prints the jobs the job depends on, along with the
+// jobs they depend on themselves, and the reason the job is waiting if it is.
+func (c *JobStatusCommand) outputJobDependencies(client *api.Client, job *api.Job, evals []*api.Evaluation) error {
+	q := &api.QueryOptions{Namespace: *job.Namespace}
+
+	out := []string{"Job ID|Required Status|Status"}
+	visited := map[string]struct{}{*job.ID: {}}
+	var walk func(deps []*api.JobDependency, depth int) error
+	walk = func(deps []*api.JobDependency, depth int) error {
+		for _, dep := range deps {
+			jobID := strings.Repeat("  ", depth) + *dep.JobID
+			upstream, _, err := client.Jobs().Info(*dep.JobID, q)
+			if err != nil {
+				if !strings.Contains(err.Error(), "404") {
+					return fmt.Errorf("Error querying job %q: %s", *dep.JobID, err)
+				}
+				out = append(out, fmt.Sprintf("%s|%s|<not registered>", jobID, *dep.Status))
+				continue
+			}
+			out = append(out, fmt.Sprintf("%s|%s|%s",
+				jobID, *dep.Status, getStatusString(*upstream.Status, upstream.Stop)))
+
+			if _, ok := visited[*dep.JobID]; ok {
+				continue
+			}
+			visited[*dep.JobID] = struct{}{}
+			if err := walk(upstream.DependsOn, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(job.DependsOn, 0); err != nil {
+		return err
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Dependencies[reset]"))
+	c.Ui.Output(formatList(out))
+
+	// The latest evaluation held until the dependencies are met explains
+	// why the job is waiting
+	var waiting *api.Evaluation
+	for _, eval := range evals {
+		if eval.Status != api.EvalStatusDependencyBlocked {
+			continue
+		}
+		if waiting == nil || waiting.CreateIndex < eval.CreateIndex {
+			waiting = eval
+		}
+	}
+	if waiting != nil {
+		c.Ui.Output(fmt.Sprintf("\nWaiting for dependencies: %s", waiting.StatusDescription))
+	}
+	return nil
+}
+
 func (c *JobStatusCommand) formatDeployment(client *api.Client, d *api.Deployment) string {
 	// Format the high-level elements
 	high := []string{
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -490,6 +491,50 @@ namespace "default" {
 	}
 }
 
+func TestJobStatusCommand_FormatIndexedBatch(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &api.Job{Version: pointer.Of(uint64(1))}
+	tg := &api.TaskGroup{
+		Name: pointer.Of("shard"),
+		IndexedBatch: &api.IndexedBatch{
+			Completions:          pointer.Of(4),
+			Parallelism:          pointer.Of(2),
+			BackoffLimitPerIndex: pointer.Of(1),
+		},
+	}
+	alloc := func(index int, clientStatus string, version uint64) *api.AllocationListStub {
+		return &api.AllocationListStub{
+			Name:          fmt.Sprintf("example.shard[%d]", index),
+			TaskGroup:     "shard",
+			JobVersion:    version,
+			ClientStatus:  clientStatus,
+			DesiredStatus: api.AllocDesiredStatusRun,
+		}
+	}
+	allocs := []*api.AllocationListStub{
+		alloc(0, api.AllocClientStatusFailed, 1),
+		alloc(0, api.AllocClientStatusComplete, 1),
+		alloc(1, api.AllocClientStatusFailed, 1),
+		alloc(1, api.AllocClientStatusFailed, 1),
+		alloc(2, api.AllocClientStatusRunning, 1),
+		alloc(3, api.AllocClientStatusComplete, 0),
+	}
+
+	summary, indexes := formatIndexedBatch(job, tg, allocs)
+	must.Eq(t, []string{
+		"Completions|Parallelism|Backoff Limit Per Index|Complete|Running|Failed|Pending",
+		"4|2|1|1|1|1|1",
+	}, summary)
+	must.Eq(t, []string{
+		"Index|Status|Failures",
+		"0|complete|1",
+		"1|failed|2",
+		"2|running|0",
+		"3|pending|0",
+	}, indexes)
+}
+
 func waitForSuccess(ui cli.Ui, client *api.Client, length int, t *testing.T, evalId string) int {
 	mon := newMonitor(Meta{Ui: ui}, client, length)
 	monErr := mon.monitor(evalId)
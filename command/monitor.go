@@ -386,6 +386,16 @@ func formatAllocMetrics(
 		out += fmt.Sprintf("%s* Quota limit hit %q\n", prefix, dim)
 	}
 
+	// Print disruption budget info
+	for tg, num := range metrics.PreemptionBlocked {
+		out += fmt.Sprintf("%s* Preemption of %q blocked by its disruption budget on %d nodes\n", prefix, tg, num)
+	}
+
+	// Print gang info
+	if gang := metrics.GangUnsatisfiable; gang != "" {
+		out += fmt.Sprintf("%s* Gang %q not satisfiable: no allocations placed until all of its groups fit\n", prefix, gang)
+	}
+
 	// Print scores
 	if scores {
 		if len(metrics.ScoreMetaData) > 0 {
@@ -417,6 +427,19 @@ func formatAllocMetrics(
 			}
 
 			out += formatList(scoreOutput)
+
+			// Print the explanations given by scoring plugins.
+			for _, scoreMeta := range metrics.ScoreMetaData {
+				names := make([]string, 0, len(scoreMeta.Labels))
+				for name := range scoreMeta.Labels {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					out += fmt.Sprintf("\n%s* Score %q on node %s: %s",
+						prefix, name, scoreMeta.NodeID, scoreMeta.Labels[name])
+				}
+			}
 		} else {
 			// Backwards compatibility for old allocs
 			for name, score := range metrics.Scores {
@@ -371,6 +371,39 @@ Node    score-1  score-2  score-3  score-4  final score
 node-1  1        2        0        0        1
 node-2  1        0        3        0        2
 node-3  0        0        0        4        3
+`,
+		},
+		{
+			Name: "display score labels",
+			Metrics: &api.AllocationMetric{
+				NodesEvaluated: 2,
+				NodesInPool:    2,
+				ScoreMetaData: []*api.NodeScoreMeta{
+					{
+						NodeID: "node-1",
+						Scores: map[string]float64{
+							"binpack":     0.5,
+							"plugin.cost": 1,
+						},
+						NormScore: 0.75,
+						Labels: map[string]string{
+							"plugin.cost": "spot price $0.02/h",
+						},
+					},
+					{
+						NodeID: "node-2",
+						Scores: map[string]float64{
+							"binpack": 0.5,
+						},
+						NormScore: 0.5,
+					},
+				},
+			},
+			Expected: `
+Node    binpack  plugin.cost  final score
+node-1  0.5      1            0.75
+node-2  0.5      0            0.5
+* Score "plugin.cost" on node node-1: spot price $0.02/h
 `,
 		},
 	}
@@ -6,6 +6,7 @@ package command
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/cli"
 	"github.com/hashicorp/nomad/api"
@@ -97,6 +98,11 @@ consul {
   allowed = ["prod", "apps*"]
 }
 
+variables {
+  history_limit     = 5
+  history_retention = "720h"
+}
+
 meta {
   dept = "eng"
 }`,
@@ -120,6 +126,10 @@ meta {
 					Default: "prod",
 					Allowed: []string{"prod", "apps*"},
 				},
+				VariablesConfiguration: &api.NamespaceVariablesConfiguration{
+					HistoryLimit:     5,
+					HistoryRetention: 720 * time.Hour,
+				},
 				Meta: map[string]string{
 					"dept": "eng",
 				},
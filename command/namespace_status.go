@@ -194,6 +194,20 @@ func (c *NamespaceStatusCommand) Run(args []string) int {
 		c.Ui.Output(formatKV(cConfigOut))
 	}
 
+	if ns.VariablesConfiguration != nil {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Variables Configuration[reset]"))
+		varConfig := ns.VariablesConfiguration
+		retention := "<none>"
+		if varConfig.HistoryRetention > 0 {
+			retention = varConfig.HistoryRetention.String()
+		}
+		varConfigOut := []string{
+			fmt.Sprintf("History Limit|%d", varConfig.HistoryLimit),
+			fmt.Sprintf("History Retention|%s", retention),
+		}
+		c.Ui.Output(formatKV(varConfigOut))
+	}
+
 	return 0
 }
 
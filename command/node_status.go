@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -406,6 +407,14 @@ func nodeDrivers(n *api.Node) []string {
 	return drivers
 }
 
+func nodeTaintNames(n *api.Node) []string {
+	names := make([]string, len(n.Taints))
+	for i, taint := range n.Taints {
+		names[i] = taint.String()
+	}
+	return names
+}
+
 func nodeCSIControllerNames(n *api.Node) []string {
 	var names []string
 	for name := range n.CSIControllerPlugins {
@@ -507,6 +516,7 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 		fmt.Sprintf("DC|%s", node.Datacenter),
 		fmt.Sprintf("Drain|%v", formatDrain(node)),
 		fmt.Sprintf("Eligibility|%s", node.SchedulingEligibility),
+		fmt.Sprintf("Taints|%s", strings.Join(nodeTaintNames(node), ",")),
 		fmt.Sprintf("Status|%s", node.Status),
 		fmt.Sprintf("CSI Controllers|%s", strings.Join(nodeCSIControllerNames(node), ",")),
 		fmt.Sprintf("CSI Drivers|%s", strings.Join(nodeCSINodeNames(node), ",")),
@@ -579,6 +589,11 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 	c.Ui.Output(c.Colorize().Color("\n[bold]Allocated Resources[reset]"))
 	c.Ui.Output(formatList(allocatedResources))
 
+	if c.verbose && node.NodeResources != nil && len(node.NodeResources.CustomResources) > 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Custom Resources[reset]"))
+		c.Ui.Output(formatList(getCustomResources(runningAllocs, node)))
+	}
+
 	actualResources, err := getActualResources(client, runningAllocs, node)
 	if err == nil {
 		c.Ui.Output(c.Colorize().Color("\n[bold]Allocation Resource Utilization[reset]"))
@@ -973,6 +988,37 @@ func getAllocatedResources(client *api.Client, runningAllocs []*api.Allocation,
 	return resources
 }
 
+// getCustomResources returns the number of units of each custom resource of
+// the node allocated to the running allocations.
+func getCustomResources(runningAllocs []*api.Allocation, node *api.Node) []string {
+	used := make(map[string]uint64)
+	for _, alloc := range runningAllocs {
+		if alloc.AllocatedResources == nil {
+			continue
+		}
+		for _, tr := range alloc.AllocatedResources.Tasks {
+			if tr == nil {
+				continue
+			}
+			for name, count := range tr.CustomResources {
+				used[name] += count
+			}
+		}
+	}
+
+	custom := slices.Clone(node.NodeResources.CustomResources)
+	sort.Slice(custom, func(i, j int) bool {
+		return custom[i].Name < custom[j].Name
+	})
+
+	resources := make([]string, 0, len(custom)+1)
+	resources = append(resources, "Name|Allocated")
+	for _, r := range custom {
+		resources = append(resources, fmt.Sprintf("%s|%d/%d", r.Name, used[r.Name], r.Count))
+	}
+	return resources
+}
+
 // computeNodeTotalResources returns the total allocatable resources (resources
 // minus reserved)
 func computeNodeTotalResources(node *api.Node) api.Resources {
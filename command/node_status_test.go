@@ -295,3 +295,33 @@ func TestNodeStatusCommand_FormatDrain(t *testing.T) {
 	node.DrainStrategy.IgnoreSystemJobs = true
 	must.Eq(t, "true; 1970-01-01T00:00:01Z deadline; ignoring system jobs", formatDrain(node))
 }
+
+func TestNodeStatusCommand_GetCustomResources(t *testing.T) {
+	ci.Parallel(t)
+
+	node := &api.Node{
+		NodeResources: &api.NodeResources{
+			CustomResources: []*api.NodeCustomResource{
+				{Name: "nfs_slot", Count: 8},
+				{Name: "license_seat", Count: 4},
+			},
+		},
+	}
+	allocs := []*api.Allocation{
+		{
+			AllocatedResources: &api.AllocatedResources{
+				Tasks: map[string]*api.AllocatedTaskResources{
+					"web":     {CustomResources: map[string]uint64{"license_seat": 1}},
+					"sidecar": {CustomResources: map[string]uint64{"license_seat": 2, "nfs_slot": 1}},
+				},
+			},
+		},
+		{},
+	}
+
+	must.Eq(t, []string{
+		"Name|Allocated",
+		"license_seat|3/4",
+		"nfs_slot|1/8",
+	}, getCustomResources(allocs, node))
+}
@@ -0,0 +1,259 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type NodeTaintCommand struct {
+	Meta
+}
+
+func (c *NodeTaintCommand) Help() string {
+	helpText := `
+Usage: nomad node taint [options] <node> [key[=value]:Effect ...] [key[:Effect]- ...]
+
+  Updates the taints of a node. Allocations are only placed on a node if they
+  tolerate its NoSchedule and NoExecute taints, and the scheduler avoids nodes
+  with PreferNoSchedule taints they don't tolerate. Allocations that don't
+  tolerate the NoExecute taints of a node are migrated off the node, following
+  the migrate block of their task group. Batch allocations are left to
+  complete.
+
+  Taints are added or updated with arguments in the key=value:Effect or
+  key:Effect form, where the effect is one of NoSchedule, PreferNoSchedule or
+  NoExecute. Taints are removed with arguments ending with a dash, either in
+  the key:Effect- form to remove the taint with the given effect, or in the
+  key- form to remove the taints with the given key. Without arguments after
+  the node, the taints of the node are listed.
+
+  The -self flag is useful to update the taints of the local node.
+
+  If ACLs are enabled, this option requires a token with the 'node:read'
+  capability to list taints and the 'node:write' capability to update them.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Node Taint Options:
+
+  -self
+    Update the taints of the local node.
+
+  Example:
+    $ nomad node taint f4b7a1c2 dedicated=gpu:NoSchedule maintenance:NoExecute
+    $ nomad node taint f4b7a1c2 maintenance-
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeTaintCommand) Synopsis() string {
+	return "Update the taints of a given node"
+}
+
+func (c *NodeTaintCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-self": complete.PredictNothing,
+		})
+}
+
+func (c *NodeTaintCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Nodes, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Nodes]
+	})
+}
+
+func (c *NodeTaintCommand) Name() string { return "node taint" }
+
+func (c *NodeTaintCommand) Run(args []string) int {
+	var self bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&self, "self", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got a node ID
+	args = flags.Args()
+	if !self && len(args) == 0 {
+		c.Ui.Error("Node ID must be specified if -self isn't being used")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// If -self flag is set then determine the current node.
+	var nodeID string
+	if !self {
+		nodeID, args = args[0], args[1:]
+	} else {
+		var err error
+		if nodeID, err = getLocalNodeID(client); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
+	// Parse the taint updates before looking up the node
+	add, remove, err := parseNodeTaintArgs(args)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Check if node exists
+	if len(nodeID) == 1 {
+		c.Ui.Error("Identifier must contain at least two characters.")
+		return 1
+	}
+
+	nodeID = sanitizeUUIDPrefix(nodeID)
+	nodes, _, err := client.Nodes().PrefixList(nodeID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying node: %s", err))
+		return 1
+	}
+	// Return error if no nodes are found
+	if len(nodes) == 0 {
+		c.Ui.Error(fmt.Sprintf("No node(s) with prefix or id %q found", nodeID))
+		return 1
+	}
+	if len(nodes) > 1 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple nodes\n\n%s",
+			formatNodeStubList(nodes, true)))
+		return 1
+	}
+
+	// Prefix lookup matched a single node
+	node, _, err := client.Nodes().Info(nodes[0].ID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying node: %s", err))
+		return 1
+	}
+
+	// List the taints if there is nothing to update
+	if len(add) == 0 && len(remove) == 0 {
+		c.Ui.Output(formatNodeTaints(node.Taints))
+		return 0
+	}
+
+	taints := applyNodeTaintArgs(node.Taints, add, remove)
+	if _, err := client.Nodes().UpdateTaints(node.ID, taints, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error updating node taints: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Node %q taints updated", node.ID))
+	return 0
+}
+
+// nodeTaintRemoval is a taint to remove, in the key:Effect- or key- form. An
+// empty effect removes the taints with the key whatever their effect.
+type nodeTaintRemoval struct {
+	Key    string
+	Effect string
+}
+
+// parseNodeTaintArgs parses the taints to add and remove from the command
+// arguments.
+func parseNodeTaintArgs(args []string) ([]*api.NodeTaint, []nodeTaintRemoval, error) {
+	var add []*api.NodeTaint
+	var remove []nodeTaintRemoval
+	for _, arg := range args {
+		if s, ok := strings.CutSuffix(arg, "-"); ok {
+			key, effect, _ := strings.Cut(s, ":")
+			if key == "" || strings.Contains(key, "=") {
+				return nil, nil, fmt.Errorf("Invalid taint removal %q, expected key:Effect- or key-", arg)
+			}
+			if effect != "" && !validNodeTaintEffect(effect) {
+				return nil, nil, fmt.Errorf("Invalid taint effect %q", effect)
+			}
+			remove = append(remove, nodeTaintRemoval{Key: key, Effect: effect})
+			continue
+		}
+
+		keyValue, effect, ok := strings.Cut(arg, ":")
+		if !ok {
+			return nil, nil, fmt.Errorf("Invalid taint %q, expected key=value:Effect or key:Effect", arg)
+		}
+		key, value, _ := strings.Cut(keyValue, "=")
+		if key == "" {
+			return nil, nil, fmt.Errorf("Invalid taint %q, key must be set", arg)
+		}
+		if !validNodeTaintEffect(effect) {
+			return nil, nil, fmt.Errorf("Invalid taint effect %q", effect)
+		}
+		add = append(add, &api.NodeTaint{Key: key, Value: value, Effect: effect})
+	}
+	return add, remove, nil
+}
+
+func validNodeTaintEffect(effect string) bool {
+	switch effect {
+	case api.TaintEffectNoSchedule, api.TaintEffectPreferNoSchedule, api.TaintEffectNoExecute:
+		return true
+	default:
+		return false
+	}
+}
+
+// applyNodeTaintArgs returns the taints of the node once the given taints are
+// removed and added. An added taint replaces the taint of the node with the
+// same key and effect.
+func applyNodeTaintArgs(taints, add []*api.NodeTaint, remove []nodeTaintRemoval) []*api.NodeTaint {
+	out := make([]*api.NodeTaint, 0, len(taints)+len(add))
+	for _, taint := range taints {
+		removed := slices.ContainsFunc(remove, func(r nodeTaintRemoval) bool {
+			return r.Key == taint.Key && (r.Effect == "" || r.Effect == taint.Effect)
+		})
+		replaced := slices.ContainsFunc(add, func(a *api.NodeTaint) bool {
+			return a.Key == taint.Key && a.Effect == taint.Effect
+		})
+		if !removed && !replaced {
+			out = append(out, taint)
+		}
+	}
+	return append(out, add...)
+}
+
+// formatNodeTaints formats the taints of a node as a list.
+func formatNodeTaints(taints []*api.NodeTaint) string {
+	if len(taints) == 0 {
+		return "No taints"
+	}
+	out := make([]string, len(taints)+1)
+	out[0] = "Key|Value|Effect"
+	for i, taint := range taints {
+		out[i+1] = fmt.Sprintf("%s|%s|%s", taint.Key, taint.Value, taint.Effect)
+	}
+	return formatList(out)
+}
@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeTaintCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &NodeTaintCommand{}
+}
+
+func TestNodeTaint_parseNodeTaintArgs(t *testing.T) {
+	ci.Parallel(t)
+
+	add, remove, err := parseNodeTaintArgs([]string{
+		"dedicated=gpu:NoSchedule", "maintenance:NoExecute", "old:PreferNoSchedule-", "legacy-",
+	})
+	must.NoError(t, err)
+	must.Eq(t, []*api.NodeTaint{
+		{Key: "dedicated", Value: "gpu", Effect: api.TaintEffectNoSchedule},
+		{Key: "maintenance", Effect: api.TaintEffectNoExecute},
+	}, add)
+	must.Eq(t, []nodeTaintRemoval{
+		{Key: "old", Effect: api.TaintEffectPreferNoSchedule},
+		{Key: "legacy"},
+	}, remove)
+
+	for _, arg := range []string{"dedicated=gpu", "=gpu:NoSchedule", "dedicated:Evict", "a=b-", "a:Evict-"} {
+		_, _, err := parseNodeTaintArgs([]string{arg})
+		must.Error(t, err, must.Sprintf("expected error for %q", arg))
+	}
+}
+
+func TestNodeTaint_applyNodeTaintArgs(t *testing.T) {
+	ci.Parallel(t)
+
+	taints := []*api.NodeTaint{
+		{Key: "dedicated", Value: "gpu", Effect: api.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "gpu", Effect: api.TaintEffectNoExecute},
+		{Key: "maintenance", Effect: api.TaintEffectNoExecute},
+		{Key: "zone", Value: "a", Effect: api.TaintEffectPreferNoSchedule},
+	}
+	add := []*api.NodeTaint{
+		{Key: "zone", Value: "b", Effect: api.TaintEffectPreferNoSchedule},
+	}
+	remove := []nodeTaintRemoval{
+		{Key: "dedicated", Effect: api.TaintEffectNoExecute},
+		{Key: "maintenance"},
+	}
+
+	must.Eq(t, []*api.NodeTaint{
+		{Key: "dedicated", Value: "gpu", Effect: api.TaintEffectNoSchedule},
+		{Key: "zone", Value: "b", Effect: api.TaintEffectPreferNoSchedule},
+	}, applyNodeTaintArgs(taints, add, remove))
+}
@@ -31,6 +31,14 @@ Usage: nomad operator scheduler <subcommand> [options]
 
       $ nomad operator scheduler set-config -scheduler-algorithm=spread
 
+  Review the migrations the rebalancer would make:
+
+      $ nomad operator scheduler rebalance-report
+
+  Simulate registering a job against a saved snapshot:
+
+      $ nomad operator scheduler simulate -job example.nomad.hcl backup.snap
+
   Please see the individual subcommand help for detailed usage information.
 `
 	return strings.TrimSpace(helpText)
@@ -5,6 +5,8 @@ package command
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/cli"
@@ -86,6 +88,14 @@ func (o *OperatorSchedulerGetConfig) Run(args []string) int {
 		fmt.Sprintf("Preemption Service Scheduler|%v", schedConfig.PreemptionConfig.ServiceSchedulerEnabled),
 		fmt.Sprintf("Preemption Batch Scheduler|%v", schedConfig.PreemptionConfig.BatchSchedulerEnabled),
 		fmt.Sprintf("Preemption SysBatch Scheduler|%v", schedConfig.PreemptionConfig.SysBatchSchedulerEnabled),
+		fmt.Sprintf("Fair Share|%v", schedConfig.FairShareConfig.Enabled),
+		fmt.Sprintf("Fair Share Weights|%s", formatFairShareWeights(schedConfig.FairShareConfig.NamespaceWeights)),
+		fmt.Sprintf("Rebalance|%v", schedConfig.RebalanceConfig.Enabled),
+		fmt.Sprintf("Rebalance Dry Run|%v", schedConfig.RebalanceConfig.DryRun),
+		fmt.Sprintf("Rebalance Max Migrations|%v", schedConfig.RebalanceConfig.MaxMigrationsPerHour),
+		fmt.Sprintf("Load-Aware Usage Weight|%v", schedConfig.LoadAwareConfig.UsageWeight),
+		fmt.Sprintf("Load-Aware Pressure Threshold|%v", schedConfig.LoadAwareConfig.PressureThreshold),
+		fmt.Sprintf("Load-Aware Best-Effort Priority|%v", schedConfig.LoadAwareConfig.BestEffortPriority),
 		fmt.Sprintf("Modify Index|%v", resp.SchedulerConfig.ModifyIndex),
 	}))
 	return 0
@@ -119,3 +129,18 @@ Scheduler Get Config Options:
 
 	return strings.TrimSpace(helpText)
 }
+
+// formatFairShareWeights formats namespace weights as a sorted list of
+// <namespace>=<weight> pairs.
+func formatFairShareWeights(weights map[string]int) string {
+	if len(weights) == 0 {
+		return "<none>"
+	}
+
+	namespaces := slices.Sorted(maps.Keys(weights))
+	pairs := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", namespace, weights[namespace]))
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,154 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorSchedulerRebalanceReport satisfies the cli.Command interface.
+var _ cli.Command = &OperatorSchedulerRebalanceReport{}
+
+type OperatorSchedulerRebalanceReport struct {
+	Meta
+
+	json    bool
+	tmpl    string
+	verbose bool
+}
+
+func (o *OperatorSchedulerRebalanceReport) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		},
+	)
+}
+
+func (o *OperatorSchedulerRebalanceReport) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (o *OperatorSchedulerRebalanceReport) Name() string {
+	return "operator scheduler rebalance-report"
+}
+
+func (o *OperatorSchedulerRebalanceReport) Run(args []string) int {
+
+	flags := o.Meta.FlagSet("rebalance-report", FlagSetClient)
+	flags.BoolVar(&o.json, "json", false, "")
+	flags.StringVar(&o.tmpl, "t", "", "")
+	flags.BoolVar(&o.verbose, "verbose", false, "")
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments.
+	if l := len(flags.Args()); l != 0 {
+		o.Ui.Error(uiMessageNoArguments)
+		o.Ui.Error(commandErrorText(o))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := o.Meta.Client()
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	report, _, err := client.Operator().SchedulerRebalanceReport(nil)
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error querying rebalance report: %s", err))
+		return 1
+	}
+
+	if o.json || len(o.tmpl) > 0 {
+		out, err := Format(o.json, o.tmpl, report)
+		if err != nil {
+			o.Ui.Error(err.Error())
+			return 1
+		}
+		o.Ui.Output(out)
+		return 0
+	}
+
+	length := shortId
+	if o.verbose {
+		length = fullId
+	}
+
+	o.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Migrations Used This Hour|%d", report.MigrationsUsed),
+		fmt.Sprintf("Max Migrations Per Hour|%d", report.MaxMigrationsPerHour),
+	}))
+
+	if len(report.Migrations) == 0 {
+		o.Ui.Output("\nNo migrations proposed")
+		return 0
+	}
+
+	rows := make([]string, 0, len(report.Migrations)+1)
+	rows = append(rows, "Alloc ID|Namespace|Job ID|Task Group|Node ID|Node Score|Target Node ID|Target Node Score")
+	for _, m := range report.Migrations {
+		rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%s|%.3f|%s|%.3f",
+			limit(m.AllocID, length),
+			m.Namespace,
+			m.JobID,
+			m.TaskGroup,
+			limit(m.NodeID, length),
+			m.NodeScore,
+			limit(m.TargetNodeID, length),
+			m.TargetNodeScore,
+		))
+	}
+	o.Ui.Output(o.Colorize().Color("\n[bold]Proposed Migrations[reset]"))
+	o.Ui.Output(formatList(rows))
+	return 0
+}
+
+func (o *OperatorSchedulerRebalanceReport) Synopsis() string {
+	return "Display the migrations the rebalancer would make"
+}
+
+func (o *OperatorSchedulerRebalanceReport) Help() string {
+	helpText := `
+Usage: nomad operator scheduler rebalance-report [options]
+
+  Displays the service allocations the rebalancer would migrate from lightly
+  used nodes onto busier ones if it ran against the current cluster state. No
+  allocations are migrated. The report is computed whether or not the
+  rebalancer is enabled. The scheduler prefers the target node of each
+  migration, but places the allocation elsewhere if the target no longer fits
+  it when the migration runs.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Scheduler Rebalance Report Options:
+
+  -json
+    Output the rebalance report in its JSON format.
+
+  -t
+    Format and display the rebalance report using a Go template.
+
+  -verbose
+    Display full information.
+`
+
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,44 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerRebalanceReport_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := &OperatorSchedulerRebalanceReport{Meta: Meta{Ui: ui}}
+
+	// Run the command against an idle cluster.
+	must.Zero(t, c.Run([]string{"-address=" + addr}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, "Max Migrations Per Hour   = 10")
+	must.StrContains(t, s, "No migrations proposed")
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Request JSON output and test.
+	must.Zero(t, c.Run([]string{"-address=" + addr, "-json"}))
+	var js api.RebalanceReport
+	must.NoError(t, json.Unmarshal([]byte(ui.OutputWriter.String()), &js))
+	must.Eq(t, 10, js.MaxMigrationsPerHour)
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Test an unexpected argument.
+	must.One(t, c.Run([]string{"-address=" + addr, "foo"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+}
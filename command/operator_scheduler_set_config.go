@@ -5,6 +5,7 @@ package command
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/cli"
@@ -31,6 +32,14 @@ type OperatorSchedulerSetConfig struct {
 	preemptServiceScheduler  flagHelper.BoolValue
 	preemptSysBatchScheduler flagHelper.BoolValue
 	preemptSystemScheduler   flagHelper.BoolValue
+	fairShare                flagHelper.BoolValue
+	fairShareWeights         flagHelper.StringFlag
+	rebalance                flagHelper.BoolValue
+	rebalanceDryRun          flagHelper.BoolValue
+	rebalanceMaxMigrations   flagHelper.UintValue
+	loadAwareUsageWeight     flagHelper.UintValue
+	loadAwarePressure        flagHelper.UintValue
+	loadAwareBestEffort      flagHelper.UintValue
 }
 
 func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
@@ -40,14 +49,23 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-scheduler-algorithm": complete.PredictSet(
 				string(api.SchedulerAlgorithmBinpack),
 				string(api.SchedulerAlgorithmSpread),
+				string(api.SchedulerAlgorithmLoadAware),
 			),
-			"-memory-oversubscription":    complete.PredictSet("true", "false"),
-			"-reject-job-registration":    complete.PredictSet("true", "false"),
-			"-pause-eval-broker":          complete.PredictSet("true", "false"),
-			"-preempt-batch-scheduler":    complete.PredictSet("true", "false"),
-			"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
-			"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
-			"-preempt-system-scheduler":   complete.PredictSet("true", "false"),
+			"-memory-oversubscription":         complete.PredictSet("true", "false"),
+			"-reject-job-registration":         complete.PredictSet("true", "false"),
+			"-pause-eval-broker":               complete.PredictSet("true", "false"),
+			"-preempt-batch-scheduler":         complete.PredictSet("true", "false"),
+			"-preempt-service-scheduler":       complete.PredictSet("true", "false"),
+			"-preempt-sysbatch-scheduler":      complete.PredictSet("true", "false"),
+			"-preempt-system-scheduler":        complete.PredictSet("true", "false"),
+			"-fair-share":                      complete.PredictSet("true", "false"),
+			"-fair-share-weight":               complete.PredictAnything,
+			"-rebalance":                       complete.PredictSet("true", "false"),
+			"-rebalance-dry-run":               complete.PredictSet("true", "false"),
+			"-rebalance-max-migrations":        complete.PredictAnything,
+			"-load-aware-usage-weight":         complete.PredictAnything,
+			"-load-aware-pressure-threshold":   complete.PredictAnything,
+			"-load-aware-best-effort-priority": complete.PredictAnything,
 		},
 	)
 }
@@ -72,6 +90,14 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.Var(&o.preemptServiceScheduler, "preempt-service-scheduler", "")
 	flags.Var(&o.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
 	flags.Var(&o.preemptSystemScheduler, "preempt-system-scheduler", "")
+	flags.Var(&o.fairShare, "fair-share", "")
+	flags.Var(&o.fairShareWeights, "fair-share-weight", "")
+	flags.Var(&o.rebalance, "rebalance", "")
+	flags.Var(&o.rebalanceDryRun, "rebalance-dry-run", "")
+	flags.Var(&o.rebalanceMaxMigrations, "rebalance-max-migrations", "")
+	flags.Var(&o.loadAwareUsageWeight, "load-aware-usage-weight", "")
+	flags.Var(&o.loadAwarePressure, "load-aware-pressure-threshold", "")
+	flags.Var(&o.loadAwareBestEffort, "load-aware-best-effort-priority", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -134,6 +160,25 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	o.preemptServiceScheduler.Merge(&schedulerConfig.PreemptionConfig.ServiceSchedulerEnabled)
 	o.preemptSysBatchScheduler.Merge(&schedulerConfig.PreemptionConfig.SysBatchSchedulerEnabled)
 	o.preemptSystemScheduler.Merge(&schedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
+	o.fairShare.Merge(&schedulerConfig.FairShareConfig.Enabled)
+	if err := mergeFairShareWeights(&schedulerConfig.FairShareConfig, o.fairShareWeights); err != nil {
+		o.Ui.Error(fmt.Sprintf("Error parsing fair-share-weight value: %v", err))
+		return 1
+	}
+	o.rebalance.Merge(&schedulerConfig.RebalanceConfig.Enabled)
+	o.rebalanceDryRun.Merge(&schedulerConfig.RebalanceConfig.DryRun)
+	maxMigrations := uint(schedulerConfig.RebalanceConfig.MaxMigrationsPerHour)
+	o.rebalanceMaxMigrations.Merge(&maxMigrations)
+	schedulerConfig.RebalanceConfig.MaxMigrationsPerHour = int(maxMigrations)
+	usageWeight := uint(schedulerConfig.LoadAwareConfig.UsageWeight)
+	o.loadAwareUsageWeight.Merge(&usageWeight)
+	schedulerConfig.LoadAwareConfig.UsageWeight = int(usageWeight)
+	pressure := uint(schedulerConfig.LoadAwareConfig.PressureThreshold)
+	o.loadAwarePressure.Merge(&pressure)
+	schedulerConfig.LoadAwareConfig.PressureThreshold = int(pressure)
+	bestEffort := uint(schedulerConfig.LoadAwareConfig.BestEffortPriority)
+	o.loadAwareBestEffort.Merge(&bestEffort)
+	schedulerConfig.LoadAwareConfig.BestEffortPriority = int(bestEffort)
 
 	// Check-and-set the new configuration.
 	result, _, err := client.Operator().SchedulerCASConfiguration(schedulerConfig, nil)
@@ -173,9 +218,10 @@ Scheduler Set Config Options:
     matches the current server side version. If a non-zero value is passed, it
     ensures that the scheduler config is being updated from a known state.
 
-  -scheduler-algorithm=["binpack"|"spread"]
+  -scheduler-algorithm=["binpack"|"spread"|"load-aware"]
     Specifies whether scheduler binpacks or spreads allocations on available
-    nodes.
+    nodes. The "load-aware" algorithm binpacks allocations while taking into
+    account the actual utilization published by clients.
 
   -memory-oversubscription=[true|false]
     When true, tasks may exceed their reserved memory limit, if the client has
@@ -208,6 +254,68 @@ Scheduler Set Config Options:
   -preempt-system-scheduler=[true|false]
     Specifies whether preemption for system jobs is enabled. Note that if this
     is set to true, then system jobs can preempt any other jobs.
+
+  -fair-share=[true|false]
+    Specifies whether the eval broker dequeues evaluations of equal priority in
+    proportion to the weight of their namespace, rather than in the order they
+    were created.
+
+  -fair-share-weight=<namespace>=<weight>
+    Sets the fair share weight of a namespace. Namespaces without a weight
+    have a weight of 1, and a weight of 0 removes the namespace weight. Can be
+    specified multiple times.
+
+  -rebalance=[true|false]
+    Specifies whether the leader periodically migrates service allocations off
+    lightly used nodes and onto busier ones, so that the emptied nodes can be
+    drained and scaled down.
+
+  -rebalance-dry-run=[true|false]
+    When true, the rebalancer only logs the migrations it would make. Use
+    "nomad operator scheduler rebalance-report" to review them.
+
+  -rebalance-max-migrations=<count>
+    Sets the maximum number of allocations the rebalancer migrates per hour.
+    A value of 0 uses the default of 10.
+
+  -load-aware-usage-weight=<percent>
+    Sets the percentage of the score of a node based on its actual CPU and
+    memory utilization when using the "load-aware" algorithm, the rest being
+    its binpack score. A value of 0 uses the default of 50.
+
+  -load-aware-pressure-threshold=<percent>
+    Sets the CPU, memory, or IO pressure above which nodes are not eligible
+    for best-effort jobs when using the "load-aware" algorithm. A value of 0
+    uses the default of 25, and a value of 100 disables the check.
+
+  -load-aware-best-effort-priority=<priority>
+    Sets the priority at or below which jobs are best-effort when using the
+    "load-aware" algorithm. A value of 0 uses the default of 30.
 `
 	return strings.TrimSpace(helpText)
 }
+
+// mergeFairShareWeights merges namespace weights in the form
+// <namespace>=<weight> onto the fair share configuration.
+func mergeFairShareWeights(config *api.FairShareConfig, weights []string) error {
+	for _, w := range weights {
+		namespace, value, ok := strings.Cut(w, "=")
+		if !ok || namespace == "" {
+			return fmt.Errorf("%q must be in the form <namespace>=<weight>", w)
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 {
+			return fmt.Errorf("%q must have a non-negative integer weight", w)
+		}
+
+		if weight == 0 {
+			delete(config.NamespaceWeights, namespace)
+			continue
+		}
+		if config.NamespaceWeights == nil {
+			config.NamespaceWeights = make(map[string]int)
+		}
+		config.NamespaceWeights[namespace] = weight
+	}
+	return nil
+}
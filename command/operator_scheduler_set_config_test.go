@@ -43,7 +43,7 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 	// object via the CLI.
 	modifyingArgs := []string{
 		"-address=" + addr,
-		"-scheduler-algorithm=spread",
+		"-scheduler-algorithm=load-aware",
 		"-pause-eval-broker=true",
 		"-memory-oversubscription=true",
 		"-reject-job-registration=true",
@@ -51,6 +51,14 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		"-preempt-service-scheduler=true",
 		"-preempt-sysbatch-scheduler=true",
 		"-preempt-system-scheduler=false",
+		"-fair-share=true",
+		"-fair-share-weight=batch=3",
+		"-rebalance=true",
+		"-rebalance-dry-run=true",
+		"-rebalance-max-migrations=5",
+		"-load-aware-usage-weight=70",
+		"-load-aware-pressure-threshold=40",
+		"-load-aware-best-effort-priority=20",
 	}
 	must.Zero(t, c.Run(modifyingArgs))
 	s := ui.OutputWriter.String()
@@ -59,7 +67,7 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 	modifiedConfig, _, err := srv.APIClient().Operator().SchedulerGetConfiguration(nil)
 	must.NoError(t, err)
 	schedulerConfigEquals(t, &api.SchedulerConfiguration{
-		SchedulerAlgorithm: "spread",
+		SchedulerAlgorithm: "load-aware",
 		PreemptionConfig: api.PreemptionConfig{
 			SystemSchedulerEnabled:   false,
 			SysBatchSchedulerEnabled: true,
@@ -69,6 +77,20 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		MemoryOversubscriptionEnabled: true,
 		RejectJobRegistration:         true,
 		PauseEvalBroker:               true,
+		FairShareConfig: api.FairShareConfig{
+			Enabled:          true,
+			NamespaceWeights: map[string]int{"batch": 3},
+		},
+		RebalanceConfig: api.RebalanceConfig{
+			Enabled:              true,
+			DryRun:               true,
+			MaxMigrationsPerHour: 5,
+		},
+		LoadAwareConfig: api.LoadAwareConfig{
+			UsageWeight:        70,
+			PressureThreshold:  40,
+			BestEffortPriority: 20,
+		},
 	}, modifiedConfig.SchedulerConfig)
 
 	ui.ErrorWriter.Reset()
@@ -108,4 +130,7 @@ func schedulerConfigEquals(t *testing.T, expected, actual *api.SchedulerConfigur
 	must.Eq(t, expected.MemoryOversubscriptionEnabled, actual.MemoryOversubscriptionEnabled)
 	must.Eq(t, expected.PauseEvalBroker, actual.PauseEvalBroker)
 	must.Eq(t, expected.PreemptionConfig, actual.PreemptionConfig)
+	must.Eq(t, expected.FairShareConfig, actual.FairShareConfig)
+	must.Eq(t, expected.RebalanceConfig, actual.RebalanceConfig)
+	must.Eq(t, expected.LoadAwareConfig, actual.LoadAwareConfig)
 }
@@ -0,0 +1,216 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/scheduler/simulation"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorSchedulerSimulateCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+
+type OperatorSchedulerSimulateCommand struct {
+	Meta
+	JobGetter
+}
+
+func (c *OperatorSchedulerSimulateCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler simulate [options] <file>
+
+  Runs the schedulers offline against the state stored in a snapshot file,
+  such as one saved with "nomad operator snapshot save". The given job and
+  node changes are applied in the order of the flags, and the schedulers run
+  to convergence after each one. Nothing is written back to the cluster.
+
+  The result is a JSON report of the allocations that would be placed,
+  stopped or preempted, and of the task groups that would fail to place along
+  with their allocation metrics.
+
+  To register two jobs and drain a node:
+
+    $ nomad operator scheduler simulate \
+        -job api.nomad.hcl -job cache.nomad.hcl \
+        -drain-node 4f3b2c1a backup.snap
+
+  This command does not contact the Nomad servers.
+
+Scheduler Simulate Options:
+
+  -job <path>
+    Register the job in the given jobspec file. Can be specified multiple
+    times.
+
+  -stop-job <job-id>
+    Stop the job with the given ID in the namespace given by -namespace. Can
+    be specified multiple times.
+
+  -drain-node <node-id>
+    Force drain the node with the given ID or ID prefix, migrating all of its
+    allocations except those of system jobs. Can be specified multiple times.
+
+  -namespace
+    The namespace of jobs given to -stop-job. Defaults to "default".
+
+  -json
+    Parses the job files as JSON. If the outer object has a Job field, such
+    as from "nomad job inspect" or "nomad run -output", the value of the
+    field is used as the job.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSchedulerSimulateCommand) Synopsis() string {
+	return "Simulate scheduling against a snapshot"
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-job":        complete.PredictFiles("*"),
+		"-stop-job":   complete.PredictAnything,
+		"-drain-node": complete.PredictAnything,
+		"-namespace":  complete.PredictAnything,
+		"-json":       complete.PredictNothing,
+		"-var":        complete.PredictAnything,
+		"-var-file":   complete.PredictFiles("*.var"),
+	}
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*.snap")
+}
+
+func (c *OperatorSchedulerSimulateCommand) Name() string { return "operator scheduler simulate" }
+
+// mutationFlag is a flag.Value that appends to a list of mutations shared by
+// several flags, so the mutations keep the order they were given in.
+type mutationFlag struct {
+	kind      string
+	mutations *[]*pendingMutation
+}
+
+type pendingMutation struct {
+	kind  string
+	value string
+}
+
+func (f *mutationFlag) String() string { return "" }
+
+func (f *mutationFlag) Set(value string) error {
+	if value == "" {
+		return fmt.Errorf("value must not be empty")
+	}
+	*f.mutations = append(*f.mutations, &pendingMutation{kind: f.kind, value: value})
+	return nil
+}
+
+func (c *OperatorSchedulerSimulateCommand) Run(args []string) int {
+	var pending []*pendingMutation
+	var namespace string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Var(&mutationFlag{simulation.MutationJobRegister, &pending}, "job", "")
+	flags.Var(&mutationFlag{simulation.MutationJobStop, &pending}, "stop-job", "")
+	flags.Var(&mutationFlag{simulation.MutationNodeDrain, &pending}, "drain-node", "")
+	flags.StringVar(&namespace, "namespace", "", "")
+	flags.BoolVar(&c.JobGetter.JSON, "json", false, "")
+	flags.Var(&c.JobGetter.Vars, "var", "")
+	flags.Var(&c.JobGetter.VarFiles, "var-file", "")
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+	c.JobGetter.Strict = true
+
+	if len(flags.Args()) != 1 {
+		c.Ui.Error("This command takes one argument: <file>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if len(pending) == 0 {
+		c.Ui.Error("At least one of -job, -stop-job or -drain-node must be specified")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if err := c.JobGetter.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid job options: %s", err))
+		return 1
+	}
+
+	// Parse every job file before reading the snapshot, so that mistakes in
+	// the jobspecs are reported quickly.
+	mutations := make([]*simulation.Mutation, 0, len(pending))
+	for _, p := range pending {
+		m := &simulation.Mutation{Type: p.kind}
+		switch p.kind {
+		case simulation.MutationJobRegister:
+			_, job, err := c.JobGetter.Get(p.value)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+				return 1
+			}
+			m.Job = agent.ApiJobToStructJob(job)
+		case simulation.MutationJobStop:
+			m.Namespace = namespace
+			m.JobID = p.value
+		case simulation.MutationNodeDrain:
+			m.NodeID = p.value
+		}
+		mutations = append(mutations, m)
+	}
+
+	f, err := os.Open(flags.Args()[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening snapshot file: %s", err))
+		return 1
+	}
+	defer f.Close()
+
+	_, state, _, err := raftutil.RestoreFromArchive(f, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read archive file: %s", err))
+		return 1
+	}
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:   "nomad",
+		Level:  hclog.Warn,
+		Output: os.Stderr,
+	})
+	sim, err := simulation.NewSimulator(logger, state)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error setting up simulation: %s", err))
+		return 1
+	}
+	report, err := sim.Run(mutations)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error running simulation: %s", err))
+		return 1
+	}
+
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to encode output: %v", err))
+		return 1
+	}
+	c.Ui.Output(string(out))
+	return 0
+}
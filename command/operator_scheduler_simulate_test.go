@@ -0,0 +1,64 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/scheduler/simulation"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerSimulateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+}
+
+func TestOperatorSchedulerSimulateCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("no snapshot", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+		must.One(t, cmd.Run([]string{"-job", "testdata/example-basic.nomad"}))
+		must.StrContains(t, ui.ErrorWriter.String(), "This command takes one argument")
+	})
+
+	t.Run("no mutations", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+		must.One(t, cmd.Run([]string{"backup.snap"}))
+		must.StrContains(t, ui.ErrorWriter.String(), "At least one of")
+	})
+
+	t.Run("bad jobspec", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+		must.One(t, cmd.Run([]string{"-job", "testdata/missing.nomad", "backup.snap"}))
+		must.StrContains(t, ui.ErrorWriter.String(), "Error getting job struct")
+	})
+}
+
+func TestOperatorSchedulerSimulateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	snapPath := generateSnapshotFile(t, nil)
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-job", "testdata/example-basic.nomad", snapPath})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+
+	// The snapshot has no client nodes, so the job cannot be placed.
+	var report simulation.Report
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &report))
+	must.SliceEmpty(t, report.Placements)
+	must.Len(t, 1, report.FailedPlacements)
+	must.Eq(t, "job1", report.FailedPlacements[0].JobID)
+	must.Eq(t, "group1", report.FailedPlacements[0].TaskGroup)
+	must.True(t, report.FailedPlacements[0].Blocked)
+}
@@ -38,6 +38,11 @@ Usage: nomad operator utilization [options]
     Specifies the output path for the bundle. Defaults to a time-based generated
     file name in the current working directory.
 
+  -reservations
+    Report the CPU and memory consumers use out of the capacity reserved by
+    each capacity reservation, instead of generating a bundle. Requires a token
+    with the 'operator:read' capability.
+
   -today-only
     Include snapshots from the previous 24 hours, not historical snapshots.
 
@@ -55,14 +60,15 @@ func (c *OperatorUtilizationCommand) Name() string { return "operator utilizatio
 func (c *OperatorUtilizationCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-message":    complete.PredictNothing,
-			"-today-only": complete.PredictNothing,
-			"-output":     complete.PredictFiles(""),
+			"-message":      complete.PredictNothing,
+			"-today-only":   complete.PredictNothing,
+			"-output":       complete.PredictFiles(""),
+			"-reservations": complete.PredictNothing,
 		})
 }
 
 func (c *OperatorUtilizationCommand) Run(args []string) int {
-	var todayOnly bool
+	var todayOnly, reservations bool
 	var message, outputPath string
 
 	flags := c.Meta.FlagSet("operator utilization", FlagSetClient)
@@ -70,6 +76,7 @@ func (c *OperatorUtilizationCommand) Run(args []string) int {
 	flags.BoolVar(&todayOnly, "today-only", false, "only today's snapshot")
 	flags.StringVar(&outputPath, "output", "", "output path for the bundle")
 	flags.StringVar(&message, "message", "", "provided context for logs")
+	flags.BoolVar(&reservations, "reservations", false, "report reservation usage")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -88,6 +95,20 @@ func (c *OperatorUtilizationCommand) Run(args []string) int {
 		return 1
 	}
 
+	if reservations {
+		list, _, err := client.Reservations().List(nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying reservations: %s", err))
+			return 1
+		}
+		if len(list) == 0 {
+			c.Ui.Output("No reservations found")
+			return 0
+		}
+		c.Ui.Output(formatReservationList(list, time.Now()))
+		return 0
+	}
+
 	resp, _, err := client.Operator().Utilization(
 		&api.OperatorUtilizationOptions{TodayOnly: todayOnly}, nil)
 	if err != nil {
@@ -123,17 +123,6 @@ limit {
     cpu        = 2500
     memory     = 1000
     memory_max = 1000
-    device "nvidia/gpu/1080ti" {
-      count = 1
-    }
-    storage {
-      variables    = 1000   # in MB
-      host_volumes = 100000 # in MB
-    }
-    node_pool "example" {
-      cpu    = 500
-      memory = 100
-    }
   }
 }
 `)
@@ -149,21 +138,7 @@ var defaultJsonQuotaSpec = strings.TrimSpace(`
         "Cores": 0,
         "CPU": 2500,
         "MemoryMB": 1000,
-        "MemoryMaxMB": 1000,
-        "Devices": [
-          {
-            "Name": "nvidia/gpu/1080ti",
-            "Count": 1
-          }
-        ],
-        "Storage": {
-          "Variables": 1000,
-          "HostVolumes": 100000
-        },
-        "NodePool": {
-          "CPU": 500,
-          "MemoryMB": 1000
-        }
+        "MemoryMaxMB": 1000
       }
     }
   ]
@@ -0,0 +1,113 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type ReservationCommand struct {
+	Meta
+}
+
+func (c *ReservationCommand) Name() string {
+	return "reservation"
+}
+
+func (c *ReservationCommand) Synopsis() string {
+	return "Interact with capacity reservations"
+}
+
+func (c *ReservationCommand) Help() string {
+	helpText := `
+Usage: nomad reservation <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with capacity reservations.
+  Reservations hold CPU and memory of a node pool for the jobs, namespaces or
+  priorities they name, so other jobs cannot consume it. Reservations expire
+  after their TTL, which is renewed each time they are applied.
+
+  Create, update or renew a reservation:
+
+    $ nomad reservation apply <path>
+
+  List all reservations:
+
+    $ nomad reservation list
+
+  Fetch information on an existing reservation:
+
+    $ nomad reservation status <name>
+
+  Delete a reservation:
+
+    $ nomad reservation delete <name>
+
+  Please refer to individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ReservationCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func formatReservationList(reservations []*api.Reservation, now time.Time) string {
+	out := make([]string, len(reservations)+1)
+	out[0] = "Name|Node Pool|CPU (MHz)|Memory (MiB)|Expires"
+	for i, res := range reservations {
+		cpu, mem := formatReservationUsage(res.Usage)
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
+			res.Name,
+			res.NodePool,
+			cpu,
+			mem,
+			formatReservationExpiry(res.ExpiresAt, now),
+		)
+	}
+	return formatList(out)
+}
+
+// formatReservationUsage returns the used and reserved CPU and memory of a
+// reservation.
+func formatReservationUsage(usage *api.ReservationUsage) (string, string) {
+	if usage == nil {
+		usage = &api.ReservationUsage{}
+	}
+	return fmt.Sprintf("%d/%d", usage.UsedCPU, usage.ReservedCPU),
+		fmt.Sprintf("%d/%d", usage.UsedMemoryMB, usage.ReservedMemoryMB)
+}
+
+func formatReservationExpiry(expiresAt, now time.Time) string {
+	if !expiresAt.After(now) {
+		return "expired"
+	}
+	return fmt.Sprintf("%s (in %s)", formatTime(expiresAt), expiresAt.Sub(now).Round(time.Second))
+}
+
+func reservationPredictor(factory ApiClientFactory) complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := factory()
+		if err != nil {
+			return nil
+		}
+
+		reservations, _, err := client.Reservations().PrefixList(a.Last, nil)
+		if err != nil {
+			return nil
+		}
+
+		names := make([]string, len(reservations))
+		for i, res := range reservations {
+			names[i] = res.Name
+		}
+		return names
+	})
+}
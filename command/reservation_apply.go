@@ -0,0 +1,177 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/hcl"
+	"github.com/posener/complete"
+)
+
+type ReservationApplyCommand struct {
+	Meta
+}
+
+func (c *ReservationApplyCommand) Name() string {
+	return "reservation apply"
+}
+
+func (c *ReservationApplyCommand) Synopsis() string {
+	return "Create, update or renew a capacity reservation"
+}
+
+func (c *ReservationApplyCommand) Help() string {
+	helpText := `
+Usage: nomad reservation apply [options] <input>
+
+  Apply is used to create or update a capacity reservation from an HCL or JSON
+  specification. Applying an existing reservation renews its TTL. The capacity
+  of the reservation is placed on the ready nodes of its node pool when it is
+  created, or when its resources or node pool change, and the command fails if
+  the node pool lacks free capacity. If the input is "-", the specification is
+  read from stdin.
+
+  If ACLs are enabled, this command requires a token with the 'operator:write'
+  capability.
+
+  An example specification:
+
+    reservation "launch" {
+      description = "Capacity for the product launch"
+      node_pool   = "default"
+      ttl         = "72h"
+
+      resources {
+        cores  = 200
+        memory = 524288
+      }
+
+      consumers {
+        namespaces   = ["launch"]
+        jobs         = ["checkout"]
+        min_priority = 80
+      }
+    }
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Apply Options:
+
+  -json
+    Parse the input as JSON.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ReservationApplyCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+		})
+}
+
+func (c *ReservationApplyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.hcl"),
+		complete.PredictFiles("*.json"),
+	)
+}
+
+func (c *ReservationApplyCommand) Run(args []string) int {
+	var jsonInput bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&jsonInput, "json", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we only have one argument.
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <input>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Read input content.
+	path := args[0]
+	var content []byte
+	var err error
+	switch path {
+	case "-":
+		content, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read stdin: %v", err))
+			return 1
+		}
+		// Set .hcl extension so the decoder doesn't fail.
+		if !jsonInput {
+			path = "stdin.nomad.hcl"
+		}
+	default:
+		content, err = os.ReadFile(path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read file %q: %v", path, err))
+			return 1
+		}
+	}
+
+	res, err := parseReservationSpec(content, path, jsonInput)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse input content: %v", err))
+		return 1
+	}
+
+	// Make API request.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	_, err = client.Reservations().Register(res, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error applying reservation: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully applied reservation %q!", res.Name))
+	return 0
+}
+
+type reservationSpec struct {
+	Reservation *api.Reservation `hcl:"reservation,block"`
+}
+
+// parseReservationSpec parses a reservation specification in HCL, or in JSON
+// if jsonInput is set.
+func parseReservationSpec(content []byte, path string, jsonInput bool) (*api.Reservation, error) {
+	var spec reservationSpec
+	if jsonInput {
+		if err := json.Unmarshal(content, &spec.Reservation); err != nil {
+			return nil, err
+		}
+	} else {
+		hclParser := hcl.NewParser()
+		if hclDiags := hclParser.Parse(content, &spec, path); hclDiags.HasErrors() {
+			return nil, hclDiags
+		}
+	}
+	if spec.Reservation == nil {
+		return nil, errors.New("missing reservation")
+	}
+	return spec.Reservation, nil
+}
@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestReservationApplyCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &ReservationApplyCommand{}
+}
+
+func TestParseReservationSpec(t *testing.T) {
+	ci.Parallel(t)
+
+	expected := &api.Reservation{
+		Name:        "launch",
+		Description: "capacity for the launch",
+		NodePool:    "prod",
+		Resources:   &api.ReservationResources{Cores: 200, MemoryMB: 524288},
+		Consumers: &api.ReservationConsumers{
+			Namespaces:  []string{"launch"},
+			MinPriority: 80,
+		},
+		TTL: 72 * time.Hour,
+	}
+
+	hclSpec := `
+reservation "launch" {
+  description = "capacity for the launch"
+  node_pool   = "prod"
+  ttl         = "72h"
+
+  resources {
+    cores  = 200
+    memory = 524288
+  }
+
+  consumers {
+    namespaces   = ["launch"]
+    min_priority = 80
+  }
+}
+`
+	res, err := parseReservationSpec([]byte(hclSpec), "launch.nomad.hcl", false)
+	must.NoError(t, err)
+	must.Eq(t, expected, res)
+
+	jsonSpec := `{
+  "Name": "launch",
+  "Description": "capacity for the launch",
+  "NodePool": "prod",
+  "TTL": "72h",
+  "Resources": {"Cores": 200, "MemoryMB": 524288},
+  "Consumers": {"Namespaces": ["launch"], "MinPriority": 80}
+}`
+	res, err = parseReservationSpec([]byte(jsonSpec), "launch.json", true)
+	must.NoError(t, err)
+	must.Eq(t, expected, res)
+
+	_, err = parseReservationSpec([]byte(`node_pool "prod" {}`), "pool.nomad.hcl", false)
+	must.Error(t, err)
+}
@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/posener/complete"
+)
+
+type ReservationListCommand struct {
+	Meta
+}
+
+func (c *ReservationListCommand) Name() string {
+	return "reservation list"
+}
+
+func (c *ReservationListCommand) Synopsis() string {
+	return "List capacity reservations"
+}
+
+func (c *ReservationListCommand) Help() string {
+	helpText := `
+Usage: nomad reservation list [options]
+
+  List is used to list existing capacity reservations, with the CPU and memory
+  their consumers use out of the capacity they reserve.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+List Options:
+
+  -json
+    Output the reservations in JSON format.
+
+  -t
+    Format and display the reservations using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ReservationListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *ReservationListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ReservationListCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we don't have any arguments.
+	if len(flags.Args()) != 0 {
+		c.Ui.Error(uiMessageNoArguments)
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Make list request.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	reservations, _, err := client.Reservations().List(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying reservations: %s", err))
+		return 1
+	}
+
+	// Format output if requested.
+	if json || tmpl != "" {
+		out, err := Format(json, tmpl, reservations)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting output: %s", err))
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(reservations) == 0 {
+		c.Ui.Output("No reservations found")
+		return 0
+	}
+
+	c.Ui.Output(formatReservationList(reservations, time.Now()))
+	return 0
+}
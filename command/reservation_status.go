@@ -0,0 +1,160 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type ReservationStatusCommand struct {
+	Meta
+}
+
+func (c *ReservationStatusCommand) Name() string {
+	return "reservation status"
+}
+
+func (c *ReservationStatusCommand) Synopsis() string {
+	return "Display the status of a capacity reservation"
+}
+
+func (c *ReservationStatusCommand) Help() string {
+	helpText := `
+Usage: nomad reservation status [options] <reservation>
+
+  Status is used to view the consumers, usage and node placements of a
+  capacity reservation.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Status Options:
+
+  -json
+    Output the reservation in JSON format.
+
+  -t
+    Format and display the reservation using a Go template.
+
+  -verbose
+    Display full node IDs.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ReservationStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *ReservationStatusCommand) AutocompleteArgs() complete.Predictor {
+	return reservationPredictor(c.Client)
+}
+
+func (c *ReservationStatusCommand) Run(args []string) int {
+	var json, verbose bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we only have one argument.
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <reservation>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	res, _, err := client.Reservations().Info(args[0], nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying reservation: %s", err))
+		return 1
+	}
+
+	if json || tmpl != "" {
+		out, err := Format(json, tmpl, res)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting output: %s", err))
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+	c.Ui.Output(c.Colorize().Color(formatReservation(res, time.Now(), length)))
+	return 0
+}
+
+func formatReservation(res *api.Reservation, now time.Time, length int) string {
+	cpu, mem := formatReservationUsage(res.Usage)
+	consumers := res.Consumers
+	if consumers == nil {
+		consumers = &api.ReservationConsumers{}
+	}
+	basic := []string{
+		fmt.Sprintf("Name|%s", res.Name),
+		fmt.Sprintf("Description|%s", res.Description),
+		fmt.Sprintf("Node Pool|%s", res.NodePool),
+		fmt.Sprintf("Consumer Namespaces|%s", strings.Join(consumers.Namespaces, ",")),
+		fmt.Sprintf("Consumer Jobs|%s", strings.Join(consumers.Jobs, ",")),
+		fmt.Sprintf("Consumer Min Priority|%d", consumers.MinPriority),
+		fmt.Sprintf("CPU (MHz)|%s", cpu),
+		fmt.Sprintf("Memory (MiB)|%s", mem),
+		fmt.Sprintf("TTL|%s", res.TTL),
+		fmt.Sprintf("Expires|%s", formatReservationExpiry(res.ExpiresAt, now)),
+	}
+
+	out := formatKV(basic)
+	out += "\n\n[bold]Placements[reset]\n"
+	if len(res.Placements) == 0 {
+		return out + "No placements"
+	}
+
+	nodeIDs := make([]string, 0, len(res.Placements))
+	for nodeID := range res.Placements {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	rows := make([]string, len(nodeIDs)+1)
+	rows[0] = "Node ID|CPU (MHz)|Memory (MiB)"
+	for i, nodeID := range nodeIDs {
+		p := res.Placements[nodeID]
+		rows[i+1] = fmt.Sprintf("%s|%d|%d", limit(nodeID, length), p.CPU, p.MemoryMB)
+	}
+	return out + formatList(rows)
+}
@@ -0,0 +1,53 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestReservationStatusCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &ReservationStatusCommand{}
+}
+
+func TestFormatReservation(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	res := &api.Reservation{
+		Name:      "launch",
+		NodePool:  "prod",
+		Consumers: &api.ReservationConsumers{Namespaces: []string{"launch", "web"}},
+		TTL:       time.Hour,
+		ExpiresAt: now.Add(30 * time.Minute),
+		Placements: map[string]*api.ReservationPlacement{
+			"f2b0e3c4-0000-0000-0000-000000000000": {CPU: 500, MemoryMB: 256},
+			"0a1b2c3d-0000-0000-0000-000000000000": {CPU: 1000, MemoryMB: 768},
+		},
+		Usage: &api.ReservationUsage{
+			ReservedCPU:      1500,
+			ReservedMemoryMB: 1024,
+			UsedCPU:          500,
+		},
+	}
+
+	out := formatReservation(res, now, shortId)
+	must.StrContains(t, out, "Consumer Namespaces   = launch,web")
+	must.StrContains(t, out, "CPU (MHz)             = 500/1500")
+	must.StrContains(t, out, "Memory (MiB)          = 0/1024")
+	must.StrContains(t, out, "(in 30m0s)")
+	must.RegexMatch(t, regexp.MustCompile(`0a1b2c3d\s+1000\s+768\n.*f2b0e3c4\s+500\s+256`), out)
+
+	list := formatReservationList([]*api.Reservation{res}, now.Add(time.Hour))
+	must.StrContains(t, list, "Name    Node Pool  CPU (MHz)  Memory (MiB)  Expires")
+	must.RegexMatch(t, regexp.MustCompile(`launch\s+prod\s+500/1500\s+0/1024\s+expired`), list)
+}
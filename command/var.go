@@ -51,6 +51,14 @@ Usage: nomad var <subcommand> [options] [args]
 
       $ nomad var get <path>
 
+  List the versions of a variable:
+
+      $ nomad var history <path>
+
+  Restore a prior version of a variable:
+
+      $ nomad var rollback <path> <version>
+
   List existing variables:
 
       $ nomad var list <prefix>
@@ -108,6 +116,12 @@ func renderSVAsUiTable(sv *api.Variable, c VarUI) {
 	if sv.CreateTime != sv.ModifyTime {
 		meta = append(meta, fmt.Sprintf("Modify Time|%v", formatUnixNanoTime(sv.ModifyTime)))
 	}
+	if sv.ExpireTime != 0 {
+		meta = append(meta,
+			fmt.Sprintf("TTL|%v", sv.TTL),
+			fmt.Sprintf("Expire Time|%v", formatUnixNanoTime(sv.ExpireTime)),
+		)
+	}
 	meta = append(meta, fmt.Sprintf("Check Index|%v", sv.ModifyIndex))
 	ui := c.GetConcurrentUI()
 	ui.Output(formatKV(meta))
@@ -134,6 +148,10 @@ create_index = {{.CreateIndex}}  # Set by server
 modify_index = {{.ModifyIndex}}  # Set by server; consulted for check-and-set
 create_time  = {{.CreateTime}}   # Set by server
 modify_time  = {{.ModifyTime}}   # Set by server
+{{- if .TTL}}
+ttl          = "{{.TTL}}"
+expire_time  = {{.ExpireTime}}   # Set by server
+{{- end}}
 
 items = {
 {{- $PAD := 0 -}}{{- range $k,$v := .Items}}{{if gt (len $k) $PAD}}{{$PAD = (len $k)}}{{end}}{{end -}}
@@ -49,6 +49,10 @@ Get Options:
   -template
      Template to render output with. Required when output is "go-template".
 
+  -version <version>
+     Read a prior version of the variable instead of its current version.
+     The available versions are listed by the 'nomad var history' command.
+
   -ui
     Open the variable page in the browser.
 
@@ -62,6 +66,7 @@ func (c *VarGetCommand) AutocompleteFlags() complete.Flags {
 			"-out":      complete.PredictSet("go-template", "hcl", "json", "none", "table"),
 			"-template": complete.PredictAnything,
 			"-ui":       complete.PredictNothing,
+			"-version":  complete.PredictAnything,
 		},
 	)
 }
@@ -79,12 +84,14 @@ func (c *VarGetCommand) Name() string { return "var get" }
 func (c *VarGetCommand) Run(args []string) int {
 	var out, item string
 	var openURL bool
+	var version uint64
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 
 	flags.StringVar(&item, "item", "", "")
 	flags.StringVar(&c.tmpl, "template", "", "")
 	flags.BoolVar(&openURL, "ui", false, "")
+	flags.Uint64Var(&version, "version", 0, "")
 
 	if fileInfo, _ := os.Stdout.Stat(); (fileInfo.Mode() & os.ModeCharDevice) != 0 {
 		flags.StringVar(&c.outFmt, "out", "table", "")
@@ -128,12 +135,21 @@ func (c *VarGetCommand) Run(args []string) int {
 		Namespace: c.Meta.namespace,
 	}
 
-	sv, _, err := client.Variables().Read(path, qo)
+	var sv *api.Variable
+	if version != 0 {
+		sv, _, err = client.Variables().ReadVersion(path, version, qo)
+	} else {
+		sv, _, err = client.Variables().Read(path, qo)
+	}
 	if err != nil {
 		if err.Error() == "variable not found" {
 			c.Ui.Warn(errVariableNotFound)
 			return 1
 		}
+		if errors.Is(err, api.ErrVariableVersionNotFound) {
+			c.Ui.Warn(fmt.Sprintf("Version %d of the variable not found", version))
+			return 1
+		}
 		c.Ui.Error(fmt.Sprintf("Error retrieving variable: %s", err))
 		return 1
 	}
@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarHistoryCommand struct {
+	Meta
+}
+
+func (c *VarHistoryCommand) Help() string {
+	helpText := `
+Usage: nomad var history [options] <path>
+
+  The 'var history' command is used to list the versions of a variable that
+  are retained by Nomad, newest first. The number of prior versions retained
+  for each variable is configured on its namespace.
+
+  If ACLs are enabled, this command requires a token with the 'variables:read'
+  capability for the target variable's namespace and path.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+History Options:
+
+  -json
+    Output the variable versions in their JSON format.
+
+  -t
+    Format and display the variable versions using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarHistoryCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		},
+	)
+}
+
+func (c *VarHistoryCommand) AutocompleteArgs() complete.Predictor {
+	return VariablePathPredictor(c.Meta.Client)
+}
+
+func (c *VarHistoryCommand) Synopsis() string {
+	return "List the versions of a variable"
+}
+
+func (c *VarHistoryCommand) Name() string { return "var history" }
+
+func (c *VarHistoryCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if c.Meta.namespace == "*" {
+		c.Ui.Error(errWildcardNamespaceNotAllowed)
+		return 1
+	}
+
+	path := args[0]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	versions, _, err := client.Variables().History(path, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving variable history: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, versions)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(versions) == 0 {
+		c.Ui.Warn(errVariableNotFound)
+		return 1
+	}
+
+	c.Ui.Output(formatVarVersions(versions))
+	return 0
+}
+
+func formatVarVersions(versions []*api.VariableVersionMetadata) string {
+	rows := make([]string, len(versions)+1)
+	rows[0] = "Version|Status|Created|Replaced"
+	for i, v := range versions {
+		status, replaced := "current", "<none>"
+		if !v.IsCurrent() {
+			status = "archived"
+			replaced = formatUnixNanoTime(v.ArchiveTime)
+		}
+		rows[i+1] = fmt.Sprintf("%d|%s|%s|%s",
+			v.ModifyIndex,
+			status,
+			formatUnixNanoTime(v.ModifyTime),
+			replaced,
+		)
+	}
+	return formatList(rows)
+}
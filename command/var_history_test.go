@@ -0,0 +1,73 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestVarHistoryCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarHistoryCommand{}
+}
+
+func TestVarHistoryCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	t.Run("bad_args", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarHistoryCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"some", "bad", "args"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	})
+	t.Run("bad_address", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarHistoryCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=nope", "foo"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), "retrieving variable history")
+		must.Eq(t, "", ui.OutputWriter.String())
+	})
+}
+
+func TestVarHistoryCommand_Online(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Write two versions of a variable
+	sv := testVariable()
+	first, _, err := client.Variables().Create(sv, nil)
+	must.NoError(t, err)
+	sv.Items["keyA"] = "updated"
+	second, _, err := client.Variables().Update(sv, nil)
+	must.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	cmd := &VarHistoryCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, sv.Path})
+	must.Zero(t, code)
+
+	out := ui.OutputWriter.String()
+	must.RegexMatch(t, regexp.MustCompile(fmt.Sprintf(`%d\s+current`, second.ModifyIndex)), out)
+	must.RegexMatch(t, regexp.MustCompile(fmt.Sprintf(`%d\s+archived`, first.ModifyIndex)), out)
+
+	// The prior version can be read with var get
+	ui = cli.NewMockUi()
+	getCmd := &VarGetCommand{Meta: Meta{Ui: ui}}
+	code = getCmd.Run([]string{"-address=" + url, "-out=json",
+		fmt.Sprintf("-version=%d", first.ModifyIndex), sv.Path})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), `"keyA": "valueA"`)
+}
@@ -13,6 +13,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/hashicorp/cli"
@@ -100,6 +101,12 @@ Var put Options:
      Template to render output with. Required when format is "go-template",
      invalid for other formats.
 
+  -ttl
+     Optional duration after which the variable expires and is deleted, such
+     as "30m" or "24h". The expire time is set from the TTL each time the
+     variable is written, so writing the variable again without a TTL removes
+     its expiry. Overrides the TTL of any variable specification.
+
   -verbose
      Provides additional information via standard error to preserve standard
      output (stdout) for redirected output.
@@ -116,6 +123,7 @@ func (c *VarPutCommand) AutocompleteFlags() complete.Flags {
 		complete.Flags{
 			"-in":  complete.PredictSet("hcl", "json"),
 			"-out": complete.PredictSet("none", "hcl", "json", "go-template", "table"),
+			"-ttl": complete.PredictAnything,
 			"-ui":  complete.PredictNothing,
 		},
 	)
@@ -133,7 +141,7 @@ func (c *VarPutCommand) Name() string { return "var put" }
 
 func (c *VarPutCommand) Run(args []string) int {
 	var force, enforce, doVerbose, openURL bool
-	var path, checkIndexStr string
+	var path, checkIndexStr, ttl string
 	var checkIndex uint64
 	var err error
 
@@ -146,6 +154,7 @@ func (c *VarPutCommand) Run(args []string) int {
 	flags.StringVar(&c.inFmt, "in", "json", "")
 	flags.StringVar(&c.tmpl, "template", "", "")
 	flags.BoolVar(&openURL, "ui", false, "")
+	flags.StringVar(&ttl, "ttl", "", "")
 	if fileInfo, _ := os.Stdout.Stat(); (fileInfo.Mode() & os.ModeCharDevice) != 0 {
 		flags.StringVar(&c.outFmt, "out", "none", "")
 	} else {
@@ -323,6 +332,17 @@ func (c *VarPutCommand) Run(args []string) int {
 		sv.ModifyIndex = checkIndex
 	}
 
+	// If the user set a TTL flag value, convert this to a time duration and
+	// ensure it is valid.
+	if ttl != "" {
+		ttlDuration, err := time.ParseDuration(ttl)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to parse TTL as time duration: %s", err))
+			return 1
+		}
+		sv.TTL = ttlDuration
+	}
+
 	if force {
 		sv, _, err = client.Variables().Update(sv, nil)
 	} else {
@@ -503,6 +523,8 @@ func parseVariableSpecImpl(result *api.Variable, list *ast.ObjectList) error {
 		"modify_index",
 		"create_time",
 		"modify_time",
+		"ttl",
+		"expire_time",
 		"items",
 	}
 	if err := helper.CheckHCLKeys(list, valid); err != nil {
@@ -525,7 +547,20 @@ func parseVariableSpecImpl(result *api.Variable, list *ast.ObjectList) error {
 		}
 	}
 
-	for _, index := range []string{"create_time", "modify_time"} {
+	if value, ok := m["ttl"]; ok {
+		vStr, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("ttl must be a duration string; got (%T) %[1]v", value)
+		}
+		ttl, err := time.ParseDuration(vStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse ttl as time duration: %w", err)
+		}
+		m["TTL"] = ttl
+		delete(m, "ttl")
+	}
+
+	for _, index := range []string{"create_time", "modify_time", "expire_time"} {
 		if value, ok := m[index]; ok {
 			vInt, ok := value.(int)
 			if !ok {
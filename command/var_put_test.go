@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/cli"
 	"github.com/hashicorp/nomad/api"
@@ -78,6 +79,42 @@ func TestVarPutCommand_Fails(t *testing.T) {
 		must.One(t, code)
 		must.Eq(t, errWildcardNamespaceNotAllowed, out)
 	})
+	t.Run("bad_ttl", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{`-ttl=bad`, "foo", "a=b"})
+		out := strings.TrimSpace(ui.ErrorWriter.String())
+		must.One(t, code)
+		must.StrContains(t, out, "Failed to parse TTL as time duration")
+	})
+}
+
+func TestVarPutCommand_parseVariableSpecTTL(t *testing.T) {
+	ci.Parallel(t)
+
+	spec := `
+path        = "test/var"
+ttl         = "90m"
+expire_time = 1687251876
+
+items = {
+  key = "value"
+}
+`
+	sv, err := parseVariableSpec([]byte(spec), func(string) {})
+	must.NoError(t, err)
+	must.Eq(t, 90*time.Minute, sv.TTL)
+	must.Eq(t, 1687251876, sv.ExpireTime)
+	must.Eq(t, "value", sv.Items["key"])
+
+	// The rendered specification can be parsed again.
+	sv, err = parseVariableSpec([]byte(renderAsHCL(sv)), func(string) {})
+	must.NoError(t, err)
+	must.Eq(t, 90*time.Minute, sv.TTL)
+
+	_, err = parseVariableSpec([]byte(`ttl = "bad"`), func(string) {})
+	must.ErrorContains(t, err, "failed to parse ttl")
 }
 
 func TestVarPutCommand_GoodJson(t *testing.T) {
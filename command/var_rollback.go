@@ -0,0 +1,110 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarRollbackCommand struct {
+	Meta
+}
+
+func (c *VarRollbackCommand) Help() string {
+	helpText := `
+Usage: nomad var rollback [options] <path> <version>
+
+  The 'var rollback' command is used to restore the items of a prior version
+  of a variable. The restored items are written as a new version of the
+  variable, so the version being replaced is kept in the variable's history.
+  The available versions are listed by the 'nomad var history' command.
+
+  If ACLs are enabled, this command requires a token with the 'variables:read'
+  and 'variables:write' capabilities for the target variable's namespace and
+  path.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarRollbackCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *VarRollbackCommand) AutocompleteArgs() complete.Predictor {
+	return VariablePathPredictor(c.Meta.Client)
+}
+
+func (c *VarRollbackCommand) Synopsis() string {
+	return "Restore a prior version of a variable"
+}
+
+func (c *VarRollbackCommand) Name() string { return "var rollback" }
+
+func (c *VarRollbackCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got two arguments
+	args = flags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("This command takes two arguments: <path> <version>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if c.Meta.namespace == "*" {
+		c.Ui.Error(errWildcardNamespaceNotAllowed)
+		return 1
+	}
+
+	path := args[0]
+	version, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil || version == 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid version %q: must be a positive integer", args[1]))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	sv, _, err := client.Variables().Rollback(path, version, nil)
+	if err != nil {
+		if errors.Is(err, api.ErrVariableVersionNotFound) {
+			c.Ui.Warn(fmt.Sprintf("Version %d of the variable not found", version))
+			return 1
+		}
+		if handled := handleCASError(err, c); handled {
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Error rolling back variable: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully rolled back variable %q to version %d; the new version is %d",
+		path, version, sv.ModifyIndex))
+	return 0
+}
+
+func (c *VarRollbackCommand) GetConcurrentUI() cli.ConcurrentUi {
+	return cli.ConcurrentUi{Ui: c.Ui}
+}
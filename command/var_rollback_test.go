@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestVarRollbackCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarRollbackCommand{}
+}
+
+func TestVarRollbackCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	t.Run("bad_args", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarRollbackCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"foo"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	})
+	t.Run("bad_version", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarRollbackCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"foo", "bar"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), `Invalid version "bar"`)
+	})
+}
+
+func TestVarRollbackCommand_Online(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Write two versions of a variable
+	sv := testVariable()
+	first, _, err := client.Variables().Create(sv, nil)
+	must.NoError(t, err)
+	sv.Items["keyA"] = "updated"
+	_, _, err = client.Variables().Update(sv, nil)
+	must.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	cmd := &VarRollbackCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, sv.Path, fmt.Sprint(first.ModifyIndex)})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+	must.StrContains(t, ui.OutputWriter.String(), "Successfully rolled back variable")
+
+	current, _, err := client.Variables().Read(sv.Path, nil)
+	must.NoError(t, err)
+	must.Eq(t, "valueA", current.Items["keyA"])
+
+	// Rolling back to a version that doesn't exist fails
+	ui = cli.NewMockUi()
+	cmd = &VarRollbackCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, sv.Path, "1"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Version 1 of the variable not found")
+}
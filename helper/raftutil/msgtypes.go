@@ -71,4 +71,15 @@ var msgTypeNames = map[structs.MessageType]string{
 	structs.HostVolumeRegisterRequestType:                "HostVolumeRegisterRequestType",
 	structs.HostVolumeDeleteRequestType:                  "HostVolumeDeleteRequestType",
 	structs.TaskGroupHostVolumeClaimDeleteRequestType:    "TaskGroupHostVolumeClaimDeleteRequestType",
+	structs.QuotaSpecUpsertRequestType:                   "QuotaSpecUpsertRequestType",
+	structs.QuotaSpecDeleteRequestType:                   "QuotaSpecDeleteRequestType",
+	structs.NodeUpdateTaintsRequestType:                  "NodeUpdateTaintsRequestType",
+	structs.NodeUpdateUtilizationRequestType:             "NodeUpdateUtilizationRequestType",
+	structs.ReservationUpsertRequestType:                 "ReservationUpsertRequestType",
+	structs.ReservationDeleteRequestType:                 "ReservationDeleteRequestType",
+	structs.VarHistoryPurgeRequestType:                   "VarHistoryPurgeRequestType",
+	structs.VarExpireRequestType:                         "VarExpireRequestType",
+	structs.EventSinkRegisterRequestType:                 "EventSinkRegisterRequestType",
+	structs.EventSinkDeregisterRequestType:               "EventSinkDeregisterRequestType",
+	structs.EventSinkCheckpointRequestType:               "EventSinkCheckpointRequestType",
 }
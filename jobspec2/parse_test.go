@@ -1098,6 +1098,121 @@ func TestParseServiceCheck(t *testing.T) {
 	require.Equal(t, expectedJob, parsedJob)
 }
 
+func TestParse_ScheduleWindow(t *testing.T) {
+	t.Parallel()
+
+	hcl := `
+job "example" {
+  type = "batch"
+
+  schedule_window {
+    cron      = "0 22 * * *"
+    duration  = "8h"
+    time_zone = "Europe/Paris"
+  }
+
+  group "group" {
+    task "task" {
+      driver = "docker"
+      config {}
+    }
+  }
+}
+`
+
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, &api.ScheduleWindow{
+		Cron:     pointerOf("0 22 * * *"),
+		Duration: pointerOf(8 * time.Hour),
+		TimeZone: pointerOf("Europe/Paris"),
+	}, job.ScheduleWindow)
+}
+
+func TestParse_DependsOn(t *testing.T) {
+	t.Parallel()
+
+	hcl := `
+job "example" {
+  type = "batch"
+
+  depends_on {
+    job_id = "extract"
+  }
+
+  depends_on {
+    job_id = "cleanup"
+    status = "dead"
+  }
+
+  group "group" {
+    task "task" {
+      driver = "docker"
+      config {}
+    }
+  }
+}
+`
+
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []*api.JobDependency{
+		{JobID: pointerOf("extract")},
+		{JobID: pointerOf("cleanup"), Status: pointerOf("dead")},
+	}, job.DependsOn)
+}
+
+func TestParse_JobAffinity(t *testing.T) {
+	t.Parallel()
+
+	hcl := `
+job "example" {
+  job_affinity {
+    namespace    = "web"
+    job_id       = "api"
+    topology_key = "${meta.rack}"
+    weight       = 80
+  }
+
+  group "group" {
+    job_affinity {
+      job_id   = "noisy-batch"
+      anti     = true
+      required = true
+    }
+
+    task "task" {
+      driver = "docker"
+      config {}
+    }
+  }
+}
+`
+
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []*api.JobAffinity{{
+		Namespace:   "web",
+		JobID:       "api",
+		TopologyKey: "${meta.rack}",
+		Weight:      pointerOf(int8(80)),
+	}}, job.JobAffinities)
+	must.Eq(t, []*api.JobAffinity{{
+		JobID:    "noisy-batch",
+		Anti:     true,
+		Required: true,
+	}}, job.TaskGroups[0].JobAffinities)
+}
+
 func TestWaitConfig(t *testing.T) {
 	t.Parallel()
 
@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/deploymentwatcher"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/hashicorp/nomad/plugins/scoring"
 	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
@@ -69,6 +70,15 @@ type Config struct {
 	// EventBufferSize is the amount of events to hold in memory.
 	EventBufferSize int64
 
+	// EventSinkCheckpointInterval is how often the leader checkpoints the
+	// index of the last event delivered to each event sink in raft. It
+	// defaults to eventsink.DefaultCheckpointInterval if unset.
+	EventSinkCheckpointInterval time.Duration
+
+	// EventSinkFileDir is the directory file event sinks are restricted to
+	// writing to. File sinks are disabled if it's empty.
+	EventSinkFileDir string
+
 	// JobMaxSourceSize limits the maximum size of a jobs source hcl/json
 	// before being discarded automatically. A value of zero indicates no job
 	// sources will be stored.
@@ -151,6 +161,10 @@ type Config struct {
 	// that the workers dequeue for processing.
 	EnabledSchedulers []string
 
+	// ScoringPlugins are the scoring plugins dispensed by the agent, keyed by
+	// plugin name. The schedulers add their scores to the ranking of nodes.
+	ScoringPlugins map[string]scoring.ScoringPlugin
+
 	// ReconcileInterval controls how often we reconcile the strongly
 	// consistent store with the Serf info. This is used to handle nodes
 	// that are force removed, as well as intermittent unavailability during
@@ -237,6 +251,23 @@ type Config struct {
 	// rekey any variables associated with a key in the Rekeying state
 	VariablesRekeyInterval time.Duration
 
+	// RebalanceInterval is how often we dispatch a job to migrate running
+	// service allocations onto fewer nodes, if the rebalancer is enabled in
+	// the scheduler configuration.
+	RebalanceInterval time.Duration
+
+	// ReservationGCInterval is how often we dispatch a job to delete expired
+	// capacity reservations.
+	ReservationGCInterval time.Duration
+
+	// VariableHistoryGCInterval is how often we dispatch a job to purge the
+	// prior versions of variables that fall outside of their retention.
+	VariableHistoryGCInterval time.Duration
+
+	// VariableExpiredGCInterval is how often we dispatch a job to delete
+	// variables that have reached their expire time.
+	VariableExpiredGCInterval time.Duration
+
 	// EvalNackTimeout controls how long we allow a sub-scheduler to
 	// work on an evaluation before we consider it failed and Nack it.
 	// This allows that evaluation to be handed to another sub-scheduler
@@ -508,6 +539,7 @@ func (c *Config) Copy() *Config {
 	// Logger log.InterceptLogger
 	// PluginLoader loader.PluginCatalog
 	// PluginSingletonLoader loader.PluginCatalog
+	// ScoringPlugins map[string]scoring.ScoringPlugin
 
 	nc.RPCAddr = pointer.Copy(c.RPCAddr)
 	nc.ClientRPCAdvertise = pointer.Copy(c.ClientRPCAdvertise)
@@ -658,6 +690,10 @@ func DefaultConfig() *Config {
 		RootKeyGCThreshold:               1 * time.Hour,
 		RootKeyRotationThreshold:         720 * time.Hour, // 30 days
 		VariablesRekeyInterval:           10 * time.Minute,
+		RebalanceInterval:                5 * time.Minute,
+		ReservationGCInterval:            1 * time.Minute,
+		VariableHistoryGCInterval:        5 * time.Minute,
+		VariableExpiredGCInterval:        5 * time.Minute,
 		EvalNackTimeout:                  60 * time.Second,
 		EvalDeliveryLimit:                3,
 		EvalNackInitialReenqueueDelay:    1 * time.Second,
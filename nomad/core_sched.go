@@ -4,10 +4,12 @@
 package nomad
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -86,6 +88,14 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 		return c.variablesRekey(eval)
 	case structs.CoreJobForceGC:
 		return c.forceGC(eval)
+	case structs.CoreJobRebalance:
+		return c.rebalance(eval)
+	case structs.CoreJobReservationGC:
+		return c.reservationGC(eval, time.Now())
+	case structs.CoreJobVariableHistoryGC:
+		return c.variableHistoryGC(eval, time.Now())
+	case structs.CoreJobVariableExpiredGC:
+		return c.variableExpiredGC(eval, time.Now())
 	default:
 		return fmt.Errorf("core scheduler cannot handle job '%s'", eval.JobID)
 	}
@@ -123,6 +133,15 @@ func (c *CoreScheduler) forceGC(eval *structs.Evaluation) error {
 	if err := c.rootKeyGC(eval, time.Now()); err != nil {
 		return err
 	}
+	if err := c.reservationGC(eval, time.Now()); err != nil {
+		return err
+	}
+	if err := c.variableHistoryGC(eval, time.Now()); err != nil {
+		return err
+	}
+	if err := c.variableExpiredGC(eval, time.Now()); err != nil {
+		return err
+	}
 
 	// Node GC must occur after the others to ensure the allocations are
 	// cleared.
@@ -148,6 +167,13 @@ func (c *CoreScheduler) jobGC(eval *structs.Evaluation, customThreshold *time.Du
 
 	cutoffTime := c.getCutoffTime(threshold)
 
+	// Jobs that other jobs are still waiting on must be kept, as their
+	// dependents can't tell a collected job from one not yet registered.
+	upstreams, err := c.waitedOnJobs()
+	if err != nil {
+		return err
+	}
+
 	// Collect the allocations, evaluations and jobs to GC
 	var gcAlloc, gcEval []string
 	var gcJob []*structs.Job
@@ -162,6 +188,11 @@ OUTER:
 			continue
 		}
 
+		// Ignore jobs other jobs depend on until they are scheduled.
+		if _, ok := upstreams[job.NamespacedID()]; ok {
+			continue
+		}
+
 		ws := memdb.NewWatchSet()
 		evals, err := c.snap.EvalsByJob(ws, job.Namespace, job.ID)
 		if err != nil {
@@ -221,6 +252,35 @@ OUTER:
 	return c.jobReap(gcJob, eval.LeaderACL)
 }
 
+// waitedOnJobs returns the jobs that the jobs with an evaluation still to be
+// processed depend on.
+func (c *CoreScheduler) waitedOnJobs() (map[structs.NamespacedID]struct{}, error) {
+	upstreams := make(map[structs.NamespacedID]struct{})
+	for _, status := range []string{
+		structs.EvalStatusPending,
+		structs.EvalStatusBlocked,
+		structs.EvalStatusDependencyBlocked,
+	} {
+		evals, err := c.snap.EvalsByStatus(nil, status)
+		if err != nil {
+			return nil, err
+		}
+		for _, eval := range evals {
+			job, err := c.snap.JobByID(nil, eval.Namespace, eval.JobID)
+			if err != nil {
+				return nil, err
+			}
+			if job == nil || job.Stopped() {
+				continue
+			}
+			for _, dep := range job.DependsOn {
+				upstreams[structs.NamespacedID{Namespace: job.Namespace, ID: dep.JobID}] = struct{}{}
+			}
+		}
+	}
+	return upstreams, nil
+}
+
 // jobReap contacts the leader and issues a reap on the passed jobs
 func (c *CoreScheduler) jobReap(jobs []*structs.Job, leaderACL string) error {
 	// Call to the leader to issue the reap with a batch size intended to be
@@ -303,7 +363,7 @@ func (c *CoreScheduler) evalGC(customThreshold *time.Duration) error {
 		eval := raw.(*structs.Evaluation)
 
 		gcCutoffTime := cutoffTime
-		if eval.Type == structs.JobTypeBatch {
+		if eval.Type == structs.JobTypeBatch || eval.Type == structs.JobTypeWorkflow {
 			gcCutoffTime = batchCutoffTime
 		}
 
@@ -365,7 +425,7 @@ func (c *CoreScheduler) gcEval(eval *structs.Evaluation, cutoffTime time.Time, a
 	//
 	// The age of the evaluation must also reach the threshold configured to be GCed so that
 	// one may debug old evaluations and referenced allocations.
-	if eval.Type == structs.JobTypeBatch {
+	if eval.Type == structs.JobTypeBatch || eval.Type == structs.JobTypeWorkflow {
 		// Check if the job is running
 
 		// Can collect if either holds:
@@ -722,7 +782,7 @@ func allocGCEligible(a *structs.Allocation, job *structs.Job, gcTime, cutoffTime
 	tg := job.LookupTaskGroup(a.TaskGroup)
 
 	if tg != nil {
-		reschedulePolicy = tg.ReschedulePolicy
+		reschedulePolicy = tg.EffectiveReschedulePolicy()
 	}
 	// No reschedule policy or rescheduling is disabled
 	if reschedulePolicy == nil || (!reschedulePolicy.Unlimited && reschedulePolicy.Attempts == 0) {
@@ -1264,6 +1324,20 @@ func (c *CoreScheduler) variablesRekey(eval *structs.Evaluation) error {
 			return err
 		}
 
+		// Prior versions of variables are encrypted with the key they had
+		// when they were current, so they are rekeyed as well.
+		versionIter, err := c.snap.GetVariableVersionsByKeyID(ws, wrappedKeys.KeyID)
+		if err != nil {
+			return err
+		}
+		if err = c.rotateVariables(versionIter, eval); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				c.logger.Info("timeout reached rekeying variable versions", "key_id", wrappedKeys.KeyID)
+				return nil
+			}
+			return err
+		}
+
 		rootKey, err := c.srv.encrypter.GetKey(wrappedKeys.KeyID)
 		if err != nil {
 			return fmt.Errorf("rotated key does not exist in keyring: %w", err)
@@ -1292,9 +1366,9 @@ func (c *CoreScheduler) variablesRekey(eval *structs.Evaluation) error {
 	return nil
 }
 
-// rotateVariables runs over an iterator of variables and decrypts them, and
-// then sends them back to be re-encrypted with the currently active key,
-// checking for conflicts.
+// rotateVariables runs over an iterator of variables, or of their prior
+// versions, and decrypts them, and then sends them back to be re-encrypted
+// with the currently active key, checking for conflicts.
 //
 // This function uses a rate limiter and a timeout to avoid blocking the
 // scheduler goroutine for too long. If the timeout is reached, a new eval
@@ -1302,8 +1376,15 @@ func (c *CoreScheduler) variablesRekey(eval *structs.Evaluation) error {
 // context.DeadlineExceeded.
 func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs.Evaluation) error {
 
+	// Rekeying with a CAS would add a version to the history of every
+	// variable, so it is only used until all servers support rekeying.
+	op := structs.VarOpCAS
+	if c.srv.peersCache.ServersMeetMinimumVersion(c.srv.Region(), minVersionVariablesHistory, true) {
+		op = structs.VarOpRekey
+	}
+
 	args := &structs.VariablesApplyRequest{
-		Op: structs.VarOpCAS,
+		Op: op,
 		WriteRequest: structs.WriteRequest{
 			Region:    c.srv.config.Region,
 			AuthToken: eval.LeaderACL,
@@ -1359,7 +1440,13 @@ func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs
 		default:
 		}
 
-		ev := raw.(*structs.VariableEncrypted)
+		var ev *structs.VariableEncrypted
+		switch v := raw.(type) {
+		case *structs.VariableEncrypted:
+			ev = v
+		case *structs.VariableVersion:
+			ev = &v.VariableEncrypted
+		}
 		cleartext, err := c.srv.encrypter.Decrypt(ev.Data, ev.KeyID)
 		if err != nil {
 			return err
@@ -1398,3 +1485,233 @@ func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs
 func (c *CoreScheduler) getCutoffTime(configThreshold time.Duration) time.Time {
 	return time.Now().UTC().Add(-1 * configThreshold)
 }
+
+// rebalance is used to migrate running service allocations from lightly used
+// nodes onto nodes where they pack more tightly. Migrations go through the
+// regular scheduler, so each task group's migrate block applies to them.
+func (c *CoreScheduler) rebalance(eval *structs.Evaluation) error {
+	_, schedConfig, err := c.snap.SchedulerConfig()
+	if err != nil {
+		return err
+	}
+	if schedConfig == nil || !schedConfig.RebalanceConfig.Enabled {
+		return nil
+	}
+
+	report, err := computeRebalance(c.snap, schedConfig, time.Now(), c.logger)
+	if err != nil {
+		return err
+	}
+	if len(report.Migrations) == 0 {
+		c.logger.Debug("rebalance found no allocations to migrate",
+			"migrations_used", report.MigrationsUsed,
+			"max_migrations_per_hour", report.MaxMigrationsPerHour)
+		return nil
+	}
+
+	if schedConfig.RebalanceConfig.DryRun {
+		for _, m := range report.Migrations {
+			c.logger.Info("rebalance dry run would migrate allocation",
+				"alloc_id", m.AllocID, "namespace", m.Namespace, "job_id", m.JobID,
+				"task_group", m.TaskGroup, "node_id", m.NodeID, "target_node_id", m.TargetNodeID,
+				"node_score", m.NodeScore, "target_node_score", m.TargetNodeScore)
+		}
+		return nil
+	}
+
+	transitions := make(map[string]*structs.DesiredTransition, len(report.Migrations))
+	jobs := make(map[structs.NamespacedID]*structs.Job)
+	for _, m := range report.Migrations {
+		transitions[m.AllocID] = &structs.DesiredTransition{
+			Migrate:               pointer.Of(true),
+			Rebalance:             pointer.Of(true),
+			RebalanceTargetNodeID: m.TargetNodeID,
+		}
+
+		id := structs.NamespacedID{Namespace: m.Namespace, ID: m.JobID}
+		if _, ok := jobs[id]; ok {
+			continue
+		}
+		job, err := c.snap.JobByID(nil, m.Namespace, m.JobID)
+		if err != nil {
+			return err
+		}
+		jobs[id] = job
+	}
+
+	now := time.Now().UTC().UnixNano()
+	evals := make([]*structs.Evaluation, 0, len(jobs))
+	for _, job := range jobs {
+		evals = append(evals, &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      job.Namespace,
+			Priority:       job.Priority,
+			Type:           job.Type,
+			TriggeredBy:    structs.EvalTriggerRebalance,
+			JobID:          job.ID,
+			JobModifyIndex: job.ModifyIndex,
+			Status:         structs.EvalStatusPending,
+			CreateTime:     now,
+			ModifyTime:     now,
+		})
+	}
+
+	req := &structs.AllocUpdateDesiredTransitionRequest{
+		Allocs: transitions,
+		Evals:  evals,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.config.Region,
+			AuthToken: eval.LeaderACL,
+		},
+	}
+	if err := c.srv.RPC("Alloc.UpdateDesiredTransition", req, &structs.GenericResponse{}); err != nil {
+		c.logger.Error("rebalance failed to migrate allocations", "error", err)
+		return err
+	}
+
+	c.logger.Info("rebalance migrating allocations", "count", len(report.Migrations))
+	return nil
+}
+
+// reservationGC is used to delete the capacity reservations that expired
+// before now, releasing the capacity they hold.
+func (c *CoreScheduler) reservationGC(eval *structs.Evaluation, now time.Time) error {
+	iter, err := c.snap.Reservations(nil)
+	if err != nil {
+		return err
+	}
+
+	var expired []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if res := raw.(*structs.Reservation); res.Expired(now) {
+			expired = append(expired, res.Name)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	c.logger.Debug("reservation GC found eligible reservations", "reservations", len(expired))
+	req := &structs.ReservationDeleteRequest{
+		Names: expired,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.Region(),
+			AuthToken: eval.LeaderACL,
+		},
+	}
+	return c.srv.RPC("Reservation.DeleteReservations", req, &structs.GenericResponse{})
+}
+
+// variableHistoryGC is used to purge the prior versions of variables that
+// exceed the history limit or retention of their namespace, or whose
+// namespace has been deleted.
+func (c *CoreScheduler) variableHistoryGC(eval *structs.Evaluation, now time.Time) error {
+	iter, err := c.snap.VariablesHistory(nil)
+	if err != nil {
+		return err
+	}
+
+	type variableKey struct{ namespace, path string }
+	versions := map[variableKey][]*structs.VariableVersion{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		vv := raw.(*structs.VariableVersion)
+		key := variableKey{vv.Namespace, vv.Path}
+		versions[key] = append(versions[key], vv)
+	}
+
+	var purge []*structs.VariableVersionID
+	configs := map[string]*structs.NamespaceVariablesConfiguration{}
+	for key, vs := range versions {
+		config, ok := configs[key.namespace]
+		if !ok {
+			ns, err := c.snap.NamespaceByName(nil, key.namespace)
+			if err != nil {
+				return err
+			}
+			if ns == nil {
+				// The history of variables in a deleted namespace is purged
+				// entirely.
+				config = &structs.NamespaceVariablesConfiguration{HistoryLimit: -1}
+			} else {
+				config = ns.VariablesConfiguration
+			}
+			configs[key.namespace] = config
+		}
+
+		// Sort the versions newest first so the ones over the limit are
+		// the oldest.
+		slices.SortFunc(vs, func(a, b *structs.VariableVersion) int {
+			return cmp.Compare(b.Version(), a.Version())
+		})
+		limit, retention := config.Limit(), config.Retention()
+		for i, vv := range vs {
+			expired := retention > 0 &&
+				time.Unix(0, vv.ArchiveTime).Add(retention).Before(now)
+			if i >= limit || expired {
+				purge = append(purge, &structs.VariableVersionID{
+					Namespace: vv.Namespace,
+					Path:      vv.Path,
+					Version:   vv.Version(),
+				})
+			}
+		}
+	}
+	if len(purge) == 0 {
+		return nil
+	}
+
+	c.logger.Debug("variable history GC found eligible versions", "versions", len(purge))
+	for chunk := range slices.Chunk(purge, structs.MaxUUIDsPerWriteRequest) {
+		req := &structs.VariablesPurgeHistoryRequest{
+			Versions: chunk,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.Region(),
+				AuthToken: eval.LeaderACL,
+			},
+		}
+		if err := c.srv.RPC(structs.VariablesPurgeHistoryRPCMethod, req, &structs.GenericResponse{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// variableExpiredGC is used to delete variables that have reached their
+// expire time. The leader expires variables with timers, so this only catches
+// the variables whose timers were lost, for example during a leader election.
+func (c *CoreScheduler) variableExpiredGC(eval *structs.Evaluation, now time.Time) error {
+	iter, err := c.snap.Variables(nil)
+	if err != nil {
+		return err
+	}
+
+	var expired []*structs.VariableVersionID
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		sv := raw.(*structs.VariableEncrypted)
+		if sv.IsExpired(now) {
+			expired = append(expired, &structs.VariableVersionID{
+				Namespace: sv.Namespace,
+				Path:      sv.Path,
+				Version:   sv.ModifyIndex,
+			})
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	c.logger.Debug("variable expired GC found eligible variables", "variables", len(expired))
+	for chunk := range slices.Chunk(expired, structs.MaxUUIDsPerWriteRequest) {
+		req := &structs.VariablesExpireRequest{
+			Variables: chunk,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.Region(),
+				AuthToken: eval.LeaderACL,
+			},
+		}
+		if err := c.srv.RPC(structs.VariablesExpireRPCMethod, req, &structs.GenericResponse{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1468,6 +1468,59 @@ func TestCoreScheduler_JobGC_Stopped(t *testing.T) {
 	}
 }
 
+func TestCoreScheduler_JobGC_DependedOn(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	upstream := mock.BatchJob()
+	upstream.Stop = true
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, upstream))
+
+	eval := mock.Eval()
+	eval.JobID = upstream.ID
+	eval.Status = structs.EvalStatusComplete
+	eval.CreateTime = time.Now().Add(-6 * time.Hour).UnixNano()
+	eval.ModifyTime = time.Now().Add(-5 * time.Hour).UnixNano()
+
+	// Insert a job waiting on the upstream job
+	downstream := mock.BatchJob()
+	downstream.DependsOn = []*structs.JobDependency{
+		{JobID: upstream.ID, Status: structs.JobDependencyStatusDead},
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, downstream))
+
+	blocked := mock.Eval()
+	blocked.JobID = downstream.ID
+	blocked.Status = structs.EvalStatusDependencyBlocked
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 1002,
+		[]*structs.Evaluation{eval, blocked}))
+
+	gcUpstream := func(index uint64) *structs.Job {
+		snap, err := store.Snapshot()
+		must.NoError(t, err)
+		core := NewCoreScheduler(s1, snap, nil)
+		must.NoError(t, core.Process(s1.coreJobEval(structs.CoreJobJobGC, index)))
+
+		out, err := store.JobByID(nil, upstream.Namespace, upstream.ID)
+		must.NoError(t, err)
+		return out
+	}
+
+	// The upstream job is kept while the downstream job waits on it
+	must.NotNil(t, gcUpstream(2000))
+
+	// Once the downstream evaluation is processed it can be collected
+	released := blocked.Copy()
+	released.Status = structs.EvalStatusComplete
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 2001,
+		[]*structs.Evaluation{released}))
+	must.Nil(t, gcUpstream(2002))
+}
+
 func TestCoreScheduler_JobGC_Force(t *testing.T) {
 	ci.Parallel(t)
 	for _, withAcl := range []bool{false, true} {
@@ -3163,6 +3216,240 @@ func TestCoreScheduler_ExpiredACLTokenGC_Force(t *testing.T) {
 	must.SliceContainsAll(t, append(nonExpiredGlobalTokens, nonExpiredLocalTokens...), tokens)
 }
 
+func TestCoreScheduler_ReservationGC(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, testServerShutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer testServerShutdown()
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	now := time.Now().UTC()
+
+	expired := mock.Reservation()
+	expired.ExpiresAt = now.Add(-time.Minute)
+
+	unexpired := mock.Reservation()
+	unexpired.ExpiresAt = now.Add(time.Hour)
+
+	err := testServer.State().UpsertReservations(structs.MsgTypeTestSetup, 10,
+		[]*structs.Reservation{expired, unexpired})
+	must.NoError(t, err)
+
+	// Generate the core scheduler and trigger the reservation GC.
+	snap, err := testServer.State().Snapshot()
+	must.NoError(t, err)
+	coreScheduler := NewCoreScheduler(testServer, snap, nil)
+
+	index, err := testServer.State().LatestIndex()
+	must.NoError(t, err)
+	index++
+
+	gcEval := testServer.coreJobEval(structs.CoreJobReservationGC, index)
+	must.NoError(t, coreScheduler.Process(gcEval))
+
+	// Only the expired reservation should have been deleted.
+	out, err := testServer.State().ReservationByName(nil, expired.Name)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	out, err = testServer.State().ReservationByName(nil, unexpired.Name)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+}
+
+func TestCoreScheduler_VariableHistoryGC(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, testServerShutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer testServerShutdown()
+	testutil.WaitForLeader(t, testServer.RPC)
+	store := testServer.State()
+
+	now := time.Now()
+
+	ns := mock.Namespace()
+	ns.VariablesConfiguration = &structs.NamespaceVariablesConfiguration{
+		HistoryRetention: time.Hour,
+	}
+	must.NoError(t, store.UpsertNamespaces(10, []*structs.Namespace{ns}))
+
+	// The first version is replaced two hours ago and the second one just
+	// now, so only the first one is outside of the retention.
+	sv := mock.VariableEncrypted()
+	sv.Namespace = ns.Name
+	for i, modifyTime := range []time.Time{now, now.Add(-2 * time.Hour), now} {
+		next := sv.Copy()
+		next.ModifyTime = modifyTime.UnixNano()
+		resp := store.VarSet(uint64(20+i), &structs.VarApplyStateRequest{
+			Op: structs.VarOpSet, Var: &next,
+		})
+		must.NoError(t, resp.Error)
+	}
+
+	// The history of a variable in a namespace that no longer exists is
+	// purged entirely.
+	orphan := mock.VariableEncrypted()
+	orphan.Namespace = "deleted"
+	for i := range 2 {
+		next := orphan.Copy()
+		next.ModifyTime = now.UnixNano()
+		resp := store.VarSet(uint64(30+i), &structs.VarApplyStateRequest{
+			Op: structs.VarOpSet, Var: &next,
+		})
+		must.NoError(t, resp.Error)
+	}
+
+	// Generate the core scheduler and trigger the variable history GC.
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	coreScheduler := NewCoreScheduler(testServer, snap, nil)
+
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+	index++
+
+	gcEval := testServer.coreJobEval(structs.CoreJobVariableHistoryGC, index)
+	must.NoError(t, coreScheduler.Process(gcEval))
+
+	versions, err := store.GetVariableVersions(nil, ns.Name, sv.Path)
+	must.NoError(t, err)
+	must.Len(t, 1, versions)
+	must.Eq(t, 21, versions[0].Version())
+
+	versions, err = store.GetVariableVersions(nil, orphan.Namespace, orphan.Path)
+	must.NoError(t, err)
+	must.Len(t, 0, versions)
+}
+
+func TestCoreScheduler_VariableExpiredGC(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, testServerShutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer testServerShutdown()
+	testutil.WaitForLeader(t, testServer.RPC)
+	store := testServer.State()
+
+	now := time.Now()
+
+	expired := mock.VariableEncrypted()
+	expired.Path = "expired"
+	expired.TTL = time.Minute
+	expired.ExpireTime = now.Add(-time.Minute).UnixNano()
+
+	unexpired := mock.VariableEncrypted()
+	unexpired.Path = "unexpired"
+	unexpired.TTL = time.Hour
+	unexpired.ExpireTime = now.Add(time.Hour).UnixNano()
+
+	permanent := mock.VariableEncrypted()
+	permanent.Path = "permanent"
+
+	for i, sv := range []*structs.VariableEncrypted{expired, unexpired, permanent} {
+		resp := store.VarSet(uint64(10+i), &structs.VarApplyStateRequest{
+			Op: structs.VarOpSet, Var: sv,
+		})
+		must.NoError(t, resp.Error)
+	}
+
+	// Generate the core scheduler and trigger the variable expired GC.
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	coreScheduler := NewCoreScheduler(testServer, snap, nil)
+
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+	index++
+
+	gcEval := testServer.coreJobEval(structs.CoreJobVariableExpiredGC, index)
+	must.NoError(t, coreScheduler.Process(gcEval))
+
+	// Only the expired variable should have been deleted.
+	out, err := store.GetVariable(nil, expired.Namespace, expired.Path)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	for _, sv := range []*structs.VariableEncrypted{unexpired, permanent} {
+		out, err := store.GetVariable(nil, sv.Namespace, sv.Path)
+		must.NoError(t, err)
+		must.NotNil(t, out)
+	}
+}
+
+func TestCoreScheduler_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	light, busy := mock.Node(), mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, light))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1001, busy))
+
+	filler := mock.MinJob()
+	filler.Type = structs.JobTypeBatch
+	job := mock.MinJob()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, filler))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1003, nil, job))
+
+	var allocs []*structs.Allocation
+	for range 8 {
+		alloc := mock.MinAllocForJob(filler)
+		alloc.NodeID = busy.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	alloc := mock.MinAllocForJob(job)
+	alloc.NodeID = light.ID
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	allocs = append(allocs, alloc)
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1004, allocs))
+
+	runRebalance := func(config structs.RebalanceConfig) {
+		_, schedConfig, err := store.SchedulerConfig()
+		must.NoError(t, err)
+		schedConfig = schedConfig.Copy()
+		schedConfig.RebalanceConfig = config
+		must.NoError(t, store.SchedulerSetConfig(2000, schedConfig))
+
+		snap, err := store.Snapshot()
+		must.NoError(t, err)
+		core := NewCoreScheduler(s1, snap, nil)
+		must.NoError(t, core.Process(s1.coreJobEval(structs.CoreJobRebalance, 2000)))
+	}
+
+	// A dry run only reports the migration.
+	runRebalance(structs.RebalanceConfig{Enabled: true, DryRun: true})
+	out, err := store.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.False(t, out.DesiredTransition.ShouldMigrate())
+
+	runRebalance(structs.RebalanceConfig{Enabled: true})
+	out, err = store.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.True(t, out.DesiredTransition.ShouldRebalance())
+	must.Eq(t, busy.ID, out.DesiredTransition.RebalanceTargetNodeID)
+
+	evals, err := store.EvalsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, evals)
+	must.Eq(t, structs.EvalTriggerRebalance, evals[0].TriggeredBy)
+
+	// The filler job is never migrated.
+	evals, err = store.EvalsByJob(nil, filler.Namespace, filler.ID)
+	must.NoError(t, err)
+	must.SliceEmpty(t, evals)
+}
+
 // mockPlanner is an implementation of the Planner interface for testing that
 // allows us to store and query its usage without inacting change in the system.
 type mockPlanner struct {
@@ -3199,3 +3486,5 @@ func (m *mockPlanner) ReblockEval(_ *structs.Evaluation) error { return nil }
 func (m *mockPlanner) ServersMeetMinimumVersion(minVersion *version.Version, checkFailedServers bool) bool {
 	return false
 }
+
+func (m *mockPlanner) NodeScorers() []sstructs.NodeScorer { return nil }
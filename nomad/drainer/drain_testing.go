@@ -123,6 +123,17 @@ func (m *MockRaftApplierShim) NodesDrainComplete(
 	return index, err
 }
 
+// NodesEmitEvents mocks a write to raft as a state store update
+func (m *MockRaftApplierShim) NodesEmitEvents(events map[string][]*structs.NodeEvent) (uint64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	index, _ := m.state.LatestIndex()
+	index++
+	err := m.state.UpsertNodeEvents(structs.MsgTypeTestSetup, index, events)
+	return index, err
+}
+
 func testNodeDrainWatcher(t *testing.T) (*nodeDrainWatcher, *state.StateStore, *NodeDrainer) {
 	t.Helper()
 	store := state.TestStateStore(t)
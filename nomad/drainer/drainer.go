@@ -42,6 +42,11 @@ const (
 	// NodeDrainEventDetailDeadlined is the key to use when the drain is
 	// complete because a deadline. The acceptable values are "true" and "false"
 	NodeDrainEventDetailDeadlined = "deadline_reached"
+
+	// NodeDrainEventBlocked is used to indicate that allocations on the node
+	// can't be drained without violating the disruption budget of their task
+	// group.
+	NodeDrainEventBlocked = "Node drain blocked by disruption budget"
 )
 
 // RaftApplier contains methods for applying the raft requests required by the
@@ -49,6 +54,7 @@ const (
 type RaftApplier interface {
 	AllocUpdateDesiredTransition(allocs map[string]*structs.DesiredTransition, evals []*structs.Evaluation) (uint64, error)
 	NodesDrainComplete(nodes []string, event *structs.NodeEvent) (uint64, error)
+	NodesEmitEvents(events map[string][]*structs.NodeEvent) (uint64, error)
 }
 
 // NodeTracker is the interface to notify an object that is tracking draining
@@ -285,6 +291,16 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 // transition to drain. The handler blocks till the changes to the allocation
 // have occurred.
 func (n *NodeDrainer) handleJobAllocDrain(req *DrainRequest) {
+	if len(req.Events) != 0 {
+		if _, err := n.raft.NodesEmitEvents(req.Events); err != nil {
+			n.logger.Error("failed to emit node drain events", "error", err)
+		}
+	}
+	if len(req.Allocs) == 0 {
+		req.Resp.Respond(0, nil)
+		return
+	}
+
 	index, err := n.batchDrainAllocs(req.Allocs)
 	req.Resp.Respond(index, err)
 }
@@ -320,7 +336,11 @@ func (n *NodeDrainer) handleMigratedAllocs(allocs []*structs.Allocation) {
 			continue
 		}
 
-		done = append(done, node)
+		// Nodes with NoExecute taints aren't draining, so only their
+		// remaining system allocs are stopped
+		if draining.GetNode().DrainStrategy != nil {
+			done = append(done, node)
+		}
 
 		remaining, err := draining.RemainingAllocs()
 		if err != nil {
@@ -405,7 +425,7 @@ func (n *NodeDrainer) drainAllocs(future *structs.BatchFuture, allocs []*structs
 		// When draining batch job allocations, the allocation should be
 		// be stopped. Setting this ensures the allocation is stopped in
 		// the migration process, but that a new allocation is not placed.
-		if alloc.Job.Type == structs.JobTypeBatch {
+		if alloc.Job.Type == structs.JobTypeBatch || alloc.Job.Type == structs.JobTypeWorkflow {
 			transitions[alloc.ID].MigrateDisablePlacement = pointer.Of(true)
 		}
 		jobs[alloc.JobNamespacedID()] = alloc.Job
@@ -33,6 +33,22 @@ func partitionIds(maxIds int, ids []string) [][]string {
 	return partitions
 }
 
+// isDrainingNode returns whether allocations must be migrated off the node,
+// either because it is draining or because it has NoExecute taints.
+func isDrainingNode(node *structs.Node) bool {
+	return node != nil && (node.DrainStrategy != nil || node.HasNoExecuteTaints())
+}
+
+// mustLeaveNode returns whether the allocation must be migrated off the node,
+// either because the node is draining or because the allocation doesn't
+// tolerate the NoExecute taints of the node.
+func mustLeaveNode(node *structs.Node, alloc *structs.Allocation) bool {
+	if node == nil {
+		return false
+	}
+	return node.DrainStrategy != nil || !alloc.ToleratesNoExecuteTaints(node.Taints)
+}
+
 // transitionTuple is used to group desired transitions and evals
 type transitionTuple struct {
 	Transitions map[string]*structs.DesiredTransition
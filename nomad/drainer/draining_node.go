@@ -58,8 +58,8 @@ func (n *drainingNode) IsDone() (bool, error) {
 	defer n.l.RUnlock()
 
 	// Should never happen
-	if n.node == nil || n.node.DrainStrategy == nil {
-		return false, fmt.Errorf("node doesn't have a drain strategy set")
+	if !isDrainingNode(n.node) {
+		return false, fmt.Errorf("node doesn't have a drain strategy or NoExecute taints set")
 	}
 
 	// Retrieve the allocs on the node
@@ -75,6 +75,11 @@ func (n *drainingNode) IsDone() (bool, error) {
 			continue
 		}
 
+		// Allocations tolerating the NoExecute taints of the node are kept
+		if !mustLeaveNode(n.node, alloc) {
+			continue
+		}
+
 		// If there is a non-terminal we aren't done
 		if !alloc.ClientTerminalStatus() {
 			return false, nil
@@ -91,12 +96,12 @@ func (n *drainingNode) RemainingAllocs() ([]*structs.Allocation, error) {
 	defer n.l.RUnlock()
 
 	// Should never happen
-	if n.node == nil || n.node.DrainStrategy == nil {
-		return nil, fmt.Errorf("node doesn't have a drain strategy set")
+	if !isDrainingNode(n.node) {
+		return nil, fmt.Errorf("node doesn't have a drain strategy or NoExecute taints set")
 	}
 
 	// Grab the relevant drain info
-	ignoreSystem := n.node.DrainStrategy.IgnoreSystemJobs
+	ignoreSystem := n.node.DrainStrategy != nil && n.node.DrainStrategy.IgnoreSystemJobs
 
 	// Retrieve the allocs on the node
 	allocs, err := n.state.AllocsByNode(nil, n.node.ID)
@@ -116,6 +121,11 @@ func (n *drainingNode) RemainingAllocs() ([]*structs.Allocation, error) {
 			continue
 		}
 
+		// Skip allocations tolerating the NoExecute taints of the node
+		if !mustLeaveNode(n.node, alloc) {
+			continue
+		}
+
 		drain = append(drain, alloc)
 	}
 
@@ -129,8 +139,8 @@ func (n *drainingNode) DrainingJobs() ([]structs.NamespacedID, error) {
 	defer n.l.RUnlock()
 
 	// Should never happen
-	if n.node == nil || n.node.DrainStrategy == nil {
-		return nil, fmt.Errorf("node doesn't have a drain strategy set")
+	if !isDrainingNode(n.node) {
+		return nil, fmt.Errorf("node doesn't have a drain strategy or NoExecute taints set")
 	}
 
 	// Retrieve the allocs on the node
@@ -142,7 +152,8 @@ func (n *drainingNode) DrainingJobs() ([]structs.NamespacedID, error) {
 	jobIDs := make(map[structs.NamespacedID]struct{})
 	var jobs []structs.NamespacedID
 	for _, alloc := range allocs {
-		if alloc.TerminalStatus() || alloc.Job.Type == structs.JobTypeSystem || alloc.Job.IsPlugin() {
+		if alloc.TerminalStatus() || alloc.Job.Type == structs.JobTypeSystem || alloc.Job.IsPlugin() ||
+			!mustLeaveNode(n.node, alloc) {
 			continue
 		}
 
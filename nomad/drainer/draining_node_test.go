@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -219,3 +220,46 @@ func TestDrainingNode_Table(t *testing.T) {
 		})
 	}
 }
+
+// TestDrainingNode_NoExecuteTaints asserts that only the allocations that don't
+// tolerate the NoExecute taints of a node that isn't draining are drained.
+func TestDrainingNode_NoExecuteTaints(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	node := mock.Node()
+	node.Taints = []*structs.NodeTaint{
+		{Key: "maintenance", Effect: structs.TaintEffectNoExecute},
+	}
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+	dn := NewDrainingNode(node, store)
+
+	// Nothing runs on the node yet
+	assertDrainingNode(t, dn, true, 0, 0)
+
+	tolerated := mock.Alloc()
+	tolerated.Job.TaskGroups[0].Tolerations = []*structs.Toleration{
+		{Key: "maintenance", Operator: structs.TolerationOperatorExists},
+	}
+	untolerated := mock.Alloc()
+	system := mock.SystemAlloc()
+	allocs := []*structs.Allocation{tolerated, untolerated, system}
+	for _, a := range allocs {
+		a.NodeID = node.ID
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, a.Job))
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 102, allocs))
+
+	// Only the untolerated service alloc blocks the node, and the system
+	// alloc is stopped once it's migrated
+	assertDrainingNode(t, dn, false, 2, 1)
+
+	jobs, err := dn.DrainingJobs()
+	must.NoError(t, err)
+	must.Eq(t, []structs.NamespacedID{{Namespace: untolerated.Namespace, ID: untolerated.JobID}}, jobs)
+
+	untolerated = untolerated.Copy()
+	untolerated.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, store.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 103, []*structs.Allocation{untolerated}))
+	assertDrainingNode(t, dn, true, 1, 0)
+}
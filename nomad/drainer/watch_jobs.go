@@ -20,6 +20,10 @@ import (
 type DrainRequest struct {
 	Allocs []*structs.Allocation
 	Resp   *structs.BatchFuture
+
+	// Events are node events to emit, keyed by node ID, for allocations
+	// whose drain is blocked.
+	Events map[string][]*structs.NodeEvent
 }
 
 func NewDrainRequest(allocs []*structs.Allocation) *DrainRequest {
@@ -65,6 +69,11 @@ type drainingJobWatcher struct {
 	drainCh    chan *DrainRequest
 	migratedCh chan []*structs.Allocation
 
+	// blocked is the set of nodes, jobs and task groups whose drain was last
+	// seen blocked by a disruption budget, so events are only emitted when
+	// a drain becomes blocked. It is only accessed by the watch goroutine.
+	blocked map[string]struct{}
+
 	l sync.RWMutex
 }
 
@@ -86,6 +95,7 @@ func NewDrainingJobWatcher(ctx context.Context, limiter *rate.Limiter, state *st
 		jobs:        make(map[structs.NamespacedID]struct{}, 64),
 		drainCh:     make(chan *DrainRequest),
 		migratedCh:  make(chan []*structs.Allocation),
+		blocked:     make(map[string]struct{}),
 	}
 
 	go w.watch()
@@ -191,6 +201,7 @@ func (w *drainingJobWatcher) watch() {
 
 		currentJobs := w.drainingJobs()
 		var allDrain, allMigrated []*structs.Allocation
+		var allBlocked []*blockedDrain
 		for jns, allocs := range jobAllocs {
 			// Check if the job is still registered
 			if _, ok := currentJobs[jns]; !ok {
@@ -230,6 +241,7 @@ func (w *drainingJobWatcher) watch() {
 
 			allDrain = append(allDrain, result.drain...)
 			allMigrated = append(allMigrated, result.migrated...)
+			allBlocked = append(allBlocked, result.blocked...)
 
 			// Stop tracking this job
 			if result.done {
@@ -237,10 +249,12 @@ func (w *drainingJobWatcher) watch() {
 			}
 		}
 
-		if len(allDrain) != 0 {
+		events := w.blockedEvents(allBlocked)
+		if len(allDrain) != 0 || len(events) != 0 {
 			// Create the request
 			req := NewDrainRequest(allDrain)
-			w.logger.Trace("sending drain request for allocs", "num_allocs", len(allDrain))
+			req.Events = events
+			w.logger.Trace("sending drain request for allocs", "num_allocs", len(allDrain), "num_blocked_nodes", len(events))
 
 			select {
 			case w.drainCh <- req:
@@ -280,6 +294,40 @@ func (w *drainingJobWatcher) watch() {
 	}
 }
 
+// blockedEvents returns the node events to emit for drains that became
+// blocked by disruption budgets since they were last handled.
+func (w *drainingJobWatcher) blockedEvents(blocked []*blockedDrain) map[string][]*structs.NodeEvent {
+	events := make(map[string][]*structs.NodeEvent)
+	current := make(map[string]struct{}, len(blocked))
+	for _, b := range blocked {
+		key := b.nodeID + "/" + b.job.Namespace + "/" + b.job.ID + "/" + b.taskGroup
+		current[key] = struct{}{}
+		if _, ok := w.blocked[key]; ok {
+			continue
+		}
+
+		event := structs.NewNodeEvent().
+			SetSubsystem(structs.NodeEventSubsystemDrain).
+			SetMessage(NodeDrainEventBlocked).
+			AddDetail("job", b.job.ID).
+			AddDetail("namespace", b.job.Namespace).
+			AddDetail("task_group", b.taskGroup).
+			AddDetail("reason", b.reason)
+		events[b.nodeID] = append(events[b.nodeID], event)
+	}
+	w.blocked = current
+	return events
+}
+
+// blockedDrain is a draining node with allocations of a task group that can't
+// be drained without violating its disruption budget.
+type blockedDrain struct {
+	nodeID    string
+	job       structs.NamespacedID
+	taskGroup string
+	reason    string
+}
+
 // jobResult is the set of actions to take for a draining job given its current
 // state.
 type jobResult struct {
@@ -289,6 +337,10 @@ type jobResult struct {
 	// migrated is the set of allocations to emit as migrated
 	migrated []*structs.Allocation
 
+	// blocked is the set of draining nodes whose allocations can't be
+	// drained because of disruption budgets.
+	blocked []*blockedDrain
+
 	// done marks whether the job has been fully drained.
 	done bool
 }
@@ -308,7 +360,7 @@ func (r *jobResult) String() string {
 // handleJob takes the state of a draining job and returns the desired actions.
 func handleJob(snap *state.StateSnapshot, job *structs.Job, allocs []*structs.Allocation, lastHandledIndex uint64) (*jobResult, error) {
 	r := newJobResult()
-	batch := job.Type == structs.JobTypeBatch
+	batch := job.Type == structs.JobTypeBatch || job.Type == structs.JobTypeWorkflow
 	taskGroups := make(map[string]*structs.TaskGroup, len(job.TaskGroups))
 	for _, tg := range job.TaskGroups {
 		// Only capture the groups that have a migrate strategy or we are just
@@ -330,9 +382,13 @@ func handleJob(snap *state.StateSnapshot, job *structs.Job, allocs []*structs.Al
 
 	for name, tg := range taskGroups {
 		allocs := tgAllocs[name]
+		blocked := len(r.blocked)
 		if err := handleTaskGroup(snap, batch, tg, allocs, lastHandledIndex, r); err != nil {
 			return nil, fmt.Errorf("drain for task group %q failed: %v", name, err)
 		}
+		for _, b := range r.blocked[blocked:] {
+			b.job = job.NamespacedID()
+		}
 	}
 
 	return r, nil
@@ -346,26 +402,27 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 	allocs []*structs.Allocation, lastHandledIndex uint64, result *jobResult) error {
 
 	// Determine how many allocations can be drained
-	drainingNodes := make(map[string]bool, 4)
+	nodes := make(map[string]*structs.Node, 4)
 	healthy := 0
 	remainingDrainingAlloc := false
 	var drainable []*structs.Allocation
 
 	for _, alloc := range allocs {
-		// Check if the alloc is on a draining node.
-		onDrainingNode, ok := drainingNodes[alloc.NodeID]
+		node, ok := nodes[alloc.NodeID]
 		if !ok {
 			// Look up the node
-			node, err := snap.NodeByID(nil, alloc.NodeID)
+			var err error
+			node, err = snap.NodeByID(nil, alloc.NodeID)
 			if err != nil {
 				return err
 			}
-
-			// Check if the node exists and whether it has a drain strategy
-			onDrainingNode = node != nil && node.DrainStrategy != nil
-			drainingNodes[alloc.NodeID] = onDrainingNode
+			nodes[alloc.NodeID] = node
 		}
 
+		// Check if the alloc is on a draining node, or on a node with
+		// NoExecute taints it doesn't tolerate.
+		onDrainingNode := mustLeaveNode(node, alloc)
+
 		// Check if the alloc should be considered migrated. A migrated
 		// allocation is one that is terminal on the client, is on a draining
 		// node, and has been updated since our last handled index to
@@ -412,9 +469,28 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 		return nil
 	}
 
-	// Determine how many we can drain
+	// Determine how many we can drain, keeping at least the number of
+	// healthy allocations the disruption budget of the group requires.
 	thresholdCount := tg.Count - tg.Migrate.MaxParallel
 	numToDrain := healthy - thresholdCount
+	if tg.DisruptionBudget != nil {
+		allowed := tg.DisruptionBudget.AllowedDisruptions(tg.Count, healthy)
+		if allowed == 0 && numToDrain > 0 && len(drainable) > 0 {
+			reason := tg.DisruptionBudget.BlockedReason(tg.Count, healthy)
+			nodeIDs := make(map[string]struct{})
+			for _, alloc := range drainable {
+				if _, ok := nodeIDs[alloc.NodeID]; !ok {
+					nodeIDs[alloc.NodeID] = struct{}{}
+					result.blocked = append(result.blocked, &blockedDrain{
+						nodeID:    alloc.NodeID,
+						taskGroup: tg.Name,
+						reason:    reason,
+					})
+				}
+			}
+		}
+		numToDrain = min(numToDrain, allowed)
+	}
 	numToDrain = min(len(drainable), numToDrain)
 
 	if numToDrain <= 0 {
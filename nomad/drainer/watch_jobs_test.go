@@ -328,6 +328,7 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 		batch       bool // use a batch job
 		allocCount  int  // number of allocs in test (defaults to 10)
 		maxParallel int  // max_parallel (defaults to 1)
+		budget      *structs.DisruptionBudget
 
 		// addAllocFn will be called allocCount times to create test allocs,
 		// and the allocs default to be healthy on the draining node
@@ -335,6 +336,7 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 
 		expectDrained  int
 		expectMigrated int
+		expectBlocked  int
 		expectDone     bool
 	}{
 		{
@@ -344,6 +346,29 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 			expectMigrated: 0,
 			expectDone:     false,
 		},
+		{
+			// max_parallel=5 allows more than the disruption budget
+			name:           "drain-respects-disruption-budget",
+			maxParallel:    5,
+			budget:         &structs.DisruptionBudget{MinHealthy: 8},
+			expectDrained:  2,
+			expectMigrated: 0,
+			expectDone:     false,
+		},
+		{
+			name:           "drain-blocked-by-disruption-budget",
+			maxParallel:    3,
+			budget:         &structs.DisruptionBudget{MaxUnavailable: 1},
+			expectDrained:  0,
+			expectMigrated: 0,
+			expectBlocked:  1,
+			expectDone:     false,
+			addAllocFn: func(i int, a *structs.Allocation, drainingID, runningID string) {
+				if i == 1 {
+					a.DeploymentStatus = nil
+				}
+			},
+		},
 		{
 			name:           "migrating-allocs-not-healty-max-parallel-1",
 			expectDrained:  0,
@@ -622,6 +647,7 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 			if tc.maxParallel > 0 {
 				job.TaskGroups[0].Migrate.MaxParallel = tc.maxParallel
 			}
+			job.TaskGroups[0].DisruptionBudget = tc.budget
 			must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, job))
 
 			var allocs []*structs.Allocation
@@ -653,11 +679,41 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 			must.NoError(t, handleTaskGroup(snap, tc.batch, job.TaskGroups[0], allocs, 102, res))
 			test.Len(t, tc.expectDrained, res.drain, test.Sprint("expected drained allocs"))
 			test.Len(t, tc.expectMigrated, res.migrated, test.Sprint("expected migrated allocs"))
+			test.Len(t, tc.expectBlocked, res.blocked, test.Sprint("expected blocked nodes"))
 			test.Eq(t, tc.expectDone, res.done)
 		})
 	}
 }
 
+func TestDrainingJobWatcher_BlockedEvents(t *testing.T) {
+	ci.Parallel(t)
+
+	w := &drainingJobWatcher{blocked: make(map[string]struct{})}
+	job := structs.NewNamespacedID("api", structs.DefaultNamespace)
+	blocked := []*blockedDrain{
+		{nodeID: "n1", job: job, taskGroup: "web", reason: "budget"},
+		{nodeID: "n2", job: job, taskGroup: "web", reason: "budget"},
+	}
+
+	events := w.blockedEvents(blocked)
+	must.MapLen(t, 2, events)
+	event := events["n1"][0]
+	must.Eq(t, NodeDrainEventBlocked, event.Message)
+	must.Eq(t, map[string]string{
+		"job":        "api",
+		"namespace":  structs.DefaultNamespace,
+		"task_group": "web",
+		"reason":     "budget",
+	}, event.Details)
+
+	// Drains that are still blocked don't emit new events.
+	must.MapEmpty(t, w.blockedEvents(blocked))
+
+	// Drains that become blocked again emit new events.
+	must.MapEmpty(t, w.blockedEvents(blocked[:1]))
+	must.MapLen(t, 1, w.blockedEvents(blocked))
+}
+
 func TestHandleTaskGroup_Migrations(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
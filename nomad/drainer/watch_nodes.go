@@ -60,7 +60,11 @@ func (n *NodeDrainer) Update(node *structs.Node) {
 		draining.Update(node)
 	}
 
-	if inf, deadline := node.DrainStrategy.DeadlineTime(); !inf {
+	if node.DrainStrategy == nil {
+		// The node is only tracked for its NoExecute taints, which don't
+		// have a deadline
+		n.deadlineNotifier.Remove(node.ID)
+	} else if inf, deadline := node.DrainStrategy.DeadlineTime(); !inf {
 		n.deadlineNotifier.Watch(node.ID, deadline)
 	} else {
 		// There is an infinite deadline so it shouldn't be tracked for
@@ -99,6 +103,12 @@ func (n *NodeDrainer) Update(node *structs.Node) {
 			}
 		}
 
+		// Nodes with NoExecute taints aren't draining, so there is no drain
+		// to complete
+		if node.DrainStrategy == nil {
+			return
+		}
+
 		// Create the node event
 		event := structs.NewNodeEvent().
 			SetSubsystem(structs.NodeEventSubsystemDrain).
@@ -114,7 +124,7 @@ func (n *NodeDrainer) Update(node *structs.Node) {
 }
 
 // nodeDrainWatcher is used to watch nodes that are entering, leaving or
-// changing their drain strategy.
+// changing their drain strategy or NoExecute taints.
 type nodeDrainWatcher struct {
 	ctx    context.Context
 	logger log.Logger
@@ -173,7 +183,7 @@ func (w *nodeDrainWatcher) watch() {
 
 		tracked := w.tracker.TrackedNodes()
 		for nodeID, node := range nodes {
-			newDraining := node.DrainStrategy != nil
+			newDraining := isDrainingNode(node)
 			currentNode, tracked := tracked[nodeID]
 
 			switch {
@@ -186,7 +196,8 @@ func (w *nodeDrainWatcher) watch() {
 				// If the node is not being tracked but is draining, track
 				w.tracker.Update(node)
 
-			case tracked && newDraining && !currentNode.DrainStrategy.Equal(node.DrainStrategy):
+			case tracked && newDraining && (!currentNode.DrainStrategy.Equal(node.DrainStrategy) ||
+				!structs.NodeTaintsEqual(currentNode.Taints, node.Taints)):
 				// If the node is being tracked but has changed, update
 				w.tracker.Update(node)
 
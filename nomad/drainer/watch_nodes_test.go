@@ -200,6 +200,48 @@ func TestNodeDrainWatcher_Update_DrainComplete(t *testing.T) {
 	must.MapEmpty(t, tracker.deadlineNotifier.(*MockDeadlineNotifier).nodes)
 }
 
+// TestNodeDrainWatcher_NoExecuteTaints tests that nodes with NoExecute taints
+// are tracked without a deadline, and untracked once the taints are removed.
+func TestNodeDrainWatcher_NoExecuteTaints(t *testing.T) {
+	ci.Parallel(t)
+	_, store, tracker := testNodeDrainWatcher(t)
+
+	job := mock.Job()
+	jobID := structs.NamespacedID{Namespace: job.Namespace, ID: job.ID}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, job))
+
+	node := mock.Node()
+	alloc := mock.Alloc()
+	alloc.JobID = job.ID
+	alloc.Job = job
+	alloc.TaskGroup = job.TaskGroups[0].Name
+	alloc.NodeID = node.ID
+	must.NoError(t, store.UpsertAllocs(
+		structs.MsgTypeTestSetup, 102, []*structs.Allocation{alloc}))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 103, node))
+	assertTrackerSettled(t, tracker, []string{})
+
+	// NoSchedule taints don't evict allocations
+	must.NoError(t, store.UpdateNodeTaints(structs.MsgTypeTestSetup, 104, node.ID,
+		[]*structs.NodeTaint{{Key: "dedicated", Effect: structs.TaintEffectNoSchedule}}, 0, nil))
+	assertTrackerSettled(t, tracker, []string{})
+
+	must.NoError(t, store.UpdateNodeTaints(structs.MsgTypeTestSetup, 105, node.ID,
+		[]*structs.NodeTaint{{Key: "maintenance", Effect: structs.TaintEffectNoExecute}}, 0, nil))
+	assertTrackerSettled(t, tracker, []string{node.ID})
+	must.MapContainsKey(t, tracker.jobWatcher.(*MockJobWatcher).jobs, jobID)
+	must.MapEmpty(t, tracker.deadlineNotifier.(*MockDeadlineNotifier).nodes)
+
+	// The node isn't draining so its drain isn't completed
+	n, err := store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Nil(t, n.DrainStrategy)
+	must.Nil(t, n.LastDrain)
+
+	must.NoError(t, store.UpdateNodeTaints(structs.MsgTypeTestSetup, 106, node.ID, nil, 0, nil))
+	assertTrackerSettled(t, tracker, []string{})
+}
+
 func testNodeDrainWatcherSetup(
 	t *testing.T, store *state.StateStore, tracker *NodeDrainer) (
 	*structs.Node, structs.NamespacedID) {
@@ -44,3 +44,12 @@ func (d drainerShim) AllocUpdateDesiredTransition(allocs map[string]*structs.Des
 	_, index, err := d.s.raftApply(structs.AllocUpdateDesiredTransitionRequestType, args)
 	return index, err
 }
+
+func (d drainerShim) NodesEmitEvents(events map[string][]*structs.NodeEvent) (uint64, error) {
+	args := &structs.EmitNodeEventsRequest{
+		NodeEvents:   events,
+		WriteRequest: structs.WriteRequest{Region: d.s.config.Region},
+	}
+	_, index, err := d.s.raftApply(structs.UpsertNodeEventsType, args)
+	return index, err
+}
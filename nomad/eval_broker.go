@@ -4,10 +4,12 @@
 package nomad
 
 import (
+	"cmp"
 	"container/heap"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
 	"strconv"
 	"sync"
@@ -74,8 +76,25 @@ type EvalBroker struct {
 	// now safe for the Eval.Ack RPC to cancel in batches
 	cancelable []*structs.Evaluation
 
-	// ready tracks the ready jobs by scheduler in a priority queue
-	ready map[string]ReadyEvaluations
+	// ready tracks the ready jobs by scheduler and namespace in a priority
+	// queue
+	ready map[string]map[string]ReadyEvaluations
+
+	// fairShare configures whether evaluations of equal priority are dequeued
+	// in proportion to the weight of their namespace. When enabled,
+	// virtualTime tracks the virtual time of each namespace, which advances
+	// by the inverse of the namespace weight on each dequeue, and the
+	// namespace with the lowest virtual time is dequeued first. virtualClock
+	// is the virtual time of the last dequeue, used so that a namespace
+	// becoming ready doesn't catch up on the time it was idle.
+	fairShare    structs.FairShareConfig
+	virtualTime  map[string]float64
+	virtualClock float64
+
+	// readyOrder tracks the order ready evaluations were enqueued in by ID,
+	// to dequeue evaluations equally eligible across namespaces in order.
+	readyOrder map[string]uint64
+	readySeq   uint64
 
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
@@ -157,7 +176,9 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 		jobEvals:             make(map[structs.NamespacedID]string),
 		pending:              make(map[structs.NamespacedID]PendingEvaluations),
 		cancelable:           make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest),
-		ready:                make(map[string]ReadyEvaluations),
+		ready:                make(map[string]map[string]ReadyEvaluations),
+		virtualTime:          make(map[string]float64),
+		readyOrder:           make(map[string]uint64),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -175,6 +196,22 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 	return b, nil
 }
 
+// SetFairShare sets whether evaluations of equal priority are shared between
+// namespaces in proportion to their weight. The virtual times of the
+// namespaces are reset when fair sharing is enabled or disabled.
+func (b *EvalBroker) SetFairShare(config structs.FairShareConfig) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if config.Enabled != b.fairShare.Enabled {
+		b.virtualTime = make(map[string]float64)
+		b.virtualClock = 0
+	}
+
+	config.NamespaceWeights = maps.Clone(config.NamespaceWeights)
+	b.fairShare = config
+}
+
 // Enabled is used to check if the broker is enabled.
 func (b *EvalBroker) Enabled() bool {
 	b.l.RLock()
@@ -348,18 +385,31 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 		return
 	}
 
-	// Find the next ready eval by scheduler class
-	readyQueue, ok := b.ready[sched]
+	// Find the next ready eval by scheduler class and namespace
+	readyQueues, ok := b.ready[sched]
 	if !ok {
-		readyQueue = make([]*structs.Evaluation, 0, 16)
+		readyQueues = make(map[string]ReadyEvaluations)
+		b.ready[sched] = readyQueues
 		if _, ok := b.waiting[sched]; !ok {
 			b.waiting[sched] = make(chan struct{}, 1)
 		}
 	}
+	readyQueue, ok := readyQueues[eval.Namespace]
+	if !ok {
+		readyQueue = make([]*structs.Evaluation, 0, 16)
+	}
 
 	// Push onto the heap
 	heap.Push(&readyQueue, eval)
-	b.ready[sched] = readyQueue
+	readyQueues[eval.Namespace] = readyQueue
+	b.readySeq++
+	b.readyOrder[eval.ID] = b.readySeq
+
+	// A namespace that was idle resumes at the current virtual time so it
+	// can't starve the others with the share it didn't use
+	if b.fairShare.Enabled && b.virtualTime[eval.Namespace] < b.virtualClock {
+		b.virtualTime[eval.Namespace] = b.virtualClock
+	}
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -443,29 +493,25 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 
 	// Scan for eligible work
 	var eligibleSched []string
-	var eligiblePriority int
+	var eligible *structs.Evaluation
 	for _, sched := range schedulers {
-		// Get the ready queue for this scheduler
-		readyQueue, ok := b.ready[sched]
-		if !ok {
-			continue
-		}
-
-		// Peek at the next item
-		ready := readyQueue.Peek()
+		// Peek at the next item for this scheduler
+		ready := b.peekReady(sched)
 		if ready == nil {
 			continue
 		}
 
 		// Add to eligible if equal or greater priority
-		if len(eligibleSched) == 0 || ready.Priority > eligiblePriority {
+		if eligible == nil {
 			eligibleSched = []string{sched}
-			eligiblePriority = ready.Priority
-
-		} else if eligiblePriority > ready.Priority {
+			eligible = ready
 			continue
-
-		} else if eligiblePriority == ready.Priority {
+		}
+		switch c := b.compareReady(ready, eligible); {
+		case c < 0:
+			eligibleSched = []string{sched}
+			eligible = ready
+		case c == 0:
 			eligibleSched = append(eligibleSched, sched)
 		}
 	}
@@ -488,13 +534,63 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 	}
 }
 
+// peekReady returns the next ready evaluation for a given scheduler across
+// namespaces, or nil if there is none. This assumes locks are held.
+func (b *EvalBroker) peekReady(sched string) *structs.Evaluation {
+	var next *structs.Evaluation
+	for _, readyQueue := range b.ready[sched] {
+		ready := readyQueue.Peek()
+		if next == nil {
+			next = ready
+			continue
+		}
+
+		c := b.compareReady(ready, next)
+		if c == 0 {
+			c = cmp.Or(cmp.Compare(ready.CreateIndex, next.CreateIndex),
+				cmp.Compare(b.readyOrder[ready.ID], b.readyOrder[next.ID]))
+		}
+		if c < 0 {
+			next = ready
+		}
+	}
+	return next
+}
+
+// compareReady returns a negative number when ready evaluation x should be
+// dequeued before y, and zero when they are equally eligible. Higher priority
+// evaluations are dequeued first and, with fair sharing enabled, evaluations
+// of equal priority are dequeued from the namespace with the lowest virtual
+// time first. This assumes locks are held.
+func (b *EvalBroker) compareReady(x, y *structs.Evaluation) int {
+	if x.Priority != y.Priority {
+		return cmp.Compare(y.Priority, x.Priority)
+	}
+	if b.fairShare.Enabled {
+		return cmp.Compare(b.virtualTime[x.Namespace], b.virtualTime[y.Namespace])
+	}
+	return 0
+}
+
 // dequeueForSched is used to dequeue the next work item for a given scheduler.
 // This assumes locks are held and that this scheduler has work
 func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
-	readyQueue := b.ready[sched]
-	raw := heap.Pop(&readyQueue)
-	b.ready[sched] = readyQueue
-	eval := raw.(*structs.Evaluation)
+	eval := b.peekReady(sched)
+	readyQueues := b.ready[sched]
+	readyQueue := readyQueues[eval.Namespace]
+	eval = heap.Pop(&readyQueue).(*structs.Evaluation)
+	if len(readyQueue) > 0 {
+		readyQueues[eval.Namespace] = readyQueue
+	} else {
+		delete(readyQueues, eval.Namespace)
+	}
+	delete(b.readyOrder, eval.ID)
+
+	// Advance the virtual time of the namespace by its share
+	if b.fairShare.Enabled {
+		b.virtualClock = b.virtualTime[eval.Namespace]
+		b.virtualTime[eval.Namespace] += 1 / float64(b.fairShare.NamespaceWeight(eval.Namespace))
+	}
 
 	// Generate a UUID for the token
 	token := uuid.Generate()
@@ -653,7 +749,7 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 		// If any remain, enqueue an eval
 		if len(pending) > 0 {
 			raw := heap.Pop(&pending)
-			eval := raw.(*structs.Evaluation)
+			eval := widenNodeEval(raw.(*structs.Evaluation), cancelable)
 			b.stats.TotalPending -= 1
 			b.enqueueLocked(eval, eval.Type, true)
 		}
@@ -856,7 +952,10 @@ func (b *EvalBroker) flush() {
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.pending = make(map[structs.NamespacedID]PendingEvaluations)
 	b.cancelable = make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest)
-	b.ready = make(map[string]ReadyEvaluations)
+	b.ready = make(map[string]map[string]ReadyEvaluations)
+	b.virtualTime = make(map[string]float64)
+	b.virtualClock = 0
+	b.readyOrder = make(map[string]uint64)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -941,6 +1040,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	stats := new(BrokerStats)
 	stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -959,6 +1059,35 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		subStatCopy := *subStat
 		stats.ByScheduler[sched] = &subStatCopy
 	}
+
+	// Namespace stats are computed from the queues rather than tracked as
+	// evaluations move between them
+	byNamespace := func(namespace string) *NamespaceStats {
+		nsStats, ok := stats.ByNamespace[namespace]
+		if !ok {
+			nsStats = &NamespaceStats{}
+			stats.ByNamespace[namespace] = nsStats
+		}
+		return nsStats
+	}
+	now := time.Now()
+	for _, readyQueues := range b.ready {
+		for namespace, readyQueue := range readyQueues {
+			nsStats := byNamespace(namespace)
+			nsStats.Ready += len(readyQueue)
+			for _, eval := range readyQueue {
+				if t, ok := b.enqueuedTime[eval.ID]; ok {
+					nsStats.WaitTime = max(nsStats.WaitTime, now.Sub(t))
+				}
+			}
+		}
+	}
+	for namespacedID, pending := range b.pending {
+		byNamespace(namespacedID.Namespace).Pending += len(pending)
+	}
+	for _, unack := range b.unack {
+		byNamespace(unack.Eval.Namespace).Unacked += 1
+	}
 	return stats
 }
 
@@ -985,6 +1114,10 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 	timer, stop := helper.NewSafeTimer(period)
 	defer stop()
 
+	// namespaces tracks the namespaces whose gauges were last emitted, so
+	// they are reset once the namespace has no evaluations left
+	namespaces := make(map[string]struct{})
+
 	for {
 		timer.Reset(period)
 
@@ -1008,6 +1141,22 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for namespace := range namespaces {
+				if _, ok := stats.ByNamespace[namespace]; !ok {
+					stats.ByNamespace[namespace] = &NamespaceStats{}
+				}
+			}
+			clear(namespaces)
+			for namespace, nsStats := range stats.ByNamespace {
+				labels := []metrics.Label{{Name: "namespace", Value: namespace}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "ready"}, float32(nsStats.Ready), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "pending"}, float32(nsStats.Pending), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "unacked"}, float32(nsStats.Unacked), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "wait_time"}, float32(nsStats.WaitTime.Seconds()), labels)
+				if *nsStats != (NamespaceStats{}) {
+					namespaces[namespace] = struct{}{}
+				}
+			}
 
 		case <-stopCh:
 			return
@@ -1024,6 +1173,7 @@ type BrokerStats struct {
 	TotalCancelable int
 	DelayedEvals    map[string]*structs.Evaluation
 	ByScheduler     map[string]*SchedulerStats
+	ByNamespace     map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -1032,6 +1182,15 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace. WaitTime is how long the
+// oldest ready evaluation of the namespace has been waiting to be dequeued.
+type NamespaceStats struct {
+	Ready    int
+	Pending  int
+	Unacked  int
+	WaitTime time.Duration
+}
+
 // Len is for the sorting interface
 func (r ReadyEvaluations) Len() int {
 	return len(r)
@@ -1066,13 +1225,15 @@ func (r *ReadyEvaluations) Pop() interface{} {
 	return e
 }
 
-// Peek is used to peek at the next element that would be popped
+// Peek is used to peek at the next element that would be popped. The root of
+// the heap is at index 0, which is what heap.Pop returns; the last element is
+// only the last leaf, so the fair-share scheduling which compares the next
+// evaluation of each namespace must not use it.
 func (r ReadyEvaluations) Peek() *structs.Evaluation {
-	n := len(r)
-	if n == 0 {
+	if len(r) == 0 {
 		return nil
 	}
-	return r[n-1]
+	return r[0]
 }
 
 // Len is for the sorting interface
@@ -1109,6 +1270,24 @@ func (p *PendingEvaluations) Pop() interface{} {
 	return e
 }
 
+// widenNodeEval returns the eval to enqueue in place of the cancelable evals
+// of the same job. The system scheduler only reconciles the node of evals
+// triggered by a node, so if any of the cancelable evals was for another node
+// or for the whole job, the returned copy of the eval reconciles every node.
+func widenNodeEval(eval *structs.Evaluation, cancelable []*structs.Evaluation) *structs.Evaluation {
+	if eval.Type != structs.JobTypeSystem || eval.NodeID == "" {
+		return eval
+	}
+	for _, other := range cancelable {
+		if other.NodeID != eval.NodeID {
+			eval = eval.Copy()
+			eval.NodeID = ""
+			return eval
+		}
+	}
+	return eval
+}
+
 // MarkForCancel is used to clear the pending list of all but the one with the
 // highest modify index and highest priority. It returns a slice of cancelable
 // evals so that Eval.Ack RPCs can write batched raft entries to cancel
@@ -436,6 +436,7 @@ func TestEvalBroker_Serialize_DuplicateJobID(t *testing.T) {
 		stats := b.Stats()
 		stats.DelayedEvals = nil
 		stats.ByScheduler = nil
+		stats.ByNamespace = nil
 		return *stats
 	}
 
@@ -507,6 +508,54 @@ func TestEvalBroker_Serialize_DuplicateJobID(t *testing.T) {
 		TotalPending: 0, TotalCancelable: 3}, getStats())
 }
 
+func TestEvalBroker_Ack_WidenNodeEvals(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	systemSched := []string{structs.JobTypeSystem}
+
+	newEval := func(idx uint64, nodeID string) *structs.Evaluation {
+		eval := mock.Eval()
+		eval.ID = fmt.Sprintf("eval:%d", idx)
+		eval.Type = structs.JobTypeSystem
+		eval.JobID = "example"
+		eval.TriggeredBy = structs.EvalTriggerNodeUpdate
+		eval.NodeID = nodeID
+		eval.CreateIndex = idx
+		eval.ModifyIndex = idx
+		b.Enqueue(eval)
+		return eval
+	}
+
+	eval1 := newEval(1, "node-a")
+	newEval(2, "node-a")
+	eval3 := newEval(3, "node-a")
+
+	out, token, err := b.Dequeue(systemSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval1, out)
+	must.NoError(t, b.Ack(out.ID, token))
+
+	// The pending evals were all for the same node, so the retained eval
+	// stays scoped to the node.
+	out, token, err = b.Dequeue(systemSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval3, out)
+	newEval(4, "node-b")
+	eval5 := newEval(5, "node-c")
+	must.NoError(t, b.Ack(out.ID, token))
+
+	// The pending evals were for different nodes, so the retained eval
+	// reconciles every node without modifying the enqueued eval.
+	out, token, err = b.Dequeue(systemSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval5.ID, out.ID)
+	must.Eq(t, "", out.NodeID)
+	must.Eq(t, "node-c", eval5.NodeID)
+	must.NoError(t, b.Ack(out.ID, token))
+}
+
 func TestEvalBroker_Enqueue_Disable(t *testing.T) {
 	ci.Parallel(t)
 	b := testBroker(t, 0)
@@ -1406,6 +1455,120 @@ func TestEvalBroker_NamespacedJobs(t *testing.T) {
 
 }
 
+func TestEvalBroker_FairShare(t *testing.T) {
+	ci.Parallel(t)
+
+	// enqueue evaluations of separate jobs for a noisy namespace before those
+	// of two quieter namespaces, and return the namespaces in dequeue order
+	dequeueOrder := func(t *testing.T, config structs.FairShareConfig) []string {
+		b := testBroker(t, 0)
+		b.SetEnabled(true)
+		b.SetFairShare(config)
+
+		for _, ns := range []string{"noisy", "noisy", "noisy", "noisy", "noisy", "noisy", "a", "a", "b"} {
+			eval := mock.Eval()
+			eval.Namespace = ns
+			b.Enqueue(eval)
+		}
+
+		var order []string
+		for {
+			out, token, err := b.Dequeue(defaultSched, 5*time.Millisecond)
+			must.NoError(t, err)
+			if out == nil {
+				return order
+			}
+			must.NoError(t, b.Ack(out.ID, token))
+			order = append(order, out.Namespace)
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		must.Eq(t, []string{"noisy", "noisy", "noisy", "noisy", "noisy", "noisy", "a", "a", "b"},
+			dequeueOrder(t, structs.FairShareConfig{}))
+	})
+
+	t.Run("equal weights", func(t *testing.T) {
+		must.Eq(t, []string{"noisy", "a", "b", "noisy", "a", "noisy", "noisy", "noisy", "noisy"},
+			dequeueOrder(t, structs.FairShareConfig{Enabled: true}))
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		must.Eq(t, []string{"noisy", "a", "b", "noisy", "noisy", "a", "noisy", "noisy", "noisy"},
+			dequeueOrder(t, structs.FairShareConfig{
+				Enabled:          true,
+				NamespaceWeights: map[string]int{"noisy": 2},
+			}))
+	})
+}
+
+func TestEvalBroker_FairShare_Priority(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetFairShare(structs.FairShareConfig{Enabled: true})
+
+	// Priority is honored before the fair share of namespaces
+	low := mock.Eval()
+	low.Namespace = "quiet"
+	low.Priority = 10
+	b.Enqueue(low)
+
+	for i := 0; i < 2; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "noisy"
+		eval.Priority = 90
+		b.Enqueue(eval)
+	}
+
+	for _, expected := range []string{"noisy", "noisy", "quiet"} {
+		out, token, err := b.Dequeue(defaultSched, 5*time.Millisecond)
+		must.NoError(t, err)
+		must.NotNil(t, out)
+		must.Eq(t, expected, out.Namespace)
+		must.NoError(t, b.Ack(out.ID, token))
+	}
+}
+
+func TestEvalBroker_Stats_ByNamespace(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	eval1 := mock.Eval()
+	eval1.Namespace = "a"
+	b.Enqueue(eval1)
+
+	// A second eval for the same job is pending behind the first
+	eval2 := mock.Eval()
+	eval2.Namespace = "a"
+	eval2.JobID = eval1.JobID
+	b.Enqueue(eval2)
+
+	eval3 := mock.Eval()
+	eval3.Namespace = "b"
+	eval3.Priority = eval1.Priority + 10
+	b.Enqueue(eval3)
+
+	out, _, err := b.Dequeue(defaultSched, 5*time.Millisecond)
+	must.NoError(t, err)
+	must.Eq(t, eval3.ID, out.ID)
+
+	stats := b.Stats()
+	must.MapLen(t, 2, stats.ByNamespace)
+
+	nsA := stats.ByNamespace["a"]
+	must.Eq(t, 1, nsA.Ready)
+	must.Eq(t, 1, nsA.Pending)
+	must.Eq(t, 0, nsA.Unacked)
+	must.Positive(t, nsA.WaitTime)
+
+	nsB := stats.ByNamespace["b"]
+	must.Eq(t, 0, nsB.Ready)
+	must.Eq(t, 0, nsB.Pending)
+	must.Eq(t, 1, nsB.Unacked)
+}
+
 func TestEvalBroker_ReadyEvals_Ordering(t *testing.T) {
 
 	ready := ReadyEvaluations{}
@@ -1441,6 +1604,37 @@ func TestEvalBroker_ReadyEvals_Ordering(t *testing.T) {
 
 }
 
+func TestEvalBroker_ReadyEvals_Peek(t *testing.T) {
+	ci.Parallel(t)
+
+	ready := ReadyEvaluations{}
+	must.Nil(t, ready.Peek())
+
+	newEval := func(evalID string, priority int, index uint64) *structs.Evaluation {
+		eval := mock.Eval()
+		eval.ID = evalID
+		eval.Priority = priority
+		eval.CreateIndex = index
+		return eval
+	}
+
+	// Peek must always return the evaluation heap.Pop returns next, which
+	// isn't the last element of the slice once the heap is reordered.
+	heap.Push(&ready, newEval("eval01", 50, 1))
+	heap.Push(&ready, newEval("eval02", 70, 2))
+	heap.Push(&ready, newEval("eval03", 30, 3))
+	heap.Push(&ready, newEval("eval04", 90, 4))
+	heap.Push(&ready, newEval("eval05", 50, 5))
+
+	for _, expected := range []string{"eval04", "eval02", "eval01", "eval05", "eval03"} {
+		peeked := ready.Peek()
+		must.NotNil(t, peeked)
+		must.Eq(t, expected, peeked.ID)
+		must.Eq(t, peeked, heap.Pop(&ready).(*structs.Evaluation))
+	}
+	must.Nil(t, ready.Peek())
+}
+
 func TestEvalBroker_PendingEval_Ordering(t *testing.T) {
 	pending := PendingEvaluations{}
 
@@ -1585,6 +1779,7 @@ func TestEvalBroker_IntegrationTest(t *testing.T) {
 		stats := srv.evalBroker.Stats()
 		stats.DelayedEvals = nil
 		stats.ByScheduler = nil
+		stats.ByNamespace = nil
 		return *stats
 	}
 
@@ -5,13 +5,16 @@ package nomad
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"time"
 
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/auth"
 	"github.com/hashicorp/nomad/nomad/peers"
 	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -70,6 +73,16 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 		return
 	}
 
+	var filter *bexpr.Evaluator
+	if args.Filter != "" {
+		filter, err = bexpr.CreateEvaluator(args.Filter)
+		if err != nil {
+			handleJsonResultError(fmt.Errorf("failed to read filter expression: %v", err),
+				pointer.Of(int64(400)), encoder)
+			return
+		}
+	}
+
 	// Generate the subscription request
 	subReq := &stream.SubscribeRequest{
 		Token:  args.AuthToken,
@@ -89,6 +102,8 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 			_, err = e.validateACL(args.Namespace, args.Topics, resolvedACL)
 			return err
 		},
+		AllowEvent: e.allowEventFn(&args, resolvedACL),
+		Filter:     filter,
 	}
 
 	// Get the servers broker and subscribe
@@ -298,6 +313,12 @@ func validateNsOp(namespace string, topics map[structs.Topic][]string, aclObj *a
 			if ok := aclObj.AllowOperatorRead(); !ok {
 				return structs.ErrPermissionDenied
 			}
+		case structs.TopicVariables:
+			// Access to each variable path is checked per event by
+			// allowEventFn, so only require some access to the namespace.
+			if ok := aclObj.AllowVariableSearch(namespace); !ok {
+				return structs.ErrPermissionDenied
+			}
 		default: // including TopicAll
 			if ok := aclObj.IsManagement(); !ok {
 				return structs.ErrPermissionDenied
@@ -308,3 +329,17 @@ func validateNsOp(namespace string, topics map[structs.Topic][]string, aclObj *a
 	return nil
 
 }
+
+// allowEventFn returns the per-event ACL check for a subscription. Variable
+// events are only sent if the token can list the variable's path, which is
+// the same check used by Variables.List for metadata.
+func (e *Event) allowEventFn(args *structs.EventStreamRequest, aclObj *acl.ACL) func(*structs.Event) bool {
+	claim := auth.IdentityToACLClaim(args.GetIdentity(), e.srv.State())
+	return func(event *structs.Event) bool {
+		if event.Topic != structs.TopicVariables {
+			return true
+		}
+		return aclObj.AllowVariableOperation(event.Namespace, event.Key,
+			acl.PolicyList, claim)
+	}
+}
@@ -212,6 +212,137 @@ OUTER:
 	}
 }
 
+// TestEventStream_Filter asserts only the events matching the filter
+// expression of the request are streamed.
+func TestEventStream_Filter(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.EnableEventBroker = true
+	})
+	defer cleanupS1()
+
+	testutil.WaitForLeader(t, s1.RPC)
+
+	req := structs.EventStreamRequest{
+		Topics: map[structs.Topic][]string{"*": {"*"}},
+		QueryOptions: structs.QueryOptions{
+			Region: s1.Region(),
+			Filter: `Payload.Node.Status == "down"`,
+		},
+	}
+
+	handler, err := s1.StreamingRpcHandler("Event.Stream")
+	must.NoError(t, err)
+
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+	defer p2.Close()
+
+	errCh := make(chan error)
+	streamMsg := make(chan *structs.EventStreamWrapper)
+
+	go handler(p2)
+
+	go func() {
+		decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+		for {
+			var msg structs.EventStreamWrapper
+			if err := decoder.Decode(&msg); err != nil {
+				if err == io.EOF || strings.Contains(err.Error(), "closed") {
+					return
+				}
+				errCh <- fmt.Errorf("error decoding: %w", err)
+			}
+
+			streamMsg <- &msg
+		}
+	}()
+
+	publisher, err := s1.State().EventBroker()
+	must.NoError(t, err)
+
+	encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+	must.NoError(t, encoder.Encode(req))
+
+	ready := mock.Node()
+	down := mock.Node()
+	down.Status = structs.NodeStatusDown
+	publisher.Publish(&structs.Events{Index: uint64(1), Events: []structs.Event{
+		{Topic: structs.TopicNode, Key: ready.ID, Payload: &structs.NodeStreamEvent{Node: ready}},
+		{Topic: structs.TopicJob, Key: "job", Payload: &structs.JobEvent{Job: mock.Job()}},
+	}})
+	publisher.Publish(&structs.Events{Index: uint64(2), Events: []structs.Event{
+		{Topic: structs.TopicNode, Key: ready.ID, Payload: &structs.NodeStreamEvent{Node: ready}},
+		{Topic: structs.TopicNode, Key: down.ID, Payload: &structs.NodeStreamEvent{Node: down}},
+	}})
+
+	timeout := time.After(5 * time.Second)
+OUTER:
+	for {
+		select {
+		case <-timeout:
+			t.Fatal("timeout waiting for event stream")
+		case err := <-errCh:
+			t.Fatal(err)
+		case msg := <-streamMsg:
+			must.Nil(t, msg.Error)
+
+			// ignore heartbeat
+			if bytes.Equal(msg.Event.Data, stream.JsonHeartbeat.Data) {
+				continue
+			}
+
+			var event structs.Events
+			must.NoError(t, json.Unmarshal(msg.Event.Data, &event))
+			must.Eq(t, 2, event.Index)
+			must.Len(t, 1, event.Events)
+			must.Eq(t, down.ID, event.Events[0].Key)
+			break OUTER
+		}
+	}
+}
+
+// TestEventStream_Filter_Invalid asserts an error is returned for a filter
+// expression that can't be parsed.
+func TestEventStream_Filter_Invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.EnableEventBroker = true
+	})
+	defer cleanupS1()
+
+	testutil.WaitForLeader(t, s1.RPC)
+
+	req := structs.EventStreamRequest{
+		Topics: map[structs.Topic][]string{"*": {"*"}},
+		QueryOptions: structs.QueryOptions{
+			Region: s1.Region(),
+			Filter: `Payload.Node.Status ==`,
+		},
+	}
+
+	handler, err := s1.StreamingRpcHandler("Event.Stream")
+	must.NoError(t, err)
+
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+	defer p2.Close()
+
+	go handler(p2)
+
+	encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+	must.NoError(t, encoder.Encode(req))
+
+	var msg structs.EventStreamWrapper
+	decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+	must.NoError(t, decoder.Decode(&msg))
+	must.NotNil(t, msg.Error)
+	must.StrContains(t, msg.Error.Error(), "failed to read filter expression")
+	must.Eq(t, int64(400), *msg.Error.Code)
+}
+
 // TestEventStream_RegionForward tests event streaming from one server
 // to another in a different region
 func TestEventStream_RegionForward(t *testing.T) {
@@ -469,6 +600,27 @@ func TestEventStream_validateNsOp(t *testing.T) {
 			Management:  false,
 			ExpectedErr: structs.ErrPermissionDenied,
 		},
+		{
+			Name: "read variables - correct policy and ns",
+			Topics: map[structs.Topic][]string{
+				structs.TopicVariables: {"app/"},
+			},
+			Policy: mock.NamespacePolicyWithVariables("foo", "", nil,
+				map[string][]string{"app/*": {acl.VariablesCapabilityList}}),
+			Namespace:   "foo",
+			Management:  false,
+			ExpectedErr: nil,
+		},
+		{
+			Name: "read variables - incorrect policy",
+			Topics: map[structs.Topic][]string{
+				structs.TopicVariables: {"*"},
+			},
+			Policy:      mock.NamespacePolicy("foo", "", []string{acl.NamespaceCapabilityReadJob}),
+			Namespace:   "foo",
+			Management:  false,
+			ExpectedErr: structs.ErrPermissionDenied,
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,257 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/eventsink"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// EventSink endpoint is used for manipulating durable event sinks and
+// querying their delivery status.
+type EventSink struct {
+	srv *Server
+	ctx *RPCContext
+}
+
+func NewEventSinkEndpoint(srv *Server, ctx *RPCContext) *EventSink {
+	return &EventSink{srv: srv, ctx: ctx}
+}
+
+// Register is used to create or update an event sink. Sinks receive events
+// of every namespace and topic they are configured with, so registering them
+// requires a management token.
+func (e *EventSink) Register(args *structs.EventSinkRegisterRequest, reply *structs.GenericResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("EventSink.Register", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event_sink", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event_sink", "register"}, time.Now())
+
+	if aclObj, err := e.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !e.srv.peersCache.ServersMeetMinimumVersion(e.srv.Region(), minVersionEventSinks, true) {
+		return fmt.Errorf("all servers must be running version %v or later to register event sinks", minVersionEventSinks)
+	}
+
+	if args.Sink == nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "missing event sink")
+	}
+	args.Sink.Canonicalize()
+	if err := args.Sink.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid event sink %q: %v", args.Sink.ID, err)
+	}
+
+	// File sinks can only write within the directory set for them in the
+	// server configuration
+	if args.Sink.Type == structs.EventSinkFile {
+		if _, err := eventsink.CheckFilePath(e.srv.config.EventSinkFileDir, args.Sink.Path); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid event sink %q: %v", args.Sink.ID, err)
+		}
+	}
+
+	_, index, err := e.srv.raftApply(structs.EventSinkRegisterRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// Deregister is used to delete a set of event sinks.
+func (e *EventSink) Deregister(args *structs.EventSinkDeregisterRequest, reply *structs.GenericResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("EventSink.Deregister", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event_sink", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event_sink", "deregister"}, time.Now())
+
+	if aclObj, err := e.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !e.srv.peersCache.ServersMeetMinimumVersion(e.srv.Region(), minVersionEventSinks, true) {
+		return fmt.Errorf("all servers must be running version %v or later to deregister event sinks", minVersionEventSinks)
+	}
+
+	if len(args.IDs) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one event sink to deregister")
+	}
+
+	_, index, err := e.srv.raftApply(structs.EventSinkDeregisterRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// List is used to list the event sinks.
+func (e *EventSink) List(args *structs.EventSinkListRequest, reply *structs.EventSinkListResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("EventSink.List", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event_sink", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event_sink", "list"}, time.Now())
+
+	if aclObj, err := e.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = store.EventSinksByIDPrefix(ws, prefix)
+			} else {
+				iter, err = store.EventSinks(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.Sinks = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				reply.Sinks = append(reply.Sinks, raw.(*structs.EventSink))
+			}
+
+			// Use the last index that affected the event sinks table.
+			index, err := store.Index(state.TableEventSinks)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+			return nil
+		}}
+	return e.srv.blockingRPC(&opts)
+}
+
+// GetSink is used to get a specific event sink.
+func (e *EventSink) GetSink(args *structs.EventSinkSpecificRequest, reply *structs.EventSinkResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("EventSink.GetSink", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event_sink", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event_sink", "get_sink"}, time.Now())
+
+	if aclObj, err := e.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			sink, err := store.EventSinkByID(ws, args.ID)
+			if err != nil {
+				return err
+			}
+
+			reply.Sink = sink
+			if sink != nil {
+				reply.Index = sink.ModifyIndex
+			} else {
+				// Return the last index that affected the event sinks table
+				// if the requested sink doesn't exist.
+				index, err := store.Index(state.TableEventSinks)
+				if err != nil {
+					return err
+				}
+				reply.Index = max(1, index)
+			}
+			return nil
+		}}
+	return e.srv.blockingRPC(&opts)
+}
+
+// Status is used to get the delivery status of an event sink, or of all
+// sinks if no ID is given. The delivery is run by the leader, so the request
+// is always served by the leader.
+func (e *EventSink) Status(args *structs.EventSinkStatusRequest, reply *structs.EventSinkStatusResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	args.AllowStale = false
+	if done, err := e.srv.forward("EventSink.Status", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event_sink", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event_sink", "status"}, time.Now())
+
+	if aclObj, err := e.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	store := e.srv.fsm.State()
+	latest, err := store.LatestIndex()
+	if err != nil {
+		return err
+	}
+
+	var sinks []*structs.EventSink
+	if args.ID != "" {
+		sink, err := store.EventSinkByID(nil, args.ID)
+		if err != nil {
+			return err
+		}
+		if sink != nil {
+			sinks = append(sinks, sink)
+		}
+	} else {
+		iter, err := store.EventSinks(nil)
+		if err != nil {
+			return err
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			sinks = append(sinks, raw.(*structs.EventSink))
+		}
+	}
+
+	reply.Statuses = make([]*structs.EventSinkStatus, 0, len(sinks))
+	for _, sink := range sinks {
+		reply.Statuses = append(reply.Statuses, e.srv.eventSinkManager.Status(sink, latest))
+	}
+	reply.Index = latest
+	e.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
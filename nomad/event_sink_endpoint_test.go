@@ -0,0 +1,226 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"path/filepath"
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestEventSinkEndpoint_CRUD(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// Register a sink, which is canonicalized by the server.
+	sink := &structs.EventSink{
+		ID:      "audit",
+		Type:    structs.EventSinkWebhook,
+		Address: "http://127.0.0.1:1/events",
+	}
+	regReq := &structs.EventSinkRegisterRequest{
+		Sink:         sink,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var regResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.Register", regReq, &regResp))
+	must.NonZero(t, regResp.Index)
+
+	getReq := &structs.EventSinkSpecificRequest{
+		ID:           sink.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.EventSinkResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.GetSink", getReq, &getResp))
+	must.NotNil(t, getResp.Sink)
+	must.Eq(t, structs.AllNamespacesSentinel, getResp.Sink.Namespace)
+	must.Eq(t, map[structs.Topic][]string{structs.TopicAll: {"*"}}, getResp.Sink.Topics)
+	must.Eq(t, regResp.Index, getResp.Sink.LatestIndex)
+
+	listReq := &structs.EventSinkListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.EventSinkListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.List", listReq, &listResp))
+	must.Len(t, 1, listResp.Sinks)
+	must.Eq(t, regResp.Index, listResp.Index)
+
+	// The status is reported by the leader.
+	statusReq := &structs.EventSinkStatusRequest{
+		ID:           sink.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var statusResp structs.EventSinkStatusResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.Status", statusReq, &statusResp))
+	must.Len(t, 1, statusResp.Statuses)
+	must.Eq(t, sink.ID, statusResp.Statuses[0].ID)
+	must.Eq(t, structs.EventSinkWebhook, statusResp.Statuses[0].Type)
+
+	// Invalid sinks are rejected.
+	invalid := sink.Copy()
+	invalid.Address = "audit.example.com/events"
+	regReq.Sink = invalid
+	err := msgpackrpc.CallWithCodec(codec, "EventSink.Register", regReq, &regResp)
+	must.ErrorContains(t, err, "address must be an http or https URL")
+
+	delReq := &structs.EventSinkDeregisterRequest{
+		IDs:          []string{sink.ID},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var delResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.Deregister", delReq, &delResp))
+
+	getResp = structs.EventSinkResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.GetSink", getReq, &getResp))
+	must.Nil(t, getResp.Sink)
+	must.Eq(t, delResp.Index, getResp.Index)
+}
+
+func TestEventSinkEndpoint_Register_FileSink(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	register := func(t *testing.T, s *Server, path string) error {
+		codec := rpcClient(t, s)
+		req := &structs.EventSinkRegisterRequest{
+			Sink: &structs.EventSink{
+				ID:   "audit",
+				Type: structs.EventSinkFile,
+				Path: path,
+			},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.GenericResponse
+		return msgpackrpc.CallWithCodec(codec, "EventSink.Register", req, &resp)
+	}
+
+	// File sinks are disabled by default.
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	err := register(t, s1, filepath.Join(dir, "sink.ndjson"))
+	must.ErrorContains(t, err, "file sinks are disabled")
+
+	// Only files within the configured directory are accepted.
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.EventSinkFileDir = dir
+	})
+	defer cleanupS2()
+	testutil.WaitForLeader(t, s2.RPC)
+
+	must.NoError(t, register(t, s2, filepath.Join(dir, "audit", "sink.ndjson")))
+
+	err = register(t, s2, dir+"/../sink.ndjson")
+	must.ErrorContains(t, err, "is not within the file sink directory")
+
+	err = register(t, s2, filepath.Join(t.TempDir(), "sink.ndjson"))
+	must.ErrorContains(t, err, "is not within the file sink directory")
+}
+
+func TestEventSinkEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	sink := mock.EventSink()
+	must.NoError(t, s.fsm.State().UpsertEventSink(structs.MsgTypeTestSetup, 1000, sink))
+
+	operatorToken := mock.CreatePolicyAndToken(t, s.fsm.State(), 1001, "operator-read",
+		`operator { policy = "read" }`)
+	noPolicyToken := mock.CreateToken(t, s.fsm.State(), 1003, nil)
+
+	testCases := []struct {
+		name     string
+		token    string
+		readErr  bool
+		writeErr bool
+	}{
+		{
+			name:  "management token",
+			token: root.SecretID,
+		},
+		{
+			name:     "operator read token",
+			token:    operatorToken.SecretID,
+			writeErr: true,
+		},
+		{
+			name:     "no policy token",
+			token:    noPolicyToken.SecretID,
+			readErr:  true,
+			writeErr: true,
+		},
+		{
+			name:     "no token",
+			readErr:  true,
+			writeErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			listReq := &structs.EventSinkListRequest{
+				QueryOptions: structs.QueryOptions{
+					Region:    "global",
+					AuthToken: tc.token,
+				},
+			}
+			var listResp structs.EventSinkListResponse
+			err := msgpackrpc.CallWithCodec(codec, "EventSink.List", listReq, &listResp)
+			if tc.readErr {
+				must.EqError(t, err, structs.ErrPermissionDenied.Error())
+			} else {
+				must.NoError(t, err)
+				must.Len(t, 1, listResp.Sinks)
+			}
+
+			statusReq := &structs.EventSinkStatusRequest{
+				ID: sink.ID,
+				QueryOptions: structs.QueryOptions{
+					Region:    "global",
+					AuthToken: tc.token,
+				},
+			}
+			var statusResp structs.EventSinkStatusResponse
+			err = msgpackrpc.CallWithCodec(codec, "EventSink.Status", statusReq, &statusResp)
+			if tc.readErr {
+				must.EqError(t, err, structs.ErrPermissionDenied.Error())
+			} else {
+				must.NoError(t, err)
+				must.Len(t, 1, statusResp.Statuses)
+			}
+
+			regReq := &structs.EventSinkRegisterRequest{
+				Sink: sink.Copy(),
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					AuthToken: tc.token,
+				},
+			}
+			var regResp structs.GenericResponse
+			err = msgpackrpc.CallWithCodec(codec, "EventSink.Register", regReq, &regResp)
+			if tc.writeErr {
+				must.EqError(t, err, structs.ErrPermissionDenied.Error())
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package eventsink
+
+// RaftApplier contains methods for applying the delivery progress of sinks
+// via raft. It avoids a circular reference between the nomad package and the
+// eventsink package.
+type RaftApplier interface {
+	// CheckpointEventSinks records the index of the last event delivered to
+	// each sink, keyed by sink ID.
+	CheckpointEventSinks(indexes map[string]uint64) (uint64, error)
+}
@@ -0,0 +1,538 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-bexpr"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// DefaultCheckpointInterval is how often the delivery progress of the
+	// sinks is checkpointed in raft. It bounds how many events may be
+	// delivered again after a leader election.
+	DefaultCheckpointInterval = 5 * time.Second
+)
+
+var (
+	// retryBase and retryLimit bound the backoff between failed deliveries
+	// to a sink.
+	retryBase  = time.Second
+	retryLimit = time.Minute
+)
+
+// ManagerConfig is used to configure the event sink manager.
+type ManagerConfig struct {
+	Logger log.Logger
+
+	// Raft is used to checkpoint the delivery progress of the sinks.
+	Raft RaftApplier
+
+	// State returns the current state store. It is called again whenever the
+	// state store may have been replaced by a snapshot restore.
+	State func() *state.StateStore
+
+	// CheckpointInterval is how often the delivery progress is checkpointed.
+	CheckpointInterval time.Duration
+
+	// FileDir is the directory file sinks can write to. File sinks are
+	// disabled if it's empty.
+	FileDir string
+}
+
+// Manager delivers the events of the event broker to the event sinks in the
+// state store. Each sink is delivered to in index order by its own worker,
+// which only advances once a batch of events was accepted by the sink, so
+// events are delivered at least once as long as the event buffer still holds
+// them; events evicted before they were delivered are reported as missed in
+// the status of the sink. The manager must only be enabled on the leader.
+type Manager struct {
+	logger             log.Logger
+	raft               RaftApplier
+	state              func() *state.StateStore
+	checkpointInterval time.Duration
+	fileDir            string
+
+	// l protects the fields below.
+	l       sync.Mutex
+	enabled bool
+	workers map[string]*worker
+
+	// exitFn cancels the goroutines of the manager and its workers.
+	exitFn context.CancelFunc
+}
+
+// NewManager returns an event sink manager, which is disabled until
+// SetEnabled is called.
+func NewManager(c *ManagerConfig) *Manager {
+	interval := c.CheckpointInterval
+	if interval == 0 {
+		interval = DefaultCheckpointInterval
+	}
+	return &Manager{
+		logger:             c.Logger.Named("event_sinks"),
+		raft:               c.Raft,
+		state:              c.State,
+		checkpointInterval: interval,
+		fileDir:            c.FileDir,
+		workers:            make(map[string]*worker),
+	}
+}
+
+// SetEnabled is used to control if the manager delivers events. It should
+// only be enabled on the active leader, which resumes the delivery to each
+// sink after the last checkpointed index.
+func (m *Manager) SetEnabled(enabled bool) {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	wasEnabled := m.enabled
+	m.enabled = enabled
+
+	switch {
+	case enabled && !wasEnabled:
+		var ctx context.Context
+		ctx, m.exitFn = context.WithCancel(context.Background())
+		go m.watchSinks(ctx)
+		go m.checkpointLoop(ctx)
+
+	case !enabled && wasEnabled:
+		m.exitFn()
+		for id, w := range m.workers {
+			w.stop()
+			delete(m.workers, id)
+		}
+	}
+}
+
+// Status returns the delivery status of the given sink. The latest index is
+// the index the lag of the sink is measured against.
+func (m *Manager) Status(sink *structs.EventSink, latestIndex uint64) *structs.EventSinkStatus {
+	status := &structs.EventSinkStatus{
+		ID:              sink.ID,
+		Type:            sink.Type,
+		Status:          structs.EventSinkStatusPending,
+		DeliveredIndex:  sink.LatestIndex,
+		CheckpointIndex: sink.LatestIndex,
+		LatestIndex:     latestIndex,
+	}
+
+	m.l.Lock()
+	w := m.workers[sink.ID]
+	m.l.Unlock()
+
+	idle := false
+	if w != nil {
+		idle = w.status(status)
+	}
+	if !idle && latestIndex > status.DeliveredIndex {
+		status.Lag = latestIndex - status.DeliveredIndex
+	}
+	return status
+}
+
+// watchSinks is the long lived goroutine that starts, restarts and stops the
+// workers as sinks are registered, updated and deregistered.
+func (m *Manager) watchSinks(ctx context.Context) {
+	for {
+		store := m.state()
+		ws := memdb.NewWatchSet()
+		ws.Add(store.AbandonCh())
+
+		iter, err := store.EventSinks(ws)
+		if err != nil {
+			m.logger.Error("failed to retrieve event sinks", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryBase):
+				continue
+			}
+		}
+
+		var sinks []*structs.EventSink
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			sinks = append(sinks, raw.(*structs.EventSink))
+		}
+		m.sync(ctx, sinks)
+
+		if err := ws.WatchCtx(ctx); err != nil {
+			return
+		}
+	}
+}
+
+// sync reconciles the running workers with the given sinks.
+func (m *Manager) sync(ctx context.Context, sinks []*structs.EventSink) {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	// The manager may have been disabled while the sinks were read.
+	if ctx.Err() != nil {
+		return
+	}
+
+	seen := make(map[string]struct{}, len(sinks))
+	for _, sink := range sinks {
+		seen[sink.ID] = struct{}{}
+
+		existing, ok := m.workers[sink.ID]
+		if ok && existing.sink.SameConfig(sink) {
+			continue
+		}
+
+		// A sink whose configuration changed continues after the events
+		// already delivered, even if they aren't checkpointed yet.
+		delivered := sink.LatestIndex
+		if ok {
+			existing.stop()
+			delivered = max(delivered, existing.deliveredIndex())
+		}
+
+		w := newWorker(ctx, m.logger, m.state, m.fileDir, sink.Copy(), delivered)
+		m.workers[sink.ID] = w
+		go w.run()
+	}
+
+	for id, w := range m.workers {
+		if _, ok := seen[id]; !ok {
+			w.stop()
+			delete(m.workers, id)
+		}
+	}
+}
+
+// checkpointLoop periodically checkpoints the delivery progress of the
+// sinks.
+func (m *Manager) checkpointLoop(ctx context.Context) {
+	ticker := time.NewTicker(m.checkpointInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkpoint()
+		}
+	}
+}
+
+// checkpoint writes the index of the last event delivered to each sink to
+// raft, if it moved since the last checkpoint.
+func (m *Manager) checkpoint() {
+	m.l.Lock()
+	indexes := make(map[string]uint64)
+	for id, w := range m.workers {
+		if index, ok := w.uncheckpointedIndex(); ok {
+			indexes[id] = index
+		}
+	}
+	m.l.Unlock()
+
+	if len(indexes) == 0 {
+		return
+	}
+
+	if _, err := m.raft.CheckpointEventSinks(indexes); err != nil {
+		m.logger.Error("failed to checkpoint event sinks", "error", err)
+		return
+	}
+
+	m.l.Lock()
+	defer m.l.Unlock()
+	for id, index := range indexes {
+		if w, ok := m.workers[id]; ok {
+			w.setCheckpointed(index)
+		}
+	}
+}
+
+// worker delivers the events of a single sink.
+type worker struct {
+	sink    *structs.EventSink
+	logger  log.Logger
+	state   func() *state.StateStore
+	fileDir string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+
+	// dest, sub, pending and resumed are only accessed by the run
+	// goroutine. resumed is set while the subscription resumes at the oldest
+	// buffered events, the events after the delivered index having been
+	// evicted.
+	dest    sink
+	sub     *stream.Subscription
+	pending *structs.Events
+	resumed bool
+
+	// l protects the delivery progress below, which is read by the manager.
+	l            sync.Mutex
+	delivered    uint64
+	checkpointed uint64
+	subscribed   bool
+	idle         bool
+	lastErr      string
+	lastDelivery time.Time
+	missedFrom   uint64
+	missedTo     uint64
+}
+
+func newWorker(parent context.Context, logger log.Logger, state func() *state.StateStore,
+	fileDir string, sink *structs.EventSink, delivered uint64) *worker {
+
+	ctx, cancel := context.WithCancel(parent)
+	return &worker{
+		sink:         sink,
+		logger:       logger.With("sink_id", sink.ID, "sink_type", sink.Type),
+		state:        state,
+		fileDir:      fileDir,
+		ctx:          ctx,
+		cancel:       cancel,
+		doneCh:       make(chan struct{}),
+		delivered:    delivered,
+		checkpointed: sink.LatestIndex,
+	}
+}
+
+// stop stops the worker and waits for it to release its sink.
+func (w *worker) stop() {
+	w.cancel()
+	<-w.doneCh
+}
+
+// run delivers events until the worker is stopped, retrying failed
+// deliveries with a backoff.
+func (w *worker) run() {
+	defer close(w.doneCh)
+	defer w.close()
+
+	var failures uint64
+	for {
+		err := w.step()
+		if w.ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			failures = 0
+			continue
+		}
+
+		w.logger.Warn("failed to deliver events to sink", "error", err)
+		w.setError(err)
+		failures++
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-time.After(helper.Backoff(retryBase, retryLimit, failures-1)):
+		}
+	}
+}
+
+// step opens the sink if needed, waits for the next batch of events unless a
+// failed batch is pending, and delivers it.
+func (w *worker) step() error {
+	if w.dest == nil {
+		dest, err := newSink(w.sink, w.fileDir)
+		if err != nil {
+			return err
+		}
+		w.dest = dest
+	}
+
+	if w.pending == nil {
+		events, err := w.next()
+		if err != nil {
+			return err
+		}
+		w.pending = events
+	}
+
+	if err := w.dest.Send(w.ctx, w.pending); err != nil {
+		return fmt.Errorf("failed to deliver events at index %d: %w", w.pending.Index, err)
+	}
+
+	w.l.Lock()
+	w.delivered = w.pending.Index
+	w.lastErr = ""
+	w.lastDelivery = time.Now().UTC()
+	w.l.Unlock()
+
+	w.pending = nil
+	return nil
+}
+
+// next returns the next batch of events after the delivered index,
+// subscribing to the event broker if needed.
+func (w *worker) next() (*structs.Events, error) {
+	delivered := w.deliveredIndex()
+
+	if w.sub == nil {
+		broker, err := w.state().EventBroker()
+		if err != nil {
+			return nil, err
+		}
+
+		// Resume exactly at the last delivered batch of events, so no event
+		// after it is skipped. A sink that hasn't delivered any event starts
+		// after its registration, which doesn't publish events.
+		req := &stream.SubscribeRequest{
+			Index:               delivered,
+			StartExactlyAtIndex: true,
+			Topics:              w.sink.Topics,
+			Namespaces:          []string{w.sink.Namespace},
+		}
+		if w.sink.Filter != "" {
+			req.Filter, err = bexpr.CreateEvaluator(w.sink.Filter)
+			if err != nil {
+				return nil, fmt.Errorf("invalid filter: %w", err)
+			}
+		}
+		if delivered == w.sink.CreateIndex {
+			req.Index = delivered + 1
+			req.StartExactlyAtIndex = false
+		}
+		w.resumed = false
+		sub, err := broker.Subscribe(req)
+		if errors.Is(err, stream.ErrIndexNotInBuffer) {
+			// The events after the delivered index were evicted from the
+			// buffer, so resume at the oldest ones and report the gap once
+			// the first of them is received.
+			req.Index = delivered + 1
+			req.StartExactlyAtIndex = false
+			sub, err = broker.Subscribe(req)
+			w.resumed = true
+		}
+		if err != nil {
+			return nil, err
+		}
+		w.sub = sub
+
+		w.l.Lock()
+		w.subscribed = true
+		w.l.Unlock()
+	}
+
+	for {
+		events, err := w.sub.NextNoBlock()
+		if err == nil && len(events) == 0 {
+			w.setIdle(true)
+			var batch structs.Events
+			batch, err = w.sub.Next(w.ctx)
+			events = batch.Events
+			w.setIdle(false)
+		}
+		if err != nil {
+			w.sub.Unsubscribe()
+			w.sub = nil
+			return nil, err
+		}
+
+		// The subscription starts at the last delivered batch of events,
+		// which is skipped.
+		if len(events) == 0 || events[0].Index <= delivered {
+			continue
+		}
+		if w.resumed {
+			w.resumed = false
+			if events[0].Index > delivered+1 {
+				w.setMissed(delivered+1, events[0].Index-1)
+			}
+		}
+		return &structs.Events{Index: events[0].Index, Events: events}, nil
+	}
+}
+
+// close releases the subscription and the sink.
+func (w *worker) close() {
+	if w.sub != nil {
+		w.sub.Unsubscribe()
+	}
+	if w.dest != nil {
+		if err := w.dest.Close(); err != nil {
+			w.logger.Warn("failed to close sink", "error", err)
+		}
+	}
+}
+
+func (w *worker) deliveredIndex() uint64 {
+	w.l.Lock()
+	defer w.l.Unlock()
+	return w.delivered
+}
+
+// uncheckpointedIndex returns the delivered index if it wasn't checkpointed
+// yet.
+func (w *worker) uncheckpointedIndex() (uint64, bool) {
+	w.l.Lock()
+	defer w.l.Unlock()
+	return w.delivered, w.delivered > w.checkpointed
+}
+
+func (w *worker) setCheckpointed(index uint64) {
+	w.l.Lock()
+	defer w.l.Unlock()
+	w.checkpointed = max(w.checkpointed, index)
+}
+
+func (w *worker) setIdle(idle bool) {
+	w.l.Lock()
+	defer w.l.Unlock()
+	w.idle = idle
+}
+
+// setMissed records that the events between the given indexes, inclusive,
+// may not have been delivered.
+func (w *worker) setMissed(from, to uint64) {
+	w.logger.Warn("events were evicted from the event buffer before they were delivered",
+		"from_index", from, "to_index", to)
+
+	w.l.Lock()
+	defer w.l.Unlock()
+	if w.missedTo == 0 {
+		w.missedFrom = from
+	}
+	w.missedTo = to
+}
+
+func (w *worker) setError(err error) {
+	w.l.Lock()
+	defer w.l.Unlock()
+	w.lastErr = err.Error()
+}
+
+// status fills in the delivery progress of the worker and returns whether it
+// is waiting for new events, having delivered all the available ones.
+func (w *worker) status(status *structs.EventSinkStatus) bool {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	status.DeliveredIndex = w.delivered
+	status.CheckpointIndex = w.checkpointed
+	status.LastError = w.lastErr
+	status.LastDelivery = w.lastDelivery
+	status.MissedFromIndex = w.missedFrom
+	status.MissedToIndex = w.missedTo
+	switch {
+	case w.lastErr != "":
+		status.Status = structs.EventSinkStatusFailing
+	case w.subscribed:
+		status.Status = structs.EventSinkStatusRunning
+	}
+	return w.idle
+}
@@ -0,0 +1,353 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package eventsink
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// mockRaft applies checkpoints directly to the state store.
+type mockRaft struct {
+	l     sync.Mutex
+	state *state.StateStore
+	index uint64
+}
+
+func (m *mockRaft) CheckpointEventSinks(indexes map[string]uint64) (uint64, error) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.index++
+	return m.index, m.state.CheckpointEventSinks(structs.MsgTypeTestSetup, m.index, indexes)
+}
+
+func testManager(t *testing.T, store *state.StateStore, raft RaftApplier, fileDir string) *Manager {
+	m := NewManager(&ManagerConfig{
+		Logger:             testlog.HCLogger(t),
+		Raft:               raft,
+		State:              func() *state.StateStore { return store },
+		CheckpointInterval: 10 * time.Millisecond,
+		FileDir:            fileDir,
+	})
+	t.Cleanup(func() { m.SetEnabled(false) })
+	return m
+}
+
+func testStore(t *testing.T) *state.StateStore {
+	store := state.TestStateStoreCfg(t, state.TestStateStorePublisher(t))
+	t.Cleanup(store.StopEventBroker)
+	return store
+}
+
+func upsertJob(t *testing.T, store *state.StateStore, index uint64) {
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.JobRegisterRequestType, index, nil, job))
+}
+
+// readIndexes returns the index of each batch of events in a file sink.
+func readIndexes(t *testing.T, path string) []uint64 {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	must.NoError(t, err)
+	defer f.Close()
+
+	var indexes []uint64
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var events structs.Events
+		must.NoError(t, json.Unmarshal(scanner.Bytes(), &events))
+		indexes = append(indexes, events.Index)
+	}
+	must.NoError(t, scanner.Err())
+	return indexes
+}
+
+func TestManager_FileSink_Failover(t *testing.T) {
+	ci.Parallel(t)
+
+	store := testStore(t)
+	raft := &mockRaft{state: store, index: 1000}
+
+	sink := mock.EventSink()
+	sink.Type = structs.EventSinkFile
+	sink.Address = ""
+	dir := t.TempDir()
+	sink.Path = filepath.Join(dir, "events", "sink.ndjson")
+	must.NoError(t, store.UpsertEventSink(structs.MsgTypeTestSetup, 10, sink))
+
+	m := testManager(t, store, raft, dir)
+	m.SetEnabled(true)
+
+	upsertJob(t, store, 20)
+	upsertJob(t, store, 30)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			stored, err := store.EventSinkByID(nil, sink.ID)
+			must.NoError(t, err)
+			return stored.LatestIndex == 30
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Eq(t, []uint64{20, 30}, readIndexes(t, sink.Path))
+
+	stored, err := store.EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	status := m.Status(stored, 30)
+	must.Eq(t, structs.EventSinkStatusRunning, status.Status)
+	must.Eq(t, 30, status.DeliveredIndex)
+	must.Eq(t, 0, status.Lag)
+
+	// A new leader resumes after the checkpointed index.
+	m.SetEnabled(false)
+	upsertJob(t, store, 40)
+
+	m = testManager(t, store, raft, dir)
+	m.SetEnabled(true)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			return len(readIndexes(t, sink.Path)) == 3
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Eq(t, []uint64{20, 30, 40}, readIndexes(t, sink.Path))
+}
+
+func TestManager_FileSink_MissedEvents(t *testing.T) {
+	ci.Parallel(t)
+
+	// Keep only the last few batches of events in the buffer
+	cfg := state.TestStateStorePublisher(t)
+	cfg.EventBufferSize = 2
+	store := state.TestStateStoreCfg(t, cfg)
+	t.Cleanup(store.StopEventBroker)
+	raft := &mockRaft{state: store, index: 1000}
+
+	sink := mock.EventSink()
+	sink.Type = structs.EventSinkFile
+	sink.Address = ""
+	dir := t.TempDir()
+	sink.Path = filepath.Join(dir, "sink.ndjson")
+	must.NoError(t, store.UpsertEventSink(structs.MsgTypeTestSetup, 10, sink))
+
+	m := testManager(t, store, raft, dir)
+	m.SetEnabled(true)
+	upsertJob(t, store, 20)
+
+	waitCheckpoint := func(index uint64) {
+		t.Helper()
+		must.Wait(t, wait.InitialSuccess(
+			wait.BoolFunc(func() bool {
+				stored, err := store.EventSinkByID(nil, sink.ID)
+				must.NoError(t, err)
+				return stored.LatestIndex == index
+			}),
+			wait.Timeout(5*time.Second),
+			wait.Gap(10*time.Millisecond),
+		))
+	}
+	waitCheckpoint(20)
+
+	// A leader resuming after the buffer still holds the delivered events
+	// doesn't miss any of them
+	m.SetEnabled(false)
+	upsertJob(t, store, 30)
+
+	m = testManager(t, store, raft, dir)
+	m.SetEnabled(true)
+	waitCheckpoint(30)
+	must.Eq(t, []uint64{20, 30}, readIndexes(t, sink.Path))
+
+	stored, err := store.EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	status := m.Status(stored, 30)
+	must.Zero(t, status.MissedFromIndex)
+	must.Zero(t, status.MissedToIndex)
+
+	// Events evicted before the delivery resumes are reported as missed
+	m.SetEnabled(false)
+	for _, index := range []uint64{40, 50, 60, 70} {
+		upsertJob(t, store, index)
+	}
+
+	m = testManager(t, store, raft, dir)
+	m.SetEnabled(true)
+	waitCheckpoint(70)
+	must.Eq(t, []uint64{20, 30, 50, 60, 70}, readIndexes(t, sink.Path))
+
+	stored, err = store.EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	status = m.Status(stored, 70)
+	must.Eq(t, 31, status.MissedFromIndex)
+	must.Eq(t, 49, status.MissedToIndex)
+}
+
+func TestManager_FileSink_Filter(t *testing.T) {
+	ci.Parallel(t)
+
+	store := testStore(t)
+	raft := &mockRaft{state: store, index: 1000}
+
+	sink := mock.EventSink()
+	sink.Type = structs.EventSinkFile
+	sink.Address = ""
+	sink.Filter = `Payload.Job.Meta.team == "payments"`
+	dir := t.TempDir()
+	sink.Path = filepath.Join(dir, "sink.ndjson")
+	must.NoError(t, store.UpsertEventSink(structs.MsgTypeTestSetup, 10, sink))
+
+	m := testManager(t, store, raft, dir)
+	m.SetEnabled(true)
+
+	for i, team := range []string{"payments", "search", "payments"} {
+		job := mock.Job()
+		job.Meta["team"] = team
+		must.NoError(t, store.UpsertJob(structs.JobRegisterRequestType, uint64(20+10*i), nil, job))
+	}
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			stored, err := store.EventSinkByID(nil, sink.ID)
+			must.NoError(t, err)
+			return stored.LatestIndex == 40
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Eq(t, []uint64{20, 40}, readIndexes(t, sink.Path))
+}
+
+func TestManager_FileSink_OutsideDir(t *testing.T) {
+	ci.Parallel(t)
+
+	store := testStore(t)
+
+	sink := mock.EventSink()
+	sink.Type = structs.EventSinkFile
+	sink.Address = ""
+	sink.Path = filepath.Join(t.TempDir(), "sink.ndjson")
+	must.NoError(t, store.UpsertEventSink(structs.MsgTypeTestSetup, 10, sink))
+
+	m := testManager(t, store, &mockRaft{state: store, index: 1000}, t.TempDir())
+	m.SetEnabled(true)
+
+	// The sink is never opened since its file is outside of the directory
+	// file sinks are restricted to.
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			return m.Status(sink, 10).LastError != ""
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.StrContains(t, m.Status(sink, 10).LastError, "is not within the file sink directory")
+	must.FileNotExists(t, sink.Path)
+}
+
+func TestManager_WebhookSink_Retry(t *testing.T) {
+	ci.Parallel(t)
+
+	var l sync.Mutex
+	var requests int
+	var delivered []uint64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.Lock()
+		defer l.Unlock()
+
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		must.NoError(t, err)
+		var events structs.Events
+		must.NoError(t, json.Unmarshal(body, &events))
+		delivered = append(delivered, events.Index)
+	}))
+	t.Cleanup(srv.Close)
+
+	store := testStore(t)
+	raft := &mockRaft{state: store, index: 1000}
+
+	sink := mock.EventSink()
+	sink.Address = srv.URL
+	must.NoError(t, store.UpsertEventSink(structs.MsgTypeTestSetup, 10, sink))
+
+	m := testManager(t, store, raft, "")
+	m.SetEnabled(true)
+
+	upsertJob(t, store, 20)
+
+	// The failed batch is retried until the webhook accepts it.
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			l.Lock()
+			defer l.Unlock()
+			return len(delivered) == 1
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	l.Lock()
+	must.Eq(t, []uint64{20}, delivered)
+	must.Eq(t, 2, requests)
+	l.Unlock()
+
+	status := m.Status(sink, 20)
+	must.Eq(t, structs.EventSinkStatusRunning, status.Status)
+	must.Eq(t, 20, status.DeliveredIndex)
+	must.Eq(t, "", status.LastError)
+	must.False(t, status.LastDelivery.IsZero())
+
+	// Deregistering the sink stops its worker.
+	must.NoError(t, store.DeleteEventSinks(structs.MsgTypeTestSetup, 30, []string{sink.ID}))
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			return m.Status(sink, 30).Status == structs.EventSinkStatusPending
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
+
+func TestManager_Status_Pending(t *testing.T) {
+	ci.Parallel(t)
+
+	store := testStore(t)
+	m := testManager(t, store, &mockRaft{state: store}, "")
+
+	sink := mock.EventSink()
+	sink.LatestIndex = 10
+
+	status := m.Status(sink, 25)
+	must.Eq(t, structs.EventSinkStatusPending, status.Status)
+	must.Eq(t, 10, status.DeliveredIndex)
+	must.Eq(t, 10, status.CheckpointIndex)
+	must.Eq(t, 15, status.Lag)
+}
@@ -0,0 +1,199 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// webhookTimeout is the time allowed for a webhook to accept a batch of
+// events.
+const webhookTimeout = 10 * time.Second
+
+// sink is the destination events are delivered to. Send must only return
+// once the events are durably accepted by the destination, since the index of
+// the events is then checkpointed as delivered.
+type sink interface {
+	Send(ctx context.Context, events *structs.Events) error
+	Close() error
+}
+
+// ErrFileSinksDisabled is returned for file sinks when the server doesn't set
+// a directory for them.
+var ErrFileSinksDisabled = errors.New("file sinks are disabled, set event_sink_file_dir in the server configuration to enable them")
+
+// newSink returns the sink for the type of the given configuration. File
+// sinks can only write to files within fileDir.
+func newSink(cfg *structs.EventSink, fileDir string) (sink, error) {
+	switch cfg.Type {
+	case structs.EventSinkWebhook:
+		return &webhookSink{
+			address: cfg.Address,
+			client:  &http.Client{Timeout: webhookTimeout},
+		}, nil
+	case structs.EventSinkFile:
+		path, err := CheckFilePath(fileDir, cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		return newFileSink(path)
+	default:
+		return nil, fmt.Errorf("unsupported event sink type %q", cfg.Type)
+	}
+}
+
+// encodeEvents encodes the events as a line of JSON, in the same form as the
+// frames of the event stream.
+func encodeEvents(events *structs.Events) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := codec.NewEncoder(&buf, structs.JsonHandleWithExtensions)
+	if err := enc.Encode(events); err != nil {
+		return nil, fmt.Errorf("error marshaling json for sink: %w", err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// webhookSink POSTs each batch of events to an HTTP endpoint. Any response
+// other than a 2xx is a failed delivery.
+type webhookSink struct {
+	address string
+	client  *http.Client
+}
+
+func (w *webhookSink) Send(ctx context.Context, events *structs.Events) error {
+	body, err := encodeEvents(events)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.address, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+func (w *webhookSink) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
+
+// fileSink appends each batch of events as a line of JSON to a file, which
+// is synced before the delivery is acknowledged.
+type fileSink struct {
+	f *os.File
+}
+
+// CheckFilePath returns the path a file sink with the given path writes to,
+// once cleaned and with its symlinks resolved. It returns an error if the
+// file is not within dir, the directory file sinks are restricted to, or if
+// dir is empty.
+func CheckFilePath(dir, path string) (string, error) {
+	if dir == "" {
+		return "", ErrFileSinksDisabled
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("path %q must be absolute", path)
+	}
+
+	resolvedDir, err := filepath.EvalSymlinks(filepath.Clean(dir))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve file sink directory: %w", err)
+	}
+	resolved, err := resolvePath(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %q: %w", path, err)
+	}
+
+	if resolved == resolvedDir || escapingfs.PathEscapesSandbox(resolvedDir, resolved) {
+		return "", fmt.Errorf("path %q is not within the file sink directory %q", path, dir)
+	}
+	return resolved, nil
+}
+
+// resolvePath returns the path with the symlinks of its existing components
+// resolved. Missing components are kept as is, since the file sink creates
+// them, but dangling symlinks are rejected since creating the file would
+// follow them.
+func resolvePath(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		return resolved, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	if _, err := os.Lstat(path); err == nil {
+		return "", fmt.Errorf("%q is a dangling symlink", path)
+	}
+
+	parent := filepath.Dir(path)
+	if parent == path {
+		return path, nil
+	}
+	resolvedParent, err := resolvePath(parent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolvedParent, filepath.Base(path)), nil
+}
+
+func newFileSink(path string) (*fileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create directory for sink: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sink file: %w", err)
+	}
+	return &fileSink{f: f}, nil
+}
+
+func (s *fileSink) Send(_ context.Context, events *structs.Events) error {
+	line, err := encodeEvents(events)
+	if err != nil {
+		return err
+	}
+	info, err := s.f.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := s.f.Write(line); err != nil {
+		// Drop any partially written line so the retry leaves the file
+		// readable.
+		_ = s.f.Truncate(info.Size())
+		return err
+	}
+	return s.f.Sync()
+}
+
+func (s *fileSink) Close() error {
+	return s.f.Close()
+}
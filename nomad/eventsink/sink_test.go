@@ -0,0 +1,134 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package eventsink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestCheckFilePath(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "sinks")
+	outside := filepath.Join(root, "outside")
+	must.NoError(t, os.Mkdir(dir, 0o700))
+	must.NoError(t, os.Mkdir(outside, 0o700))
+
+	// Links within the directory that resolve outside of it.
+	must.NoError(t, os.Symlink(outside, filepath.Join(dir, "linkdir")))
+	must.NoError(t, os.Symlink(filepath.Join(outside, "target.ndjson"), filepath.Join(dir, "dangling.ndjson")))
+	must.NoError(t, os.WriteFile(filepath.Join(outside, "existing.ndjson"), nil, 0o600))
+	must.NoError(t, os.Symlink(filepath.Join(outside, "existing.ndjson"), filepath.Join(dir, "link.ndjson")))
+
+	// A link to the directory can be used as the directory itself.
+	linkToDir := filepath.Join(root, "linktodir")
+	must.NoError(t, os.Symlink(dir, linkToDir))
+
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	must.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		dir      string
+		path     string
+		expected string
+		err      string
+	}{
+		{
+			name: "disabled",
+			path: filepath.Join(dir, "sink.ndjson"),
+			err:  "file sinks are disabled",
+		},
+		{
+			name: "relative",
+			dir:  dir,
+			path: "sink.ndjson",
+			err:  "must be absolute",
+		},
+		{
+			name:     "within directory",
+			dir:      dir,
+			path:     filepath.Join(dir, "events", "sink.ndjson"),
+			expected: filepath.Join(resolvedDir, "events", "sink.ndjson"),
+		},
+		{
+			name:     "cleaned within directory",
+			dir:      dir,
+			path:     dir + "/events/../sink.ndjson",
+			expected: filepath.Join(resolvedDir, "sink.ndjson"),
+		},
+		{
+			name:     "directory symlink",
+			dir:      linkToDir,
+			path:     filepath.Join(linkToDir, "sink.ndjson"),
+			expected: filepath.Join(resolvedDir, "sink.ndjson"),
+		},
+		{
+			name: "directory itself",
+			dir:  dir,
+			path: dir,
+			err:  "is not within the file sink directory",
+		},
+		{
+			name: "traversal",
+			dir:  dir,
+			path: dir + "/../outside/sink.ndjson",
+			err:  "is not within the file sink directory",
+		},
+		{
+			name: "outside directory",
+			dir:  dir,
+			path: filepath.Join(outside, "sink.ndjson"),
+			err:  "is not within the file sink directory",
+		},
+		{
+			name: "prefix of directory",
+			dir:  dir,
+			path: dir + "-other/sink.ndjson",
+			err:  "is not within the file sink directory",
+		},
+		{
+			name: "symlinked file",
+			dir:  dir,
+			path: filepath.Join(dir, "link.ndjson"),
+			err:  "is not within the file sink directory",
+		},
+		{
+			name: "symlinked parent",
+			dir:  dir,
+			path: filepath.Join(dir, "linkdir", "new", "sink.ndjson"),
+			err:  "is not within the file sink directory",
+		},
+		{
+			name: "dangling symlink",
+			dir:  dir,
+			path: filepath.Join(dir, "dangling.ndjson"),
+			err:  "dangling symlink",
+		},
+		{
+			name: "missing directory",
+			dir:  filepath.Join(root, "missing"),
+			path: filepath.Join(root, "missing", "sink.ndjson"),
+			err:  "failed to resolve file sink directory",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := CheckFilePath(tc.dir, tc.path)
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expected, path)
+		})
+	}
+}
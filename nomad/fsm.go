@@ -59,6 +59,15 @@ const (
 	JobSubmissionSnapshot                SnapshotType = 29
 	RootKeySnapshot                      SnapshotType = 30
 	HostVolumeSnapshot                   SnapshotType = 31
+	QuotaSpecSnapshot                    SnapshotType = 32
+	QuotaUsageSnapshot                   SnapshotType = 33
+	ReservationSnapshot                  SnapshotType = 34
+	VariablesHistorySnapshot             SnapshotType = 35
+
+	// EventSinksSnapshot holds the durable event sinks. It doesn't reuse
+	// EventSinkSnapshot, which belonged to the event sinks of the 1.0-beta
+	// series.
+	EventSinksSnapshot SnapshotType = 36
 
 	// TimeTableSnapshot
 	// Deprecated: Nomad no longer supports TimeTable snapshots since 1.9.2
@@ -110,6 +119,11 @@ var snapshotTypeStrings = map[SnapshotType]string{
 	JobSubmissionSnapshot:                "JobSubmission",
 	RootKeySnapshot:                      "WrappedRootKeys",
 	HostVolumeSnapshot:                   "HostVolumeSnapshot",
+	QuotaSpecSnapshot:                    "QuotaSpec",
+	QuotaUsageSnapshot:                   "QuotaUsage",
+	ReservationSnapshot:                  "Reservation",
+	VariablesHistorySnapshot:             "VariablesHistory",
+	EventSinksSnapshot:                   "EventSinks",
 	NamespaceSnapshot:                    "Namespace",
 }
 
@@ -397,6 +411,28 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyHostVolumeDelete(msgType, buf[1:], log.Index)
 	case structs.TaskGroupHostVolumeClaimDeleteRequestType:
 		return n.applyTaskGroupHostVolumeClaimDelete(buf[1:], log.Index)
+	case structs.QuotaSpecUpsertRequestType:
+		return n.applyQuotaSpecUpsert(msgType, buf[1:], log.Index)
+	case structs.QuotaSpecDeleteRequestType:
+		return n.applyQuotaSpecDelete(msgType, buf[1:], log.Index)
+	case structs.NodeUpdateTaintsRequestType:
+		return n.applyNodeTaintsUpdate(msgType, buf[1:], log.Index)
+	case structs.NodeUpdateUtilizationRequestType:
+		return n.applyNodeUtilizationUpdate(msgType, buf[1:], log.Index)
+	case structs.ReservationUpsertRequestType:
+		return n.applyReservationUpsert(msgType, buf[1:], log.Index)
+	case structs.ReservationDeleteRequestType:
+		return n.applyReservationDelete(msgType, buf[1:], log.Index)
+	case structs.VarHistoryPurgeRequestType:
+		return n.applyVariablesHistoryPurge(msgType, buf[1:], log.Index)
+	case structs.VarExpireRequestType:
+		return n.applyVariablesExpire(msgType, buf[1:], log.Index)
+	case structs.EventSinkRegisterRequestType:
+		return n.applyEventSinkRegister(msgType, buf[1:], log.Index)
+	case structs.EventSinkDeregisterRequestType:
+		return n.applyEventSinkDeregister(msgType, buf[1:], log.Index)
+	case structs.EventSinkCheckpointRequestType:
+		return n.applyEventSinkCheckpoint(msgType, buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -624,6 +660,62 @@ func (n *nomadFSM) applyNodeEligibilityUpdate(msgType structs.MessageType, buf [
 	return nil
 }
 
+func (n *nomadFSM) applyNodeTaintsUpdate(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "node_taints_update"}, time.Now())
+	var req structs.NodeUpdateTaintsRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateNodeTaints(msgType, index, req.NodeID, req.Taints, req.UpdatedAt, req.NodeEvent); err != nil {
+		n.logger.Error("UpdateNodeTaints failed", "error", err)
+		return err
+	}
+
+	// Unblock evals for the new computed node class of the node, since
+	// removing taints may allow blocked allocations to be placed on it.
+	node, err := n.state.NodeByID(nil, req.NodeID)
+	if err != nil {
+		n.logger.Error("UpdateNodeTaints failed to lookup node", "node_id", req.NodeID, "error", err)
+		return err
+	}
+	if node != nil && node.Status == structs.NodeStatusReady {
+		n.blockedEvals.Unblock(node.ComputedClass, index)
+		n.blockedEvals.UnblockNode(req.NodeID)
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyNodeUtilizationUpdate(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "node_utilization_update"}, time.Now())
+	var req structs.NodeUpdateUtilizationRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	node, err := n.state.NodeByID(nil, req.NodeID)
+	if err != nil {
+		n.logger.Error("UpdateNodeUtilization failed to lookup node", "node_id", req.NodeID, "error", err)
+		return err
+	}
+
+	if err := n.state.UpdateNodeUtilization(msgType, index, req.NodeID, req.Utilization); err != nil {
+		n.logger.Error("UpdateNodeUtilization failed", "error", err)
+		return err
+	}
+
+	// Unblock evals for the computed node class of the node when its
+	// pressure decreases, since best-effort allocations may now be placed on
+	// it under the load-aware scheduler algorithm.
+	if node != nil && node.Status == structs.NodeStatusReady && node.Utilization != nil &&
+		req.Utilization != nil && req.Utilization.MaxPressure() < node.Utilization.MaxPressure() {
+		n.blockedEvals.Unblock(node.ComputedClass, index)
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) applyNodePoolUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_node_pool_upsert"}, time.Now())
 	var req structs.NodePoolUpsertRequest
@@ -1155,6 +1247,25 @@ func (n *nomadFSM) applyPlanResults(msgType structs.MessageType, buf []byte, ind
 		return err
 	}
 
+	// Stopped and preempted allocations release their quota usage, so unblock
+	// any evals waiting on the associated quotas.
+	quotas := make(map[string]struct{})
+	for _, diffs := range [][]*structs.AllocationDiff{req.AllocsStopped, req.AllocsPreempted} {
+		for _, diff := range diffs {
+			quota, err := n.allocQuota(diff.ID)
+			if err != nil {
+				n.logger.Error("looking up quota associated with alloc failed", "alloc_id", diff.ID, "error", err)
+				return err
+			}
+			if quota != "" {
+				quotas[quota] = struct{}{}
+			}
+		}
+	}
+	for quota := range quotas {
+		n.blockedEvals.UnblockQuota(quota, index)
+	}
+
 	// Add evals for jobs that were preempted
 	n.handleUpsertedEvals(req.PreemptionEvals)
 	return nil
@@ -1549,6 +1660,168 @@ func (n *nomadFSM) applyNamespaceDelete(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyQuotaSpecUpsert is used to upsert a set of quota specifications
+func (n *nomadFSM) applyQuotaSpecUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_quota_spec_upsert"}, time.Now())
+	var req structs.QuotaSpecUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertQuotaSpecs(msgType, index, req.Quotas); err != nil {
+		n.logger.Error("UpsertQuotaSpecs failed", "error", err)
+		return err
+	}
+
+	// The limits may have been raised so unblock any evals waiting on the
+	// quotas.
+	for _, spec := range req.Quotas {
+		n.blockedEvals.UnblockQuota(spec.Name, index)
+	}
+
+	return nil
+}
+
+// applyQuotaSpecDelete is used to delete a set of quota specifications
+func (n *nomadFSM) applyQuotaSpecDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_quota_spec_delete"}, time.Now())
+	var req structs.QuotaSpecDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteQuotaSpecs(msgType, index, req.Names); err != nil {
+		n.logger.Error("DeleteQuotaSpecs failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyReservationUpsert is used to upsert a set of capacity reservations
+func (n *nomadFSM) applyReservationUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_reservation_upsert"}, time.Now())
+	var req structs.ReservationUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	// Capture the previous placements, which may release capacity when they
+	// change.
+	var previous []*structs.Reservation
+	for _, res := range req.Reservations {
+		existing, err := n.state.ReservationByName(nil, res.Name)
+		if err != nil {
+			n.logger.Error("looking up reservation failed", "reservation", res.Name, "error", err)
+			return err
+		}
+		if existing != nil && !existing.SamePlacement(res) {
+			previous = append(previous, existing)
+		}
+	}
+
+	if err := n.state.UpsertReservations(msgType, index, req.Reservations); err != nil {
+		n.logger.Error("UpsertReservations failed", "error", err)
+		return err
+	}
+
+	n.unblockReservationNodes(previous, index)
+	return nil
+}
+
+// applyReservationDelete is used to delete a set of capacity reservations
+func (n *nomadFSM) applyReservationDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_reservation_delete"}, time.Now())
+	var req structs.ReservationDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	var deleted []*structs.Reservation
+	for _, name := range req.Names {
+		existing, err := n.state.ReservationByName(nil, name)
+		if err != nil {
+			n.logger.Error("looking up reservation failed", "reservation", name, "error", err)
+			return err
+		}
+		if existing != nil {
+			deleted = append(deleted, existing)
+		}
+	}
+
+	if err := n.state.DeleteReservations(msgType, index, req.Names); err != nil {
+		n.logger.Error("DeleteReservations failed", "error", err)
+		return err
+	}
+
+	n.unblockReservationNodes(deleted, index)
+	return nil
+}
+
+// unblockReservationNodes unblocks the evals waiting on the classes of the
+// nodes the reservations held capacity on, since it has been released.
+func (n *nomadFSM) unblockReservationNodes(reservations []*structs.Reservation, index uint64) {
+	classes := make(map[string]struct{})
+	for _, res := range reservations {
+		for _, nodeID := range res.NodeIDs() {
+			node, err := n.state.NodeByID(nil, nodeID)
+			if err != nil || node == nil {
+				continue
+			}
+			classes[node.ComputedClass] = struct{}{}
+		}
+	}
+	for class := range classes {
+		n.blockedEvals.Unblock(class, index)
+	}
+}
+
+// applyEventSinkRegister is used to create or update an event sink
+func (n *nomadFSM) applyEventSinkRegister(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_event_sink_register"}, time.Now())
+	var req structs.EventSinkRegisterRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertEventSink(msgType, index, req.Sink); err != nil {
+		n.logger.Error("UpsertEventSink failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyEventSinkDeregister is used to delete a set of event sinks
+func (n *nomadFSM) applyEventSinkDeregister(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_event_sink_deregister"}, time.Now())
+	var req structs.EventSinkDeregisterRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteEventSinks(msgType, index, req.IDs); err != nil {
+		n.logger.Error("DeleteEventSinks failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyEventSinkCheckpoint is used to checkpoint the delivery progress of
+// event sinks
+func (n *nomadFSM) applyEventSinkCheckpoint(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_event_sink_checkpoint"}, time.Now())
+	var req structs.EventSinkCheckpointRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.CheckpointEventSinks(msgType, index, req.Indexes); err != nil {
+		n.logger.Error("CheckpointEventSinks failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (n *nomadFSM) Snapshot() (raft.FSMSnapshot, error) {
 	// Create a new snapshot
 	snap, err := n.state.Snapshot()
@@ -2002,6 +2275,51 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				}
 			}
 
+		case QuotaSpecSnapshot:
+			spec := new(structs.QuotaSpec)
+			if err := dec.Decode(spec); err != nil {
+				return err
+			}
+			if err := restore.QuotaSpecRestore(spec); err != nil {
+				return err
+			}
+
+		case QuotaUsageSnapshot:
+			usage := new(structs.QuotaUsage)
+			if err := dec.Decode(usage); err != nil {
+				return err
+			}
+			if err := restore.QuotaUsageRestore(usage); err != nil {
+				return err
+			}
+
+		case VariablesHistorySnapshot:
+			version := new(structs.VariableVersion)
+			if err := dec.Decode(version); err != nil {
+				return err
+			}
+			if err := restore.VariableVersionRestore(version); err != nil {
+				return err
+			}
+
+		case EventSinksSnapshot:
+			sink := new(structs.EventSink)
+			if err := dec.Decode(sink); err != nil {
+				return err
+			}
+			if err := restore.EventSinkRestore(sink); err != nil {
+				return err
+			}
+
+		case ReservationSnapshot:
+			res := new(structs.Reservation)
+			if err := dec.Decode(res); err != nil {
+				return err
+			}
+			if err := restore.ReservationRestore(res); err != nil {
+				return err
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -2398,6 +2716,8 @@ func (n *nomadFSM) applyVariableOperation(msgType structs.MessageType, buf []byt
 		return n.state.VarLockAcquire(index, &req)
 	case structs.VarOpLockRelease:
 		return n.state.VarLockRelease(index, &req)
+	case structs.VarOpRekey:
+		return n.state.VarRekey(index, &req)
 	default:
 		err := fmt.Errorf("Invalid variable operation '%s'", req.Op)
 		n.logger.Warn("Invalid variable operation", "operation", req.Op)
@@ -2405,6 +2725,35 @@ func (n *nomadFSM) applyVariableOperation(msgType structs.MessageType, buf []byt
 	}
 }
 
+// applyVariablesHistoryPurge is used to delete prior versions of variables
+func (n *nomadFSM) applyVariablesHistoryPurge(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_variables_history_purge"}, time.Now())
+	var req structs.VariablesPurgeHistoryRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.VarHistoryPurge(msgType, index, req.Versions); err != nil {
+		n.logger.Error("VarHistoryPurge failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (n *nomadFSM) applyVariablesExpire(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_variables_expire"}, time.Now())
+	var req structs.VariablesExpireRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.VarExpire(msgType, index, req.Variables); err != nil {
+		n.logger.Error("VarExpire failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (n *nomadFSM) applyRootKeyMetaUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_root_key_meta_upsert"}, time.Now())
 
@@ -2644,6 +2993,22 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistQuotas(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistReservations(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistVariablesHistory(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistEventSinks(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -3352,6 +3717,83 @@ func (s *nomadSnapshot) persistHostVolumes(sink raft.SnapshotSink, encoder *code
 	return nil
 }
 
+func (s *nomadSnapshot) persistQuotas(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	specs, err := s.snap.QuotaSpecs(nil)
+	if err != nil {
+		return err
+	}
+	for raw := specs.Next(); raw != nil; raw = specs.Next() {
+		spec := raw.(*structs.QuotaSpec)
+
+		sink.Write([]byte{byte(QuotaSpecSnapshot)})
+		if err := encoder.Encode(spec); err != nil {
+			return err
+		}
+	}
+
+	usages, err := s.snap.QuotaUsages(nil)
+	if err != nil {
+		return err
+	}
+	for raw := usages.Next(); raw != nil; raw = usages.Next() {
+		usage := raw.(*structs.QuotaUsage)
+
+		sink.Write([]byte{byte(QuotaUsageSnapshot)})
+		if err := encoder.Encode(usage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistReservations(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	reservations, err := s.snap.Reservations(nil)
+	if err != nil {
+		return err
+	}
+	for raw := reservations.Next(); raw != nil; raw = reservations.Next() {
+		res := raw.(*structs.Reservation)
+
+		sink.Write([]byte{byte(ReservationSnapshot)})
+		if err := encoder.Encode(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistVariablesHistory(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	versions, err := s.snap.VariablesHistory(nil)
+	if err != nil {
+		return err
+	}
+	for raw := versions.Next(); raw != nil; raw = versions.Next() {
+		version := raw.(*structs.VariableVersion)
+
+		sink.Write([]byte{byte(VariablesHistorySnapshot)})
+		if err := encoder.Encode(version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistEventSinks(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	sinks, err := s.snap.EventSinks(nil)
+	if err != nil {
+		return err
+	}
+	for raw := sinks.Next(); raw != nil; raw = sinks.Next() {
+		eventSink := raw.(*structs.EventSink)
+
+		sink.Write([]byte{byte(EventSinksSnapshot)})
+		if err := encoder.Encode(eventSink); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
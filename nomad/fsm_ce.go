@@ -6,10 +6,18 @@
 
 package nomad
 
-// allocQuota returns the quota object associated with the allocation. In
-// anything but Premium this will always be empty
-func (n *nomadFSM) allocQuota(_ string) (string, error) {
-	return "", nil
+// allocQuota returns the quota object associated with the allocation's
+// namespace, or an empty string if there is none.
+func (n *nomadFSM) allocQuota(allocID string) (string, error) {
+	alloc, err := n.state.AllocByID(nil, allocID)
+	if err != nil || alloc == nil {
+		return "", err
+	}
+	ns, err := n.state.NamespaceByName(nil, alloc.Namespace)
+	if err != nil || ns == nil {
+		return "", err
+	}
+	return ns.Quota, nil
 }
 
 // enterpriseSnapshotType is a no-op for community edition.
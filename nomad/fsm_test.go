@@ -3289,6 +3289,92 @@ func TestFSM_SnapshotRestore_Namespaces(t *testing.T) {
 	}
 }
 
+func TestFSM_SnapshotRestore_Quotas(t *testing.T) {
+	ci.Parallel(t)
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	spec := mock.QuotaSpec()
+	must.NoError(t, state.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+	usage, err := state.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out, err := state2.QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, spec, out)
+
+	outUsage, err := state2.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, usage, outUsage)
+}
+
+func TestFSM_UpsertQuotaSpecs(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	spec := mock.QuotaSpec()
+	req := structs.QuotaSpecUpsertRequest{Quotas: []*structs.QuotaSpec{spec}}
+	buf, err := structs.Encode(structs.QuotaSpecUpsertRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+
+	delReq := structs.QuotaSpecDeleteRequest{Names: []string{spec.Name}}
+	buf, err = structs.Encode(structs.QuotaSpecDeleteRequestType, delReq)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err = fsm.State().QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Nil(t, out)
+}
+
+func TestFSM_SnapshotRestore_Reservations(t *testing.T) {
+	ci.Parallel(t)
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	res := mock.Reservation()
+	res.Placements = map[string]*structs.ReservationPlacement{uuid.Generate(): {CPU: 1000, MemoryMB: 1024}}
+	must.NoError(t, state.UpsertReservations(structs.MsgTypeTestSetup, 1000, []*structs.Reservation{res}))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	out, err := fsm2.State().ReservationByName(nil, res.Name)
+	must.NoError(t, err)
+	must.Eq(t, res, out)
+}
+
+func TestFSM_UpsertReservations(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	res := mock.Reservation()
+	req := structs.ReservationUpsertRequest{Reservations: []*structs.Reservation{res}}
+	buf, err := structs.Encode(structs.ReservationUpsertRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().ReservationByName(nil, res.Name)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+
+	delReq := structs.ReservationDeleteRequest{Names: []string{res.Name}}
+	buf, err = structs.Encode(structs.ReservationDeleteRequestType, delReq)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err = fsm.State().ReservationByName(nil, res.Name)
+	must.NoError(t, err)
+	must.Nil(t, out)
+}
+
 func TestFSM_UpsertServiceRegistrations(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
@@ -3876,3 +3962,56 @@ func TestFSM_ApplyJob_IdempotencyToken(t *testing.T) {
 	must.NoError(t, err)
 	must.Eq(t, 1, store.IterCount(iter))
 }
+
+func TestFSM_SnapshotRestore_EventSinks(t *testing.T) {
+	ci.Parallel(t)
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	sink := mock.EventSink()
+	must.NoError(t, state.UpsertEventSink(structs.MsgTypeTestSetup, 1000, sink))
+	must.NoError(t, state.CheckpointEventSinks(structs.MsgTypeTestSetup, 1001,
+		map[string]uint64{sink.ID: 1500}))
+
+	// Verify the contents, including the delivery progress
+	fsm2 := testSnapshotRestore(t, fsm)
+	out, err := fsm2.State().EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, 1500, out.LatestIndex)
+	must.True(t, sink.SameConfig(out))
+}
+
+func TestFSM_EventSinks(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	sink := mock.EventSink()
+	req := structs.EventSinkRegisterRequest{Sink: sink}
+	buf, err := structs.Encode(structs.EventSinkRegisterRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, 1, out.LatestIndex)
+
+	checkpointReq := structs.EventSinkCheckpointRequest{Indexes: map[string]uint64{sink.ID: 50}}
+	buf, err = structs.Encode(structs.EventSinkCheckpointRequestType, checkpointReq)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err = fsm.State().EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	must.Eq(t, 50, out.LatestIndex)
+
+	delReq := structs.EventSinkDeregisterRequest{IDs: []string{sink.ID}}
+	buf, err = structs.Encode(structs.EventSinkDeregisterRequestType, delReq)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err = fsm.State().EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+}
@@ -0,0 +1,170 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// watchJobDependencies is a long lived function that releases the
+// evaluations held until the jobs their job depends on reach their required
+// status. It watches the job and job summary of the upstream jobs, so
+// evaluations are released as soon as the last upstream allocation finishes.
+func (s *Server) watchJobDependencies(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	index := uint64(1)
+	for {
+		resp, idx, err := s.State().BlockingQuery(releasableDependencyEvals, index, ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			s.logger.Error("failed to get dependency blocked evaluations", "error", err)
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		index = idx
+
+		updates := resp.([]*structs.Evaluation)
+		if len(updates) == 0 {
+			continue
+		}
+		req := structs.EvalUpdateRequest{
+			Evals: updates,
+		}
+		if _, _, err := s.raftApply(structs.EvalUpdateRequestType, &req); err != nil {
+			s.logger.Error("failed to release dependency blocked evaluations", "error", err)
+
+			// Retry without waiting for the state to change
+			index = 1
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
+// releasableDependencyEvals returns the updates to the dependency blocked
+// evaluations that either can be released, because the dependencies of their
+// job are met, or canceled, because their job was stopped.
+func releasableDependencyEvals(ws memdb.WatchSet, store *state.StateStore) (interface{}, uint64, error) {
+	evals, err := store.EvalsByStatus(ws, structs.EvalStatusDependencyBlocked)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var updates []*structs.Evaluation
+	for _, eval := range evals {
+		job, err := store.JobByID(ws, eval.Namespace, eval.JobID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if job == nil || job.Stopped() {
+			canceled := eval.Copy()
+			canceled.Status = structs.EvalStatusCancelled
+			canceled.StatusDescription = fmt.Sprintf("job %q was stopped or deregistered", eval.JobID)
+			canceled.UpdateModifyTime()
+			updates = append(updates, canceled)
+			continue
+		}
+
+		met, err := jobDependenciesMet(ws, store, job)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !met {
+			continue
+		}
+
+		next := &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      eval.Namespace,
+			Priority:       eval.Priority,
+			Type:           eval.Type,
+			TriggeredBy:    structs.EvalTriggerJobDependency,
+			JobID:          eval.JobID,
+			JobModifyIndex: job.JobModifyIndex,
+			Status:         structs.EvalStatusPending,
+			PreviousEval:   eval.ID,
+		}
+		next.UpdateModifyTime()
+		next.CreateTime = next.ModifyTime
+
+		released := eval.Copy()
+		released.Status = structs.EvalStatusComplete
+		released.StatusDescription = "job dependencies met"
+		released.NextEval = next.ID
+		released.UpdateModifyTime()
+		updates = append(updates, released, next)
+	}
+
+	index, err := maxTableIndex(store, "evals", "jobs", "job_summary")
+	if err != nil {
+		return nil, 0, err
+	}
+	return updates, index, nil
+}
+
+// jobDependenciesMet returns whether every job the given job depends on
+// reached its required status, watching the upstream jobs and job summaries.
+func jobDependenciesMet(ws memdb.WatchSet, store *state.StateStore, job *structs.Job) (bool, error) {
+	for _, dep := range job.DependsOn {
+		upstream, err := store.JobByID(ws, job.Namespace, dep.JobID)
+		if err != nil {
+			return false, err
+		}
+		if _, err := store.JobSummaryByID(ws, job.Namespace, dep.JobID); err != nil {
+			return false, err
+		}
+
+		var allocs []*structs.Allocation
+		if upstream != nil {
+			allocs, err = store.AllocsByJob(nil, job.Namespace, dep.JobID, false)
+			if err != nil {
+				return false, err
+			}
+		}
+		if ok, _ := dep.Satisfied(upstream, allocs); !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// maxTableIndex returns the highest index of the given tables.
+func maxTableIndex(store *state.StateStore, tables ...string) (uint64, error) {
+	var highest uint64
+	for _, table := range tables {
+		index, err := store.Index(table)
+		if err != nil {
+			return 0, err
+		}
+		highest = max(highest, index)
+	}
+	return highest, nil
+}
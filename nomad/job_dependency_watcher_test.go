@@ -0,0 +1,98 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobDependencyWatcher_Release(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	// Create an upstream job with a running allocation
+	upstream := mock.BatchJob()
+	upstream.TaskGroups[0].Count = 1
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, upstream))
+	alloc := mock.MinAllocForJob(upstream)
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	// Create a job whose eval waits for the upstream job to complete, and
+	// another stopped job whose eval waits for the upstream job
+	job := mock.BatchJob()
+	job.DependsOn = []*structs.JobDependency{
+		{JobID: upstream.ID, Status: structs.JobDependencyStatusComplete},
+	}
+	stopped := job.Copy()
+	stopped.ID = "stopped"
+	stopped.Stop = true
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, job))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1003, nil, stopped))
+
+	waiting, waitingStopped := mock.Eval(), mock.Eval()
+	waiting.JobID = job.ID
+	waiting.Status = structs.EvalStatusDependencyBlocked
+	waitingStopped.JobID = stopped.ID
+	waitingStopped.Status = structs.EvalStatusDependencyBlocked
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 1004,
+		[]*structs.Evaluation{waiting, waitingStopped}))
+
+	// The eval of the stopped job is canceled
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := store.EvalByID(nil, waitingStopped.ID)
+		if err != nil {
+			return false, err
+		}
+		if out.Status != structs.EvalStatusCancelled {
+			return false, fmt.Errorf("expected eval to be canceled, got %q", out.Status)
+		}
+		return true, nil
+	}, func(err error) { must.NoError(t, err) })
+
+	out, err := store.EvalByID(nil, waiting.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.EvalStatusDependencyBlocked, out.Status)
+
+	// The eval is released once the upstream job completed
+	alloc = alloc.Copy()
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, store.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 1005, []*structs.Allocation{alloc}))
+	upstreamEval := mock.Eval()
+	upstreamEval.JobID = upstream.ID
+	upstreamEval.Status = structs.EvalStatusComplete
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 1006, []*structs.Evaluation{upstreamEval}))
+
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := store.EvalByID(nil, waiting.ID)
+		if err != nil {
+			return false, err
+		}
+		if out.Status != structs.EvalStatusComplete {
+			return false, fmt.Errorf("expected eval to be complete, got %q", out.Status)
+		}
+		return true, nil
+	}, func(err error) { must.NoError(t, err) })
+
+	out, err = store.EvalByID(nil, waiting.ID)
+	must.NoError(t, err)
+	next, err := store.EvalByID(nil, out.NextEval)
+	must.NoError(t, err)
+	must.NotNil(t, next)
+	must.Eq(t, structs.EvalStatusPending, next.Status)
+	must.Eq(t, structs.EvalTriggerJobDependency, next.TriggeredBy)
+	must.Eq(t, waiting.ID, next.PreviousEval)
+}
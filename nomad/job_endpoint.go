@@ -215,6 +215,14 @@ func (j *Job) doRegister(aclObj *acl.ACL, additionalAllowedPermissions []string,
 		}
 	}
 
+	// Job affinities are placed relative to the allocations of jobs in other
+	// namespaces, so check that they can be read
+	for _, ns := range args.Job.JobAffinityNamespaces() {
+		if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) {
+			return structs.ErrPermissionDenied
+		}
+	}
+
 	// Lookup the job
 	snap, err := j.srv.State().Snapshot()
 	if err != nil {
@@ -246,6 +254,17 @@ func (j *Job) doRegister(aclObj *acl.ACL, additionalAllowedPermissions []string,
 		return err
 	}
 
+	// Ensure all servers can hold evaluations until dependencies are met
+	if len(args.Job.DependsOn) != 0 &&
+		!j.srv.peersCache.ServersMeetMinimumVersion(j.srv.Region(), minVersionJobDependencies, true) {
+		return fmt.Errorf("all servers must be running version %v or later to register jobs with dependencies", minVersionJobDependencies)
+	}
+
+	// Ensure the job's dependencies don't form a cycle
+	if err := validateJobDependencies(snap, args.Job); err != nil {
+		return err
+	}
+
 	// Ensure that all scaling policies have an appropriate ID
 	if err := propagateScalingPolicyIDs(existingJob, args.Job); err != nil {
 		return err
@@ -1568,6 +1587,62 @@ func (j *Job) Allocations(args *structs.JobSpecificRequest,
 	return j.srv.blockingRPC(&opts)
 }
 
+// WorkflowSteps is used to get the status of the steps of a workflow job
+func (j *Job) WorkflowSteps(args *structs.JobWorkflowStepsRequest,
+	reply *structs.JobWorkflowStepsResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.WorkflowSteps", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "workflow_steps"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID")
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			job, err := state.JobByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			reply.Steps = nil
+			if job != nil {
+				allocs, err := state.AllocsByJob(ws, args.RequestNamespace(), args.JobID, false)
+				if err != nil {
+					return err
+				}
+				reply.Steps = job.WorkflowSteps(allocs)
+			}
+
+			// Use the last index that affected the jobs or allocs tables
+			index, err := maxTableIndex(state, "jobs", "allocs")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
 // Evaluations is used to list the evaluations for a job
 func (j *Job) Evaluations(args *structs.JobSpecificRequest,
 	reply *structs.JobEvaluationsResponse) error {
@@ -1896,6 +1971,7 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		JobModifyIndex: updatedIndex,
 		Status:         structs.EvalStatusPending,
 		AnnotatePlan:   true,
+		ExplainPlan:    args.Explain,
 		// Timestamps are added for consistency but this eval is never persisted
 		CreateTime: now,
 		ModifyTime: now,
@@ -1907,7 +1983,8 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	// Create an in-memory Planner that returns no errors and stores the
 	// submitted plan and created evals.
 	planner := &sstructs.PlanBuilder{
-		State: &snap.StateStore,
+		State:   &snap.StateStore,
+		Scorers: j.srv.nodeScorers,
 	}
 
 	// Create the scheduler and run it
@@ -1952,6 +2029,9 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	}
 
 	reply.FailedTGAllocs = updatedEval.FailedTGAllocs
+	if args.Explain {
+		reply.Placements = planPlacementExplanations(planner.Plans[0])
+	}
 	reply.JobModifyIndex = index
 	reply.Annotations = annotations
 	reply.CreatedEvals = planner.CreateEvals
@@ -1959,6 +2039,67 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	return nil
 }
 
+// planPlacementExplanations returns the explanations of the placements of
+// the plan, sorted by allocation name.
+func planPlacementExplanations(plan *structs.Plan) []*structs.PlacementExplanation {
+	var placements []*structs.PlacementExplanation
+	for _, allocs := range plan.NodeAllocation {
+		for _, alloc := range allocs {
+			// Skip in-place updates, which aren't placed by the stack
+			if alloc.Metrics == nil || alloc.CreateIndex != 0 {
+				continue
+			}
+			placements = append(placements, &structs.PlacementExplanation{
+				AllocName: alloc.Name,
+				TaskGroup: alloc.TaskGroup,
+				NodeID:    alloc.NodeID,
+				NodeName:  alloc.NodeName,
+				Metrics:   alloc.Metrics,
+			})
+		}
+	}
+	sort.Slice(placements, func(i, j int) bool {
+		return placements[i].AllocName < placements[j].AllocName
+	})
+	return placements
+}
+
+// validateJobDependencies returns an error if the job depends on a job that
+// transitively depends on it.
+func validateJobDependencies(snap *state.StateSnapshot, job *structs.Job) error {
+	if len(job.DependsOn) == 0 {
+		return nil
+	}
+
+	visited := make(map[string]struct{})
+	var visit func(path []string, deps []*structs.JobDependency) error
+	visit = func(path []string, deps []*structs.JobDependency) error {
+		for _, dep := range deps {
+			if dep.JobID == job.ID {
+				return fmt.Errorf("job dependencies form a cycle: %s",
+					strings.Join(append(path, dep.JobID), " -> "))
+			}
+			if _, ok := visited[dep.JobID]; ok {
+				continue
+			}
+			visited[dep.JobID] = struct{}{}
+
+			upstream, err := snap.JobByID(nil, job.Namespace, dep.JobID)
+			if err != nil {
+				return err
+			}
+			if upstream == nil {
+				continue
+			}
+			if err := visit(append(path, dep.JobID), upstream.DependsOn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return visit([]string{job.ID}, job.DependsOn)
+}
+
 // validateJobUpdate ensures updates to a job are valid.
 func validateJobUpdate(old, new *structs.Job) error {
 	// Validate Dispatch not set on new Jobs
@@ -1066,6 +1066,20 @@ func TestJobEndpoint_Register_ACL(t *testing.T) {
 	pluginPolicy := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityCSIRegisterPlugin})
 	pluginToken := mock.CreatePolicyAndToken(t, s1.State(), 1005, "test-csi-register-plugin", submitJobPolicy+pluginPolicy)
 
+	newJobAffinityJob := func() *structs.Job {
+		j := mock.Job()
+		j.TaskGroups[0].JobAffinities = []*structs.JobAffinity{{
+			Namespace:   "web",
+			JobID:       "api",
+			TopologyKey: structs.JobAffinityDefaultTopologyKey,
+			Required:    true,
+		}}
+		return j
+	}
+
+	readWebPolicy := mock.NamespacePolicy("web", "", []string{acl.NamespaceCapabilityReadJob})
+	readWebToken := mock.CreatePolicyAndToken(t, s1.State(), 1006, "test-read-web", submitJobPolicy+readWebPolicy)
+
 	cases := []struct {
 		Name        string
 		Job         *structs.Job
@@ -1126,6 +1140,18 @@ func TestJobEndpoint_Register_ACL(t *testing.T) {
 			Token:       registerJobToken.SecretID,
 			ErrExpected: false,
 		},
+		{
+			Name:        "with a token that can submit a job, job affinity namespace rejected",
+			Job:         newJobAffinityJob(),
+			Token:       submitJobToken.SecretID,
+			ErrExpected: true,
+		},
+		{
+			Name:        "with a token that can also read the job affinity namespace, accepted",
+			Job:         newJobAffinityJob(),
+			Token:       readWebToken.SecretID,
+			ErrExpected: false,
+		},
 	}
 
 	for _, tt := range cases {
@@ -1492,6 +1518,42 @@ func TestJobEndpoint_Register_Dispatched(t *testing.T) {
 	must.EqError(t, err, "job can't be submitted with 'Dispatched' set")
 }
 
+func TestJobEndpoint_Register_DependencyCycle(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	register := func(id string, deps ...string) error {
+		job := mock.BatchJob()
+		job.ID = id
+		for _, dep := range deps {
+			job.DependsOn = append(job.DependsOn, &structs.JobDependency{JobID: dep})
+		}
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		return msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	}
+
+	// Jobs may depend on jobs that aren't registered yet
+	must.NoError(t, register("load", "transform"))
+	must.NoError(t, register("transform", "extract"))
+	must.NoError(t, register("report", "load", "transform"))
+
+	err := register("extract", "report")
+	must.ErrorContains(t, err, "job dependencies form a cycle: extract -> report -> load -> transform -> extract")
+}
+
 func TestJobEndpoint_Register_EnforceIndex(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5394,6 +5456,56 @@ func TestJobEndpoint_Allocations(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_WorkflowSteps(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a workflow job whose "load" step runs after the "web" step
+	job := mock.Job()
+	job.Type = structs.JobTypeWorkflow
+	job.TaskGroups[0].Count = 1
+	load := job.TaskGroups[0].Copy()
+	load.Name = "load"
+	load.After = []string{"web"}
+	job.TaskGroups = append(job.TaskGroups, load)
+
+	state := s1.fsm.State()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+	job, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.TaskGroup = "web"
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	get := &structs.JobWorkflowStepsRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobWorkflowStepsResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.WorkflowSteps", get, &resp))
+	must.Eq(t, 1001, resp.Index)
+	must.Eq(t, []*structs.WorkflowStep{
+		{Name: "web", Count: 1, Complete: 1, Status: structs.WorkflowStepComplete},
+		{Name: "load", After: []string{"web"}, Count: 1, Status: structs.WorkflowStepPending},
+	}, resp.Steps)
+
+	// Unknown jobs have no steps
+	get.JobID = "unknown"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.WorkflowSteps", get, &resp))
+	must.Nil(t, resp.Steps)
+}
+
 func TestJobEndpoint_Allocations_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -6357,6 +6469,67 @@ func TestJobEndpoint_Plan_NoDiff(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Plan_Explain(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	// Create a linux node and two windows nodes of the same computed class,
+	// which the job constraint filters
+	linux := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, linux))
+	var windows []*structs.Node
+	for i := range 2 {
+		node := mock.Node()
+		node.Attributes["kernel.name"] = "windows"
+		must.NoError(t, node.ComputeClass())
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(1001+i), node))
+		windows = append(windows, node)
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	planReq := &structs.JobPlanRequest{
+		Job:     job,
+		Explain: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var planResp structs.JobPlanResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Plan", planReq, &planResp))
+
+	// Every node visited is explained, with the exact constraint that
+	// filtered the windows nodes rather than their computed class
+	must.MapEmpty(t, planResp.FailedTGAllocs)
+	must.Len(t, 1, planResp.Placements)
+	placement := planResp.Placements[0]
+	must.Eq(t, linux.ID, placement.NodeID)
+	must.Eq(t, job.ID+".web[0]", placement.AllocName)
+
+	exps := placement.Metrics.NodeExplanations
+	must.MapLen(t, 3, exps)
+	must.Eq(t, "", exps[linux.ID].Filtered)
+	must.Positive(t, exps[linux.ID].NormScore)
+	must.MapContainsKey(t, exps[linux.ID].Scores, "binpack")
+	for _, node := range windows {
+		must.Eq(t, "${attr.kernel.name} = linux", exps[node.ID].Filtered)
+	}
+
+	// Explanations are only recorded when requested
+	planReq.Explain = false
+	planResp = structs.JobPlanResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Plan", planReq, &planResp))
+	must.SliceEmpty(t, planResp.Placements)
+}
+
 // TestJobEndpoint_Plan_Scaling asserts that the plan endpoint handles
 // jobs with scaling block
 func TestJobEndpoint_Plan_Scaling(t *testing.T) {
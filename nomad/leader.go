@@ -105,6 +105,46 @@ var minVersionNodeIntro = version.Must(version.NewVersion("1.11.0"))
 // we submit a full Job object like we used to before.
 var minVersionPlanLeanJob = version.Must(version.NewVersion("1.12.0"))
 
+// minVersionQuotas is the Nomad version at which quota specifications can be
+// written in the community edition. It forms the minimum version all servers
+// must meet before the feature can be used.
+var minVersionQuotas = version.Must(version.NewVersion("1.11.3"))
+
+// minVersionNodeTaints is the Nomad version at which operators can taint
+// nodes. It forms the minimum version all servers must meet before node taints
+// can be written to raft.
+var minVersionNodeTaints = version.Must(version.NewVersion("1.11.3"))
+
+// minVersionNodeUtilization is the Nomad version at which clients can publish
+// the utilization of their node. It forms the minimum version all servers must
+// meet before the feature can be used.
+var minVersionNodeUtilization = version.Must(version.NewVersion("1.11.3"))
+
+// minVersionReservations is the Nomad version at which capacity reservations
+// can be written. It forms the minimum version all servers must meet before
+// the feature can be used.
+var minVersionReservations = version.Must(version.NewVersion("1.11.3"))
+
+// minVersionVariablesHistory is the Nomad version at which prior versions of
+// variables are kept. It forms the minimum version all servers must meet
+// before versions can be rekeyed or purged.
+var minVersionVariablesHistory = version.Must(version.NewVersion("1.11.3"))
+
+// minVersionVariablesTTL is the Nomad version at which variables can be written
+// with a TTL. It forms the minimum version all servers must meet before
+// variables can be written with a TTL or expired.
+var minVersionVariablesTTL = version.Must(version.NewVersion("1.11.3"))
+
+// minVersionEventSinks is the Nomad version at which durable event sinks can
+// be registered. It forms the minimum version all servers must meet before
+// event sinks can be written to raft.
+var minVersionEventSinks = version.Must(version.NewVersion("1.11.3"))
+
+// minVersionJobDependencies is the Nomad version at which jobs can depend on
+// other jobs. It forms the minimum version all servers must meet before
+// dependency blocked evaluations can be written to raft.
+var minVersionJobDependencies = version.Must(version.NewVersion("1.11.3"))
+
 // monitorLeadership is used to monitor if we acquire or lose our role
 // as the leader in the Raft cluster. There is some work the leader is
 // expected to do, so we must react to changes
@@ -418,6 +458,10 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Enable the volume watcher, since we are now the leader
 	s.volumeWatcher.SetEnabled(true, s.State(), s.getLeaderAcl())
 
+	// Resume the delivery of events to the event sinks, since we are now the
+	// leader
+	s.eventSinkManager.SetEnabled(true)
+
 	// Restore the eval broker state and blocked eval state. If these are
 	// currently paused, we do not need to do this.
 	if restoreEvals {
@@ -460,6 +504,9 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically unblock failed allocations
 	go s.periodicUnblockFailedEvals(stopCh)
 
+	// Release the evaluations waiting for their job's dependencies
+	go s.watchJobDependencies(stopCh)
+
 	// Periodically publish job summary metrics
 	go s.publishJobSummaryMetrics(stopCh)
 
@@ -472,6 +519,12 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 		return err
 	}
 
+	// Populate the variable expiry timers, so variables written with a TTL
+	// are deleted once they expire.
+	if err := s.restoreVariableExpiryTimers(); err != nil {
+		return err
+	}
+
 	// Periodically publish metrics for the lock timer trackers which are only
 	// run on the leader.
 	go s.lockTTLTimer.EmitMetrics(1*time.Second, stopCh)
@@ -510,6 +563,7 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 			go s.replicateACLBindingRules(stopCh)
 			go s.replicateNamespaces(stopCh)
 			go s.replicateNodePools(stopCh)
+			go s.replicateQuotaSpecs(stopCh)
 		}
 	}
 
@@ -671,6 +725,140 @@ func diffNamespaces(state *state.StateStore, minIndex uint64, remoteList []*stru
 	return
 }
 
+// replicateQuotaSpecs is used to replicate quota specifications from the
+// authoritative region to this region.
+func (s *Server) replicateQuotaSpecs(stopCh chan struct{}) {
+	req := structs.QuotaSpecListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting quota replication from authoritative region", "region", req.Region)
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		// Rate limit how often we attempt replication
+		limiter.Wait(context.Background())
+
+		if !s.peersCache.ServersMeetMinimumVersion(s.Region(), minVersionQuotas, true) {
+			s.logger.Trace(
+				"all servers must be upgraded to 1.11.3 before quotas can be replicated")
+			if s.replicationBackoffContinue(stopCh) {
+				continue
+			} else {
+				return
+			}
+		}
+
+		var resp structs.QuotaSpecListResponse
+		req.AuthToken = s.ReplicationToken()
+		err := s.forwardRegion(s.config.AuthoritativeRegion, "Quota.ListQuotaSpecs", &req, &resp)
+		if err != nil {
+			s.logger.Error("failed to fetch quotas from authoritative region", "error", err)
+			if s.replicationBackoffContinue(stopCh) {
+				continue
+			} else {
+				return
+			}
+		}
+
+		// Perform a two-way diff
+		delete, update := diffQuotaSpecs(s.State(), req.MinQueryIndex, resp.Quotas)
+
+		// A significant amount of time could pass between the last check
+		// on whether we should stop the replication process. Therefore, do
+		// a check here, before calling Raft.
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		// Update local quotas before deleting any, so namespaces moving
+		// between quotas never reference a missing quota
+		if len(update) > 0 {
+			args := &structs.QuotaSpecUpsertRequest{
+				Quotas: update,
+			}
+			_, _, err := s.raftApply(structs.QuotaSpecUpsertRequestType, args)
+			if err != nil {
+				s.logger.Error("failed to update quotas", "error", err)
+				if s.replicationBackoffContinue(stopCh) {
+					continue
+				} else {
+					return
+				}
+			}
+		}
+
+		// Delete quotas that should not exist
+		if len(delete) > 0 {
+			args := &structs.QuotaSpecDeleteRequest{
+				Names: delete,
+			}
+			_, _, err := s.raftApply(structs.QuotaSpecDeleteRequestType, args)
+			if err != nil {
+				s.logger.Error("failed to delete quotas", "error", err)
+				if s.replicationBackoffContinue(stopCh) {
+					continue
+				} else {
+					return
+				}
+			}
+		}
+
+		// Update the minimum query index, blocks until there is a change.
+		req.MinQueryIndex = resp.Index
+	}
+}
+
+// diffQuotaSpecs is used to perform a two-way diff between the local quota
+// specifications and the remote ones to determine which need to be deleted or
+// updated.
+func diffQuotaSpecs(store *state.StateStore, minIndex uint64, remoteList []*structs.QuotaSpec) ([]string, []*structs.QuotaSpec) {
+	var delete []string
+	var update []*structs.QuotaSpec
+
+	// Construct a set of the local and remote quotas
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	iter, err := store.QuotaSpecs(nil)
+	if err != nil {
+		panic("failed to iterate local quotas")
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		spec := raw.(*structs.QuotaSpec)
+		local[spec.Name] = spec.Hash
+	}
+
+	for _, rspec := range remoteList {
+		remote[rspec.Name] = struct{}{}
+
+		if localHash, ok := local[rspec.Name]; !ok {
+			// Quota is missing locally
+			update = append(update, rspec)
+		} else if rspec.ModifyIndex > minIndex && !bytes.Equal(localHash, rspec.Hash) {
+			// Quota is newer remotely and there is a hash mismatch
+			update = append(update, rspec)
+		}
+	}
+
+	for name := range local {
+		if _, ok := remote[name]; !ok {
+			delete = append(delete, name)
+		}
+	}
+	return delete, update
+}
+
 // replicateNodePools is used to replicate node pools from the authoritative
 // region to this region.
 func (s *Server) replicateNodePools(stopCh chan struct{}) {
@@ -956,6 +1144,14 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 	defer rootKeyGC.Stop()
 	variablesRekey := time.NewTicker(s.config.VariablesRekeyInterval)
 	defer variablesRekey.Stop()
+	rebalance := time.NewTicker(s.config.RebalanceInterval)
+	defer rebalance.Stop()
+	reservationGC := time.NewTicker(s.config.ReservationGCInterval)
+	defer reservationGC.Stop()
+	variableHistoryGC := time.NewTicker(s.config.VariableHistoryGCInterval)
+	defer variableHistoryGC.Stop()
+	variableExpiredGC := time.NewTicker(s.config.VariableExpiredGCInterval)
+	defer variableExpiredGC.Stop()
 
 	// Set up the expired ACL local token garbage collection timer.
 	localTokenExpiredGC, localTokenExpiredGCStop := helper.NewSafeTimer(s.config.ACLTokenExpirationGCInterval)
@@ -1013,6 +1209,26 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 			if index, ok := s.getLatestIndex(); ok {
 				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobVariablesRekey, index))
 			}
+		case <-rebalance.C:
+			_, schedConfig, err := s.fsm.State().SchedulerConfig()
+			if err != nil || schedConfig == nil || !schedConfig.RebalanceConfig.Enabled {
+				continue
+			}
+			if index, ok := s.getLatestIndex(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobRebalance, index))
+			}
+		case <-reservationGC.C:
+			if index, ok := s.getLatestIndex(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobReservationGC, index))
+			}
+		case <-variableHistoryGC.C:
+			if index, ok := s.getLatestIndex(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobVariableHistoryGC, index))
+			}
+		case <-variableExpiredGC.C:
+			if index, ok := s.getLatestIndex(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobVariableExpiredGC, index))
+			}
 		case <-stopCh:
 			return
 		}
@@ -1453,6 +1669,9 @@ func (s *Server) revokeLeadership() error {
 	// Disable the volume watcher
 	s.volumeWatcher.SetEnabled(false, nil, "")
 
+	// Stop delivering events to the event sinks
+	s.eventSinkManager.SetEnabled(false)
+
 	// Disable any enterprise systems required.
 	if err := s.revokeEnterpriseLeadership(); err != nil {
 		return err
@@ -1462,6 +1681,9 @@ func (s *Server) revokeLeadership() error {
 	s.lockTTLTimer.StopAndRemoveAll()
 	s.lockDelayTimer.RemoveAll()
 
+	// Stop all the tracked variable expiry timers.
+	s.variableExpiryTimer.StopAndRemoveAll()
+
 	// Clear the heartbeat timers on either shutdown or step down,
 	// since we are no longer responsible for TTL expirations.
 	if err := s.clearAllHeartbeatTimers(); err != nil {
@@ -2777,6 +2999,7 @@ func (s *Server) handleEvalBrokerStateChange(schedConfig *structs.SchedulerConfi
 	// whether using a persisted Raft configuration, or the default bootstrap
 	// config.
 	var enableBrokers, restoreEvals bool
+	var fairShare structs.FairShareConfig
 
 	// The scheduler config can only be persisted to Raft once quorum has been
 	// established. If this is a fresh cluster, we need to use the default
@@ -2784,10 +3007,16 @@ func (s *Server) handleEvalBrokerStateChange(schedConfig *structs.SchedulerConfi
 	switch schedConfig {
 	case nil:
 		enableBrokers = !s.config.DefaultSchedulerConfig.PauseEvalBroker
+		fairShare = s.config.DefaultSchedulerConfig.FairShareConfig
 	default:
 		enableBrokers = !schedConfig.PauseEvalBroker
+		fairShare = schedConfig.FairShareConfig
 	}
 
+	// Apply the fair share configuration whether or not the broker is
+	// enabled, so it is in place before any evaluation is dequeued.
+	s.evalBroker.SetFairShare(fairShare)
+
 	// If the evalBroker status is changing, set the new state.
 	if enableBrokers != s.evalBroker.Enabled() {
 		s.logger.Info("eval broker status modified", "paused", !enableBrokers)
@@ -230,6 +230,52 @@ func Events(index uint64) *structs.Events {
 	}
 }
 
+func QuotaSpec() *structs.QuotaSpec {
+	qs := &structs.QuotaSpec{
+		Name:        fmt.Sprintf("quota-%s", uuid.Generate()[:8]),
+		Description: "test quota",
+		Limits: []*structs.QuotaLimit{
+			{
+				Region: "global",
+				RegionLimit: &structs.QuotaResources{
+					CPU:      2000,
+					MemoryMB: 2000,
+				},
+			},
+		},
+	}
+	qs.SetHash()
+	return qs
+}
+
+func Reservation() *structs.Reservation {
+	return &structs.Reservation{
+		Name:        fmt.Sprintf("reservation-%s", uuid.Generate()[:8]),
+		Description: "test reservation",
+		NodePool:    structs.NodePoolDefault,
+		Resources: &structs.ReservationResources{
+			CPU:      1000,
+			MemoryMB: 1024,
+		},
+		Consumers: &structs.ReservationConsumers{
+			Namespaces: []string{"launch"},
+		},
+		TTL: time.Hour,
+	}
+}
+
+func EventSink() *structs.EventSink {
+	return &structs.EventSink{
+		ID:        fmt.Sprintf("sink-%s", uuid.Generate()[:8]),
+		Type:      structs.EventSinkWebhook,
+		Address:   "http://127.0.0.1:8080/events",
+		Namespace: structs.AllNamespacesSentinel,
+		Topics: map[structs.Topic][]string{
+			structs.TopicJob: {"*"},
+		},
+	}
+}
+
 func Namespace() *structs.Namespace {
 	id := uuid.Generate()
 	ns := &structs.Namespace{
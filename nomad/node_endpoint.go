@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -45,6 +46,9 @@ const (
 	// ineligible
 	NodeEligibilityEventIneligible = "Node marked as ineligible for scheduling"
 
+	// NodeTaintsEventUpdated is used when the taints of the node are updated
+	NodeTaintsEventUpdated = "Node taints updated"
+
 	// NodeHeartbeatEventReregistered is the message used when the node becomes
 	// reregistered by the heartbeat.
 	NodeHeartbeatEventReregistered = "Node reregistered by heartbeat"
@@ -188,6 +192,16 @@ func (n *Node) Register(args *structs.NodeRegisterRequest, reply *structs.NodeUp
 		if originalNode.Status != "" {
 			args.Node.Status = originalNode.Status
 		}
+
+		// The taints set in the client configuration only apply when the node
+		// first registers, after which they are managed with the UpdateTaints
+		// method.
+		if !structs.NodeTaintsEqual(args.Node.Taints, originalNode.Taints) {
+			args.Node.Taints = originalNode.Taints
+			if err := args.Node.ComputeClass(); err != nil {
+				return fmt.Errorf("failed to computed node class: %v", err)
+			}
+		}
 		// The called function performs all the required logging and metric
 		// emitting, so we only need to check the return value.
 	} else if !n.newRegistrationAllowed(args, authErr) {
@@ -1129,6 +1143,174 @@ func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 	return nil
 }
 
+// UpdateTaints is used to replace the taints of a node
+func (n *Node) UpdateTaints(args *structs.NodeUpdateTaintsRequest,
+	reply *structs.NodeTaintsUpdateResponse) error {
+
+	authErr := n.srv.Authenticate(n.ctx, args)
+	if done, err := n.srv.forward("Node.UpdateTaints", args, args, reply); done {
+		return err
+	}
+	n.srv.MeasureRPCRate("node", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "update_taints"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := n.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Verify the arguments
+	if args.NodeID == "" {
+		return fmt.Errorf("missing node ID for updating taints")
+	}
+	if args.NodeEvent != nil {
+		return fmt.Errorf("node event must not be set")
+	}
+	if err := structs.ValidateNodeTaints(args.Taints); err != nil {
+		return err
+	}
+
+	if !n.srv.peersCache.ServersMeetMinimumVersion(n.srv.Region(), minVersionNodeTaints, true) {
+		return fmt.Errorf("all servers must be running version %v or later to update node taints", minVersionNodeTaints)
+	}
+
+	// Look for the node
+	snap, err := n.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	node, err := snap.NodeByID(nil, args.NodeID)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("node not found")
+	}
+	if structs.NodeTaintsEqual(node.Taints, args.Taints) {
+		reply.NodeModifyIndex = node.ModifyIndex
+		reply.Index = node.ModifyIndex
+		return nil // Nothing to do
+	}
+
+	// Update the timestamp of when the node status was updated
+	args.UpdatedAt = time.Now().Unix()
+
+	// Construct the node event
+	taints := make([]string, len(args.Taints))
+	for i, taint := range args.Taints {
+		taints[i] = taint.String()
+	}
+	args.NodeEvent = structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemCluster).
+		SetMessage(NodeTaintsEventUpdated).
+		AddDetail("taints", strings.Join(taints, ","))
+
+	// Commit this update via Raft
+	outErr, index, err := n.srv.raftApply(structs.NodeUpdateTaintsRequestType, args)
+	if err != nil {
+		n.logger.Error("taints update failed", "error", err)
+		return err
+	}
+	if outErr != nil {
+		if err, ok := outErr.(error); ok && err != nil {
+			n.logger.Error("taints update failed", "error", err)
+			return err
+		}
+	}
+	reply.NodeModifyIndex = index
+
+	// Create Node evaluations, because allocations of system jobs may be
+	// placed on or removed from the node with its new taints. Allocations
+	// that don't tolerate NoExecute taints are migrated by the drainer.
+	evalIDs, evalIndex, err := n.createNodeEvals(node, index)
+	if err != nil {
+		n.logger.Error("eval creation failed", "error", err)
+		return err
+	}
+	reply.EvalIDs = evalIDs
+	reply.EvalCreateIndex = evalIndex
+
+	// Set the reply index
+	reply.Index = index
+	return nil
+}
+
+// UpdateUtilization is used by clients to publish the utilization of their
+// node. To bound the raft traffic, the utilization is only written when it
+// changed significantly or when the previous one is getting old.
+func (n *Node) UpdateUtilization(args *structs.NodeUpdateUtilizationRequest, reply *structs.GenericResponse) error {
+	aclObj, err := n.srv.AuthenticateClientOnly(n.ctx, args)
+	n.srv.MeasureRPCRate("node", structs.RateMetricWrite, args)
+	if err != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	if done, err := n.srv.forward("Node.UpdateUtilization", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "update_utilization"}, time.Now())
+
+	if !aclObj.AllowClientOp() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !n.srv.peersCache.ServersMeetMinimumVersion(n.srv.Region(), minVersionNodeUtilization, true) {
+		return fmt.Errorf("all servers must be running version %v or later to update node utilization", minVersionNodeUtilization)
+	}
+
+	// Verify the arguments
+	if args.NodeID == "" {
+		return fmt.Errorf("missing node ID for updating utilization")
+	}
+	if args.Utilization == nil {
+		return fmt.Errorf("missing utilization")
+	}
+	if err := args.Utilization.Validate(); err != nil {
+		return err
+	}
+
+	// Look for the node
+	snap, err := n.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	node, err := snap.NodeByID(nil, args.NodeID)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("node not found")
+	}
+
+	now := time.Now()
+	if !node.Utilization.NeedsUpdate(args.Utilization, now) {
+		reply.Index = node.ModifyIndex
+		return nil // Nothing to do
+	}
+	args.Utilization.UpdatedAt = now.Unix()
+
+	// Commit this update via Raft
+	outErr, index, err := n.srv.raftApply(structs.NodeUpdateUtilizationRequestType, args)
+	if err != nil {
+		n.logger.Error("utilization update failed", "error", err)
+		return err
+	}
+	if outErr != nil {
+		if err, ok := outErr.(error); ok && err != nil {
+			n.logger.Error("utilization update failed", "error", err)
+			return err
+		}
+	}
+
+	reply.Index = index
+	return nil
+}
+
 // Evaluate is used to force a re-evaluation of the node
 func (n *Node) Evaluate(args *structs.NodeEvaluateRequest, reply *structs.NodeUpdateResponse) error {
 
@@ -1601,11 +1783,29 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 			}
 
 			// Set trigger by failed if not an orphan.
-			if alloc.RescheduleEligible(taskGroup.ReschedulePolicy, now) {
+			if alloc.RescheduleEligible(taskGroup.EffectiveReschedulePolicy(), now) {
 				evalTriggerBy = structs.EvalTriggerRetryFailedAlloc
 			}
 		}
 
+		// Add an evaluation to place the next steps of a workflow job once
+		// one of their upstream steps completes
+		if evalTriggerBy == "" &&
+			allocToUpdate.ClientStatus == structs.AllocClientStatusComplete &&
+			job.HasWorkflowDownstream(alloc.TaskGroup) {
+			evalTriggerBy = structs.EvalTriggerWorkflowStep
+		}
+
+		// Add an evaluation to place the pending indexes of an indexed batch
+		// task group once one of its allocations finishes, since only
+		// parallelism allocations of the group run at once
+		if evalTriggerBy == "" &&
+			allocToUpdate.ClientTerminalStatus() &&
+			taskGroup != nil && taskGroup.IndexedBatch != nil &&
+			taskGroup.IndexedBatch.Parallelism < taskGroup.IndexedBatch.Completions {
+			evalTriggerBy = structs.EvalTriggerIndexedBatch
+		}
+
 		var eval *structs.Evaluation
 		// If unknown, and not an orphan, set the trigger by.
 		if evalTriggerBy != structs.EvalTriggerJobDeregister &&
@@ -1864,7 +2064,7 @@ func (n *Node) createNodeEvals(node *structs.Node, nodeIndex uint64) ([]string,
 		// allocations. Terminal batch allocations should not be retried
 		// otherwise the job status will flap between dead and pending each time
 		// the node updates while the allocation is still held in state.
-		case structs.JobTypeBatch:
+		case structs.JobTypeBatch, structs.JobTypeWorkflow:
 			if alloc.Terminated() {
 				continue
 			}
@@ -2449,6 +2449,140 @@ func TestClientEndpoint_UpdateEligibility(t *testing.T) {
 	require.Equal(NodeEligibilityEventEligible, out.Events[2].Message)
 }
 
+func TestClientEndpoint_UpdateTaints(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForKeyring(t, s1.RPC, s1.config.Region)
+
+	// Register a node with a taint from its configuration
+	node := mock.Node()
+	node.Taints = []*structs.NodeTaint{
+		{Key: "dedicated", Value: "gpu", Effect: structs.TaintEffectNoSchedule},
+	}
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	store := s1.fsm.State()
+	out, err := store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, out.Taints)
+	class := out.ComputedClass
+
+	// Register a system job
+	job := mock.SystemJob()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	// Invalid taints are rejected
+	req := &structs.NodeUpdateTaintsRequest{
+		NodeID: node.ID,
+		Taints: []*structs.NodeTaint{
+			{Key: "maintenance", Effect: "Evict"},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp2 structs.NodeTaintsUpdateResponse
+	err = msgpackrpc.CallWithCodec(codec, "Node.UpdateTaints", req, &resp2)
+	must.ErrorContains(t, err, `Invalid taint effect "Evict"`)
+
+	// Replace the taints and expect evals
+	req.Taints = []*structs.NodeTaint{
+		{Key: "maintenance", Effect: structs.TaintEffectNoExecute},
+	}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateTaints", req, &resp2))
+	must.NonZero(t, resp2.Index)
+	must.NonZero(t, resp2.EvalCreateIndex)
+	must.Len(t, 1, resp2.EvalIDs)
+
+	out, err = store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, req.Taints, out.Taints)
+	must.NotEq(t, class, out.ComputedClass)
+	must.Eq(t, NodeTaintsEventUpdated, out.Events[len(out.Events)-1].Message)
+
+	// Registering the node again doesn't reset its taints
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+	out, err = store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, req.Taints, out.Taints)
+
+	// Updating the taints to the same value is a no-op
+	var resp3 structs.NodeTaintsUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateTaints", req, &resp3))
+	must.Eq(t, out.ModifyIndex, resp3.NodeModifyIndex)
+	must.SliceEmpty(t, resp3.EvalIDs)
+}
+
+func TestClientEndpoint_UpdateUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForKeyring(t, s1.RPC, s1.config.Region)
+
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	// Only clients can publish utilization
+	req := &structs.NodeUpdateUtilizationRequest{
+		NodeID:       node.ID,
+		Utilization:  &structs.NodeUtilization{CPUPercent: 40, MemoryPercent: 60},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp2 structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "Node.UpdateUtilization", req, &resp2)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Invalid utilizations are rejected
+	req.AuthToken = node.SecretID
+	req.Utilization.CPUPercent = 140
+	err = msgpackrpc.CallWithCodec(codec, "Node.UpdateUtilization", req, &resp2)
+	must.ErrorContains(t, err, "Utilization CPU percent must be between 0 and 100")
+
+	// The first utilization is written
+	req.Utilization.CPUPercent = 40
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateUtilization", req, &resp2))
+	must.NonZero(t, resp2.Index)
+
+	store := s1.fsm.State()
+	out, err := store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out.Utilization)
+	must.Eq(t, 40, out.Utilization.CPUPercent)
+	must.NonZero(t, out.Utilization.UpdatedAt)
+	index := out.ModifyIndex
+
+	// A small change isn't written
+	req.Utilization.CPUPercent = 42
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateUtilization", req, &resp2))
+	out, err = store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, index, out.ModifyIndex)
+	must.Eq(t, 40, out.Utilization.CPUPercent)
+
+	// A large change is written
+	req.Utilization.MemoryPressure = 30
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateUtilization", req, &resp2))
+	out, err = store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Greater(t, index, out.ModifyIndex)
+	must.Eq(t, 30, out.Utilization.MemoryPressure)
+}
+
 func TestClientEndpoint_UpdateEligibility_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4725,6 +4859,8 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 		missingJob         bool
 		missingAlloc       bool
 		invalidTaskGroup   bool
+		workflow           bool
+		indexedBatch       bool
 	}
 
 	testCases := []testCase{
@@ -4764,6 +4900,20 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 			missingAlloc:       false,
 			invalidTaskGroup:   false,
 		},
+		{
+			name:               "complete-workflow-step",
+			clientStatus:       structs.AllocClientStatusComplete,
+			serverClientStatus: structs.AllocClientStatusRunning,
+			triggerBy:          structs.EvalTriggerWorkflowStep,
+			workflow:           true,
+		},
+		{
+			name:               "complete-indexed-batch",
+			clientStatus:       structs.AllocClientStatusComplete,
+			serverClientStatus: structs.AllocClientStatusRunning,
+			triggerBy:          structs.EvalTriggerIndexedBatch,
+			indexedBatch:       true,
+		},
 		{
 			name:               "no-alloc-at-server",
 			clientStatus:       structs.AllocClientStatusUnknown,
@@ -4814,6 +4964,17 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 
 			job := mock.Job()
 			job.ID = tc.name + "-test-job"
+			if tc.workflow {
+				job.Type = structs.JobTypeWorkflow
+				next := job.TaskGroups[0].Copy()
+				next.Name = "next"
+				next.After = []string{job.TaskGroups[0].Name}
+				job.TaskGroups = append(job.TaskGroups, next)
+			}
+			if tc.indexedBatch {
+				job.Type = structs.JobTypeBatch
+				job.TaskGroups[0].IndexedBatch = &structs.IndexedBatch{Completions: 10, Parallelism: 2}
+			}
 
 			if !tc.missingJob {
 				err = fsmState.UpsertJob(structs.MsgTypeTestSetup, 101, nil, job)
@@ -511,6 +511,50 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 	return nil
 }
 
+// SchedulerRebalanceReport is used to report the migrations the rebalancer
+// would make against the current state, without making them.
+func (op *Operator) SchedulerRebalanceReport(args *structs.GenericRequest, reply *structs.SchedulerRebalanceReportResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.SchedulerRebalanceReport", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	index, config, err := snap.SchedulerConfig()
+	if err != nil {
+		return err
+	} else if config == nil {
+		return fmt.Errorf("scheduler config not initialized yet")
+	}
+
+	report, err := computeRebalance(snap, config, time.Now(), op.logger)
+	if err != nil {
+		return err
+	}
+
+	reply.Report = report
+	reply.QueryMeta.Index = index
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
 func (op *Operator) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := op.srv.findRegionServer(region)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"sync"
 	"time"
 
 	log "github.com/hashicorp/go-hclog"
@@ -72,6 +73,76 @@ func newPlanner(s *Server) (*planner, error) {
 // the plan queue, determines if they can be applied safely and applies
 // them via Raft.
 //
+// Plans are dispatched to a shard per node pool, so plans of jobs in
+// different node pools are verified and applied concurrently. Plans that
+// change the same nodes, count against the same quota or belong to the same
+// job must still be serialized, so each plan holds keys for those until it is
+// committed. A plan whose keys are held by another shard is dispatched to that
+// shard instead, and a plan whose keys are held by several shards waits for
+// them to be released. A plan dispatched to a shard is evaluated against a
+// snapshot that includes every plan previously committed for its keys.
+//
+// The shards share a single pool of workers to evaluate plans, and shards
+// that don't receive plans for planShardIdleTimeout are stopped.
+func (p *planner) planApply() {
+	locks := newPlanKeyLocks()
+	shards := make(map[string]*planShard)
+
+	// Setup a worker pool with half the cores, with at least 1
+	poolSize := runtime.NumCPU() / 2
+	if poolSize == 0 {
+		poolSize = 1
+	}
+	pool := NewEvaluatePool(poolSize, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	var wg sync.WaitGroup
+	defer func() {
+		for _, shard := range shards {
+			close(shard.plans)
+		}
+		wg.Wait()
+	}()
+
+	for {
+		// Pull the next pending plan, exit if we are no longer leader
+		pending, err := p.planQueue.Dequeue(planShardIdleTimeout)
+		if err != nil {
+			return
+		}
+
+		stopIdleShards(shards, locks)
+		if pending == nil {
+			continue
+		}
+
+		name, keys, err := planPartition(p.srv.State(), pending.plan)
+		if err != nil {
+			p.srv.logger.Error("failed to partition plan", "error", err)
+			pending.respond(nil, err)
+			continue
+		}
+
+		name, minIndex := locks.acquire(name, keys)
+		shard, ok := shards[name]
+		if !ok {
+			shard = newPlanShard(name)
+			shards[name] = shard
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p.applyShardPlans(shard, locks, pool)
+			}()
+		}
+		shard.lastDispatch = time.Now()
+		shard.plans <- &shardPlan{pending: pending, keys: keys, minIndex: minIndex}
+	}
+}
+
+// applyShardPlans is a long lived goroutine that determines if the plans
+// dispatched to a shard can be applied safely and applies them via Raft.
+//
 // Naively, we could simply dequeue a plan, verify, apply and then respond.
 // However, the plan application is bounded by the Raft apply time and
 // subject to some latency. This creates a stall condition, where we are
@@ -92,8 +163,10 @@ func newPlanner(s *Server) (*planner, error) {
 // wasted work during a time we would have been waiting anyways. However,
 // in anticipation of this case we cannot respond to the plan until
 // the Raft log is updated. This means our schedulers will stall,
-// but there are many of those and only a single plan verifier.
-func (p *planner) planApply() {
+// but there are many of those and only a single plan verifier per shard.
+func (p *planner) applyShardPlans(shard *planShard, locks *planKeyLocks, pool *EvaluatePool) {
+	labels := []metrics.Label{{Name: "node_pool", Value: shard.name}}
+
 	// planIndexCh is used to track an outstanding application and receive
 	// its committed index while snap holds an optimistic state which
 	// includes that plan application.
@@ -105,24 +178,27 @@ func (p *planner) planApply() {
 	// snapshot, it's possible the current snapshot's and plan's indexes
 	// are less than the index the previous plan result was committed at.
 	// prevPlanResultIndex also guards against the previous plan committing
-	// during Dequeue, thus causing the snapshot containing the optimistic
-	// commit to be discarded and potentially evaluating the current plan
-	// against an index older than the previous plan was committed at.
+	// while waiting for the next plan, thus causing the snapshot containing
+	// the optimistic commit to be discarded and potentially evaluating the
+	// current plan against an index older than the previous plan was
+	// committed at.
 	var prevPlanResultIndex uint64
 
-	// Setup a worker pool with half the cores, with at least 1
-	poolSize := runtime.NumCPU() / 2
-	if poolSize == 0 {
-		poolSize = 1
-	}
-	pool := NewEvaluatePool(poolSize, workerPoolBufferSize)
-	defer pool.Shutdown()
+	for sp := range shard.plans {
+		pending := sp.pending
+		metrics.MeasureSinceWithLabels([]string{"nomad", "plan", "queue_latency"}, pending.enqueueTime, labels)
+		metrics.SetGaugeWithLabels([]string{"nomad", "plan", "shard_queue_depth"}, float32(len(shard.plans)), labels)
 
-	for {
-		// Pull the next pending plan, exit if we are no longer leader
-		pending, err := p.planQueue.Dequeue(0)
-		if err != nil {
-			return
+		// respond releases the plan's keys when it won't be applied
+		respond := func(result *structs.PlanResult, err error) {
+			pending.respond(result, err)
+			locks.release(sp.keys, 0)
+		}
+
+		// Plans dispatched before we lost leadership are flushed
+		if !p.planQueue.Enabled() {
+			respond(nil, planQueueFlushed)
+			continue
 		}
 
 		// If last plan has completed get a new snapshot
@@ -137,11 +213,15 @@ func (p *planner) planApply() {
 		default:
 		}
 
+		// The snapshot must also include the plans other shards committed
+		// for the keys of this plan.
+		prevIndex := max(prevPlanResultIndex, sp.minIndex)
+
 		if snap != nil {
 			// If snapshot doesn't contain the previous plan
 			// result's index and the current plan's snapshot it,
 			// discard it and get a new one below.
-			minIndex := max(prevPlanResultIndex, pending.plan.SnapshotIndex)
+			minIndex := max(prevIndex, pending.plan.SnapshotIndex)
 			if idx, err := snap.LatestIndex(); err != nil || idx < minIndex {
 				snap = nil
 			}
@@ -150,13 +230,22 @@ func (p *planner) planApply() {
 		// Snapshot the state so that we have a consistent view of the world
 		// if no snapshot is available.
 		//  - planIndexCh will be nil if the previous plan result applied
-		//    during Dequeue
+		//    while waiting for this plan
 		//  - snap will be nil if its index < max(prevIndex, curIndex)
 		if planIndexCh == nil || snap == nil {
-			snap, err = p.snapshotMinIndex(prevPlanResultIndex, pending.plan.SnapshotIndex)
+			// A discarded snapshot held the optimistic result of the
+			// outstanding application, so wait for it to be committed.
+			if planIndexCh != nil {
+				prevPlanResultIndex = max(prevPlanResultIndex, <-planIndexCh)
+				prevIndex = max(prevIndex, prevPlanResultIndex)
+				planIndexCh = nil
+			}
+
+			var err error
+			snap, err = p.snapshotMinIndex(prevIndex, pending.plan.SnapshotIndex)
 			if err != nil {
 				p.srv.logger.Error("failed to snapshot state", "error", err)
-				pending.respond(nil, err)
+				respond(nil, err)
 				continue
 			}
 		}
@@ -165,7 +254,7 @@ func (p *planner) planApply() {
 		result, err := evaluatePlan(pool, snap, pending.plan, p.srv.logger)
 		if err != nil {
 			p.srv.logger.Error("failed to evaluate plan", "error", err)
-			pending.respond(nil, err)
+			respond(nil, err)
 			continue
 		}
 
@@ -178,7 +267,7 @@ func (p *planner) planApply() {
 
 		// Fast-path the response if there is nothing to do
 		if result.IsNoOp() {
-			pending.respond(result, nil)
+			respond(result, nil)
 			continue
 		}
 
@@ -188,10 +277,10 @@ func (p *planner) planApply() {
 			idx := <-planIndexCh
 			planIndexCh = nil
 			prevPlanResultIndex = max(prevPlanResultIndex, idx)
-			snap, err = p.snapshotMinIndex(prevPlanResultIndex, pending.plan.SnapshotIndex)
+			snap, err = p.snapshotMinIndex(max(prevPlanResultIndex, sp.minIndex), pending.plan.SnapshotIndex)
 			if err != nil {
 				p.srv.logger.Error("failed to update snapshot state", "error", err)
-				pending.respond(nil, err)
+				respond(nil, err)
 				continue
 			}
 		}
@@ -200,13 +289,22 @@ func (p *planner) planApply() {
 		future, err := p.applyPlan(pending.plan, result, snap)
 		if err != nil {
 			p.srv.logger.Error("failed to submit plan", "error", err)
-			pending.respond(nil, err)
+			respond(nil, err)
 			continue
 		}
 
-		// Respond to the plan in async; receive plan's committed index via chan
+		// Respond to the plan in async; receive plan's committed index via
+		// chan once its keys are released
 		planIndexCh = make(chan uint64, 1)
-		go p.asyncPlanWait(planIndexCh, future, result, pending)
+		go func(indexCh chan<- uint64, keys []string) {
+			defer close(indexCh)
+
+			committedCh := make(chan uint64, 1)
+			p.asyncPlanWait(committedCh, future, result, pending)
+			idx := <-committedCh
+			locks.release(keys, idx)
+			indexCh <- idx
+		}(planIndexCh, sp.keys)
 	}
 }
 
@@ -585,9 +683,10 @@ func evaluatePlanPlacements(pool *EvaluatePool, snap *state.StateSnapshot, plan
 		return
 	}
 
-	// Get the pool channels
+	// Get the pool request channel, and receive the results on a channel of
+	// our own since the pool is shared by the plan shards
 	req := pool.RequestCh()
-	resp := pool.ResultCh()
+	resp := make(chan evaluateResult, workerPoolBufferSize)
 	outstanding := 0
 	didCancel := false
 
@@ -597,7 +696,7 @@ OUTER:
 	for len(nodeIDList) > 0 {
 		nodeID := nodeIDList[0]
 		select {
-		case req <- evaluateRequest{snap, plan, nodeID}:
+		case req <- evaluateRequest{snap: snap, plan: plan, nodeID: nodeID, res: resp}:
 			outstanding++
 			nodeIDList = nodeIDList[1:]
 		case r := <-resp:
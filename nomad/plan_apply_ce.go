@@ -12,7 +12,7 @@ import (
 )
 
 // refreshIndex returns the index the scheduler should refresh to as the maximum
-// of both the allocation and node tables.
+// of the allocation, node and quota specification tables.
 func refreshIndex(snap *state.StateSnapshot) (uint64, error) {
 	allocIndex, err := snap.Index("allocs")
 	if err != nil {
@@ -22,10 +22,85 @@ func refreshIndex(snap *state.StateSnapshot) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return maxUint64(nodeIndex, allocIndex), nil
+	quotaIndex, err := snap.Index(state.TableQuotaSpec)
+	if err != nil {
+		return 0, err
+	}
+	return max(nodeIndex, allocIndex, quotaIndex), nil
 }
 
 // evaluatePlanQuota returns whether the plan would be over quota
-func evaluatePlanQuota(_ *state.StateSnapshot, _ *structs.Plan) (bool, error) {
+func evaluatePlanQuota(snap *state.StateSnapshot, plan *structs.Plan) (bool, error) {
+	// Cache the quota of each namespace the plan touches
+	var lookupErr error
+	nsQuotas := make(map[string]string)
+	namespaceQuota := func(namespace string) string {
+		quota, ok := nsQuotas[namespace]
+		if !ok {
+			ns, err := snap.NamespaceByName(nil, namespace)
+			if err != nil {
+				lookupErr = err
+			} else if ns != nil {
+				quota = ns.Quota
+			}
+			nsQuotas[namespace] = quota
+		}
+		return quota
+	}
+
+	// Only quotas of namespaces receiving placements can be exceeded
+	quotas := make(map[string]struct{})
+	for _, allocs := range plan.NodeAllocation {
+		for _, alloc := range allocs {
+			if quota := namespaceQuota(alloc.Namespace); quota != "" {
+				quotas[quota] = struct{}{}
+			}
+		}
+	}
+	if lookupErr != nil {
+		return false, lookupErr
+	}
+
+	region := snap.Config().Region
+	existing := func(allocID string) (*structs.Allocation, error) {
+		return snap.AllocByID(nil, allocID)
+	}
+
+	for quota := range quotas {
+		spec, err := snap.QuotaSpecByName(nil, quota)
+		if err != nil {
+			return false, err
+		}
+		if spec == nil {
+			continue
+		}
+		limit := spec.RegionLimit(region)
+		if limit == nil {
+			continue
+		}
+
+		var used *structs.QuotaResources
+		usage, err := snap.QuotaUsageByName(nil, quota)
+		if err != nil {
+			return false, err
+		}
+		if usage != nil && usage.Used[limit.HashKey()] != nil {
+			used = usage.Used[limit.HashKey()].RegionLimit
+		}
+
+		inQuota := func(namespace string) bool { return namespaceQuota(namespace) == quota }
+		delta, err := plan.QuotaUsageDelta(inQuota, existing)
+		if err != nil {
+			return false, err
+		}
+		if lookupErr != nil {
+			return false, lookupErr
+		}
+
+		if len(limit.RegionLimit.Exceeds(used, delta)) != 0 {
+			return true, nil
+		}
+	}
+
 	return false, nil
 }
@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
@@ -43,6 +44,10 @@ type CachedBadNodeTracker struct {
 	limiter   *rate.Limiter
 	window    time.Duration
 	threshold int
+
+	// l guards the stats of tracked nodes, which are recorded by
+	// concurrent plan shards.
+	l sync.Mutex
 }
 
 type CachedBadNodeTrackerConfig struct {
@@ -96,6 +101,9 @@ func NewCachedBadNodeTracker(logger hclog.Logger, config CachedBadNodeTrackerCon
 // cache. If the cache is full the least recently updated or accessed node is
 // evicted.
 func (c *CachedBadNodeTracker) Add(nodeID string) bool {
+	c.l.Lock()
+	defer c.l.Unlock()
+
 	stats, ok := c.cache.Get(nodeID)
 	if !ok {
 		stats = newBadNodeStats(nodeID, c.window)
@@ -148,6 +156,9 @@ func (c *CachedBadNodeTracker) isBad(t time.Time, stats *badNodeStats) bool {
 }
 
 func (c *CachedBadNodeTracker) emitStats() {
+	c.l.Lock()
+	defer c.l.Unlock()
+
 	now := time.Now()
 	for _, nodeID := range c.cache.Keys() {
 		stats, _ := c.cache.Get(nodeID)
@@ -29,6 +29,10 @@ type evaluateRequest struct {
 	snap   *state.StateSnapshot
 	plan   *structs.Plan
 	nodeID string
+
+	// res receives the result of the request if set, so callers sharing the
+	// pool each get their own results. Otherwise it is sent to ResultCh.
+	res chan<- evaluateResult
 }
 
 type evaluateResult struct {
@@ -107,7 +111,11 @@ func (p *EvaluatePool) run(stopCh chan struct{}) {
 		select {
 		case req := <-p.req:
 			fit, reason, err := evaluateNodePlan(req.snap, req.plan, req.nodeID)
-			p.res <- evaluateResult{req.nodeID, fit, reason, err}
+			var res chan<- evaluateResult = p.res
+			if req.res != nil {
+				res = req.res
+			}
+			res <- evaluateResult{req.nodeID, fit, reason, err}
 
 		case <-stopCh:
 			return
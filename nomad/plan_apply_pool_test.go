@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 )
 
 func TestEvaluatePool(t *testing.T) {
@@ -30,7 +31,7 @@ func TestEvaluatePool(t *testing.T) {
 
 	// Push a request
 	req := pool.RequestCh()
-	req <- evaluateRequest{snap, plan, node.ID}
+	req <- evaluateRequest{snap: snap, plan: plan, nodeID: node.ID}
 
 	// Get the response
 	res := <-pool.ResultCh()
@@ -44,6 +45,43 @@ func TestEvaluatePool(t *testing.T) {
 	}
 }
 
+func TestEvaluatePool_ResultChannel(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+	node1, node2 := mock.Node(), mock.Node()
+	state.UpsertNode(structs.MsgTypeTestSetup, 1000, node1)
+	state.UpsertNode(structs.MsgTypeTestSetup, 1001, node2)
+	snap, _ := state.Snapshot()
+
+	plan := &structs.Plan{
+		NodeAllocation: map[string][]*structs.Allocation{
+			node1.ID: {mock.Alloc()},
+			node2.ID: {mock.Alloc()},
+		},
+	}
+
+	pool := NewEvaluatePool(2, 4)
+	defer pool.Shutdown()
+
+	// Requests with their own result channel are not answered on the shared
+	// one
+	resCh1, resCh2 := make(chan evaluateResult, 1), make(chan evaluateResult, 1)
+	pool.RequestCh() <- evaluateRequest{snap: snap, plan: plan, nodeID: node1.ID, res: resCh1}
+	pool.RequestCh() <- evaluateRequest{snap: snap, plan: plan, nodeID: node2.ID, res: resCh2}
+
+	res := <-resCh1
+	must.NoError(t, res.err)
+	must.Eq(t, node1.ID, res.nodeID)
+	must.True(t, res.fit)
+
+	res = <-resCh2
+	must.NoError(t, res.err)
+	must.Eq(t, node2.ID, res.nodeID)
+	must.True(t, res.fit)
+
+	must.Zero(t, len(pool.ResultCh()))
+}
+
 func TestEvaluatePool_Resize(t *testing.T) {
 	ci.Parallel(t)
 	pool := NewEvaluatePool(1, 4)
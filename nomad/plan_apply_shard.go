@@ -0,0 +1,236 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// planShardBufferSize is the number of plans dispatched to a shard that
+	// can wait for it to be evaluated. It is kept small so the priority
+	// ordering of the plan queue is mostly preserved across shards.
+	planShardBufferSize = 8
+
+	// planShardIdleTimeout is how long a shard can go without being
+	// dispatched a plan before it is stopped. A stopped shard is started
+	// again by the next plan for its node pool.
+	planShardIdleTimeout = 5 * time.Minute
+)
+
+// planShard evaluates and applies the plans of jobs in a node pool. Each shard
+// pipelines its plans like a single plan applier would, while shards run
+// concurrently with each other.
+type planShard struct {
+	// name is the node pool of the jobs whose plans are applied by the shard.
+	name string
+
+	// plans are the plans dispatched to the shard, in priority order.
+	plans chan *shardPlan
+
+	// lastDispatch is when the last plan was dispatched to the shard.
+	lastDispatch time.Time
+}
+
+// newPlanShard returns a new shard for the given node pool.
+func newPlanShard(name string) *planShard {
+	return &planShard{
+		name:  name,
+		plans: make(chan *shardPlan, planShardBufferSize),
+	}
+}
+
+// stopIdleShards stops the shards that haven't been dispatched a plan for
+// planShardIdleTimeout and removes them from shards. Shards holding plan keys
+// still have plans waiting or being applied, so they are kept.
+func stopIdleShards(shards map[string]*planShard, locks *planKeyLocks) {
+	for name, shard := range shards {
+		if time.Since(shard.lastDispatch) < planShardIdleTimeout || locks.holding(name) {
+			continue
+		}
+		close(shard.plans)
+		delete(shards, name)
+	}
+}
+
+// shardPlan is a plan dispatched to a shard with the keys it holds.
+type shardPlan struct {
+	pending *pendingPlan
+
+	// keys are the plan keys held by the shard until the plan is committed.
+	keys []string
+
+	// minIndex is the highest index another shard committed a plan at for
+	// any of the keys. The plan must be evaluated against a snapshot that
+	// includes it.
+	minIndex uint64
+}
+
+// planKeyLocks tracks the plan keys held by shards. A key is held by a single
+// shard at a time, from when a plan is dispatched until it is committed, so
+// plans that share a node, quota or job are serialized through one shard.
+type planKeyLocks struct {
+	l    sync.Mutex
+	cond *sync.Cond
+
+	// held are the keys held by shards for dispatched plans.
+	held map[string]*planKeyHold
+
+	// released is the highest index a plan holding each key was committed at.
+	released map[string]uint64
+}
+
+// planKeyHold is the shard holding a key and the number of its plans that
+// hold it.
+type planKeyHold struct {
+	shard string
+	count int
+}
+
+// newPlanKeyLocks returns a new set of plan key locks.
+func newPlanKeyLocks() *planKeyLocks {
+	k := &planKeyLocks{
+		held:     make(map[string]*planKeyHold),
+		released: make(map[string]uint64),
+	}
+	k.cond = sync.NewCond(&k.l)
+	return k
+}
+
+// acquire holds the keys for a plan and returns the shard it must be
+// dispatched to with the minimum index its snapshot must include. The plan
+// goes to the given shard unless another shard already holds some of its
+// keys, in which case it joins that shard. If the keys are held by more than
+// one shard, acquire blocks until they are released.
+func (k *planKeyLocks) acquire(shard string, keys []string) (string, uint64) {
+	k.l.Lock()
+	defer k.l.Unlock()
+
+	for {
+		holders := make(map[string]struct{})
+		for _, key := range keys {
+			if hold, ok := k.held[key]; ok {
+				holders[hold.shard] = struct{}{}
+			}
+		}
+		if len(holders) > 1 {
+			k.cond.Wait()
+			continue
+		}
+		for holder := range holders {
+			shard = holder
+		}
+		break
+	}
+
+	var minIndex uint64
+	for _, key := range keys {
+		minIndex = max(minIndex, k.released[key])
+
+		hold, ok := k.held[key]
+		if !ok {
+			hold = &planKeyHold{shard: shard}
+			k.held[key] = hold
+		}
+		hold.count++
+	}
+	return shard, minIndex
+}
+
+// release releases the keys of a plan committed at the given index. The index
+// is 0 if the plan wasn't committed.
+func (k *planKeyLocks) release(keys []string, index uint64) {
+	k.l.Lock()
+	defer k.l.Unlock()
+
+	for _, key := range keys {
+		if index > k.released[key] {
+			k.released[key] = index
+		}
+		if hold, ok := k.held[key]; ok {
+			hold.count--
+			if hold.count == 0 {
+				delete(k.held, key)
+			}
+		}
+	}
+	k.cond.Broadcast()
+}
+
+// holding returns whether the shard holds any keys.
+func (k *planKeyLocks) holding(shard string) bool {
+	k.l.Lock()
+	defer k.l.Unlock()
+
+	for _, hold := range k.held {
+		if hold.shard == shard {
+			return true
+		}
+	}
+	return false
+}
+
+// planPartition returns the shard a plan should be applied by and the keys it
+// must hold while being applied. The shard is the node pool of the plan's job.
+// The keys are the nodes the plan changes, the quotas its placements count
+// against and its job.
+func planPartition(store *state.StateStore, plan *structs.Plan) (string, []string, error) {
+	job := plan.Job
+	var namespace, jobID string
+	if job != nil {
+		namespace, jobID = job.Namespace, job.ID
+	} else if plan.JobInfo != nil {
+		namespace, jobID = plan.JobInfo.Namespace, plan.JobInfo.ID
+
+		var err error
+		job, err = store.JobByID(nil, namespace, jobID)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	// Plans of jobs that no longer exist only stop allocations, so apply
+	// them with the plans of any node pool.
+	shard := structs.NodePoolAll
+	if job != nil && job.NodePool != "" {
+		shard = job.NodePool
+	}
+
+	keys := []string{"job:" + namespace + "/" + jobID}
+	for _, nodes := range []map[string][]*structs.Allocation{
+		plan.NodeUpdate, plan.NodeAllocation, plan.NodePreemptions,
+	} {
+		for nodeID := range nodes {
+			keys = append(keys, "node:"+nodeID)
+		}
+	}
+
+	// Only quotas of namespaces receiving placements are checked by
+	// evaluatePlanQuota, so only they need to be serialized.
+	quotas := make(map[string]string)
+	for _, allocs := range plan.NodeAllocation {
+		for _, alloc := range allocs {
+			if _, ok := quotas[alloc.Namespace]; ok {
+				continue
+			}
+			ns, err := store.NamespaceByName(nil, alloc.Namespace)
+			if err != nil {
+				return "", nil, err
+			}
+			quotas[alloc.Namespace] = ""
+			if ns != nil && ns.Quota != "" {
+				quotas[alloc.Namespace] = ns.Quota
+				keys = append(keys, "quota:"+ns.Quota)
+			}
+		}
+	}
+
+	slices.Sort(keys)
+	return shard, slices.Compact(keys), nil
+}
@@ -0,0 +1,213 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestPlanKeyLocks(t *testing.T) {
+	ci.Parallel(t)
+
+	locks := newPlanKeyLocks()
+
+	shard, minIndex := locks.acquire("a", []string{"n1"})
+	must.Eq(t, "a", shard)
+	must.Zero(t, minIndex)
+
+	// Plans sharing a key with another shard join it.
+	shard, _ = locks.acquire("b", []string{"n1", "n2"})
+	must.Eq(t, "a", shard)
+
+	shard, _ = locks.acquire("b", []string{"n3"})
+	must.Eq(t, "b", shard)
+
+	// Plans with keys held by several shards wait for them to be released.
+	type acquired struct {
+		shard    string
+		minIndex uint64
+	}
+	acquiredCh := make(chan acquired, 1)
+	go func() {
+		shard, minIndex := locks.acquire("c", []string{"n2", "n3"})
+		acquiredCh <- acquired{shard, minIndex}
+	}()
+
+	locks.release([]string{"n1"}, 5)
+	select {
+	case <-acquiredCh:
+		t.Fatal("plan acquired keys held by several shards")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Once only one shard holds its keys, the plan joins it and its snapshot
+	// must include the commits of its keys.
+	locks.release([]string{"n3"}, 10)
+	select {
+	case got := <-acquiredCh:
+		must.Eq(t, acquired{"a", 10}, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for keys")
+	}
+	must.Eq(t, &planKeyHold{shard: "a", count: 2}, locks.held["n2"])
+	must.Eq(t, &planKeyHold{shard: "a", count: 1}, locks.held["n3"])
+
+	// Released keys can be acquired by any shard.
+	locks.release([]string{"n1", "n2"}, 7)
+	locks.release([]string{"n2", "n3"}, 12)
+	shard, minIndex = locks.acquire("b", []string{"n1", "n2", "n4"})
+	must.Eq(t, "b", shard)
+	must.Eq(t, 12, minIndex)
+	must.MapLen(t, 3, locks.held)
+}
+
+func TestStopIdleShards(t *testing.T) {
+	ci.Parallel(t)
+
+	locks := newPlanKeyLocks()
+	idle := time.Now().Add(-planShardIdleTimeout)
+
+	shards := map[string]*planShard{
+		"idle":    newPlanShard("idle"),
+		"holding": newPlanShard("holding"),
+		"active":  newPlanShard("active"),
+	}
+	shards["idle"].lastDispatch = idle
+	shards["holding"].lastDispatch = idle
+	shards["active"].lastDispatch = time.Now()
+	locks.acquire("holding", []string{"n1"})
+
+	// Only the idle shard without plans waiting or being applied is stopped.
+	stopped := shards["idle"]
+	stopIdleShards(shards, locks)
+	must.MapLen(t, 2, shards)
+	must.MapContainsKeys(t, shards, []string{"holding", "active"})
+	_, ok := <-stopped.plans
+	must.False(t, ok)
+
+	// Once its plans are committed, the idle shard holding keys is stopped.
+	locks.release([]string{"n1"}, 10)
+	stopIdleShards(shards, locks)
+	must.MapLen(t, 1, shards)
+	must.MapContainsKey(t, shards, "active")
+}
+
+func TestPlanPartition(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	quota := mock.QuotaSpec()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 999, []*structs.QuotaSpec{quota}))
+
+	ns := mock.Namespace()
+	ns.Quota = quota.Name
+	must.NoError(t, store.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	pool := mock.NodePool()
+	must.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 1001, []*structs.NodePool{pool}))
+
+	job := mock.Job()
+	job.Namespace = ns.Name
+	job.NodePool = pool.Name
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, job))
+
+	alloc := mock.Alloc()
+	alloc.Namespace = ns.Name
+	stopped := mock.Alloc()
+
+	plan := &structs.Plan{
+		JobInfo:         &structs.PlanJobTuple{Namespace: job.Namespace, ID: job.ID},
+		NodeAllocation:  map[string][]*structs.Allocation{"n1": {alloc}},
+		NodeUpdate:      map[string][]*structs.Allocation{"n1": {stopped}, "n2": {stopped}},
+		NodePreemptions: map[string][]*structs.Allocation{"n3": {stopped}},
+	}
+	shard, keys, err := planPartition(store, plan)
+	must.NoError(t, err)
+	must.Eq(t, pool.Name, shard)
+	must.Eq(t, []string{
+		"job:" + ns.Name + "/" + job.ID,
+		"node:n1",
+		"node:n2",
+		"node:n3",
+		"quota:" + quota.Name,
+	}, keys)
+
+	// Plans of jobs that no longer exist can be applied by any shard.
+	plan.JobInfo.ID = "purged"
+	shard, _, err = planPartition(store, plan)
+	must.NoError(t, err)
+	must.Eq(t, structs.NodePoolAll, shard)
+}
+
+func TestPlanApply_Shards(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
+	store := s1.fsm.State()
+
+	prod, dev := mock.NodePool(), mock.NodePool()
+	prod.Name, dev.Name = "prod", "dev"
+	must.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 999, []*structs.NodePool{prod, dev}))
+
+	// Submit plans for jobs in different node pools, and for a second job
+	// sharing a node with the first.
+	var futures []PlanFuture
+	var allocs []*structs.Allocation
+	node := mock.Node()
+	for i, pool := range []string{"prod", "dev", "dev"} {
+		job := mock.Job()
+		job.NodePool = pool
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, uint64(1000+i), nil, job))
+
+		if i < 2 {
+			node = mock.Node()
+			node.NodePool = pool
+			testRegisterNode(t, s1, node)
+		}
+
+		alloc := mock.Alloc()
+		alloc.NodeID = node.ID
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.AllocatedResources.Shared.Networks = nil
+		alloc.AllocatedResources.Tasks["web"].Networks = nil
+		allocs = append(allocs, alloc)
+
+		future, err := s1.planQueue.Enqueue(&structs.Plan{
+			Priority:       job.Priority,
+			JobInfo:        &structs.PlanJobTuple{Namespace: job.Namespace, ID: job.ID},
+			NodeAllocation: map[string][]*structs.Allocation{node.ID: {alloc}},
+		})
+		must.NoError(t, err)
+		futures = append(futures, future)
+	}
+
+	for _, future := range futures {
+		result, err := future.Wait()
+		must.NoError(t, err)
+		must.NonZero(t, result.AllocIndex)
+	}
+	for _, alloc := range allocs {
+		out, err := store.AllocByID(nil, alloc.ID)
+		must.NoError(t, err)
+		must.NotNil(t, out)
+	}
+}
@@ -553,6 +553,62 @@ func TestPlanApply_EvalPlan_Simple(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalPlan_Quota(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	spec := mock.QuotaSpec()
+	spec.Limits[0].RegionLimit.CPU = 1000
+	must.NoError(t, state.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1001, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, state.UpsertNamespaces(1002, []*structs.Namespace{ns}))
+
+	existing := mock.Alloc()
+	existing.Namespace = ns.Name
+	existing.Job.Namespace = ns.Name
+	existing.NodeID = node.ID
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1003, nil, existing.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1004, []*structs.Allocation{existing}))
+
+	newAlloc := func() *structs.Allocation {
+		alloc := mock.Alloc()
+		alloc.Namespace = ns.Name
+		alloc.Job = existing.Job
+		alloc.JobID = existing.JobID
+		alloc.NodeID = node.ID
+		return alloc
+	}
+
+	pool := NewEvaluatePool(workerPoolSize, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	// Two new allocations would go over the quota.
+	plan := &structs.Plan{
+		Job: existing.Job,
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {newAlloc(), newAlloc()},
+		},
+	}
+	snap, err := state.Snapshot()
+	must.NoError(t, err)
+	result, err := evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.MapEmpty(t, result.NodeAllocation)
+	must.Eq(t, 1004, result.RefreshIndex)
+
+	// Stopping the existing allocation in the same plan makes room.
+	plan.NodeAllocation[node.ID] = plan.NodeAllocation[node.ID][:1]
+	plan.NodeUpdate = map[string][]*structs.Allocation{node.ID: {existing}}
+	result, err = evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.Len(t, 1, result.NodeAllocation[node.ID])
+	must.Zero(t, result.RefreshIndex)
+}
+
 func TestPlanApply_EvalPlan_Preemption(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
@@ -0,0 +1,317 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Quota endpoint is used for manipulating quota specifications and querying
+// their usage.
+type Quota struct {
+	srv *Server
+	ctx *RPCContext
+}
+
+func NewQuotaEndpoint(srv *Server, ctx *RPCContext) *Quota {
+	return &Quota{srv: srv, ctx: ctx}
+}
+
+// UpsertQuotaSpecs is used to upsert a set of quota specifications
+func (q *Quota) UpsertQuotaSpecs(args *structs.QuotaSpecUpsertRequest, reply *structs.GenericResponse) error {
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if q.srv.config.ACLEnabled || args.Region == "" {
+		// only forward to the authoritative region if ACLs are enabled,
+		// otherwise we silently write to the local region
+		args.Region = q.srv.config.AuthoritativeRegion
+	}
+	if done, err := q.srv.forward("Quota.UpsertQuotaSpecs", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "upsert_quota_specs"}, time.Now())
+
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !q.srv.peersCache.ServersMeetMinimumVersion(q.srv.Region(), minVersionQuotas, true) {
+		return fmt.Errorf("all servers must be running version %v or later to upsert quotas", minVersionQuotas)
+	}
+
+	if len(args.Quotas) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one quota specification")
+	}
+	for _, spec := range args.Quotas {
+		if err := spec.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid quota %q: %v", spec.Name, err)
+		}
+		spec.SetHash()
+	}
+
+	_, index, err := q.srv.raftApply(structs.QuotaSpecUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// DeleteQuotaSpecs is used to delete a set of quota specifications
+func (q *Quota) DeleteQuotaSpecs(args *structs.QuotaSpecDeleteRequest, reply *structs.GenericResponse) error {
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if q.srv.config.ACLEnabled || args.Region == "" {
+		// only forward to the authoritative region if ACLs are enabled,
+		// otherwise we silently write to the local region
+		args.Region = q.srv.config.AuthoritativeRegion
+	}
+	if done, err := q.srv.forward("Quota.DeleteQuotaSpecs", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "delete_quota_specs"}, time.Now())
+
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !q.srv.peersCache.ServersMeetMinimumVersion(q.srv.Region(), minVersionQuotas, true) {
+		return fmt.Errorf("all servers must be running version %v or later to delete quotas", minVersionQuotas)
+	}
+
+	if len(args.Names) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one quota specification to delete")
+	}
+
+	// Namespaces are global, so a quota still referenced locally is
+	// referenced in every region.
+	snap, err := q.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, name := range args.Names {
+		iter, err := snap.Namespaces(nil)
+		if err != nil {
+			return err
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			if ns := raw.(*structs.Namespace); ns.Quota == name {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest,
+					"quota %q is in use by namespace %q", name, ns.Name)
+			}
+		}
+	}
+
+	_, index, err := q.srv.raftApply(structs.QuotaSpecDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// ListQuotaSpecs is used to list the quota specifications
+func (q *Quota) ListQuotaSpecs(args *structs.QuotaSpecListRequest, reply *structs.QuotaSpecListResponse) error {
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if done, err := q.srv.forward("Quota.ListQuotaSpecs", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "list_quota_specs"}, time.Now())
+
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = store.QuotaSpecsByNamePrefix(ws, prefix)
+			} else {
+				iter, err = store.QuotaSpecs(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.Quotas = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				reply.Quotas = append(reply.Quotas, raw.(*structs.QuotaSpec))
+			}
+
+			// Use the last index that affected the quota spec table.
+			index, err := store.Index(state.TableQuotaSpec)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+			return nil
+		}}
+	return q.srv.blockingRPC(&opts)
+}
+
+// GetQuotaSpec is used to get a specific quota specification
+func (q *Quota) GetQuotaSpec(args *structs.QuotaSpecSpecificRequest, reply *structs.SingleQuotaSpecResponse) error {
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if done, err := q.srv.forward("Quota.GetQuotaSpec", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "get_quota_spec"}, time.Now())
+
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			spec, err := store.QuotaSpecByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			reply.Quota = spec
+			if spec != nil {
+				reply.Index = spec.ModifyIndex
+			} else {
+				// Return the last index that affected the quota spec table
+				// if the requested quota doesn't exist.
+				index, err := store.Index(state.TableQuotaSpec)
+				if err != nil {
+					return err
+				}
+				reply.Index = max(1, index)
+			}
+			return nil
+		}}
+	return q.srv.blockingRPC(&opts)
+}
+
+// ListQuotaUsages is used to list the usage of the quota specifications in
+// the region
+func (q *Quota) ListQuotaUsages(args *structs.QuotaSpecListRequest, reply *structs.QuotaUsageListResponse) error {
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if done, err := q.srv.forward("Quota.ListQuotaUsages", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "list_quota_usages"}, time.Now())
+
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = store.QuotaUsagesByNamePrefix(ws, prefix)
+			} else {
+				iter, err = store.QuotaUsages(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.Usages = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				reply.Usages = append(reply.Usages, raw.(*structs.QuotaUsage))
+			}
+
+			// Use the last index that affected the quota usage table.
+			index, err := store.Index(state.TableQuotaUsage)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+			return nil
+		}}
+	return q.srv.blockingRPC(&opts)
+}
+
+// GetQuotaUsage is used to get the usage of a specific quota specification in
+// the region
+func (q *Quota) GetQuotaUsage(args *structs.QuotaSpecSpecificRequest, reply *structs.SingleQuotaUsageResponse) error {
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if done, err := q.srv.forward("Quota.GetQuotaUsage", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "get_quota_usage"}, time.Now())
+
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			usage, err := store.QuotaUsageByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			reply.Usage = usage
+			if usage != nil {
+				reply.Index = usage.ModifyIndex
+			} else {
+				// Return the last index that affected the quota usage table
+				// if the requested quota doesn't exist.
+				index, err := store.Index(state.TableQuotaUsage)
+				if err != nil {
+					return err
+				}
+				reply.Index = max(1, index)
+			}
+			return nil
+		}}
+	return q.srv.blockingRPC(&opts)
+}
@@ -0,0 +1,122 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestQuotaEndpoint_UpsertGetDelete(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	spec := mock.QuotaSpec()
+	upsertReq := &structs.QuotaSpecUpsertRequest{
+		Quotas:       []*structs.QuotaSpec{spec},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsertReq, &upsertResp))
+	must.NonZero(t, upsertResp.Index)
+
+	getReq := &structs.QuotaSpecSpecificRequest{
+		Name:         spec.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleQuotaSpecResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaSpec", getReq, &getResp))
+	must.NotNil(t, getResp.Quota)
+	must.Eq(t, spec.Hash, getResp.Quota.Hash)
+
+	var usageResp structs.SingleQuotaUsageResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaUsage", getReq, &usageResp))
+	must.NotNil(t, usageResp.Usage)
+	must.MapLen(t, 1, usageResp.Usage.Used)
+
+	listReq := &structs.QuotaSpecListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.QuotaSpecListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.ListQuotaSpecs", listReq, &listResp))
+	must.Len(t, 1, listResp.Quotas)
+
+	// Invalid quotas are rejected.
+	invalid := spec.Copy()
+	invalid.Limits = nil
+	upsertReq.Quotas = []*structs.QuotaSpec{invalid}
+	err := msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsertReq, &upsertResp)
+	must.ErrorContains(t, err, "at least one limit")
+
+	// Quotas in use by a namespace can't be deleted.
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, s.fsm.State().UpsertNamespaces(upsertResp.Index+1, []*structs.Namespace{ns}))
+
+	deleteReq := &structs.QuotaSpecDeleteRequest{
+		Names:        []string{spec.Name},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var deleteResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, "Quota.DeleteQuotaSpecs", deleteReq, &deleteResp)
+	must.ErrorContains(t, err, "in use by namespace")
+
+	must.NoError(t, s.fsm.State().DeleteNamespaces(upsertResp.Index+2, []string{ns.Name}))
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.DeleteQuotaSpecs", deleteReq, &deleteResp))
+
+	getResp = structs.SingleQuotaSpecResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaSpec", getReq, &getResp))
+	must.Nil(t, getResp.Quota)
+}
+
+func TestQuotaEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	readToken := mock.CreatePolicyAndToken(t, s.fsm.State(), 1001, "quota-read",
+		mock.QuotaPolicy(acl.PolicyRead))
+
+	spec := mock.QuotaSpec()
+	upsertReq := &structs.QuotaSpecUpsertRequest{
+		Quotas: []*structs.QuotaSpec{spec},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var upsertResp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsertReq, &upsertResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsertReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsertReq, &upsertResp))
+
+	listReq := &structs.QuotaSpecListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.QuotaSpecListResponse
+	err = msgpackrpc.CallWithCodec(codec, "Quota.ListQuotaSpecs", listReq, &listResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	listReq.AuthToken = readToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.ListQuotaSpecs", listReq, &listResp))
+	must.Len(t, 1, listResp.Quotas)
+}
@@ -0,0 +1,397 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler/feasible"
+)
+
+// rebalanceWindow is the window over which the rebalancer enforces the
+// maximum number of migrations.
+const rebalanceWindow = time.Hour
+
+// rebalanceNode is a node considered by the rebalancer along with its bin
+// packing score before any migration.
+type rebalanceNode struct {
+	node  *structs.Node
+	score float64
+}
+
+// rebalancer computes which running service allocations to migrate so they
+// are packed onto fewer nodes. It only proposes migrations and never writes
+// to the state store, so it's safe to use for dry-run reports.
+type rebalancer struct {
+	snap        *state.StateSnapshot
+	schedConfig *structs.SchedulerConfiguration
+	logger      log.Logger
+	now         time.Time
+
+	// ctx tracks the proposed migrations in its plan, so the capacity they
+	// use and free is accounted for by later decisions.
+	ctx *feasible.EvalContext
+
+	// nodes are the ready nodes, in increasing order of bin packing score.
+	nodes []*rebalanceNode
+
+	// budget is the number of migrations left within the current window.
+	budget int
+
+	// groupBudgets is the negated number of migrations in flight for each
+	// task group, keyed by namespace, job ID and task group name.
+	groupBudgets map[string]int
+
+	report *structs.RebalanceReport
+}
+
+// computeRebalance returns the migrations the rebalancer would make against
+// the snapshot.
+func computeRebalance(snap *state.StateSnapshot, schedConfig *structs.SchedulerConfiguration,
+	now time.Time, logger log.Logger) (*structs.RebalanceReport, error) {
+
+	plan := &structs.Plan{
+		NodeUpdate:      make(map[string][]*structs.Allocation),
+		NodeAllocation:  make(map[string][]*structs.Allocation),
+		NodePreemptions: make(map[string][]*structs.Allocation),
+	}
+	r := &rebalancer{
+		snap:         snap,
+		schedConfig:  schedConfig,
+		logger:       logger.Named("rebalance"),
+		now:          now,
+		ctx:          feasible.NewEvalContext(nil, snap, plan, logger),
+		groupBudgets: make(map[string]int),
+		report: &structs.RebalanceReport{
+			MaxMigrationsPerHour: schedConfig.RebalanceConfig.EffectiveMaxMigrationsPerHour(),
+		},
+	}
+
+	if err := r.computeUsage(); err != nil {
+		return nil, err
+	}
+	r.budget = r.report.MaxMigrationsPerHour - r.report.MigrationsUsed
+	if r.budget <= 0 {
+		return r.report, nil
+	}
+
+	if err := r.scoreNodes(); err != nil {
+		return nil, err
+	}
+	for i, source := range r.nodes {
+		if r.budget <= 0 {
+			break
+		}
+		if err := r.drainNode(source, r.nodes[i+1:]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.report, nil
+}
+
+// computeUsage counts the migrations the rebalancer made within the window
+// and the migrations still in flight for each task group.
+func (r *rebalancer) computeUsage() error {
+	iter, err := r.snap.Allocs(nil, state.SortDefault)
+	if err != nil {
+		return err
+	}
+
+	var allocs []*structs.Allocation
+	rebalanced := make(map[string]struct{})
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		alloc := raw.(*structs.Allocation)
+		allocs = append(allocs, alloc)
+		if alloc.DesiredTransition.ShouldRebalance() {
+			rebalanced[alloc.ID] = struct{}{}
+		}
+	}
+
+	cutoff := r.now.Add(-rebalanceWindow).UnixNano()
+	for _, alloc := range allocs {
+		_, replacesRebalanced := rebalanced[alloc.PreviousAllocation]
+		if replacesRebalanced && alloc.CreateTime >= cutoff {
+			r.report.MigrationsUsed++
+		}
+		if alloc.TerminalStatus() {
+			continue
+		}
+
+		// The migration was requested but the scheduler hasn't replaced the
+		// allocation yet.
+		if alloc.DesiredTransition.ShouldRebalance() {
+			r.report.MigrationsUsed++
+		}
+
+		// Allocations being migrated, for any reason, or whose replacement
+		// isn't healthy yet count against the task group's migrate
+		// max_parallel.
+		switch {
+		case alloc.DesiredTransition.ShouldMigrate(),
+			alloc.ClientStatus == structs.AllocClientStatusPending,
+			replacesRebalanced && !alloc.DeploymentStatus.IsHealthy():
+			r.groupBudgets[rebalanceGroupKey(alloc.Namespace, alloc.JobID, alloc.TaskGroup)]--
+		}
+	}
+
+	return nil
+}
+
+// scoreNodes collects the ready nodes and sorts them from the least to the
+// most tightly packed.
+func (r *rebalancer) scoreNodes() error {
+	iter, err := r.snap.Nodes(nil)
+	if err != nil {
+		return err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+
+		score, err := r.nodeScore(node)
+		if err != nil {
+			return err
+		}
+		r.nodes = append(r.nodes, &rebalanceNode{node: node, score: score})
+	}
+
+	slices.SortStableFunc(r.nodes, func(a, b *rebalanceNode) int {
+		return cmp.Or(cmp.Compare(a.score, b.score), cmp.Compare(a.node.ID, b.node.ID))
+	})
+	return nil
+}
+
+// nodeScore returns the normalized bin packing score of the node given the
+// allocations proposed for it.
+func (r *rebalancer) nodeScore(node *structs.Node) (float64, error) {
+	proposed, err := r.ctx.ProposedAllocs(node.ID)
+	if err != nil {
+		return 0, err
+	}
+	_, _, used, err := structs.AllocsFit(node, proposed, nil, false)
+	if err != nil {
+		return 0, err
+	}
+	return feasible.NormalizedScoreFitBinPack(node, used), nil
+}
+
+// drainNode proposes migrations for the allocations of the source node onto
+// the more tightly packed target nodes.
+func (r *rebalancer) drainNode(source *rebalanceNode, targets []*rebalanceNode) error {
+	allocs, err := r.snap.AllocsByNodeTerminal(nil, source.node.ID, false)
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(allocs, func(a, b *structs.Allocation) int {
+		return cmp.Compare(a.CreateIndex, b.CreateIndex)
+	})
+
+	for _, alloc := range allocs {
+		if r.budget <= 0 {
+			return nil
+		}
+
+		job, tg, err := r.eligible(alloc)
+		if err != nil {
+			return err
+		}
+		if tg == nil {
+			continue
+		}
+
+		option, err := r.selectTarget(source, targets, job, tg)
+		if err != nil {
+			return err
+		}
+		if option == nil {
+			continue
+		}
+
+		r.propose(alloc, tg, source, option)
+	}
+	return nil
+}
+
+// eligible returns the job and task group of the allocation if the
+// rebalancer may migrate it, or a nil task group otherwise.
+func (r *rebalancer) eligible(alloc *structs.Allocation) (*structs.Job, *structs.TaskGroup, error) {
+	if alloc.DesiredStatus != structs.AllocDesiredStatusRun ||
+		alloc.ClientStatus != structs.AllocClientStatusRunning ||
+		alloc.DesiredTransition.ShouldMigrate() {
+		return nil, nil, nil
+	}
+
+	job, err := r.snap.JobByID(nil, alloc.Namespace, alloc.JobID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job == nil || job.Type != structs.JobTypeService || job.Stopped() ||
+		alloc.Job == nil || alloc.Job.Version != job.Version {
+		return nil, nil, nil
+	}
+
+	// Leave jobs with an active deployment to the deployment.
+	deployment, err := r.snap.LatestDeploymentByJobID(nil, job.Namespace, job.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if deployment != nil && deployment.Active() {
+		return nil, nil, nil
+	}
+
+	// Allocations with volumes or a sticky ephemeral disk are tied to the
+	// data on their node.
+	tg := job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || len(tg.Volumes) > 0 {
+		return nil, nil, nil
+	}
+	if disk := tg.EphemeralDisk; disk != nil && (disk.Sticky || disk.Migrate) {
+		return nil, nil, nil
+	}
+
+	// groupBudgets only holds the migrations in flight, so the budget left
+	// is relative to the task group's max_parallel.
+	key := rebalanceGroupKey(job.Namespace, job.ID, tg.Name)
+	if rebalanceGroupParallel(tg)+r.groupBudgets[key] <= 0 {
+		return nil, nil, nil
+	}
+
+	return job, tg, nil
+}
+
+// selectTarget returns the target node with the best bin packing score for
+// the task group, or nil if no target packs it more tightly than the source.
+func (r *rebalancer) selectTarget(source *rebalanceNode, targets []*rebalanceNode,
+	job *structs.Job, tg *structs.TaskGroup) (*feasible.RankedNode, error) {
+
+	tgConstr := feasible.TaskGroupConstraints(tg)
+	jobConstraints := feasible.NewConstraintChecker(r.ctx, job.Constraints)
+	tgConstraints := feasible.NewConstraintChecker(r.ctx, tgConstr.Constraints)
+	drivers := feasible.NewDriverChecker(r.ctx, tgConstr.Drivers)
+
+	ranked := make([]*feasible.RankedNode, 0, len(targets))
+	for _, target := range targets {
+		node := target.node
+		if target.score <= source.score ||
+			!node.IsInPool(job.NodePool) ||
+			!node.IsInAnyDC(job.Datacenters) ||
+			!jobConstraints.Feasible(node) ||
+			!tgConstraints.Feasible(node) ||
+			!drivers.Feasible(node) {
+			continue
+		}
+		ranked = append(ranked, &feasible.RankedNode{Node: node})
+	}
+	if len(ranked) == 0 {
+		return nil, nil
+	}
+
+	// Node pools that spread allocations don't want them packed.
+	schedConfig := r.schedConfig
+	if pool, err := r.snap.NodePoolByName(nil, job.NodePool); err != nil {
+		return nil, err
+	} else if pool != nil {
+		schedConfig = schedConfig.WithNodePool(pool)
+	}
+	if schedConfig.EffectiveSchedulerAlgorithm() != structs.SchedulerAlgorithmBinpack {
+		return nil, nil
+	}
+
+	binPack := feasible.NewBinPackIterator(r.ctx, feasible.NewStaticRankIterator(r.ctx, ranked), false, job.Priority)
+	binPack.SetJob(job)
+	binPack.SetTaskGroup(tg)
+	binPack.SetSchedulerConfiguration(schedConfig)
+
+	r.ctx.Reset()
+	var best *feasible.RankedNode
+	for option := binPack.Next(); option != nil; option = binPack.Next() {
+		if len(option.Scores) == 0 {
+			continue
+		}
+		if best == nil || option.Scores[0] > best.Scores[0] {
+			best = option
+		}
+	}
+
+	if best == nil || best.Scores[0] <= source.score {
+		return nil, nil
+	}
+	return best, nil
+}
+
+// propose records the migration of the allocation onto the target node in
+// the report and in the plan, so the capacity it uses is accounted for.
+func (r *rebalancer) propose(alloc *structs.Allocation, tg *structs.TaskGroup,
+	source *rebalanceNode, option *feasible.RankedNode) {
+
+	resources := &structs.AllocatedResources{
+		Tasks:          option.TaskResources,
+		TaskLifecycles: option.TaskLifecycles,
+		Shared: structs.AllocatedSharedResources{
+			DiskMB: int64(tg.EphemeralDisk.SizeMB),
+		},
+	}
+	if option.AllocResources != nil {
+		resources.Shared.Networks = option.AllocResources.Networks
+		resources.Shared.Ports = option.AllocResources.Ports
+	}
+
+	placement := &structs.Allocation{
+		ID:                 uuid.Generate(),
+		Namespace:          alloc.Namespace,
+		JobID:              alloc.JobID,
+		Job:                alloc.Job,
+		TaskGroup:          alloc.TaskGroup,
+		NodeID:             option.Node.ID,
+		AllocatedResources: resources,
+		DesiredStatus:      structs.AllocDesiredStatusRun,
+		ClientStatus:       structs.AllocClientStatusPending,
+	}
+
+	plan := r.ctx.Plan()
+	plan.AppendStoppedAlloc(alloc, "", "", "")
+	plan.AppendAlloc(placement, nil)
+
+	r.budget--
+	r.groupBudgets[rebalanceGroupKey(alloc.Namespace, alloc.JobID, alloc.TaskGroup)]--
+	r.report.Migrations = append(r.report.Migrations, &structs.RebalanceMigration{
+		AllocID:         alloc.ID,
+		Namespace:       alloc.Namespace,
+		JobID:           alloc.JobID,
+		TaskGroup:       alloc.TaskGroup,
+		NodeID:          source.node.ID,
+		TargetNodeID:    option.Node.ID,
+		NodeScore:       source.score,
+		TargetNodeScore: option.Scores[0],
+	})
+}
+
+// rebalanceGroupParallel returns the number of allocations of the task group
+// that may be migrating at the same time, honoring both its migrate and
+// update blocks.
+func rebalanceGroupParallel(tg *structs.TaskGroup) int {
+	parallel := 1
+	if tg.Migrate != nil && tg.Migrate.MaxParallel > 0 {
+		parallel = tg.Migrate.MaxParallel
+	}
+	if tg.Update != nil && tg.Update.MaxParallel > 0 {
+		parallel = min(parallel, tg.Update.MaxParallel)
+	}
+	return parallel
+}
+
+func rebalanceGroupKey(namespace, jobID, taskGroup string) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, jobID, taskGroup)
+}
@@ -0,0 +1,212 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// rebalanceTestCluster is a lightly used node and a busy node, where the busy
+// node runs a batch job the rebalancer never migrates.
+type rebalanceTestCluster struct {
+	store *state.StateStore
+	light *structs.Node
+	busy  *structs.Node
+	index uint64
+}
+
+func newRebalanceTestCluster(t *testing.T) *rebalanceTestCluster {
+	c := &rebalanceTestCluster{
+		store: state.TestStateStore(t),
+		light: mock.Node(),
+		busy:  mock.Node(),
+		index: 100,
+	}
+	must.NoError(t, c.store.UpsertNode(structs.MsgTypeTestSetup, c.nextIndex(), c.light))
+	must.NoError(t, c.store.UpsertNode(structs.MsgTypeTestSetup, c.nextIndex(), c.busy))
+
+	filler := mock.MinJob()
+	filler.Type = structs.JobTypeBatch
+	c.upsertJob(t, filler)
+	c.upsertAllocs(t, filler, c.busy, 8)
+	return c
+}
+
+func (c *rebalanceTestCluster) nextIndex() uint64 {
+	c.index++
+	return c.index
+}
+
+func (c *rebalanceTestCluster) upsertJob(t *testing.T, job *structs.Job) {
+	must.NoError(t, c.store.UpsertJob(structs.MsgTypeTestSetup, c.nextIndex(), nil, job))
+}
+
+func (c *rebalanceTestCluster) upsertAllocs(t *testing.T, job *structs.Job, node *structs.Node, count int) []*structs.Allocation {
+	allocs := make([]*structs.Allocation, 0, count)
+	for range count {
+		alloc := mock.MinAllocForJob(job)
+		alloc.NodeID = node.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.CreateTime = time.Now().UnixNano()
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, c.store.UpsertAllocs(structs.MsgTypeTestSetup, c.nextIndex(), allocs))
+	return allocs
+}
+
+func (c *rebalanceTestCluster) report(t *testing.T, config structs.RebalanceConfig) *structs.RebalanceReport {
+	snap, err := c.store.Snapshot()
+	must.NoError(t, err)
+	report, err := computeRebalance(snap, &structs.SchedulerConfiguration{RebalanceConfig: config},
+		time.Now(), testlog.HCLogger(t))
+	must.NoError(t, err)
+	return report
+}
+
+func TestRebalance_PacksLightNode(t *testing.T) {
+	ci.Parallel(t)
+
+	c := newRebalanceTestCluster(t)
+	job := mock.MinJob()
+	c.upsertJob(t, job)
+	allocs := c.upsertAllocs(t, job, c.light, 1)
+
+	report := c.report(t, structs.RebalanceConfig{Enabled: true})
+	must.Len(t, 1, report.Migrations)
+	must.Eq(t, structs.DefaultRebalanceMaxMigrationsPerHour, report.MaxMigrationsPerHour)
+	must.Zero(t, report.MigrationsUsed)
+
+	m := report.Migrations[0]
+	must.Eq(t, allocs[0].ID, m.AllocID)
+	must.Eq(t, c.light.ID, m.NodeID)
+	must.Eq(t, c.busy.ID, m.TargetNodeID)
+	must.Greater(t, m.NodeScore, m.TargetNodeScore)
+}
+
+func TestRebalance_Ineligible(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name  string
+		setup func(*structs.Job)
+	}{
+		{
+			name:  "batch job",
+			setup: func(job *structs.Job) { job.Type = structs.JobTypeBatch },
+		},
+		{
+			name:  "stopped job",
+			setup: func(job *structs.Job) { job.Stop = true },
+		},
+		{
+			name: "group with volumes",
+			setup: func(job *structs.Job) {
+				job.TaskGroups[0].Volumes = map[string]*structs.VolumeRequest{
+					"data": {Name: "data", Type: structs.VolumeTypeHost, Source: "data"},
+				}
+			},
+		},
+		{
+			name: "group with sticky ephemeral disk",
+			setup: func(job *structs.Job) {
+				job.TaskGroups[0].EphemeralDisk = &structs.EphemeralDisk{Sticky: true, SizeMB: 10}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newRebalanceTestCluster(t)
+			job := mock.MinJob()
+			tc.setup(job)
+			c.upsertJob(t, job)
+			c.upsertAllocs(t, job, c.light, 1)
+
+			report := c.report(t, structs.RebalanceConfig{Enabled: true})
+			must.SliceEmpty(t, report.Migrations)
+		})
+	}
+}
+
+func TestRebalance_MaxMigrationsPerHour(t *testing.T) {
+	ci.Parallel(t)
+
+	c := newRebalanceTestCluster(t)
+	for range 3 {
+		job := mock.MinJob()
+		c.upsertJob(t, job)
+		c.upsertAllocs(t, job, c.light, 1)
+	}
+
+	report := c.report(t, structs.RebalanceConfig{Enabled: true, MaxMigrationsPerHour: 2})
+	must.Len(t, 2, report.Migrations)
+	must.Eq(t, 2, report.MaxMigrationsPerHour)
+
+	// Mark an allocation as rebalanced and replace it, which uses one
+	// migration of the window.
+	job := mock.MinJob()
+	c.upsertJob(t, job)
+	previous := c.upsertAllocs(t, job, c.light, 1)[0].Copy()
+	previous.DesiredStatus = structs.AllocDesiredStatusStop
+	previous.ClientStatus = structs.AllocClientStatusComplete
+	previous.DesiredTransition = structs.DesiredTransition{
+		Migrate:   pointer.Of(true),
+		Rebalance: pointer.Of(true),
+	}
+	replacement := mock.MinAllocForJob(job)
+	replacement.NodeID = c.busy.ID
+	replacement.ClientStatus = structs.AllocClientStatusRunning
+	replacement.PreviousAllocation = previous.ID
+	replacement.CreateTime = time.Now().UnixNano()
+	replacement.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(true)}
+	must.NoError(t, c.store.UpsertAllocs(structs.MsgTypeTestSetup, c.nextIndex(),
+		[]*structs.Allocation{previous, replacement}))
+
+	report = c.report(t, structs.RebalanceConfig{Enabled: true, MaxMigrationsPerHour: 2})
+	must.Eq(t, 1, report.MigrationsUsed)
+	must.Len(t, 1, report.Migrations)
+
+	// Replacements from outside the window don't count.
+	replacement = replacement.Copy()
+	replacement.CreateTime = time.Now().Add(-2 * rebalanceWindow).UnixNano()
+	must.NoError(t, c.store.UpsertAllocs(structs.MsgTypeTestSetup, c.nextIndex(),
+		[]*structs.Allocation{replacement}))
+
+	report = c.report(t, structs.RebalanceConfig{Enabled: true, MaxMigrationsPerHour: 2})
+	must.Zero(t, report.MigrationsUsed)
+	must.Len(t, 2, report.Migrations)
+}
+
+func TestRebalance_MigrateMaxParallel(t *testing.T) {
+	ci.Parallel(t)
+
+	c := newRebalanceTestCluster(t)
+	job := mock.MinJob()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].Migrate = &structs.MigrateStrategy{MaxParallel: 2}
+	c.upsertJob(t, job)
+	allocs := c.upsertAllocs(t, job, c.light, 3)
+
+	report := c.report(t, structs.RebalanceConfig{Enabled: true})
+	must.Len(t, 2, report.Migrations)
+
+	// An allocation already migrating uses one of the task group's slots.
+	migrating := allocs[0].Copy()
+	migrating.DesiredTransition = structs.DesiredTransition{Migrate: pointer.Of(true)}
+	must.NoError(t, c.store.UpsertAllocs(structs.MsgTypeTestSetup, c.nextIndex(),
+		[]*structs.Allocation{migrating}))
+
+	report = c.report(t, structs.RebalanceConfig{Enabled: true})
+	must.Len(t, 1, report.Migrations)
+	must.NotEq(t, migrating.ID, report.Migrations[0].AllocID)
+}
@@ -0,0 +1,317 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Reservation endpoint is used for manipulating capacity reservations and
+// querying their usage.
+type Reservation struct {
+	srv *Server
+	ctx *RPCContext
+}
+
+func NewReservationEndpoint(srv *Server, ctx *RPCContext) *Reservation {
+	return &Reservation{srv: srv, ctx: ctx}
+}
+
+// UpsertReservations is used to upsert a set of capacity reservations. The
+// capacity of new reservations, or of reservations whose resources or node
+// pool changed, is placed on the nodes of their pool. Every upsert renews the
+// TTL of the reservations.
+func (r *Reservation) UpsertReservations(args *structs.ReservationUpsertRequest, reply *structs.GenericResponse) error {
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Reservation.UpsertReservations", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("reservation", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "reservation", "upsert_reservations"}, time.Now())
+
+	if aclObj, err := r.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !r.srv.peersCache.ServersMeetMinimumVersion(r.srv.Region(), minVersionReservations, true) {
+		return fmt.Errorf("all servers must be running version %v or later to upsert reservations", minVersionReservations)
+	}
+
+	if len(args.Reservations) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one reservation")
+	}
+
+	snap, err := r.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	active, err := activeReservations(&snap.StateStore, now)
+	if err != nil {
+		return err
+	}
+
+	for _, res := range args.Reservations {
+		res.Canonicalize()
+		if err := res.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid reservation %q: %v", res.Name, err)
+		}
+
+		pool, err := snap.NodePoolByName(nil, res.NodePool)
+		if err != nil {
+			return err
+		}
+		if pool == nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest,
+				"invalid reservation %q: node pool %q does not exist", res.Name, res.NodePool)
+		}
+
+		others := slices.DeleteFunc(slices.Clone(active), func(other *structs.Reservation) bool {
+			return other.Name == res.Name
+		})
+
+		existing, err := snap.ReservationByName(nil, res.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.SamePlacement(res) {
+			res.Placements = existing.Copy().Placements
+		} else if err := placeReservation(&snap.StateStore, res, others); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid reservation %q: %v", res.Name, err)
+		}
+
+		res.ExpiresAt = now.Add(res.TTL)
+		res.Usage = nil
+		active = append(others, res)
+	}
+
+	_, index, err := r.srv.raftApply(structs.ReservationUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// DeleteReservations is used to delete a set of capacity reservations,
+// releasing the capacity they hold.
+func (r *Reservation) DeleteReservations(args *structs.ReservationDeleteRequest, reply *structs.GenericResponse) error {
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Reservation.DeleteReservations", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("reservation", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "reservation", "delete_reservations"}, time.Now())
+
+	if aclObj, err := r.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !r.srv.peersCache.ServersMeetMinimumVersion(r.srv.Region(), minVersionReservations, true) {
+		return fmt.Errorf("all servers must be running version %v or later to delete reservations", minVersionReservations)
+	}
+
+	if len(args.Names) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one reservation to delete")
+	}
+
+	_, index, err := r.srv.raftApply(structs.ReservationDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// ListReservations is used to list the capacity reservations with their
+// usage.
+func (r *Reservation) ListReservations(args *structs.ReservationListRequest, reply *structs.ReservationListResponse) error {
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Reservation.ListReservations", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("reservation", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "reservation", "list_reservations"}, time.Now())
+
+	if aclObj, err := r.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = store.ReservationsByNamePrefix(ws, prefix)
+			} else {
+				iter, err = store.Reservations(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.Reservations = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				res, err := reservationWithUsage(store, raw.(*structs.Reservation))
+				if err != nil {
+					return err
+				}
+				reply.Reservations = append(reply.Reservations, res)
+			}
+
+			// Use the last index that affected the reservations table.
+			index, err := store.Index(state.TableReservations)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+			return nil
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// GetReservation is used to get a specific capacity reservation with its
+// usage.
+func (r *Reservation) GetReservation(args *structs.ReservationSpecificRequest, reply *structs.SingleReservationResponse) error {
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Reservation.GetReservation", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("reservation", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "reservation", "get_reservation"}, time.Now())
+
+	if aclObj, err := r.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			res, err := store.ReservationByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			if res != nil {
+				reply.Reservation, err = reservationWithUsage(store, res)
+				if err != nil {
+					return err
+				}
+				reply.Index = res.ModifyIndex
+			} else {
+				reply.Reservation = nil
+
+				// Return the last index that affected the reservations table
+				// if the requested reservation doesn't exist.
+				index, err := store.Index(state.TableReservations)
+				if err != nil {
+					return err
+				}
+				reply.Index = max(1, index)
+			}
+			return nil
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// activeReservations returns the reservations that haven't expired.
+func activeReservations(store *state.StateStore, now time.Time) ([]*structs.Reservation, error) {
+	iter, err := store.Reservations(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var active []*structs.Reservation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if res := raw.(*structs.Reservation); !res.Expired(now) {
+			active = append(active, res)
+		}
+	}
+	return active, nil
+}
+
+// placeReservation places the capacity of the reservation on the ready nodes
+// of its node pool, around the capacity held by the other reservations.
+func placeReservation(store *state.StateStore, res *structs.Reservation, others []*structs.Reservation) error {
+	var iter memdb.ResultIterator
+	var err error
+	if res.NodePool == structs.NodePoolAll {
+		iter, err = store.Nodes(nil)
+	} else {
+		iter, err = store.NodesByNodePool(nil, res.NodePool)
+	}
+	if err != nil {
+		return err
+	}
+
+	var nodes []*structs.Node
+	allocsByNode := make(map[string][]*structs.Allocation)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+		allocs, err := store.AllocsByNodeTerminal(nil, node.ID, false)
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, node)
+		allocsByNode[node.ID] = allocs
+	}
+	slices.SortFunc(nodes, func(a, b *structs.Node) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return structs.PlaceReservation(res, nodes, allocsByNode, others)
+}
+
+// reservationWithUsage returns a copy of the reservation with the usage of
+// its capacity by consumers.
+func reservationWithUsage(store *state.StateStore, res *structs.Reservation) (*structs.Reservation, error) {
+	usage := new(structs.ReservationUsage)
+	for _, nodeID := range res.NodeIDs() {
+		allocs, err := store.AllocsByNodeTerminal(nil, nodeID, false)
+		if err != nil {
+			return nil, err
+		}
+		usage.Add(res.NodeUsage(nodeID, allocs))
+	}
+
+	res = res.Copy()
+	res.Usage = usage
+	return res, nil
+}
@@ -0,0 +1,162 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestReservationEndpoint_UpsertGetDelete(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	store := s.fsm.State()
+
+	node1, node2 := mock.Node(), mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node1))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1001, node2))
+
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, job))
+
+	// Upsert a reservation and verify its capacity is placed.
+	res := mock.Reservation()
+	res.Consumers.Jobs = []string{job.ID}
+	upsertReq := &structs.ReservationUpsertRequest{
+		Reservations: []*structs.Reservation{res},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.UpsertReservations", upsertReq, &upsertResp))
+	must.NonZero(t, upsertResp.Index)
+
+	getReq := &structs.ReservationSpecificRequest{
+		Name:         res.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleReservationResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.GetReservation", getReq, &getResp))
+	must.NotNil(t, getResp.Reservation)
+	got := getResp.Reservation
+	must.Eq(t, upsertResp.Index, getResp.Index)
+	must.MapLen(t, 1, got.Placements)
+	must.False(t, got.ExpiresAt.IsZero())
+	must.Eq(t, &structs.ReservationUsage{ReservedCPU: 1000, ReservedMemoryMB: 1024}, got.Usage)
+	placements := got.Placements
+
+	// Reservations can't hold more capacity than the node pool has free.
+	huge := mock.Reservation()
+	huge.Resources.MemoryMB = 2 * 7936
+	upsertReq.Reservations = []*structs.Reservation{huge}
+	err := msgpackrpc.CallWithCodec(codec, "Reservation.UpsertReservations", upsertReq, &upsertResp)
+	must.ErrorContains(t, err, "lacks free capacity")
+
+	// Updating the description keeps the placements and renews the TTL.
+	update := res.Copy()
+	update.Description = "updated"
+	upsertReq.Reservations = []*structs.Reservation{update}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.UpsertReservations", upsertReq, &upsertResp))
+
+	// Allocations of consumers draw down the capacity held on their node.
+	var nodeID string
+	for id := range placements {
+		nodeID = id
+	}
+	alloc := mock.Alloc()
+	alloc.NodeID = nodeID
+	alloc.Job = job
+	alloc.JobID = job.ID
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, upsertResp.Index+1, []*structs.Allocation{alloc}))
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.GetReservation", getReq, &getResp))
+	got = getResp.Reservation
+	must.Eq(t, "updated", got.Description)
+	must.Eq(t, placements, got.Placements)
+	must.True(t, got.ExpiresAt.After(res.ExpiresAt))
+	must.Eq(t, &structs.ReservationUsage{
+		ReservedCPU:      1000,
+		ReservedMemoryMB: 1024,
+		UsedCPU:          500,
+		UsedMemoryMB:     256,
+	}, got.Usage)
+
+	listReq := &structs.ReservationListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.ReservationListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.ListReservations", listReq, &listResp))
+	must.Len(t, 1, listResp.Reservations)
+	must.Eq(t, res.Name, listResp.Reservations[0].Name)
+
+	// Delete the reservation.
+	deleteReq := &structs.ReservationDeleteRequest{
+		Names:        []string{res.Name},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var deleteResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.DeleteReservations", deleteReq, &deleteResp))
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.GetReservation", getReq, &getResp))
+	must.Nil(t, getResp.Reservation)
+	must.Eq(t, deleteResp.Index, getResp.Index)
+}
+
+func TestReservationEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	store := s.fsm.State()
+
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, mock.Node()))
+	readToken := mock.CreatePolicyAndToken(t, store, 1001, "operator-read", `operator { policy = "read" }`)
+
+	res := mock.Reservation()
+	upsertReq := &structs.ReservationUpsertRequest{
+		Reservations: []*structs.Reservation{res},
+		WriteRequest: structs.WriteRequest{Region: "global", AuthToken: readToken.SecretID},
+	}
+	var upsertResp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "Reservation.UpsertReservations", upsertReq, &upsertResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsertReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.UpsertReservations", upsertReq, &upsertResp))
+
+	listReq := &structs.ReservationListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.ReservationListResponse
+	err = msgpackrpc.CallWithCodec(codec, "Reservation.ListReservations", listReq, &listResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	listReq.AuthToken = readToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.ListReservations", listReq, &listResp))
+	must.Len(t, 1, listResp.Reservations)
+
+	deleteReq := &structs.ReservationDeleteRequest{
+		Names:        []string{res.Name},
+		WriteRequest: structs.WriteRequest{Region: "global", AuthToken: readToken.SecretID},
+	}
+	var deleteResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, "Reservation.DeleteReservations", deleteReq, &deleteResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	deleteReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Reservation.DeleteReservations", deleteReq, &deleteResp))
+}
@@ -1210,6 +1210,9 @@ func TestRPC_TLS_Enforcement_RPC(t *testing.T) {
 		"Node.UpdateAlloc": &structs.AllocUpdateRequest{
 			WriteRequest: structs.WriteRequest{Region: "global"},
 		},
+		"Node.UpdateUtilization": &structs.NodeUpdateUtilizationRequest{
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		},
 		"ServiceRegistration.Upsert": &structs.ServiceRegistrationUpsertRequest{
 			WriteRequest: structs.WriteRequest{Region: "global"},
 		},
@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/scoring"
+	sstructs "github.com/hashicorp/nomad/scheduler/structs"
+)
+
+// scoringPluginTimeout bounds how long the schedulers wait for a scoring
+// plugin to score a single node. Plugins are called for every ranked node so
+// a slow plugin directly slows down scheduling.
+const scoringPluginTimeout = 250 * time.Millisecond
+
+// pluginNodeScorer adapts a scoring plugin to the NodeScorer interface used
+// by the schedulers.
+type pluginNodeScorer struct {
+	name   string
+	plugin scoring.ScoringPlugin
+}
+
+// newPluginNodeScorers wraps the given scoring plugins, ordered by name so
+// that nodes are scored in a consistent order.
+func newPluginNodeScorers(plugins map[string]scoring.ScoringPlugin) []sstructs.NodeScorer {
+	if len(plugins) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	scorers := make([]sstructs.NodeScorer, 0, len(plugins))
+	for _, name := range names {
+		scorers = append(scorers, &pluginNodeScorer{name: name, plugin: plugins[name]})
+	}
+	return scorers
+}
+
+// Name returns the name used for the plugin in the allocation metrics.
+func (p *pluginNodeScorer) Name() string {
+	return "plugin." + p.name
+}
+
+func (p *pluginNodeScorer) ScoreNode(req *sstructs.NodeScoreRequest) (float64, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), scoringPluginTimeout)
+	defer cancel()
+
+	resp, err := p.plugin.Score(ctx, scoreRequest(req))
+	if err != nil {
+		return 0, "", err
+	}
+	return resp.Score, resp.Label, nil
+}
+
+// scoreRequest converts a scheduler request into the subset of the job,
+// task group and node exposed to scoring plugins.
+func scoreRequest(req *sstructs.NodeScoreRequest) *scoring.ScoreRequest {
+	out := &scoring.ScoreRequest{
+		RankedNode: &scoring.RankedNode{
+			Scores:         req.Scores,
+			ProposedAllocs: req.ProposedAllocs,
+		},
+	}
+
+	if job := req.Job; job != nil {
+		out.Job = &scoring.Job{
+			Namespace: job.Namespace,
+			ID:        job.ID,
+			Name:      job.Name,
+			Type:      job.Type,
+			Priority:  job.Priority,
+			NodePool:  job.NodePool,
+			Meta:      job.Meta,
+		}
+	}
+
+	if tg := req.TaskGroup; tg != nil {
+		out.TaskGroup = &scoring.TaskGroup{
+			Name:  tg.Name,
+			Count: tg.Count,
+			Meta:  tg.Meta,
+			Tasks: make([]*scoring.Task, 0, len(tg.Tasks)),
+		}
+		for _, task := range tg.Tasks {
+			out.TaskGroup.Tasks = append(out.TaskGroup.Tasks, scoreRequestTask(task))
+		}
+	}
+
+	if node := req.Node; node != nil {
+		out.Node = &scoring.Node{
+			ID:         node.ID,
+			Name:       node.Name,
+			Datacenter: node.Datacenter,
+			NodeClass:  node.NodeClass,
+			NodePool:   node.NodePool,
+			Attributes: node.Attributes,
+			Meta:       node.Meta,
+		}
+	}
+
+	return out
+}
+
+func scoreRequestTask(task *structs.Task) *scoring.Task {
+	out := &scoring.Task{
+		Name:   task.Name,
+		Driver: task.Driver,
+	}
+
+	// The driver config has already been validated so it always encodes
+	if config, err := json.Marshal(task.Config); err == nil {
+		out.Config = config
+	}
+	if task.Resources != nil {
+		out.CPU = task.Resources.CPU
+		out.MemoryMB = task.Resources.MemoryMB
+	}
+	return out
+}
@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/scoring"
+	sstructs "github.com/hashicorp/nomad/scheduler/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestPluginNodeScorer(t *testing.T) {
+	ci.Parallel(t)
+
+	var received *scoring.ScoreRequest
+	plugin := &scoring.MockScoringPlugin{
+		MockPlugin: &base.MockPlugin{},
+		ScoreF: func(_ context.Context, req *scoring.ScoreRequest) (*scoring.ScoreResponse, error) {
+			received = req
+			return &scoring.ScoreResponse{Score: 0.5, Label: "warm cache"}, nil
+		},
+	}
+
+	scorers := newPluginNodeScorers(map[string]scoring.ScoringPlugin{
+		"image-cache": plugin,
+		"cost":        &scoring.MockScoringPlugin{ScoreF: scoring.StaticScore(0, "")},
+	})
+	must.Len(t, 2, scorers)
+	must.Eq(t, "plugin.cost", scorers[0].Name())
+	must.Eq(t, "plugin.image-cache", scorers[1].Name())
+
+	job := mock.Job()
+	node := mock.Node()
+	score, label, err := scorers[1].ScoreNode(&sstructs.NodeScoreRequest{
+		Job:            job,
+		TaskGroup:      job.TaskGroups[0],
+		Node:           node,
+		Scores:         []float64{0.25},
+		ProposedAllocs: 2,
+	})
+	must.NoError(t, err)
+	must.Eq(t, 0.5, score)
+	must.Eq(t, "warm cache", label)
+
+	must.Eq(t, job.ID, received.Job.ID)
+	must.Eq(t, job.Priority, received.Job.Priority)
+	must.Eq(t, "web", received.TaskGroup.Name)
+	must.Eq(t, "web", received.TaskGroup.Tasks[0].Name)
+	must.Eq(t, 500, received.TaskGroup.Tasks[0].CPU)
+	must.StrContains(t, string(received.TaskGroup.Tasks[0].Config), "/bin/date")
+	must.Eq(t, node.ID, received.Node.ID)
+	must.Eq(t, node.Attributes, received.Node.Attributes)
+	must.Eq(t, []float64{0.25}, received.RankedNode.Scores)
+	must.Eq(t, 2, received.RankedNode.ProposedAllocs)
+
+	must.Nil(t, newPluginNodeScorers(nil))
+}
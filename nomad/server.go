@@ -45,6 +45,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/auth"
 	"github.com/hashicorp/nomad/nomad/deploymentwatcher"
 	"github.com/hashicorp/nomad/nomad/drainer"
+	"github.com/hashicorp/nomad/nomad/eventsink"
 	"github.com/hashicorp/nomad/nomad/lock"
 	"github.com/hashicorp/nomad/nomad/peers"
 	"github.com/hashicorp/nomad/nomad/reporting"
@@ -235,6 +236,9 @@ type Server struct {
 	// volumeWatcher is used to release volume claims
 	volumeWatcher *volumewatcher.Watcher
 
+	// eventSinkManager is used to deliver events to the durable event sinks
+	eventSinkManager *eventsink.Manager
+
 	// volumeControllerFutures is a map of plugin IDs to pending controller RPCs. If
 	// no RPC is pending for a given plugin, this may be nil.
 	volumeControllerFutures map[string]context.Context
@@ -273,6 +277,10 @@ type Server struct {
 	workerConfigLock sync.RWMutex
 	workersEventCh   chan interface{}
 
+	// nodeScorers wrap the scoring plugins given in the config and are
+	// passed to the schedulers.
+	nodeScorers []sstructs.NodeScorer
+
 	// workerShutdownGroup tracks the running worker goroutines so that Shutdown()
 	// can wait on their completion
 	workerShutdownGroup group.Group
@@ -293,6 +301,10 @@ type Server struct {
 	lockTTLTimer   *lock.TTLTimer
 	lockDelayTimer *lock.DelayTimer
 
+	// variableExpiryTimer tracks the variables written with a TTL, so that
+	// the leader can delete them once they expire.
+	variableExpiryTimer *lock.TTLTimer
+
 	// leaderAcl is the management ACL token that is valid when resolved by the
 	// current leader.
 	leaderAcl     string
@@ -378,6 +390,8 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 		workersEventCh:          make(chan interface{}, 1),
 		lockTTLTimer:            lock.NewTTLTimer(),
 		lockDelayTimer:          lock.NewDelayTimer(),
+		variableExpiryTimer:     lock.NewTTLTimer(),
+		nodeScorers:             newPluginNodeScorers(config.ScoringPlugins),
 	}
 
 	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
@@ -529,6 +543,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 	// Setup the node drainer.
 	s.setupNodeDrainer()
 
+	// Setup the event sink manager.
+	s.setupEventSinkManager()
+
 	// Setup the enterprise state
 	if err := s.setupEnterprise(config); err != nil {
 		return nil, err
@@ -1198,6 +1215,18 @@ func (s *Server) setupNodeDrainer() {
 	s.nodeDrainer = drainer.NewNodeDrainer(c)
 }
 
+// setupEventSinkManager creates an event sink manager which will be enabled
+// when a server becomes a leader.
+func (s *Server) setupEventSinkManager() {
+	s.eventSinkManager = eventsink.NewManager(&eventsink.ManagerConfig{
+		Logger:             s.logger,
+		Raft:               eventSinkShim{s},
+		State:              s.State,
+		CheckpointInterval: s.config.EventSinkCheckpointInterval,
+		FileDir:            s.config.EventSinkFileDir,
+	})
+}
+
 // setupRPC is used to setup the RPC listener
 func (s *Server) setupRPC(tlsWrap tlsutil.RegionWrapper) error {
 	// Populate the static RPC server
@@ -1316,6 +1345,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	_ = server.Register(NewCSIPluginEndpoint(s, ctx))
 	_ = server.Register(NewDeploymentEndpoint(s, ctx))
 	_ = server.Register(NewEvalEndpoint(s, ctx))
+	_ = server.Register(NewEventSinkEndpoint(s, ctx))
 	_ = server.Register(NewJobEndpoints(s, ctx))
 	_ = server.Register(NewKeyringEndpoint(s, ctx, s.encrypter))
 	_ = server.Register(NewNamespaceEndpoint(s, ctx))
@@ -1323,7 +1353,9 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	_ = server.Register(NewNodePoolEndpoint(s, ctx))
 	_ = server.Register(NewPeriodicEndpoint(s, ctx))
 	_ = server.Register(NewPlanEndpoint(s, ctx))
+	_ = server.Register(NewQuotaEndpoint(s, ctx))
 	_ = server.Register(NewRegionEndpoint(s, ctx))
+	_ = server.Register(NewReservationEndpoint(s, ctx))
 	_ = server.Register(NewScalingEndpoint(s, ctx))
 	_ = server.Register(NewSearchEndpoint(s, ctx))
 	_ = server.Register(NewServiceRegistrationEndpoint(s, ctx))
@@ -24,6 +24,7 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.NodeUpdateEligibilityRequestType:             structs.TypeNodeDrain,
 	structs.NodeUpdateDrainRequestType:                   structs.TypeNodeDrain,
 	structs.BatchNodeUpdateDrainRequestType:              structs.TypeNodeDrain,
+	structs.NodeUpdateTaintsRequestType:                  structs.TypeNodeEvent,
 	structs.DeploymentStatusUpdateRequestType:            structs.TypeDeploymentUpdate,
 	structs.DeploymentPromoteRequestType:                 structs.TypeDeploymentPromotion,
 	structs.DeploymentAllocHealthRequestType:             structs.TypeDeploymentAllocHealth,
@@ -46,6 +47,8 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.CSIVolumeRegisterRequestType:                 structs.TypeCSIVolumeRegistered,
 	structs.CSIVolumeDeregisterRequestType:               structs.TypeCSIVolumeDeregistered,
 	structs.CSIVolumeClaimRequestType:                    structs.TypeCSIVolumeClaim,
+	structs.VarApplyStateRequestType:                     structs.TypeVariableUpserted,
+	structs.VarExpireRequestType:                         structs.TypeVariableExpired,
 }
 
 func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
@@ -60,7 +63,12 @@ func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
 	var events []structs.Event
 	for _, change := range changes.Changes {
 		if event, ok := eventFromChange(change); ok {
-			event.Type = eventType
+			// Events that depend on the change itself, rather than on the
+			// request, set their own type. Expired variables are deleted but
+			// reported as expired.
+			if event.Type == "" || changes.MsgType == structs.VarExpireRequestType {
+				event.Type = eventType
+			}
 			event.Index = changes.Index
 			events = append(events, event)
 		}
@@ -241,6 +249,18 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 					Plugin: before,
 				},
 			}, true
+		case TableVariables:
+			before, ok := change.Before.(*structs.VariableEncrypted)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic:     structs.TopicVariables,
+				Type:      structs.TypeVariableDeleted,
+				Key:       before.Path,
+				Namespace: before.Namespace,
+				Payload:   structs.NewVariableEvent(before),
+			}, true
 		default:
 			return enterpriseEventFromChangeDeleted(change)
 		}
@@ -338,7 +358,8 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 			alloc.DeploymentID,
 		}
 
-		// remove job info to help keep size of alloc event down
+		// remove job info to help keep size of alloc event down, which also
+		// means event filters can't select fields of the job
 		alloc.Job = nil
 
 		return structs.Event{
@@ -470,6 +491,33 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 				Plugin: after,
 			},
 		}, true
+	case TableVariables:
+		after, ok := change.After.(*structs.VariableEncrypted)
+		if !ok {
+			return structs.Event{}, false
+		}
+
+		// Lock holder changes are reported with their own event type, but
+		// the lock ID is never included in the event.
+		var beforeLock *structs.VariableLock
+		if before, ok := change.Before.(*structs.VariableEncrypted); ok {
+			beforeLock = before.Lock
+		}
+		eventType := ""
+		switch {
+		case after.Lock != nil && (beforeLock == nil || beforeLock.ID != after.Lock.ID):
+			eventType = structs.TypeVariableLockAcquired
+		case after.Lock == nil && beforeLock != nil:
+			eventType = structs.TypeVariableLockReleased
+		}
+
+		return structs.Event{
+			Topic:     structs.TopicVariables,
+			Type:      eventType,
+			Key:       after.Path,
+			Namespace: after.Namespace,
+			Payload:   structs.NewVariableEvent(after),
+		}, true
 	default:
 		return enterpriseEventFromChange(change)
 	}
@@ -4,9 +4,11 @@
 package state
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-bexpr"
 	memdb "github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper/pointer"
@@ -689,6 +691,50 @@ func TestEventsFromChanges_AllocUpdateDesiredTransitionRequestType(t *testing.T)
 	must.Len(t, 1, evalEvents)
 }
 
+// TestEventsFromChanges_AllocationFilter asserts which filter expressions can
+// select allocation events, since their payload doesn't include the job.
+func TestEventsFromChanges_AllocationFilter(t *testing.T) {
+	ci.Parallel(t)
+	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer s.StopEventBroker()
+
+	alloc := mock.Alloc()
+	alloc.Job.Meta["team"] = "payments"
+
+	must.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 10, nil, alloc.Job))
+	must.NoError(t, s.UpsertAllocs(structs.MsgTypeTestSetup, 11, []*structs.Allocation{alloc}))
+
+	failed := alloc.Copy()
+	failed.ClientStatus = structs.AllocClientStatusFailed
+	must.NoError(t, s.UpdateAllocsFromClient(structs.AllocClientUpdateRequestType, 12,
+		[]*structs.Allocation{failed}))
+
+	var event structs.Event
+	for _, e := range WaitForEvents(t, s, 12, 1, 1*time.Second) {
+		if e.Topic == structs.TopicAllocation {
+			event = e
+		}
+	}
+	must.Eq(t, alloc.ID, event.Key)
+	must.Nil(t, event.Payload.(*structs.AllocationEvent).Allocation.Job)
+
+	// The job meta isn't part of the payload, so the expression can't be
+	// evaluated and the event would be dropped.
+	evaluator, err := bexpr.CreateEvaluator(
+		`Payload.Allocation.Job.Meta.team == "payments" and Payload.Allocation.ClientStatus == "failed"`)
+	must.NoError(t, err)
+	match, err := evaluator.Evaluate(&event)
+	must.False(t, err == nil && match)
+
+	// The failed allocations of the job can be selected by its ID instead.
+	evaluator, err = bexpr.CreateEvaluator(fmt.Sprintf(
+		`Payload.Allocation.JobID == %q and Payload.Allocation.ClientStatus == "failed"`, alloc.JobID))
+	must.NoError(t, err)
+	match, err = evaluator.Evaluate(&event)
+	must.NoError(t, err)
+	must.True(t, match)
+}
+
 func TestEventsFromChanges_JobBatchDeregisterRequestType(t *testing.T) {
 	// TODO Job batch deregister logic mostly occurs in the FSM
 	t.SkipNow()
@@ -1390,3 +1436,84 @@ func testNodeID() string {
 func testNodeIDTwo() string {
 	return "694ff31d-8c59-4030-ac83-e15692560c8d"
 }
+
+func TestEvents_Variables(t *testing.T) {
+	ci.Parallel(t)
+	store := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer store.StopEventBroker()
+
+	sv := mock.VariableEncrypted()
+	must.True(t, store.VarSet(10, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpSet,
+		Var: sv,
+	}).IsOk())
+
+	locked := sv.Copy()
+	locked.Lock = &structs.VariableLock{ID: "theLockID", TTL: 15 * time.Second}
+	must.True(t, store.VarLockAcquire(20, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpLockAcquire,
+		Var: &locked,
+	}).IsOk())
+
+	must.True(t, store.VarLockRelease(30, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpLockRelease,
+		Var: &locked,
+	}).IsOk())
+
+	must.True(t, store.VarDelete(40, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpDelete,
+		Var: sv,
+	}).IsOk())
+
+	events := WaitForEvents(t, store, 0, 4, 1*time.Second)
+	must.Len(t, 4, events)
+
+	expectedTypes := []string{
+		structs.TypeVariableUpserted,
+		structs.TypeVariableLockAcquired,
+		structs.TypeVariableLockReleased,
+		structs.TypeVariableDeleted,
+	}
+	for i, event := range events {
+		must.Eq(t, structs.TopicVariables, event.Topic)
+		must.Eq(t, expectedTypes[i], event.Type)
+		must.Eq(t, sv.Path, event.Key)
+		must.Eq(t, sv.Namespace, event.Namespace)
+
+		payload := event.Payload.(*structs.VariableEvent)
+		must.Eq(t, sv.Path, payload.Variable.Path)
+	}
+
+	// The lock ID must never be sent on the event stream.
+	lockPayload := events[1].Payload.(*structs.VariableEvent)
+	must.NotNil(t, lockPayload.Variable.Lock)
+	must.Eq(t, "", lockPayload.Variable.Lock.ID)
+	must.Eq(t, 15*time.Second, lockPayload.Variable.Lock.TTL)
+}
+
+func TestEvents_VariableExpired(t *testing.T) {
+	ci.Parallel(t)
+	store := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer store.StopEventBroker()
+
+	sv := mock.VariableEncrypted()
+	sv.TTL = time.Minute
+	sv.ExpireTime = 1000
+	must.True(t, store.VarSet(10, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpSet,
+		Var: sv,
+	}).IsOk())
+
+	must.NoError(t, store.VarExpire(structs.VarExpireRequestType, 20,
+		[]*structs.VariableVersionID{{
+			Namespace: sv.Namespace,
+			Path:      sv.Path,
+			Version:   sv.ModifyIndex,
+		}}))
+
+	events := WaitForEvents(t, store, 20, 1, 1*time.Second)
+	must.Len(t, 1, events)
+	must.Eq(t, structs.TopicVariables, events[0].Topic)
+	must.Eq(t, structs.TypeVariableExpired, events[0].Type)
+	must.Eq(t, sv.Path, events[0].Key)
+}
@@ -21,6 +21,7 @@ const (
 	TableServiceRegistrations     = "service_registrations"
 	TableVariables                = "variables"
 	TableVariablesQuotas          = "variables_quota"
+	TableVariablesHistory         = "variables_history"
 	TableRootKeys                 = "root_keys"
 	TableACLRoles                 = "acl_roles"
 	TableACLAuthMethods           = "acl_auth_methods"
@@ -31,6 +32,10 @@ const (
 	TableCSIVolumes               = "csi_volumes"
 	TableCSIPlugins               = "csi_plugins"
 	TableTaskGroupHostVolumeClaim = "task_volume"
+	TableQuotaSpec                = "quota_spec"
+	TableQuotaUsage               = "quota_usage"
+	TableReservations             = "reservations"
+	TableEventSinks               = "event_sinks"
 )
 
 const (
@@ -104,6 +109,11 @@ func init() {
 		bindingRulesTableSchema,
 		hostVolumeTableSchema,
 		taskGroupHostVolumeClaimSchema,
+		quotaSpecTableSchema,
+		quotaUsageTableSchema,
+		reservationsTableSchema,
+		variablesHistoryTableSchema,
+		eventSinksTableSchema,
 	}...)
 }
 
@@ -411,9 +421,9 @@ func jobIsGCable(obj interface{}) (bool, error) {
 	}
 
 	switch j.Type {
-	// Otherwise, batch and sysbatch jobs are eligible because they complete on
+	// Otherwise, batch, sysbatch and workflow jobs are eligible because they complete on
 	// their own without a user stopping them.
-	case structs.JobTypeBatch, structs.JobTypeSysBatch:
+	case structs.JobTypeBatch, structs.JobTypeSysBatch, structs.JobTypeWorkflow:
 		return true, nil
 
 	default:
@@ -624,6 +634,18 @@ func evalTableSchema() *memdb.TableSchema {
 				},
 			},
 
+			// status index is used to lookup evaluations by status, such as
+			// the evaluations waiting for their job's dependencies.
+			"status": {
+				Name:         "status",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field:     "Status",
+					Lowercase: true,
+				},
+			},
+
 			// namespace is used to lookup evaluations by namespace.
 			"namespace": {
 				Name:         "namespace",
@@ -1490,6 +1512,41 @@ func (s *variableKeyIDFieldIndexer) FromObject(obj interface{}) (bool, []byte, e
 	return true, []byte(keyID), nil
 }
 
+// variablesHistoryTableSchema returns the MemDB schema for the prior versions
+// of Nomad variables
+func variablesHistoryTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableVariablesHistory,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "Path",
+						},
+						&memdb.UintFieldIndex{
+							Field: "ModifyIndex",
+						},
+					},
+				},
+			},
+			indexKeyID: {
+				Name:         indexKeyID,
+				AllowMissing: true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "KeyID",
+				},
+			},
+		},
+	}
+}
+
 // variablesQuotasTableSchema returns the MemDB schema for Nomad variables
 // quotas tracking
 func variablesQuotasTableSchema() *memdb.TableSchema {
@@ -1509,6 +1566,76 @@ func variablesQuotasTableSchema() *memdb.TableSchema {
 	}
 }
 
+// quotaSpecTableSchema returns the MemDB schema for quota specifications.
+func quotaSpecTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableQuotaSpec,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// quotaUsageTableSchema returns the MemDB schema for tracking the resource
+// usage of quota specifications.
+func quotaUsageTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableQuotaUsage,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// reservationsTableSchema returns the MemDB schema for capacity
+// reservations.
+func reservationsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableReservations,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// eventSinksTableSchema returns the MemDB schema for durable event sinks.
+func eventSinksTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableEventSinks,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+		},
+	}
+}
+
 // wrappedRootKeySchema returns the MemDB schema for wrapped Nomad root keys
 func wrappedRootKeySchema() *memdb.TableSchema {
 	return &memdb.TableSchema{
@@ -1014,6 +1014,8 @@ func upsertNodeTxn(txn *txn, index uint64, node *structs.Node) error {
 		node.SchedulingEligibility = exist.SchedulingEligibility // Retain the eligibility
 		node.DrainStrategy = exist.DrainStrategy                 // Retain the drain strategy
 		node.LastDrain = exist.LastDrain                         // Retain the drain metadata
+		node.Taints = exist.Taints                               // Retain the taints
+		node.Utilization = exist.Utilization                     // Retain the utilization
 
 		// Retain the last index the node missed a heartbeat.
 		if node.LastMissedHeartbeatIndex < exist.LastMissedHeartbeatIndex {
@@ -1343,6 +1345,79 @@ func (s *StateStore) updateNodeEligibilityImpl(index uint64, nodeID string, elig
 	return nil
 }
 
+// UpdateNodeTaints is used to replace the taints of a node. The computed class
+// of the node is updated since it depends on the taints.
+func (s *StateStore) UpdateNodeTaints(msgType structs.MessageType, index uint64, nodeID string, taints []*structs.NodeTaint, updatedAt int64, event *structs.NodeEvent) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// Lookup the node
+	existing, err := txn.First("nodes", "id", nodeID)
+	if err != nil {
+		return fmt.Errorf("node lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("node not found")
+	}
+
+	// Copy the existing node
+	copyNode := existing.(*structs.Node).Copy()
+	copyNode.StatusUpdatedAt = updatedAt
+
+	// Add the event if given
+	if event != nil {
+		appendNodeEvents(index, copyNode, []*structs.NodeEvent{event})
+	}
+
+	// Update the taints in the copy
+	copyNode.Taints = structs.CopySliceNodeTaints(taints)
+	if err := copyNode.ComputeClass(); err != nil {
+		return fmt.Errorf("failed to compute node class: %v", err)
+	}
+	copyNode.ModifyIndex = index
+
+	// Insert the node
+	if err := txn.Insert("nodes", copyNode); err != nil {
+		return fmt.Errorf("node update failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"nodes", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// UpdateNodeUtilization is used to update the utilization published by a
+// node.
+func (s *StateStore) UpdateNodeUtilization(msgType structs.MessageType, index uint64, nodeID string, util *structs.NodeUtilization) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// Lookup the node
+	existing, err := txn.First("nodes", "id", nodeID)
+	if err != nil {
+		return fmt.Errorf("node lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("node not found")
+	}
+
+	// Copy the existing node and update its utilization
+	copyNode := existing.(*structs.Node).Copy()
+	copyNode.Utilization = util.Copy()
+	copyNode.ModifyIndex = index
+
+	// Insert the node
+	if err := txn.Insert("nodes", copyNode); err != nil {
+		return fmt.Errorf("node update failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"nodes", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
 // UpsertNodeEvents adds the node events to the nodes, rotating events as
 // necessary.
 func (s *StateStore) UpsertNodeEvents(msgType structs.MessageType, index uint64, nodeEvents map[string][]*structs.NodeEvent) error {
@@ -3525,6 +3600,34 @@ func (s *StateStore) nestedUpsertEval(txn *txn, index uint64, eval *structs.Eval
 		}
 	}
 
+	// A job only needs a single evaluation waiting for its dependencies, so
+	// cancel the ones superseded by this evaluation
+	if eval.Status == structs.EvalStatusComplete || eval.Status == structs.EvalStatusDependencyBlocked {
+		iter, err := txn.Get("evals", "job", eval.Namespace, eval.JobID, structs.EvalStatusDependencyBlocked)
+		if err != nil {
+			return fmt.Errorf("failed to get dependency blocked evals for job %q in namespace %q: %v", eval.JobID, eval.Namespace, err)
+		}
+
+		var waiting []*structs.Evaluation
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			if e := raw.(*structs.Evaluation); e.ID != eval.ID {
+				waiting = append(waiting, e)
+			}
+		}
+
+		for _, waitingEval := range waiting {
+			newEval := waitingEval.Copy()
+			newEval.Status = structs.EvalStatusCancelled
+			newEval.StatusDescription = fmt.Sprintf("superseded by evaluation %q", eval.ID)
+			newEval.ModifyIndex = index
+			newEval.ModifyTime = eval.ModifyTime
+
+			if err := txn.Insert("evals", newEval); err != nil {
+				return fmt.Errorf("eval insert failed: %v", err)
+			}
+		}
+	}
+
 	// Insert the eval
 	if err := txn.Insert("evals", eval); err != nil {
 		return fmt.Errorf("eval insert failed: %v", err)
@@ -3675,7 +3778,7 @@ func isEvalDeleteSafe(allocs []*structs.Allocation, job *structs.Job) bool {
 		tg := job.LookupTaskGroup(alloc.TaskGroup)
 
 		if tg != nil {
-			reschedulePolicy = tg.ReschedulePolicy
+			reschedulePolicy = tg.EffectiveReschedulePolicy()
 		}
 
 		// No reschedule policy or rescheduling is disabled
@@ -3928,6 +4031,24 @@ func (s *StateStore) EvalsByJob(ws memdb.WatchSet, namespace, jobID string) ([]*
 	return out, nil
 }
 
+// EvalsByStatus returns all the evaluations with the given status.
+func (s *StateStore) EvalsByStatus(ws memdb.WatchSet, status string) ([]*structs.Evaluation, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("evals", "status", status)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	var out []*structs.Evaluation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		out = append(out, raw.(*structs.Evaluation))
+	}
+	return out, nil
+}
+
 // Evals returns an iterator over all the evaluations in ascending or descending
 // order of CreationIndex as determined by the reverse parameter.
 func (s *StateStore) Evals(ws memdb.WatchSet, sort SortOption) (memdb.ResultIterator, error) {
@@ -5467,6 +5588,18 @@ func (s *StateStore) setJobStatus(index uint64, txn *txn,
 	updated.Status = newStatus
 	updated.ModifyIndex = index
 
+	// Record whether every index of the indexed batch task groups of a
+	// finished job completed
+	if newStatus == structs.JobStatusDead && !job.Stop {
+		desc, err := s.indexedBatchStatusDescription(txn, job)
+		if err != nil {
+			return err
+		}
+		if desc != "" {
+			updated.StatusDescription = desc
+		}
+	}
+
 	// Insert the job
 	if err := txn.Insert("jobs", updated); err != nil {
 		return fmt.Errorf("job insert failed: %v", err)
@@ -5553,6 +5686,24 @@ func (s *StateStore) setJobSummary(txn *txn, updated *structs.Job, index uint64,
 	return nil
 }
 
+// indexedBatchStatusDescription returns the description of the outcome of a
+// job with indexed batch task groups, computed from its allocations.
+func (s *StateStore) indexedBatchStatusDescription(txn *txn, job *structs.Job) (string, error) {
+	if !slices.ContainsFunc(job.TaskGroups, func(tg *structs.TaskGroup) bool { return tg.IndexedBatch != nil }) {
+		return "", nil
+	}
+
+	iter, err := txn.Get("allocs", "job", job.Namespace, job.ID)
+	if err != nil {
+		return "", err
+	}
+	var allocs []*structs.Allocation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		allocs = append(allocs, raw.(*structs.Allocation))
+	}
+	return job.IndexedBatchStatusDescription(allocs), nil
+}
+
 func (s *StateStore) getJobStatus(txn *txn, job *structs.Job, evalDelete bool) (string, error) {
 	// System, Periodic and Parameterized jobs are running until explicitly
 	// stopped.
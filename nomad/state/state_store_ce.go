@@ -10,19 +10,10 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-// quotaSpecExists on returns whether the quota exists
-func (s *StateStore) quotaSpecExists(txn *txn, name string) (bool, error) {
-	return false, nil
-}
-
-func (s *StateStore) quotaReconcile(index uint64, txn *txn, newQuota, oldQuota string) error {
-	return nil
-}
-
 // updateEntWithAlloc is used to update Nomad Enterprise objects when an allocation is
 // added/modified/deleted
 func (s *StateStore) updateEntWithAlloc(index uint64, new, existing *structs.Allocation, txn *txn) error {
-	return nil
+	return s.updateQuotaWithAlloc(index, new, existing, txn)
 }
 
 // deleteRecommendationsByJob deletes all recommendations for the specified job
@@ -0,0 +1,93 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_EventSinks(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	sink := mock.EventSink()
+
+	ws := memdb.NewWatchSet()
+	_, err := state.EventSinkByID(ws, sink.ID)
+	must.NoError(t, err)
+
+	// A new sink starts after the index it was registered at.
+	must.NoError(t, state.UpsertEventSink(structs.MsgTypeTestSetup, 1000, sink))
+	must.True(t, watchFired(ws))
+
+	out, err := state.EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	must.Eq(t, 1000, out.CreateIndex)
+	must.Eq(t, 1000, out.ModifyIndex)
+	must.Eq(t, 1000, out.LatestIndex)
+
+	// Checkpoints only move forward and leave the modify index alone.
+	must.NoError(t, state.CheckpointEventSinks(structs.MsgTypeTestSetup, 1001,
+		map[string]uint64{sink.ID: 1200, "missing": 1200}))
+	must.NoError(t, state.CheckpointEventSinks(structs.MsgTypeTestSetup, 1002,
+		map[string]uint64{sink.ID: 1100}))
+
+	out, err = state.EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	must.Eq(t, 1200, out.LatestIndex)
+	must.Eq(t, 1000, out.ModifyIndex)
+
+	index, err := state.Index(TableEventSinks)
+	must.NoError(t, err)
+	must.Eq(t, 1002, index)
+
+	// Updating keeps the create index and the delivery progress.
+	update := sink.Copy()
+	update.Address = "https://127.0.0.1:8443/events"
+	update.LatestIndex = 0
+	must.NoError(t, state.UpsertEventSink(structs.MsgTypeTestSetup, 1003, update))
+
+	out, err = state.EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	must.Eq(t, update.Address, out.Address)
+	must.Eq(t, 1000, out.CreateIndex)
+	must.Eq(t, 1003, out.ModifyIndex)
+	must.Eq(t, 1200, out.LatestIndex)
+
+	other := mock.EventSink()
+	must.NoError(t, state.UpsertEventSink(structs.MsgTypeTestSetup, 1004, other))
+
+	iter, err := state.EventSinks(nil)
+	must.NoError(t, err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	must.Eq(t, 2, count)
+
+	iter, err = state.EventSinksByIDPrefix(nil, other.ID)
+	must.NoError(t, err)
+	raw := iter.Next()
+	must.NotNil(t, raw)
+	must.Eq(t, other.ID, raw.(*structs.EventSink).ID)
+	must.Nil(t, iter.Next())
+
+	// Deleting a missing sink fails without deleting the others.
+	must.Error(t, state.DeleteEventSinks(structs.MsgTypeTestSetup, 1005, []string{sink.ID, "missing"}))
+	must.NoError(t, state.DeleteEventSinks(structs.MsgTypeTestSetup, 1006, []string{sink.ID}))
+
+	out, err = state.EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	out, err = state.EventSinkByID(nil, other.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+}
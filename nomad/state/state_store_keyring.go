@@ -190,6 +190,14 @@ func (s *StateStore) IsRootKeyInUse(keyID string) (bool, error) {
 		return true, nil
 	}
 
+	iter, err = txn.Get(TableVariablesHistory, indexKeyID, keyID)
+	if err != nil {
+		return false, err
+	}
+	if version := iter.Next(); version != nil {
+		return true, nil
+	}
+
 	iter, err = txn.Get(TableNodes, indexSigningKey, keyID)
 	if err != nil {
 		return false, err
@@ -0,0 +1,321 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// QuotaSpecs returns an iterator over all quota specifications.
+func (s *StateStore) QuotaSpecs(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaSpec, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("quota specs lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// QuotaSpecsByNamePrefix returns an iterator over all quota specifications
+// that match the given name prefix.
+func (s *StateStore) QuotaSpecsByNamePrefix(ws memdb.WatchSet, namePrefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaSpec, indexID+"_prefix", namePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("quota specs prefix lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// QuotaSpecByName returns the quota specification that matches the given name
+// or nil if there is no match.
+func (s *StateStore) QuotaSpecByName(ws memdb.WatchSet, name string) (*structs.QuotaSpec, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableQuotaSpec, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("quota spec lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.QuotaSpec), nil
+}
+
+// QuotaUsages returns an iterator over the usage of all quota specifications.
+func (s *StateStore) QuotaUsages(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaUsage, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("quota usages lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// QuotaUsagesByNamePrefix returns an iterator over the usage of all quota
+// specifications that match the given name prefix.
+func (s *StateStore) QuotaUsagesByNamePrefix(ws memdb.WatchSet, namePrefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaUsage, indexID+"_prefix", namePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("quota usages prefix lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// QuotaUsageByName returns the usage of the quota specification that matches
+// the given name or nil if there is no match.
+func (s *StateStore) QuotaUsageByName(ws memdb.WatchSet, name string) (*structs.QuotaUsage, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableQuotaUsage, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("quota usage lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.QuotaUsage), nil
+}
+
+// UpsertQuotaSpecs inserts or updates the given set of quota specifications
+// and recomputes their usage.
+func (s *StateStore) UpsertQuotaSpecs(msgType structs.MessageType, index uint64, specs []*structs.QuotaSpec) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, spec := range specs {
+		// Ensure the hashes are set. This should be done outside the state
+		// store for performance reasons, but we check here for defense in
+		// depth.
+		if len(spec.Hash) == 0 {
+			spec.SetHash()
+		}
+
+		existing, err := txn.First(TableQuotaSpec, indexID, spec.Name)
+		if err != nil {
+			return fmt.Errorf("quota spec lookup failed: %w", err)
+		}
+		if existing != nil {
+			spec.CreateIndex = existing.(*structs.QuotaSpec).CreateIndex
+		} else {
+			spec.CreateIndex = index
+		}
+		spec.ModifyIndex = index
+
+		if err := txn.Insert(TableQuotaSpec, spec); err != nil {
+			return fmt.Errorf("quota spec insert failed: %w", err)
+		}
+
+		// The limits may have changed so rebuild the usage from scratch.
+		if err := s.reconcileQuotaUsageTxn(txn, index, spec.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaSpec, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteQuotaSpecs removes the given set of quota specifications and their
+// usage. Quota specifications still referenced by a namespace cannot be
+// deleted.
+func (s *StateStore) DeleteQuotaSpecs(msgType structs.MessageType, index uint64, names []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, name := range names {
+		existing, err := txn.First(TableQuotaSpec, indexID, name)
+		if err != nil {
+			return fmt.Errorf("quota spec lookup failed: %w", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("quota spec %q not found", name)
+		}
+
+		ns, err := txn.First(TableNamespaces, "quota", name)
+		if err != nil {
+			return fmt.Errorf("namespace lookup failed: %w", err)
+		}
+		if ns != nil {
+			return fmt.Errorf("quota spec %q is in use by namespace %q", name, ns.(*structs.Namespace).Name)
+		}
+
+		if err := txn.Delete(TableQuotaSpec, existing); err != nil {
+			return fmt.Errorf("quota spec deletion failed: %w", err)
+		}
+		if _, err := txn.DeleteAll(TableQuotaUsage, indexID, name); err != nil {
+			return fmt.Errorf("quota usage deletion failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaSpec, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaUsage, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// quotaSpecExists returns whether the quota specification exists.
+func (s *StateStore) quotaSpecExists(txn *txn, name string) (bool, error) {
+	existing, err := txn.First(TableQuotaSpec, indexID, name)
+	return existing != nil, err
+}
+
+// quotaReconcile rebuilds the usage of the quotas whose set of namespaces
+// changed because a namespace moved from oldQuota to newQuota.
+func (s *StateStore) quotaReconcile(index uint64, txn *txn, newQuota, oldQuota string) error {
+	if newQuota == oldQuota {
+		return nil
+	}
+	for _, quota := range []string{newQuota, oldQuota} {
+		if quota == "" {
+			continue
+		}
+		if err := s.reconcileQuotaUsageTxn(txn, index, quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileQuotaUsageTxn recomputes the usage of the quota specification from
+// the non-terminal allocations of every namespace that references it.
+func (s *StateStore) reconcileQuotaUsageTxn(txn *txn, index uint64, name string) error {
+	raw, err := txn.First(TableQuotaSpec, indexID, name)
+	if err != nil {
+		return fmt.Errorf("quota spec lookup failed: %w", err)
+	}
+	if raw == nil {
+		return nil
+	}
+	spec := raw.(*structs.QuotaSpec)
+
+	usage := structs.NewQuotaUsage(spec, s.config.Region)
+	if existing, err := txn.First(TableQuotaUsage, indexID, name); err != nil {
+		return fmt.Errorf("quota usage lookup failed: %w", err)
+	} else if existing != nil {
+		usage.CreateIndex = existing.(*structs.QuotaUsage).CreateIndex
+	} else {
+		usage.CreateIndex = index
+	}
+	usage.ModifyIndex = index
+
+	if limit := spec.RegionLimit(s.config.Region); limit != nil {
+		used := usage.Used[limit.HashKey()].RegionLimit
+
+		nsIter, err := txn.Get(TableNamespaces, "quota", name)
+		if err != nil {
+			return fmt.Errorf("namespace lookup failed: %w", err)
+		}
+		for raw := nsIter.Next(); raw != nil; raw = nsIter.Next() {
+			ns := raw.(*structs.Namespace)
+
+			allocIter, err := s.allocsByNamespaceImpl(nil, txn, ns.Name)
+			if err != nil {
+				return fmt.Errorf("alloc lookup failed: %w", err)
+			}
+			for raw := allocIter.Next(); raw != nil; raw = allocIter.Next() {
+				alloc := raw.(*structs.Allocation)
+				if !alloc.TerminalStatus() {
+					used.Add(structs.AllocQuotaResources(alloc))
+				}
+			}
+		}
+	}
+
+	if err := txn.Insert(TableQuotaUsage, usage); err != nil {
+		return fmt.Errorf("quota usage insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaUsage, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
+
+// updateQuotaWithAlloc updates the usage of the quota attached to the
+// allocation's namespace when the allocation is added, modified or deleted.
+func (s *StateStore) updateQuotaWithAlloc(index uint64, alloc, existing *structs.Allocation, txn *txn) error {
+	delta := new(structs.QuotaResources)
+	if alloc != nil && !alloc.TerminalStatus() {
+		delta.Add(structs.AllocQuotaResources(alloc))
+	}
+	if existing != nil && !existing.TerminalStatus() {
+		delta.Subtract(structs.AllocQuotaResources(existing))
+	}
+	if *delta == (structs.QuotaResources{}) {
+		return nil
+	}
+
+	namespace := existing.GetNamespace()
+	if alloc != nil {
+		namespace = alloc.Namespace
+	}
+	quota, err := s.namespaceQuotaTxn(txn, namespace)
+	if err != nil || quota == "" {
+		return err
+	}
+
+	raw, err := txn.First(TableQuotaUsage, indexID, quota)
+	if err != nil {
+		return fmt.Errorf("quota usage lookup failed: %w", err)
+	}
+	if raw == nil {
+		return nil
+	}
+
+	usage := raw.(*structs.QuotaUsage).Copy()
+	for _, used := range usage.Used {
+		if used.Region == s.config.Region {
+			used.RegionLimit.Add(delta)
+		}
+	}
+	usage.ModifyIndex = index
+
+	if err := txn.Insert(TableQuotaUsage, usage); err != nil {
+		return fmt.Errorf("quota usage insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaUsage, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
+
+// namespaceQuotaTxn returns the name of the quota attached to the namespace,
+// or an empty string if the namespace has no quota.
+func (s *StateStore) namespaceQuotaTxn(txn *txn, namespace string) (string, error) {
+	raw, err := txn.First(TableNamespaces, indexID, namespace)
+	if err != nil {
+		return "", fmt.Errorf("namespace lookup failed: %w", err)
+	}
+	if raw == nil {
+		return "", nil
+	}
+	return raw.(*structs.Namespace).Quota, nil
+}
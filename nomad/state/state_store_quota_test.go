@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// quotaUsed returns the usage of the local region limit of the quota.
+func quotaUsed(t *testing.T, state *StateStore, spec *structs.QuotaSpec) *structs.QuotaResources {
+	t.Helper()
+
+	usage, err := state.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.NotNil(t, usage)
+
+	used, ok := usage.Used[spec.RegionLimit("global").HashKey()]
+	must.True(t, ok)
+	return used.RegionLimit
+}
+
+func TestStateStore_UpsertQuotaSpecs(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	spec := mock.QuotaSpec()
+
+	ws := memdb.NewWatchSet()
+	_, err := state.QuotaSpecByName(ws, spec.Name)
+	must.NoError(t, err)
+
+	must.NoError(t, state.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+	must.True(t, watchFired(ws))
+
+	out, err := state.QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, spec, out)
+	must.Eq(t, 1000, out.CreateIndex)
+
+	usage, err := state.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.NotNil(t, usage)
+	must.Eq(t, &structs.QuotaResources{}, quotaUsed(t, state, spec))
+
+	// Updating keeps the create index.
+	update := spec.Copy()
+	update.Description = "updated"
+	update.SetHash()
+	must.NoError(t, state.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1001, []*structs.QuotaSpec{update}))
+
+	out, err = state.QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, "updated", out.Description)
+	must.Eq(t, 1000, out.CreateIndex)
+	must.Eq(t, 1001, out.ModifyIndex)
+
+	iter, err := state.QuotaSpecsByNamePrefix(nil, spec.Name[:7])
+	must.NoError(t, err)
+	must.NotNil(t, iter.Next())
+	must.Nil(t, iter.Next())
+}
+
+func TestStateStore_QuotaUsage_Allocs(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	spec := mock.QuotaSpec()
+	must.NoError(t, state.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, state.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+
+	// Allocations in the namespace count against the quota.
+	alloc := mock.Alloc()
+	alloc.Namespace = ns.Name
+	alloc.Job.Namespace = ns.Name
+	other := mock.Alloc()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, alloc.Job))
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1003, nil, other.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1004, []*structs.Allocation{alloc, other}))
+	must.Eq(t, &structs.QuotaResources{CPU: 500, MemoryMB: 256, MemoryMaxMB: 256}, quotaUsed(t, state, spec))
+
+	// Changing the limits recomputes the usage from the allocations.
+	update := spec.Copy()
+	update.Limits[0].RegionLimit.CPU = 4000
+	update.SetHash()
+	must.NoError(t, state.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1005, []*structs.QuotaSpec{update}))
+	must.Eq(t, &structs.QuotaResources{CPU: 500, MemoryMB: 256, MemoryMaxMB: 256}, quotaUsed(t, state, update))
+
+	// Terminal allocations release their usage.
+	stopped := alloc.Copy()
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 1006, []*structs.Allocation{stopped}))
+	must.Eq(t, &structs.QuotaResources{}, quotaUsed(t, state, update))
+
+	// Detaching the quota from the namespace can't leave stale usage behind.
+	running := mock.Alloc()
+	running.Namespace = ns.Name
+	running.Job = alloc.Job
+	running.JobID = alloc.JobID
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1007, []*structs.Allocation{running}))
+	must.Eq(t, 500, quotaUsed(t, state, update).CPU)
+
+	ns = ns.Copy()
+	ns.Quota = ""
+	must.NoError(t, state.UpsertNamespaces(1008, []*structs.Namespace{ns}))
+	must.Eq(t, &structs.QuotaResources{}, quotaUsed(t, state, update))
+}
+
+func TestStateStore_DeleteQuotaSpecs(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	spec := mock.QuotaSpec()
+	must.NoError(t, state.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, state.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+
+	// Quotas in use can't be deleted.
+	err := state.DeleteQuotaSpecs(structs.MsgTypeTestSetup, 1002, []string{spec.Name})
+	must.ErrorContains(t, err, "in use by namespace")
+
+	err = state.DeleteQuotaSpecs(structs.MsgTypeTestSetup, 1002, []string{"missing"})
+	must.ErrorContains(t, err, "not found")
+
+	ns = ns.Copy()
+	ns.Quota = ""
+	must.NoError(t, state.UpsertNamespaces(1003, []*structs.Namespace{ns}))
+	must.NoError(t, state.DeleteQuotaSpecs(structs.MsgTypeTestSetup, 1004, []string{spec.Name}))
+
+	out, err := state.QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	usage, err := state.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Nil(t, usage)
+}
@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Reservations returns an iterator over all capacity reservations.
+func (s *StateStore) Reservations(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableReservations, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("reservations lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ReservationsByNamePrefix returns an iterator over all capacity reservations
+// that match the given name prefix.
+func (s *StateStore) ReservationsByNamePrefix(ws memdb.WatchSet, namePrefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableReservations, indexID+"_prefix", namePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("reservations prefix lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ReservationByName returns the capacity reservation that matches the given
+// name or nil if there is no match.
+func (s *StateStore) ReservationByName(ws memdb.WatchSet, name string) (*structs.Reservation, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableReservations, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("reservation lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.Reservation), nil
+}
+
+// UpsertReservations inserts or updates the given set of capacity
+// reservations.
+func (s *StateStore) UpsertReservations(msgType structs.MessageType, index uint64, reservations []*structs.Reservation) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, res := range reservations {
+		existing, err := txn.First(TableReservations, indexID, res.Name)
+		if err != nil {
+			return fmt.Errorf("reservation lookup failed: %w", err)
+		}
+		if existing != nil {
+			res.CreateIndex = existing.(*structs.Reservation).CreateIndex
+		} else {
+			res.CreateIndex = index
+		}
+		res.ModifyIndex = index
+
+		if err := txn.Insert(TableReservations, res); err != nil {
+			return fmt.Errorf("reservation insert failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableReservations, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return txn.Commit()
+}
+
+// DeleteReservations removes the given set of capacity reservations.
+func (s *StateStore) DeleteReservations(msgType structs.MessageType, index uint64, names []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, name := range names {
+		existing, err := txn.First(TableReservations, indexID, name)
+		if err != nil {
+			return fmt.Errorf("reservation lookup failed: %w", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("reservation %s not found", name)
+		}
+		if err := txn.Delete(TableReservations, existing); err != nil {
+			return fmt.Errorf("reservation deletion failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableReservations, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return txn.Commit()
+}
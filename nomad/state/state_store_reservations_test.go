@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_UpsertDeleteReservations(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	res := mock.Reservation()
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ReservationByName(ws, res.Name)
+	must.NoError(t, err)
+
+	must.NoError(t, state.UpsertReservations(structs.MsgTypeTestSetup, 1000, []*structs.Reservation{res}))
+	must.True(t, watchFired(ws))
+
+	out, err := state.ReservationByName(nil, res.Name)
+	must.NoError(t, err)
+	must.Eq(t, res, out)
+	must.Eq(t, 1000, out.CreateIndex)
+
+	// Updating keeps the create index.
+	update := res.Copy()
+	update.Description = "updated"
+	must.NoError(t, state.UpsertReservations(structs.MsgTypeTestSetup, 1001, []*structs.Reservation{update}))
+
+	out, err = state.ReservationByName(nil, res.Name)
+	must.NoError(t, err)
+	must.Eq(t, "updated", out.Description)
+	must.Eq(t, 1000, out.CreateIndex)
+	must.Eq(t, 1001, out.ModifyIndex)
+
+	iter, err := state.ReservationsByNamePrefix(nil, "reservation-")
+	must.NoError(t, err)
+	must.NotNil(t, iter.Next())
+	must.Nil(t, iter.Next())
+
+	index, err := state.Index(TableReservations)
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+
+	must.NoError(t, state.DeleteReservations(structs.MsgTypeTestSetup, 1002, []string{res.Name}))
+	out, err = state.ReservationByName(nil, res.Name)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	err = state.DeleteReservations(structs.MsgTypeTestSetup, 1003, []string{res.Name})
+	must.ErrorContains(t, err, "not found")
+}
@@ -224,6 +224,51 @@ func (r *StateRestore) VariablesQuotaRestore(quota *structs.VariablesQuota) erro
 	return nil
 }
 
+// QuotaSpecRestore is used to restore a single quota specification into the
+// quota_spec table.
+func (r *StateRestore) QuotaSpecRestore(spec *structs.QuotaSpec) error {
+	if err := r.txn.Insert(TableQuotaSpec, spec); err != nil {
+		return fmt.Errorf("quota spec insert failed: %v", err)
+	}
+	return nil
+}
+
+// QuotaUsageRestore is used to restore a single quota usage into the
+// quota_usage table.
+func (r *StateRestore) QuotaUsageRestore(usage *structs.QuotaUsage) error {
+	if err := r.txn.Insert(TableQuotaUsage, usage); err != nil {
+		return fmt.Errorf("quota usage insert failed: %v", err)
+	}
+	return nil
+}
+
+// VariableVersionRestore is used to restore a single prior version of a
+// variable into the variables_history table.
+func (r *StateRestore) VariableVersionRestore(version *structs.VariableVersion) error {
+	if err := r.txn.Insert(TableVariablesHistory, version); err != nil {
+		return fmt.Errorf("variable version insert failed: %v", err)
+	}
+	return nil
+}
+
+// ReservationRestore is used to restore a single capacity reservation into
+// the reservations table.
+func (r *StateRestore) ReservationRestore(res *structs.Reservation) error {
+	if err := r.txn.Insert(TableReservations, res); err != nil {
+		return fmt.Errorf("reservation insert failed: %v", err)
+	}
+	return nil
+}
+
+// EventSinkRestore is used to restore a single event sink into the
+// event_sinks table.
+func (r *StateRestore) EventSinkRestore(sink *structs.EventSink) error {
+	if err := r.txn.Insert(TableEventSinks, sink); err != nil {
+		return fmt.Errorf("event sink insert failed: %v", err)
+	}
+	return nil
+}
+
 // RootKeyMetaRestore is used to restore a legacy root key meta entry into the
 // wrapped_root_keys table.
 func (r *StateRestore) RootKeyMetaRestore(meta *structs.RootKeyMeta) error {
@@ -1949,6 +1949,42 @@ func TestStateStore_UpdateNodeEligibility(t *testing.T) {
 	must.ErrorContains(t, err, "while it is draining")
 }
 
+func TestStateStore_UpdateNodeUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	ws := memdb.NewWatchSet()
+	_, err := state.NodeByID(ws, node.ID)
+	must.NoError(t, err)
+
+	util := &structs.NodeUtilization{CPUPercent: 42, IOPressure: 3, UpdatedAt: 7}
+	must.NoError(t, state.UpdateNodeUtilization(structs.MsgTypeTestSetup, 1001, node.ID, util))
+	must.True(t, watchFired(ws))
+
+	out, err := state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, util, out.Utilization)
+	must.Eq(t, 1001, out.ModifyIndex)
+	must.Eq(t, node.ComputedClass, out.ComputedClass)
+
+	index, err := state.Index("nodes")
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+
+	// The utilization is retained when the node registers again
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1002, node.Copy()))
+	out, err = state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, util, out.Utilization)
+
+	// Unknown nodes are rejected
+	err = state.UpdateNodeUtilization(structs.MsgTypeTestSetup, 1003, uuid.Generate(), util)
+	must.ErrorContains(t, err, "node not found")
+}
+
 func TestStateStore_Nodes(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4347,6 +4383,56 @@ func TestStateStore_UpsertEvals_CancelBlocked(t *testing.T) {
 	must.False(t, watchFired(ws), must.Sprint("watch should not have fired"))
 }
 
+func TestStateStore_UpsertEvals_CancelDependencyBlocked(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	// Create an eval waiting for the job's dependencies
+	j := "test-job"
+	waiting := mock.Eval()
+	waiting.JobID = j
+	waiting.Status = structs.EvalStatusDependencyBlocked
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1000, []*structs.Evaluation{waiting}))
+
+	out, err := state.EvalsByStatus(nil, structs.EvalStatusDependencyBlocked)
+	must.NoError(t, err)
+	must.Len(t, 1, out)
+
+	// A newer eval waiting for the dependencies supersedes it
+	newer := mock.Eval()
+	newer.JobID = j
+	newer.Status = structs.EvalStatusDependencyBlocked
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1001, []*structs.Evaluation{newer}))
+
+	got, err := state.EvalByID(nil, waiting.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.EvalStatusCancelled, got.Status)
+	must.StrContains(t, got.StatusDescription, newer.ID)
+
+	out, err = state.EvalsByStatus(nil, structs.EvalStatusDependencyBlocked)
+	must.NoError(t, err)
+	must.Len(t, 1, out)
+	must.Eq(t, newer.ID, out[0].ID)
+
+	// Updating the waiting eval itself doesn't cancel it
+	newer = newer.Copy()
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1002, []*structs.Evaluation{newer}))
+	got, err = state.EvalByID(nil, newer.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.EvalStatusDependencyBlocked, got.Status)
+
+	// A complete eval for the job cancels it
+	complete := mock.Eval()
+	complete.JobID = j
+	complete.Status = structs.EvalStatusComplete
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1003, []*structs.Evaluation{complete}))
+
+	out, err = state.EvalsByStatus(nil, structs.EvalStatusDependencyBlocked)
+	must.NoError(t, err)
+	must.SliceEmpty(t, out)
+}
+
 func TestStateStore_UpsertEvals_Namespace(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7157,6 +7243,43 @@ func TestStateStore_SetJobStatus(t *testing.T) {
 	must.Eq(t, index, updated.ModifyIndex)
 }
 
+func TestStateStore_SetJobStatus_IndexedBatch(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	// Create a running indexed batch job whose second index failed
+	job := mock.BatchJob()
+	job.TaskGroups[0].IndexedBatch = &structs.IndexedBatch{Completions: 2, Parallelism: 2}
+	job.TaskGroups[0].Count = 2
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	var allocs []*structs.Allocation
+	for i, clientStatus := range []string{structs.AllocClientStatusComplete, structs.AllocClientStatusFailed} {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.Name = structs.AllocName(job.ID, alloc.TaskGroup, uint(i))
+		alloc.ClientStatus = clientStatus
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+
+	// Once the job is dead its status description records the failed index
+	txn := state.db.WriteTxn(1002)
+	defer txn.Abort()
+	current, err := state.JobByIDTxn(nil, job.Namespace, job.ID, txn)
+	must.NoError(t, err)
+	must.NoError(t, state.setJobStatus(1002, txn, current, true, ""))
+
+	raw, err := txn.First("jobs", "id", job.Namespace, job.ID)
+	must.NoError(t, err)
+	updated := raw.(*structs.Job)
+	must.Eq(t, structs.JobStatusDead, updated.Status)
+	must.Eq(t, "Indexed batch failed: indexes web[1] did not complete", updated.StatusDescription)
+}
+
 func TestStateStore_GetJobStatus(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4,9 +4,11 @@
 package state
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -122,7 +124,7 @@ func (s *StateStore) GetVariable(
 
 // VarSet is used to store a variable object.
 func (s *StateStore) VarSet(idx uint64, sv *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Perform the actual set.
@@ -141,7 +143,7 @@ func (s *StateStore) VarSet(idx uint64, sv *structs.VarApplyStateRequest) *struc
 // variable. The ModifyIndex in the provided entry is used to determine if
 // we should write the entry to the state store or not.
 func (s *StateStore) VarSetCAS(idx uint64, sv *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	resp := s.varSetCASTxn(tx, idx, sv)
@@ -239,6 +241,14 @@ func (s *StateStore) varSetTxn(tx WriteTxn, idx uint64, req *structs.VarApplySta
 		}
 		sv.ModifyIndex = idx
 		quotaChange = int64(len(sv.Data) - len(existing.Data))
+
+		// Only user writes add a version to the history, lock operations
+		// and rekeys keep the data of the variable.
+		if req.Op == structs.VarOpSet || req.Op == structs.VarOpCAS {
+			if err := s.varArchiveTxn(tx, idx, existing, sv.ModifyTime); err != nil {
+				return req.ErrorResponse(idx, err)
+			}
+		}
 	} else {
 		sv.CreateIndex = idx
 		sv.ModifyIndex = idx
@@ -300,7 +310,7 @@ func (s *StateStore) varSetTxn(tx WriteTxn, idx uint64, req *structs.VarApplySta
 // VarDelete is used to delete a single variable in the
 // the state store.
 func (s *StateStore) VarDelete(idx uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Perform the actual delete
@@ -322,7 +332,7 @@ func (s *StateStore) VarDelete(idx uint64, req *structs.VarApplyStateRequest) *s
 // last observed index for the given variable, then the call is a noop,
 // otherwise a normal delete is invoked.
 func (s *StateStore) VarDeleteCAS(idx uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	resp := s.svDeleteCASTxn(tx, idx, req)
@@ -429,6 +439,10 @@ func (s *StateStore) svDeleteTxn(tx WriteTxn, idx uint64, req *structs.VarApplyS
 		}
 	}
 
+	if err := s.varArchiveTxn(tx, idx, sv, req.Var.ModifyTime); err != nil {
+		return req.ErrorResponse(idx, err)
+	}
+
 	// Delete the variable and update the index table.
 	if err := tx.Delete(TableVariables, sv); err != nil {
 		return req.ErrorResponse(idx, fmt.Errorf("failed deleting variable entry: %s", err))
@@ -486,7 +500,7 @@ func (s *StateStore) VariablesQuotaByNamespace(ws memdb.WatchSet, namespace stri
 // IMPORTANT: this method overwrites the variable, data included.
 func (s *StateStore) VarLockAcquire(idx uint64,
 	req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Try to fetch the variable.
@@ -523,7 +537,7 @@ func (s *StateStore) VarLockAcquire(idx uint64,
 
 func (s *StateStore) VarLockRelease(idx uint64,
 	req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Look up the entry in the state store.
@@ -594,3 +608,239 @@ func isLocked(lock *structs.VariableLock, req *structs.VarApplyStateRequest) boo
 	}
 	return false
 }
+
+// VarRekey is used to replace the encrypted data of a variable with data
+// encrypted with the active root key. The ModifyIndex of the request selects
+// either the current version of the variable or one of its prior versions.
+// Prior versions keep their ModifyIndex since it identifies them.
+func (s *StateStore) VarRekey(idx uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
+	defer tx.Abort()
+
+	sv := req.Var
+	raw, err := tx.First(TableVariables, indexID, sv.Namespace, sv.Path)
+	if err != nil {
+		return req.ErrorResponse(idx, fmt.Errorf("failed variable lookup: %s", err))
+	}
+
+	var resp *structs.VarApplyStateResponse
+	if existing, ok := raw.(*structs.VariableEncrypted); ok && existing.ModifyIndex == sv.ModifyIndex {
+		resp = s.varSetTxn(tx, idx, req)
+	} else {
+		resp = s.varRekeyVersionTxn(tx, idx, req)
+	}
+	if !resp.IsOk() {
+		return resp
+	}
+
+	if err := tx.Commit(); err != nil {
+		return req.ErrorResponse(idx, err)
+	}
+	return resp
+}
+
+// varRekeyVersionTxn replaces the encrypted data of a prior version of a
+// variable within an existing transaction. A missing version is reported as a
+// conflict, since it has been replaced or purged since it was read.
+func (s *StateStore) varRekeyVersionTxn(tx WriteTxn, idx uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
+	sv := req.Var
+	raw, err := tx.First(TableVariablesHistory, indexID, sv.Namespace, sv.Path, sv.ModifyIndex)
+	if err != nil {
+		return req.ErrorResponse(idx, fmt.Errorf("failed variable version lookup: %s", err))
+	}
+	if raw == nil {
+		zeroVal := &structs.VariableEncrypted{
+			VariableMetadata: structs.VariableMetadata{
+				Namespace: sv.Namespace,
+				Path:      sv.Path,
+			},
+		}
+		return req.ConflictResponse(idx, zeroVal)
+	}
+
+	version := raw.(*structs.VariableVersion).Copy()
+	version.VariableData = sv.VariableData.Copy()
+	if err := tx.Insert(TableVariablesHistory, version); err != nil {
+		return req.ErrorResponse(idx, fmt.Errorf("failed inserting variable version: %s", err))
+	}
+	if err := tx.Insert(tableIndex, &IndexEntry{TableVariablesHistory, idx}); err != nil {
+		return req.ErrorResponse(idx, fmt.Errorf("failed updating variable history index: %s", err))
+	}
+
+	return req.SuccessResponse(idx, nil)
+}
+
+// varArchiveTxn writes the version of a variable that is being replaced or
+// deleted to its history, and trims the history to the limit configured for
+// the variable's namespace.
+func (s *StateStore) varArchiveTxn(tx WriteTxn, idx uint64, sv *structs.VariableEncrypted, now int64) error {
+	raw, err := tx.First(TableNamespaces, indexID, sv.Namespace)
+	if err != nil {
+		return fmt.Errorf("namespace lookup failed: %v", err)
+	}
+	var config *structs.NamespaceVariablesConfiguration
+	if ns, ok := raw.(*structs.Namespace); ok {
+		config = ns.VariablesConfiguration
+	}
+
+	limit := config.Limit()
+	if limit > 0 {
+		if err := tx.Insert(TableVariablesHistory, structs.NewVariableVersion(sv, idx, now)); err != nil {
+			return fmt.Errorf("failed inserting variable version: %v", err)
+		}
+	}
+
+	versions, err := variableVersionsTxn(tx, nil, sv.Namespace, sv.Path)
+	if err != nil {
+		return err
+	}
+	for _, version := range versions[min(limit, len(versions)):] {
+		if err := tx.Delete(TableVariablesHistory, version); err != nil {
+			return fmt.Errorf("failed deleting variable version: %v", err)
+		}
+	}
+
+	if err := tx.Insert(tableIndex, &IndexEntry{TableVariablesHistory, idx}); err != nil {
+		return fmt.Errorf("failed updating variable history index: %v", err)
+	}
+	return nil
+}
+
+// variableVersionsTxn returns the prior versions of a variable, newest first.
+func variableVersionsTxn(txn ReadTxn, ws memdb.WatchSet, namespace, path string) ([]*structs.VariableVersion, error) {
+	iter, err := txn.Get(TableVariablesHistory, indexID+"_prefix", namespace, path)
+	if err != nil {
+		return nil, fmt.Errorf("variable version lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var versions []*structs.VariableVersion
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		// The prefix lookup also matches longer paths
+		version := raw.(*structs.VariableVersion)
+		if version.Path == path {
+			versions = append(versions, version)
+		}
+	}
+
+	slices.SortFunc(versions, func(a, b *structs.VariableVersion) int {
+		return cmp.Compare(b.Version(), a.Version())
+	})
+	return versions, nil
+}
+
+// GetVariableVersions returns the prior versions of the variable at a given
+// namespace and path, newest first.
+func (s *StateStore) GetVariableVersions(
+	ws memdb.WatchSet, namespace, path string) ([]*structs.VariableVersion, error) {
+	txn := s.db.ReadTxn()
+	return variableVersionsTxn(txn, ws, namespace, path)
+}
+
+// GetVariableVersion returns a single prior version of the variable at a
+// given namespace and path.
+func (s *StateStore) GetVariableVersion(
+	ws memdb.WatchSet, namespace, path string, version uint64) (*structs.VariableVersion, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, raw, err := txn.FirstWatch(TableVariablesHistory, indexID, namespace, path, version)
+	if err != nil {
+		return nil, fmt.Errorf("variable version lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+	if raw == nil {
+		return nil, nil
+	}
+	return raw.(*structs.VariableVersion), nil
+}
+
+// VariablesHistory queries the prior versions of all variables and is used
+// for snapshot/restore and garbage collection.
+func (s *StateStore) VariablesHistory(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableVariablesHistory, indexID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// GetVariableVersionsByKeyID returns an iterator that contains all the prior
+// versions of variables that were encrypted with a particular key.
+func (s *StateStore) GetVariableVersionsByKeyID(
+	ws memdb.WatchSet, keyID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableVariablesHistory, indexKeyID, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("variable version lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// VarHistoryPurge deletes prior versions of variables. Versions that no
+// longer exist are ignored.
+func (s *StateStore) VarHistoryPurge(msgType structs.MessageType, idx uint64, ids []*structs.VariableVersionID) error {
+	txn := s.db.WriteTxnMsgT(msgType, idx)
+	defer txn.Abort()
+
+	for _, id := range ids {
+		raw, err := txn.First(TableVariablesHistory, indexID, id.Namespace, id.Path, id.Version)
+		if err != nil {
+			return fmt.Errorf("variable version lookup failed: %v", err)
+		}
+		if raw == nil {
+			continue
+		}
+		if err := txn.Delete(TableVariablesHistory, raw); err != nil {
+			return fmt.Errorf("failed deleting variable version: %v", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableVariablesHistory, idx}); err != nil {
+		return fmt.Errorf("failed updating variable history index: %v", err)
+	}
+	return txn.Commit()
+}
+
+// VarExpire deletes expired variables. A variable is only deleted if its
+// current version is the one that was found expired and it doesn't hold a
+// lock, otherwise it is kept. The expired version is archived at its expire
+// time.
+func (s *StateStore) VarExpire(msgType structs.MessageType, idx uint64, ids []*structs.VariableVersionID) error {
+	txn := s.db.WriteTxnMsgT(msgType, idx)
+	defer txn.Abort()
+
+	for _, id := range ids {
+		raw, err := txn.First(TableVariables, indexID, id.Namespace, id.Path)
+		if err != nil {
+			return fmt.Errorf("variable lookup failed: %v", err)
+		}
+		sv, ok := raw.(*structs.VariableEncrypted)
+		if !ok || sv.ModifyIndex != id.Version || sv.ExpireTime == 0 {
+			continue
+		}
+
+		req := &structs.VarApplyStateRequest{
+			Op: structs.VarOpDeleteCAS,
+			Var: &structs.VariableEncrypted{
+				VariableMetadata: structs.VariableMetadata{
+					Namespace:   sv.Namespace,
+					Path:        sv.Path,
+					ModifyIndex: sv.ModifyIndex,
+					ModifyTime:  sv.ExpireTime,
+				},
+			},
+		}
+		if resp := s.svDeleteTxn(txn, idx, req); resp.IsError() {
+			return resp.Error
+		}
+	}
+
+	return txn.Commit()
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 	"github.com/shoenig/test/must"
@@ -1201,3 +1202,151 @@ func getAllVariables(ss *StateStore, ws memdb.WatchSet) ([]*structs.VariableEncr
 
 	return got, nil
 }
+
+func TestStateStore_VariablesHistory(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	ns := mock.Namespace()
+	ns.VariablesConfiguration = &structs.NamespaceVariablesConfiguration{HistoryLimit: 2}
+	must.NoError(t, testState.UpsertNamespaces(10, []*structs.Namespace{ns}))
+
+	sv := mock.VariableEncrypted()
+	sv.Namespace = ns.Name
+	sv.Path = "aaa"
+
+	// A sibling variable whose path shares the prefix must not show up in
+	// the history of the first one.
+	sibling := sv.Copy()
+	sibling.Path = "aaa/bbb"
+	resp := testState.VarSet(11, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: &sibling})
+	must.NoError(t, resp.Error)
+
+	// Write four versions, the first three are archived but only the two
+	// newest are kept.
+	for idx := uint64(20); idx < 24; idx++ {
+		next := sv.Copy()
+		next.ModifyTime = int64(idx)
+		resp := testState.VarSet(idx, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: &next})
+		must.NoError(t, resp.Error)
+	}
+
+	versions, err := testState.GetVariableVersions(nil, ns.Name, sv.Path)
+	must.NoError(t, err)
+	must.Len(t, 2, versions)
+	must.Eq(t, 22, versions[0].Version())
+	must.Eq(t, 23, versions[0].ArchiveIndex)
+	must.Eq(t, 23, versions[0].ArchiveTime)
+	must.Eq(t, 21, versions[1].Version())
+
+	version, err := testState.GetVariableVersion(nil, ns.Name, sv.Path, 20)
+	must.NoError(t, err)
+	must.Nil(t, version)
+
+	// Rekeying a prior version replaces its data but keeps its version and
+	// doesn't add to the history.
+	rekeyed := versions[1].VariableEncrypted.Copy()
+	rekeyed.KeyID = "new-key"
+	resp = testState.VarRekey(30, &structs.VarApplyStateRequest{Op: structs.VarOpRekey, Var: &rekeyed})
+	must.NoError(t, resp.Error)
+	must.True(t, resp.IsOk())
+
+	iter, err := testState.GetVariableVersionsByKeyID(nil, "new-key")
+	must.NoError(t, err)
+	raw := iter.Next()
+	must.NotNil(t, raw)
+	must.Eq(t, 21, raw.(*structs.VariableVersion).Version())
+	must.Nil(t, iter.Next())
+
+	// Rekeying a purged version is a conflict.
+	rekeyed.ModifyIndex = 20
+	resp = testState.VarRekey(31, &structs.VarApplyStateRequest{Op: structs.VarOpRekey, Var: &rekeyed})
+	must.True(t, resp.IsConflict())
+
+	// Deleting the variable archives its last version.
+	resp = testState.VarDelete(32, &structs.VarApplyStateRequest{Op: structs.VarOpDelete, Var: sv})
+	must.NoError(t, resp.Error)
+	versions, err = testState.GetVariableVersions(nil, ns.Name, sv.Path)
+	must.NoError(t, err)
+	must.Len(t, 2, versions)
+	must.Eq(t, 23, versions[0].Version())
+	must.Eq(t, 22, versions[1].Version())
+
+	// Purging removes the given versions and ignores missing ones.
+	must.NoError(t, testState.VarHistoryPurge(structs.MsgTypeTestSetup, 33,
+		[]*structs.VariableVersionID{
+			{Namespace: ns.Name, Path: sv.Path, Version: 22},
+			{Namespace: ns.Name, Path: sv.Path, Version: 20},
+		}))
+	versions, err = testState.GetVariableVersions(nil, ns.Name, sv.Path)
+	must.NoError(t, err)
+	must.Len(t, 1, versions)
+	must.Eq(t, 23, versions[0].Version())
+
+	historyIndex, err := testState.Index(TableVariablesHistory)
+	must.NoError(t, err)
+	must.Eq(t, 33, historyIndex)
+}
+
+func TestStateStore_VarExpire(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	expired := mock.VariableEncrypted()
+	expired.Path = "expired"
+	expired.TTL = time.Minute
+	expired.ExpireTime = 1000
+
+	rewritten := expired.Copy()
+	rewritten.Path = "rewritten"
+
+	locked := expired.Copy()
+	locked.Path = "locked"
+	locked.Lock = &structs.VariableLock{ID: "theLockID", TTL: 15 * time.Second}
+
+	for i, sv := range []*structs.VariableEncrypted{expired, &rewritten} {
+		resp := testState.VarSet(uint64(10+i), &structs.VarApplyStateRequest{
+			Op: structs.VarOpSet, Var: sv,
+		})
+		must.NoError(t, resp.Error)
+	}
+	resp := testState.VarLockAcquire(12, &structs.VarApplyStateRequest{
+		Op: structs.VarOpLockAcquire, Var: &locked,
+	})
+	must.NoError(t, resp.Error)
+
+	// The rewritten variable is expired with the version it had before it
+	// was written again, so it must be kept.
+	staleVersion := rewritten.ModifyIndex
+	next := rewritten.Copy()
+	next.ExpireTime = 2000
+	resp = testState.VarSet(13, &structs.VarApplyStateRequest{
+		Op: structs.VarOpSet, Var: &next,
+	})
+	must.NoError(t, resp.Error)
+
+	err := testState.VarExpire(structs.MsgTypeTestSetup, 20, []*structs.VariableVersionID{
+		{Namespace: expired.Namespace, Path: expired.Path, Version: expired.ModifyIndex},
+		{Namespace: rewritten.Namespace, Path: rewritten.Path, Version: staleVersion},
+		{Namespace: locked.Namespace, Path: locked.Path, Version: locked.ModifyIndex},
+		{Namespace: expired.Namespace, Path: "missing", Version: 1},
+	})
+	must.NoError(t, err)
+
+	out, err := testState.GetVariable(nil, expired.Namespace, expired.Path)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	// The expired version is archived at its expire time.
+	versions, err := testState.GetVariableVersions(nil, expired.Namespace, expired.Path)
+	must.NoError(t, err)
+	must.Len(t, 1, versions)
+	must.Eq(t, 20, versions[0].ArchiveIndex)
+	must.Eq(t, expired.ExpireTime, versions[0].ArchiveTime)
+
+	for _, path := range []string{rewritten.Path, locked.Path} {
+		out, err := testState.GetVariable(nil, expired.Namespace, path)
+		must.NoError(t, err)
+		must.NotNil(t, out, must.Sprintf("expected variable %q to be kept", path))
+	}
+}
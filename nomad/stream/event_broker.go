@@ -5,7 +5,6 @@ package stream
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"sync/atomic"
 
@@ -121,7 +120,7 @@ func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
 		head = e.eventBuf.Head()
 	}
 	if offset > 0 && req.StartExactlyAtIndex {
-		return nil, fmt.Errorf("requested index not in buffer")
+		return nil, ErrIndexNotInBuffer
 	} else if offset > 0 {
 		metrics.SetGauge([]string{"nomad", "event_broker", "subscription", "request_offset"}, float32(offset))
 		e.logger.Debug("requested index no longer in buffer", "requsted", int(req.Index), "closest", int(head.Events.Index))
@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"slices"
+	"strings"
 	"sync/atomic"
 
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -27,6 +29,10 @@ const (
 // closed. The client should Unsubscribe, then re-Subscribe.
 var ErrSubscriptionClosed = errors.New("subscription closed by server, client should resubscribe")
 
+// ErrIndexNotInBuffer is returned when subscribing with StartExactlyAtIndex
+// to an index the event buffer doesn't hold.
+var ErrIndexNotInBuffer = errors.New("requested index not in buffer")
+
 type Subscription struct {
 	// state must be accessed atomically 0 means open, 1 means closed with reload
 	state uint32
@@ -65,6 +71,19 @@ type SubscribeRequest struct {
 	// associated with the SubscribeRequest has not expired and
 	// has the correct permissions
 	Authenticate func() error
+
+	// AllowEvent is an optional callback used to drop individual events the
+	// subscriber is not allowed to see, for topics where permissions depend
+	// on the object itself rather than only its namespace.
+	AllowEvent func(event *structs.Event) bool
+
+	// Filter is an optional go-bexpr expression evaluated against each event
+	// that matches the topics, including its payload. Events the expression
+	// can't be evaluated against, such as those whose payload lacks a field
+	// it selects, are dropped rather than rejected. Allocation events don't
+	// include their job, so they can only be selected by fields of the
+	// allocation such as its JobID.
+	Filter *bexpr.Evaluator
 }
 
 func newSubscription(req *SubscribeRequest, item *bufferItem, unsub func()) *Subscription {
@@ -124,53 +143,85 @@ func (s *Subscription) Unsubscribe() {
 }
 
 // filter events to only those that match a subscriptions topic/keys/namespace
+// and filter expression
 func filter(req *SubscribeRequest, events []structs.Event) []structs.Event {
 	if len(events) == 0 {
 		return nil
 	}
 
-	allTopicKeys := req.Topics[structs.TopicAll]
-
 	var result []structs.Event
 
 	for _, event := range events {
-		if event.Namespace != "" && !slices.Contains(req.Namespaces, event.Namespace) {
+		if event.Namespace != "" && !allowNamespace(req.Namespaces, event.Namespace) {
 			continue
 		}
 
-		// *[*] always matches
-		if len(allTopicKeys) == 1 && allTopicKeys[0] == string(structs.TopicAll) {
-			result = append(result, event)
+		if req.AllowEvent != nil && !req.AllowEvent(&event) {
 			continue
 		}
 
-		keys := allTopicKeys
-
-		if topicKeys, ok := req.Topics[event.Topic]; ok {
-			keys = append(keys, topicKeys...)
-		}
-
-		if len(keys) == 1 && keys[0] == string(structs.TopicAll) {
-			result = append(result, event)
+		if !matchesTopics(req, event) {
 			continue
 		}
 
-		for _, key := range keys {
-			if eventMatchesKey(event, key) {
-				result = append(result, event)
+		if req.Filter != nil {
+			if match, err := req.Filter.Evaluate(&event); err != nil || !match {
 				continue
 			}
 		}
+
+		result = append(result, event)
 	}
 
 	return result
 }
 
+// matchesTopics returns true if the event matches one of the topics and keys
+// of the subscription.
+func matchesTopics(req *SubscribeRequest, event structs.Event) bool {
+	allTopicKeys := req.Topics[structs.TopicAll]
+
+	// *[*] always matches
+	if len(allTopicKeys) == 1 && allTopicKeys[0] == string(structs.TopicAll) {
+		return true
+	}
+
+	keys := allTopicKeys
+
+	if topicKeys, ok := req.Topics[event.Topic]; ok {
+		keys = append(keys, topicKeys...)
+	}
+
+	if len(keys) == 1 && keys[0] == string(structs.TopicAll) {
+		return true
+	}
+
+	for _, key := range keys {
+		if eventMatchesKey(event, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowNamespace returns true if the namespace is one of the namespaces of
+// the subscription, or if the subscription is for all namespaces.
+func allowNamespace(namespaces []string, namespace string) bool {
+	return slices.Contains(namespaces, structs.AllNamespacesSentinel) ||
+		slices.Contains(namespaces, namespace)
+}
+
 func eventMatchesKey(event structs.Event, key string) bool {
 	if event.Key == key {
 		return true
 	}
 
+	// Variables are matched by path prefix so that subscribers can follow
+	// a whole tree of variables.
+	if event.Topic == structs.TopicVariables && strings.HasPrefix(event.Key, key) {
+		return true
+	}
+
 	for _, fk := range event.FilterKeys {
 		if fk == key {
 			return true
@@ -6,6 +6,7 @@ package stream
 import (
 	"testing"
 
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/require"
@@ -159,6 +160,24 @@ func TestFilter_Namespace(t *testing.T) {
 	require.Equal(t, 2, cap(actual))
 }
 
+func TestFilter_AllNamespaces(t *testing.T) {
+	ci.Parallel(t)
+
+	event1 := structs.Event{Topic: "Test", Key: "One", Namespace: "foo"}
+	event2 := structs.Event{Topic: "Test", Key: "Two", Namespace: "bar"}
+	event3 := structs.Event{Topic: "Test", Key: "Three"}
+	events := []structs.Event{event1, event2, event3}
+
+	req := &SubscribeRequest{
+		Topics: map[structs.Topic][]string{
+			"*": {"*"},
+		},
+		Namespaces: []string{structs.AllNamespacesSentinel},
+	}
+	actual := filter(req, events)
+	require.Equal(t, events, actual)
+}
+
 func TestFilter_FilterKeys(t *testing.T) {
 	ci.Parallel(t)
 
@@ -178,3 +197,82 @@ func TestFilter_FilterKeys(t *testing.T) {
 
 	require.Equal(t, 1, cap(actual))
 }
+
+func TestFilter_VariablesPathPrefix(t *testing.T) {
+	ci.Parallel(t)
+
+	event1 := structs.Event{Topic: structs.TopicVariables, Key: "app/web/config"}
+	event2 := structs.Event{Topic: structs.TopicVariables, Key: "app/api/config"}
+	event3 := structs.Event{Topic: structs.TopicVariables, Key: "other/config"}
+	events := []structs.Event{event1, event2, event3}
+
+	req := &SubscribeRequest{
+		Topics: map[structs.Topic][]string{
+			structs.TopicVariables: {"app/", "app/web"},
+		},
+	}
+	actual := filter(req, events)
+	expected := []structs.Event{event1, event2}
+	require.Equal(t, expected, actual)
+}
+
+func TestFilter_AllowEvent(t *testing.T) {
+	ci.Parallel(t)
+
+	event1 := structs.Event{Topic: structs.TopicVariables, Key: "app/web/config"}
+	event2 := structs.Event{Topic: structs.TopicVariables, Key: "secret/config"}
+	events := []structs.Event{event1, event2}
+
+	req := &SubscribeRequest{
+		Topics: map[structs.Topic][]string{
+			"*": {"*"},
+		},
+		AllowEvent: func(event *structs.Event) bool {
+			return event.Key != "secret/config"
+		},
+	}
+	actual := filter(req, events)
+	expected := []structs.Event{event1}
+	require.Equal(t, expected, actual)
+}
+
+func TestFilter_Expression(t *testing.T) {
+	ci.Parallel(t)
+
+	failed := &structs.Allocation{ClientStatus: structs.AllocClientStatusFailed,
+		JobID: "payments"}
+	running := &structs.Allocation{ClientStatus: structs.AllocClientStatusRunning,
+		JobID: "payments"}
+
+	event1 := structs.Event{Topic: structs.TopicAllocation, Key: "one",
+		Payload: &structs.AllocationEvent{Allocation: failed}}
+	event2 := structs.Event{Topic: structs.TopicAllocation, Key: "two",
+		Payload: &structs.AllocationEvent{Allocation: running}}
+	event3 := structs.Event{Topic: structs.TopicJob, Key: "three",
+		Payload: &structs.JobEvent{Job: &structs.Job{ID: "payments"}}}
+	events := []structs.Event{event1, event2, event3}
+
+	evaluator, err := bexpr.CreateEvaluator(
+		`Payload.Allocation.JobID == "payments" and Payload.Allocation.ClientStatus == "failed"`)
+	require.NoError(t, err)
+
+	// The job event can't be evaluated against the expression, so it's
+	// dropped rather than failing the subscription.
+	req := &SubscribeRequest{
+		Topics: map[structs.Topic][]string{
+			"*": {"*"},
+		},
+		Filter: evaluator,
+	}
+	actual := filter(req, events)
+	expected := []structs.Event{event1}
+	require.Equal(t, expected, actual)
+
+	// The expression can select the fields of the event itself.
+	evaluator, err = bexpr.CreateEvaluator(`Topic == "Job" or Key == "two"`)
+	require.NoError(t, err)
+	req.Filter = evaluator
+	actual = filter(req, events)
+	expected = []structs.Event{event2, event3}
+	require.Equal(t, expected, actual)
+}
@@ -424,7 +424,7 @@ func (a *Allocation) ReschedulePolicy() *ReschedulePolicy {
 	if tg == nil {
 		return nil
 	}
-	return tg.ReschedulePolicy
+	return tg.EffectiveReschedulePolicy()
 }
 
 // MigrateStrategy returns the migrate strategy based on the task group
@@ -441,7 +441,7 @@ func (a *Allocation) MigrateStrategy() *MigrateStrategy {
 func (a *Allocation) NextRescheduleTime() (time.Time, bool) {
 	failTime := a.LastEventTime()
 	reschedulePolicy := a.ReschedulePolicy()
-	isRescheduledBatch := a.Job.Type == JobTypeBatch && a.DesiredTransition.ShouldReschedule()
+	isRescheduledBatch := (a.Job.Type == JobTypeBatch || a.Job.Type == JobTypeWorkflow) && a.DesiredTransition.ShouldReschedule()
 
 	// If reschedule is disabled, return early
 	if reschedulePolicy == nil || (reschedulePolicy.Attempts == 0 && !reschedulePolicy.Unlimited) {
@@ -994,6 +994,15 @@ type AllocMetric struct {
 	// QuotaExhausted provides the exhausted dimensions
 	QuotaExhausted []string
 
+	// GangUnsatisfiable is the name of the gang the task group belongs to
+	// when nothing was placed because the gang as a whole did not fit.
+	GangUnsatisfiable string
+
+	// PreemptionBlocked provides the count of nodes where the disruption
+	// budget of a task group kept its allocations from being preempted,
+	// keyed by namespace, job and task group
+	PreemptionBlocked map[string]int
+
 	// ResourcesExhausted provides the amount of resources exhausted by task
 	// during the allocation placement
 	ResourcesExhausted map[string]*Resources
@@ -1014,6 +1023,11 @@ type AllocMetric struct {
 	// the highest normalized score
 	topScores *kheap.ScoreHeap
 
+	// NodeExplanations records what happened to every node visited while
+	// placing the allocation, keyed by node ID. It is only set when the
+	// scheduler was asked to explain its placements.
+	NodeExplanations map[string]*NodeExplanation
+
 	// AllocationTime is a measure of how long the allocation
 	// attempt took. This can affect performance and SLAs.
 	AllocationTime time.Duration
@@ -1037,17 +1051,58 @@ func (a *AllocMetric) Copy() *AllocMetric {
 	na.ClassExhausted = maps.Clone(na.ClassExhausted)
 	na.DimensionExhausted = maps.Clone(na.DimensionExhausted)
 	na.QuotaExhausted = slices.Clone(na.QuotaExhausted)
+	na.PreemptionBlocked = maps.Clone(na.PreemptionBlocked)
 	na.Scores = maps.Clone(na.Scores)
 	na.ScoreMetaData = CopySliceNodeScoreMeta(na.ScoreMetaData)
+	if a.NodeExplanations != nil {
+		na.NodeExplanations = make(map[string]*NodeExplanation, len(a.NodeExplanations))
+		for id, exp := range a.NodeExplanations {
+			na.NodeExplanations[id] = exp.Copy()
+		}
+	}
 	return na
 }
 
+// EnableExplain makes the metric record what happens to every node visited
+// while placing the allocation.
+func (a *AllocMetric) EnableExplain() {
+	if a.NodeExplanations == nil {
+		a.NodeExplanations = make(map[string]*NodeExplanation)
+	}
+}
+
+// Explaining returns whether the metric records what happens to every node
+// visited while placing the allocation.
+func (a *AllocMetric) Explaining() bool {
+	return a != nil && a.NodeExplanations != nil
+}
+
+// explainNode returns the explanation of the given node, or nil if the
+// metric doesn't explain placements.
+func (a *AllocMetric) explainNode(node *Node) *NodeExplanation {
+	if !a.Explaining() || node == nil {
+		return nil
+	}
+	exp, ok := a.NodeExplanations[node.ID]
+	if !ok {
+		exp = &NodeExplanation{
+			NodeID:   node.ID,
+			NodeName: node.Name,
+		}
+		a.NodeExplanations[node.ID] = exp
+	}
+	return exp
+}
+
 func (a *AllocMetric) EvaluateNode() {
 	a.NodesEvaluated += 1
 }
 
 func (a *AllocMetric) FilterNode(node *Node, constraint string) {
 	a.NodesFiltered += 1
+	if exp := a.explainNode(node); exp != nil {
+		exp.Filtered = constraint
+	}
 	if node != nil && node.NodeClass != "" {
 		if a.ClassFiltered == nil {
 			a.ClassFiltered = make(map[string]int)
@@ -1064,6 +1119,9 @@ func (a *AllocMetric) FilterNode(node *Node, constraint string) {
 
 func (a *AllocMetric) ExhaustedNode(node *Node, dimension string) {
 	a.NodesExhausted += 1
+	if exp := a.explainNode(node); exp != nil {
+		exp.Exhausted = dimension
+	}
 	if node != nil && node.NodeClass != "" {
 		if a.ClassExhausted == nil {
 			a.ClassExhausted = make(map[string]int)
@@ -1078,6 +1136,15 @@ func (a *AllocMetric) ExhaustedNode(node *Node, dimension string) {
 	}
 }
 
+// BlockPreemption records that the disruption budget of a task group kept
+// its allocations on a node from being preempted.
+func (a *AllocMetric) BlockPreemption(taskGroup string) {
+	if a.PreemptionBlocked == nil {
+		a.PreemptionBlocked = make(map[string]int)
+	}
+	a.PreemptionBlocked[taskGroup] += 1
+}
+
 func (a *AllocMetric) ExhaustQuota(dimensions []string) {
 	if a.QuotaExhausted == nil {
 		a.QuotaExhausted = make([]string, 0, len(dimensions))
@@ -1117,6 +1184,17 @@ func (a *AllocMetric) ExhaustResources(tg *TaskGroup) {
 
 // ScoreNode is used to gather top K scoring nodes in a heap
 func (a *AllocMetric) ScoreNode(node *Node, name string, score float64) {
+	if exp := a.explainNode(node); exp != nil {
+		if name == NormScorerName {
+			exp.NormScore = score
+		} else {
+			if exp.Scores == nil {
+				exp.Scores = make(map[string]float64)
+			}
+			exp.Scores[name] = score
+		}
+	}
+
 	// Create nodeScoreMeta lazily if its the first time or if its a new node
 	if a.nodeScoreMeta == nil || a.nodeScoreMeta.NodeID != node.ID {
 		a.nodeScoreMeta = &NodeScoreMeta{
@@ -1142,6 +1220,29 @@ func (a *AllocMetric) ScoreNode(node *Node, name string, score float64) {
 	}
 }
 
+// LabelNode attaches a human readable explanation to the score given to the
+// node by the named scorer. It must be called before the normalized score of
+// the node is recorded.
+func (a *AllocMetric) LabelNode(node *Node, name, label string) {
+	if exp := a.explainNode(node); exp != nil {
+		if exp.Labels == nil {
+			exp.Labels = make(map[string]string)
+		}
+		exp.Labels[name] = label
+	}
+
+	if a.nodeScoreMeta == nil || a.nodeScoreMeta.NodeID != node.ID {
+		a.nodeScoreMeta = &NodeScoreMeta{
+			NodeID: node.ID,
+			Scores: make(map[string]float64),
+		}
+	}
+	if a.nodeScoreMeta.Labels == nil {
+		a.nodeScoreMeta.Labels = make(map[string]string)
+	}
+	a.nodeScoreMeta.Labels[name] = label
+}
+
 // PopulateScoreMetaData populates a map of scorer to scoring metadata
 // The map is populated by popping elements from a heap of top K scores
 // maintained per scorer
@@ -1168,6 +1269,51 @@ func (a *AllocMetric) MaxNormScore() *NodeScoreMeta {
 	return a.ScoreMetaData[0]
 }
 
+// NodeExplanation records what happened to a node visited by the scheduler
+// while placing an allocation: the constraint that filtered it, the resource
+// dimension it was exhausted on, or the scores it was given.
+type NodeExplanation struct {
+	NodeID   string
+	NodeName string
+
+	// Filtered is the constraint or check the node failed, if any.
+	Filtered string
+
+	// Exhausted is the resource dimension the node didn't have enough of
+	// for the allocation, if any.
+	Exhausted string
+
+	// Scores is the score given to the node by each scorer, and NormScore
+	// the final normalized score of the node.
+	Scores    map[string]float64
+	NormScore float64
+
+	// Labels holds explanations of the scores given by scoring plugins,
+	// keyed by scorer name.
+	Labels map[string]string
+}
+
+func (e *NodeExplanation) Copy() *NodeExplanation {
+	if e == nil {
+		return nil
+	}
+	ne := new(NodeExplanation)
+	*ne = *e
+	ne.Scores = maps.Clone(e.Scores)
+	ne.Labels = maps.Clone(e.Labels)
+	return ne
+}
+
+// PlacementExplanation explains why an allocation of a job plan was placed
+// on its node.
+type PlacementExplanation struct {
+	AllocName string
+	TaskGroup string
+	NodeID    string
+	NodeName  string
+	Metrics   *AllocMetric
+}
+
 const (
 	// AllocServiceRegistrationsRPCMethod is the RPC method for listing all
 	// service registrations assigned to a specific allocation.
@@ -1455,6 +1601,15 @@ type DesiredTransition struct {
 	// when Migrate is set. This field is used to prevent batch job allocations
 	// from being placed after being stopped.
 	MigrateDisablePlacement *bool
+
+	// Rebalance is used to indicate that the migration was requested by the
+	// rebalancer to pack allocations onto fewer nodes.
+	Rebalance *bool
+
+	// RebalanceTargetNodeID is the node the rebalancer expects to receive the
+	// migrated allocation. The scheduler prefers it for the replacement, but
+	// places the allocation on another node if it no longer fits there.
+	RebalanceTargetNodeID string
 }
 
 // Merge merges the two desired transitions, preferring the values from the
@@ -1479,6 +1634,14 @@ func (d *DesiredTransition) Merge(o *DesiredTransition) {
 	if o.NoShutdownDelay != nil {
 		d.NoShutdownDelay = o.NoShutdownDelay
 	}
+
+	if o.Rebalance != nil {
+		d.Rebalance = o.Rebalance
+	}
+
+	if o.RebalanceTargetNodeID != "" {
+		d.RebalanceTargetNodeID = o.RebalanceTargetNodeID
+	}
 }
 
 // ShouldMigrate returns whether the transition object dictates a migration.
@@ -1489,6 +1652,15 @@ func (d *DesiredTransition) ShouldMigrate() bool {
 	return d.Migrate != nil && *d.Migrate
 }
 
+// ShouldRebalance returns whether the transition object is a migration
+// requested by the rebalancer.
+func (d *DesiredTransition) ShouldRebalance() bool {
+	if d == nil {
+		return false
+	}
+	return d.ShouldMigrate() && d.Rebalance != nil && *d.Rebalance
+}
+
 // ShouldReschedule returns whether the transition object dictates a
 // rescheduling.
 func (d *DesiredTransition) ShouldReschedule() bool {
@@ -0,0 +1,220 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// validCustomResourceName is used to validate the name of a custom resource.
+var validCustomResourceName = regexp.MustCompile("^[a-zA-Z0-9_-]{1,128}$")
+
+// NodeCustomResource is a named countable resource of a node declared in the
+// client configuration, such as software license seats or connection slots.
+// Tasks request units of the resource, which are accounted for like CPU and
+// memory.
+type NodeCustomResource struct {
+	Name  string `hcl:",key"`
+	Count uint64 `hcl:"count"`
+}
+
+func (r *NodeCustomResource) Copy() *NodeCustomResource {
+	if r == nil {
+		return nil
+	}
+	nr := *r
+	return &nr
+}
+
+func (r *NodeCustomResource) Equal(o *NodeCustomResource) bool {
+	if r == nil || o == nil {
+		return r == o
+	}
+	return *r == *o
+}
+
+func (r *NodeCustomResource) Validate() error {
+	var mErr multierror.Error
+	if !validCustomResourceName.MatchString(r.Name) {
+		_ = multierror.Append(&mErr, fmt.Errorf("Custom resource name %q must only contain letters, digits, dashes and underscores", r.Name))
+	}
+	if r.Count == 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Custom resource %q count must be greater than 0", r.Name))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ValidateNodeCustomResources validates the custom resources of a node.
+func ValidateNodeCustomResources(resources []*NodeCustomResource) error {
+	var mErr multierror.Error
+	names := make(map[string]struct{}, len(resources))
+	for _, r := range resources {
+		if err := r.Validate(); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+		if _, ok := names[r.Name]; ok {
+			_ = multierror.Append(&mErr, fmt.Errorf("Custom resource %q is declared more than once", r.Name))
+		}
+		names[r.Name] = struct{}{}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// CopySliceNodeCustomResources returns a deep copy of the given custom
+// resources.
+func CopySliceNodeCustomResources(s []*NodeCustomResource) []*NodeCustomResource {
+	if s == nil {
+		return nil
+	}
+	c := make([]*NodeCustomResource, len(s))
+	for i, r := range s {
+		c[i] = r.Copy()
+	}
+	return c
+}
+
+// NodeCustomResourcesEqual returns whether the custom resources are equal as
+// a set keyed by name.
+func NodeCustomResourcesEqual(r1, r2 []*NodeCustomResource) bool {
+	return maps.Equal(nodeCustomResourceCounts(r1), nodeCustomResourceCounts(r2))
+}
+
+// nodeCustomResourceCounts returns the count of the custom resources of a
+// node by name.
+func nodeCustomResourceCounts(resources []*NodeCustomResource) map[string]uint64 {
+	if len(resources) == 0 {
+		return nil
+	}
+	counts := make(map[string]uint64, len(resources))
+	for _, r := range resources {
+		counts[r.Name] += r.Count
+	}
+	return counts
+}
+
+// RequestedCustomResource is a number of units of a custom resource of the
+// node requested by a task.
+type RequestedCustomResource struct {
+	// Name is the name of the custom resource declared by the client.
+	Name string
+
+	// Count is the number of units requested.
+	Count uint64
+}
+
+func (r *RequestedCustomResource) Copy() *RequestedCustomResource {
+	if r == nil {
+		return nil
+	}
+	nr := *r
+	return &nr
+}
+
+func (r *RequestedCustomResource) Equal(o *RequestedCustomResource) bool {
+	if r == nil || o == nil {
+		return r == o
+	}
+	return *r == *o
+}
+
+func (r *RequestedCustomResource) Validate() error {
+	var mErr multierror.Error
+	if !validCustomResourceName.MatchString(r.Name) {
+		_ = multierror.Append(&mErr, fmt.Errorf("name %q must only contain letters, digits, dashes and underscores", r.Name))
+	}
+	if r.Count == 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("count must be greater than 0"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ResourceCustomResources are the custom resources requested by a task.
+type ResourceCustomResources []*RequestedCustomResource
+
+// Copy returns a deep copy of the requested custom resources.
+func (c ResourceCustomResources) Copy() ResourceCustomResources {
+	if c == nil {
+		return nil
+	}
+	nc := make(ResourceCustomResources, len(c))
+	for i, r := range c {
+		nc[i] = r.Copy()
+	}
+	return nc
+}
+
+// Equal returns whether the requested custom resources are equal as a set
+// keyed by name.
+func (c ResourceCustomResources) Equal(o ResourceCustomResources) bool {
+	return len(c) == len(o) && maps.Equal(c.Counts(), o.Counts())
+}
+
+// Counts returns the number of units requested by name.
+func (c ResourceCustomResources) Counts() map[string]uint64 {
+	if len(c) == 0 {
+		return nil
+	}
+	counts := make(map[string]uint64, len(c))
+	for _, r := range c {
+		counts[r.Name] += r.Count
+	}
+	return counts
+}
+
+// addCustomResourceCounts adds the delta counts to the counts, allocating the
+// map if needed.
+func addCustomResourceCounts(counts map[string]uint64, delta map[string]uint64) map[string]uint64 {
+	if len(delta) == 0 {
+		return counts
+	}
+	if counts == nil {
+		counts = make(map[string]uint64, len(delta))
+	}
+	for name, count := range delta {
+		counts[name] += count
+	}
+	return counts
+}
+
+// maxCustomResourceCounts sets the counts to the maximum of the counts and
+// the other counts, allocating the map if needed.
+func maxCustomResourceCounts(counts map[string]uint64, other map[string]uint64) map[string]uint64 {
+	if len(other) == 0 {
+		return counts
+	}
+	if counts == nil {
+		counts = make(map[string]uint64, len(other))
+	}
+	for name, count := range other {
+		counts[name] = max(counts[name], count)
+	}
+	return counts
+}
+
+// subtractCustomResourceCounts subtracts the delta counts from the counts,
+// without going below zero.
+func subtractCustomResourceCounts(counts map[string]uint64, delta map[string]uint64) {
+	for name, count := range delta {
+		if current, ok := counts[name]; ok {
+			counts[name] = current - min(current, count)
+		}
+	}
+}
+
+// customResourcesSuperset returns whether the available counts cover the used
+// counts, and the name of the first exhausted custom resource, in
+// alphabetical order, if not.
+func customResourcesSuperset(available, used map[string]uint64) (bool, string) {
+	for _, name := range slices.Sorted(maps.Keys(used)) {
+		if count := used[name]; count > 0 && available[name] < count {
+			return false, name
+		}
+	}
+	return true, ""
+}
@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestValidateNodeCustomResources(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, ValidateNodeCustomResources([]*NodeCustomResource{
+		{Name: "license_seat", Count: 4},
+		{Name: "nfs-slot", Count: 1},
+	}))
+
+	err := ValidateNodeCustomResources([]*NodeCustomResource{
+		{Name: "license seat", Count: 4},
+		{Name: "nfs_slot", Count: 0},
+		{Name: "nfs_slot", Count: 1},
+	})
+	must.ErrorContains(t, err, `Custom resource name "license seat" must only contain`)
+	must.ErrorContains(t, err, `Custom resource "nfs_slot" count must be greater than 0`)
+	must.ErrorContains(t, err, `Custom resource "nfs_slot" is declared more than once`)
+}
+
+func TestResources_Validate_CustomResources(t *testing.T) {
+	ci.Parallel(t)
+
+	r := &Resources{
+		CPU:      100,
+		MemoryMB: 128,
+		CustomResources: ResourceCustomResources{
+			{Name: "license_seat", Count: 1},
+		},
+	}
+	must.NoError(t, r.Validate())
+
+	r.CustomResources = append(r.CustomResources,
+		&RequestedCustomResource{Name: "license_seat", Count: 1},
+		&RequestedCustomResource{Name: "nfs_slot", Count: 0},
+	)
+	err := r.Validate()
+	must.ErrorContains(t, err, `custom resource "license_seat" requested more than once`)
+	must.ErrorContains(t, err, "count must be greater than 0")
+}
+
+func TestResourceCustomResources_Equal(t *testing.T) {
+	ci.Parallel(t)
+
+	a := ResourceCustomResources{
+		{Name: "license_seat", Count: 1},
+		{Name: "nfs_slot", Count: 2},
+	}
+	b := ResourceCustomResources{
+		{Name: "nfs_slot", Count: 2},
+		{Name: "license_seat", Count: 1},
+	}
+	must.True(t, a.Equal(b))
+	must.True(t, a.Equal(a.Copy()))
+
+	b[0].Count = 3
+	must.False(t, a.Equal(b))
+	must.False(t, a.Equal(nil))
+	must.True(t, ResourceCustomResources(nil).Equal(nil))
+}
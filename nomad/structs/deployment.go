@@ -37,6 +37,7 @@ const (
 	DeploymentStatusDescriptionFailedAllocations     = "Failed due to unhealthy allocations"
 	DeploymentStatusDescriptionProgressDeadline      = "Failed due to progress deadline"
 	DeploymentStatusDescriptionFailedByUser          = "Deployment marked as failed"
+	DeploymentStatusDescriptionGangTimeout           = "Failed due to gang placement timeout"
 
 	// used only in multiregion deployments
 	DeploymentStatusDescriptionFailedByPeer   = "Failed because of an error in peer region"
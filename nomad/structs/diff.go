@@ -129,6 +129,28 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Tolerations diff
+	tolerationsDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.Tolerations),
+		interfaceSlice(other.Tolerations),
+		nil,
+		"Toleration",
+		contextual)
+	if tolerationsDiff != nil {
+		diff.Objects = append(diff.Objects, tolerationsDiff...)
+	}
+
+	// Job affinities diff
+	jobAffinitiesDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.JobAffinities),
+		interfaceSlice(other.JobAffinities),
+		nil,
+		"JobAffinity",
+		contextual)
+	if jobAffinitiesDiff != nil {
+		diff.Objects = append(diff.Objects, jobAffinitiesDiff...)
+	}
+
 	// Task groups diff
 	tgs, err := taskGroupDiffs(j.TaskGroups, other.TaskGroups, contextual)
 	if err != nil {
@@ -141,6 +163,22 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, pDiff)
 	}
 
+	// ScheduleWindow diff
+	if swDiff := primitiveObjectDiff(j.ScheduleWindow, other.ScheduleWindow, nil, "ScheduleWindow", contextual); swDiff != nil {
+		diff.Objects = append(diff.Objects, swDiff)
+	}
+
+	// DependsOn diff
+	depsDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.DependsOn),
+		interfaceSlice(other.DependsOn),
+		nil,
+		"DependsOn",
+		contextual)
+	if depsDiff != nil {
+		diff.Objects = append(diff.Objects, depsDiff...)
+	}
+
 	// ParameterizedJob diff
 	if cDiff := parameterizedJobDiff(j.ParameterizedJob, other.ParameterizedJob, contextual); cDiff != nil {
 		diff.Objects = append(diff.Objects, cDiff)
@@ -287,6 +325,28 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Tolerations diff
+	tolerationsDiff := primitiveObjectSetDiff(
+		interfaceSlice(tg.Tolerations),
+		interfaceSlice(other.Tolerations),
+		nil,
+		"Toleration",
+		contextual)
+	if tolerationsDiff != nil {
+		diff.Objects = append(diff.Objects, tolerationsDiff...)
+	}
+
+	// Job affinities diff
+	jobAffinitiesDiff := primitiveObjectSetDiff(
+		interfaceSlice(tg.JobAffinities),
+		interfaceSlice(other.JobAffinities),
+		nil,
+		"JobAffinity",
+		contextual)
+	if jobAffinitiesDiff != nil {
+		diff.Objects = append(diff.Objects, jobAffinitiesDiff...)
+	}
+
 	// Restart policy diff
 	rDiff := primitiveObjectDiff(tg.RestartPolicy, other.RestartPolicy, nil, "RestartPolicy", contextual)
 	if rDiff != nil {
@@ -327,6 +387,26 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, disconnectDiff)
 	}
 
+	// Gang diff
+	if gangDiff := primitiveObjectDiff(tg.Gang, other.Gang, nil, "Gang", contextual); gangDiff != nil {
+		diff.Objects = append(diff.Objects, gangDiff)
+	}
+
+	// Disruption budget diff
+	if dbDiff := primitiveObjectDiff(tg.DisruptionBudget, other.DisruptionBudget, nil, "DisruptionBudget", contextual); dbDiff != nil {
+		diff.Objects = append(diff.Objects, dbDiff)
+	}
+
+	// Indexed batch diff
+	if ibDiff := primitiveObjectDiff(tg.IndexedBatch, other.IndexedBatch, nil, "IndexedBatch", contextual); ibDiff != nil {
+		diff.Objects = append(diff.Objects, ibDiff)
+	}
+
+	// After diff
+	if setDiff := stringSetDiff(tg.After, other.After, "After", contextual); setDiff != nil && setDiff.Type != DiffTypeNone {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
 	// Network Resources diff
 	if nDiffs := networkResourceDiffs(tg.Networks, other.Networks, contextual); nDiffs != nil {
 		diff.Objects = append(diff.Objects, nDiffs...)
@@ -2661,6 +2741,11 @@ func (r *Resources) Diff(other *Resources, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, nDiffs...)
 	}
 
+	// Requested custom resources diff
+	if nDiffs := requestedCustomResourcesDiffs(r.CustomResources, other.CustomResources, contextual); nDiffs != nil {
+		diff.Objects = append(diff.Objects, nDiffs...)
+	}
+
 	// NUMA resources diff
 	if nDiff := r.NUMA.Diff(other.NUMA, contextual); nDiff != nil {
 		diff.Objects = append(diff.Objects, nDiff)
@@ -2977,6 +3062,39 @@ func requestedDevicesDiffs(old, new []*RequestedDevice, contextual bool) []*Obje
 
 }
 
+// requestedCustomResourcesDiffs diffs a set of RequestedCustomResources keyed
+// by name. If contextual diff is enabled, non-changed fields will still be
+// returned.
+func requestedCustomResourcesDiffs(old, new []*RequestedCustomResource, contextual bool) []*ObjectDiff {
+	makeSet := func(resources []*RequestedCustomResource) map[string]*RequestedCustomResource {
+		resourceMap := make(map[string]*RequestedCustomResource, len(resources))
+		for _, r := range resources {
+			resourceMap[r.Name] = r
+		}
+		return resourceMap
+	}
+
+	oldSet := makeSet(old)
+	newSet := makeSet(new)
+
+	var diffs []*ObjectDiff
+	for k, oldV := range oldSet {
+		if diff := primitiveObjectDiff(oldV, newSet[k], nil, "CustomResource", contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for k, newV := range newSet {
+		if _, ok := oldSet[k]; !ok {
+			if diff := primitiveObjectDiff(nil, newV, nil, "CustomResource", contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
 // configDiff returns the diff of two Task Config objects. If contextual diff is
 // enabled, all fields will be returned, even if no diff occurred.
 func configDiff(old, new map[string]interface{}, contextual bool) *ObjectDiff {
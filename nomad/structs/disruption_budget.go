@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// DisruptionBudget limits how many allocations of a task group voluntary
+// disruptions can make unavailable at once across the cluster. Node drains,
+// preemption and job restarts hold off, or pick other allocations, rather
+// than leave the task group with fewer healthy allocations than the budget
+// requires.
+type DisruptionBudget struct {
+	// MinHealthy is the minimum number of healthy allocations the task group
+	// must keep.
+	MinHealthy int
+
+	// MaxUnavailable is the maximum number of allocations of the task group
+	// that can be unavailable. Zero means no limit.
+	MaxUnavailable int
+}
+
+func (b *DisruptionBudget) Copy() *DisruptionBudget {
+	if b == nil {
+		return nil
+	}
+	nb := *b
+	return &nb
+}
+
+func (b *DisruptionBudget) Validate() error {
+	var mErr *multierror.Error
+	if b.MinHealthy < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("min_healthy must be >= 0 but found %d", b.MinHealthy))
+	}
+	if b.MaxUnavailable < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("max_unavailable must be >= 0 but found %d", b.MaxUnavailable))
+	}
+	if b.MinHealthy == 0 && b.MaxUnavailable == 0 {
+		mErr = multierror.Append(mErr, errors.New("must set min_healthy or max_unavailable"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// MinHealthyAllocs returns the number of healthy allocations a task group
+// with the given count must keep.
+func (b *DisruptionBudget) MinHealthyAllocs(count int) int {
+	if b == nil {
+		return 0
+	}
+	minHealthy := b.MinHealthy
+	if b.MaxUnavailable > 0 {
+		minHealthy = max(minHealthy, count-b.MaxUnavailable)
+	}
+	return minHealthy
+}
+
+// AllowedDisruptions returns the number of allocations that can be disrupted
+// given the count of the task group and its number of healthy allocations.
+func (b *DisruptionBudget) AllowedDisruptions(count, healthy int) int {
+	return max(0, healthy-b.MinHealthyAllocs(count))
+}
+
+// BlockedReason returns why the budget blocks a disruption given the count of
+// the task group and its number of healthy allocations.
+func (b *DisruptionBudget) BlockedReason(count, healthy int) string {
+	return fmt.Sprintf("disruption budget requires %d healthy allocations and %d are healthy",
+		b.MinHealthyAllocs(count), healthy)
+}
+
+// DisruptionHealthy returns whether the allocation counts as healthy towards
+// the disruption budget of its task group: it is running, not marked
+// unhealthy by a deployment, and not already being migrated.
+func (a *Allocation) DisruptionHealthy() bool {
+	return !a.TerminalStatus() &&
+		a.ClientStatus == AllocClientStatusRunning &&
+		!a.DeploymentStatus.IsUnhealthy() &&
+		!a.DesiredTransition.ShouldMigrate()
+}
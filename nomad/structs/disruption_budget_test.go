@@ -0,0 +1,100 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestDisruptionBudget_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, (&DisruptionBudget{MinHealthy: 2}).Validate())
+	must.NoError(t, (&DisruptionBudget{MaxUnavailable: 1}).Validate())
+
+	err := (&DisruptionBudget{}).Validate()
+	must.ErrorContains(t, err, "must set min_healthy or max_unavailable")
+
+	err = (&DisruptionBudget{MinHealthy: -1, MaxUnavailable: -1}).Validate()
+	must.ErrorContains(t, err, "min_healthy must be >= 0")
+	must.ErrorContains(t, err, "max_unavailable must be >= 0")
+}
+
+func TestDisruptionBudget_AllowedDisruptions(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name    string
+		budget  *DisruptionBudget
+		count   int
+		healthy int
+		allowed int
+	}{
+		{
+			name:    "nil budget",
+			count:   5,
+			healthy: 5,
+			allowed: 5,
+		},
+		{
+			name:    "min healthy",
+			budget:  &DisruptionBudget{MinHealthy: 3},
+			count:   5,
+			healthy: 5,
+			allowed: 2,
+		},
+		{
+			name:    "max unavailable",
+			budget:  &DisruptionBudget{MaxUnavailable: 1},
+			count:   5,
+			healthy: 4,
+			allowed: 0,
+		},
+		{
+			name:    "stricter of both",
+			budget:  &DisruptionBudget{MinHealthy: 2, MaxUnavailable: 2},
+			count:   5,
+			healthy: 5,
+			allowed: 2,
+		},
+		{
+			name:    "fewer healthy than required",
+			budget:  &DisruptionBudget{MinHealthy: 4},
+			count:   5,
+			healthy: 3,
+			allowed: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.allowed, tc.budget.AllowedDisruptions(tc.count, tc.healthy))
+		})
+	}
+}
+
+func TestTaskGroup_DisruptionBudget_Count(t *testing.T) {
+	ci.Parallel(t)
+
+	j := &Job{Type: JobTypeService}
+	tg := &TaskGroup{
+		Name:             "web",
+		Count:            3,
+		DisruptionBudget: &DisruptionBudget{MinHealthy: 2},
+	}
+	must.StrNotContains(t, tg.Validate(j).Error(), "min_healthy")
+	must.NoError(t, tg.Warnings(j))
+
+	// A budget requiring every allocation to stay healthy is allowed, but
+	// blocks all voluntary disruptions.
+	tg.DisruptionBudget.MinHealthy = 3
+	must.StrNotContains(t, tg.Validate(j).Error(), "min_healthy")
+	must.ErrorContains(t, tg.Warnings(j), "min_healthy is equal to task group count (3)")
+
+	tg.DisruptionBudget.MinHealthy = 4
+	must.ErrorContains(t, tg.Validate(j), "min_healthy is greater than task group count (4 > 3)")
+}
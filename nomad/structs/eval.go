@@ -29,12 +29,19 @@ const (
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
 	EvalTriggerAllocReschedule      = "alloc-reschedule"
-
-	EvalStatusBlocked   = "blocked"
-	EvalStatusPending   = "pending"
-	EvalStatusComplete  = "complete"
-	EvalStatusFailed    = "failed"
-	EvalStatusCancelled = "canceled"
+	EvalTriggerGangTimeout          = "gang-timeout"
+	EvalTriggerRebalance            = "rebalance"
+	EvalTriggerScheduleWindow       = "schedule-window"
+	EvalTriggerJobDependency        = "job-dependency"
+	EvalTriggerWorkflowStep         = "workflow-step"
+	EvalTriggerIndexedBatch         = "indexed-batch"
+
+	EvalStatusBlocked           = "blocked"
+	EvalStatusDependencyBlocked = "dependency-blocked"
+	EvalStatusPending           = "pending"
+	EvalStatusComplete          = "complete"
+	EvalStatusFailed            = "failed"
+	EvalStatusCancelled         = "canceled"
 
 	// EvalDeleteRPCMethod is the RPC method for batch deleting evaluations
 	// using their IDs.
@@ -100,6 +107,22 @@ const (
 
 	// CoreJobForceGC is used to force garbage collection of all GCable objects.
 	CoreJobForceGC = "force-gc"
+
+	// CoreJobRebalance is used to migrate running service allocations onto
+	// fewer nodes.
+	CoreJobRebalance = "rebalance"
+
+	// CoreJobReservationGC is used to delete capacity reservations whose TTL
+	// has expired.
+	CoreJobReservationGC = "reservation-gc"
+
+	// CoreJobVariableHistoryGC is used to delete the prior versions of
+	// variables that fall outside of their namespace's retention.
+	CoreJobVariableHistoryGC = "variable-history-gc"
+
+	// CoreJobVariableExpiredGC is used to delete variables that have reached
+	// their expire time but were not expired by the leader's timers.
+	CoreJobVariableExpiredGC = "variable-expired-gc"
 )
 
 // Evaluation is used anytime we need to apply business logic as a result
@@ -207,6 +230,11 @@ type Evaluation struct {
 	// during the evaluation. This should not be set during normal operations.
 	AnnotatePlan bool
 
+	// ExplainPlan triggers the scheduler to record what happened to every
+	// node it visited while placing allocations. Like AnnotatePlan, it is
+	// only set for the evaluations of job plans.
+	ExplainPlan bool
+
 	// QueuedAllocations is the number of unplaced allocations at the time the
 	// evaluation was processed. The map is keyed by Task Group names.
 	QueuedAllocations map[string]int
@@ -381,7 +409,8 @@ func (e *Evaluation) ShouldEnqueue() bool {
 	switch e.Status {
 	case EvalStatusPending:
 		return true
-	case EvalStatusComplete, EvalStatusFailed, EvalStatusBlocked, EvalStatusCancelled:
+	case EvalStatusComplete, EvalStatusFailed, EvalStatusBlocked, EvalStatusDependencyBlocked,
+		EvalStatusCancelled:
 		return false
 	default:
 		panic(fmt.Sprintf("unhandled evaluation (%s) status %s", e.ID, e.Status))
@@ -394,7 +423,8 @@ func (e *Evaluation) ShouldBlock() bool {
 	switch e.Status {
 	case EvalStatusBlocked:
 		return true
-	case EvalStatusComplete, EvalStatusFailed, EvalStatusPending, EvalStatusCancelled:
+	case EvalStatusComplete, EvalStatusFailed, EvalStatusPending, EvalStatusDependencyBlocked,
+		EvalStatusCancelled:
 		return false
 	default:
 		panic(fmt.Sprintf("unhandled evaluation (%s) status %s", e.ID, e.Status))
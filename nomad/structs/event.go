@@ -35,6 +35,7 @@ const (
 	TopicCSIVolume      Topic = "CSIVolume"
 	TopicCSIPlugin      Topic = "CSIPlugin"
 	TopicOperator       Topic = "Operator"
+	TopicVariables      Topic = "Variables"
 	TopicAll            Topic = "*"
 
 	TypeNodeRegistration              = "NodeRegistration"
@@ -73,6 +74,11 @@ const (
 	TypeCSIVolumeDeregistered         = "CSIVolumeDeregistered"
 	TypeCSIVolumeClaim                = "CSIVolumeClaim"
 	TypeUtilizationSnapshotUpserted   = "UtilizationSnapshotUpserted"
+	TypeVariableUpserted              = "VariableUpserted"
+	TypeVariableDeleted               = "VariableDeleted"
+	TypeVariableLockAcquired          = "VariableLockAcquired"
+	TypeVariableLockReleased          = "VariableLockReleased"
+	TypeVariableExpired               = "VariableExpired"
 )
 
 // Event represents a change in Nomads state.
@@ -216,3 +222,24 @@ type CSIVolumeEvent struct {
 type CSIPluginEvent struct {
 	Plugin *CSIPlugin
 }
+
+// VariableEvent holds the metadata of a newly updated or deleted variable to
+// be used as an event in the event stream. It never includes the variable
+// items nor the ID of its lock holder.
+type VariableEvent struct {
+	Variable *VariableMetadata
+}
+
+// NewVariableEvent takes a variable and creates a new VariableEvent with a
+// copy of its metadata. The lock ID is removed since it grants the ability to
+// renew and release the lock.
+func NewVariableEvent(sv *VariableEncrypted) *VariableEvent {
+	meta := sv.VariableMetadata
+	if meta.Lock != nil {
+		meta.Lock = &VariableLock{
+			TTL:       meta.Lock.TTL,
+			LockDelay: meta.Lock.LockDelay,
+		}
+	}
+	return &VariableEvent{Variable: &meta}
+}
@@ -0,0 +1,288 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// EventSinkWebhook is the type of sinks that POST events to an HTTP
+	// endpoint.
+	EventSinkWebhook = "webhook"
+
+	// EventSinkFile is the type of sinks that append events as newline
+	// delimited JSON to a file on the leader.
+	EventSinkFile = "file"
+)
+
+const (
+	// EventSinkStatusPending is the status of a sink the leader hasn't started
+	// delivering to yet.
+	EventSinkStatusPending = "pending"
+
+	// EventSinkStatusRunning is the status of a sink whose last delivery
+	// succeeded.
+	EventSinkStatusRunning = "running"
+
+	// EventSinkStatusFailing is the status of a sink whose last delivery
+	// failed and is being retried.
+	EventSinkStatusFailing = "failing"
+)
+
+var (
+	// validEventSinkID is the rule used to validate an event sink ID.
+	validEventSinkID = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
+)
+
+// EventSink is a durable consumer of the event stream managed by the leader.
+// Events matching the topics and namespace of the sink are delivered at least
+// once, in index order, and the index of the last delivered event is
+// checkpointed in raft so that a new leader resumes where the previous one
+// stopped. Events evicted from the event buffer before they were delivered
+// are reported as missed in the status of the sink.
+type EventSink struct {
+	// ID is the unique identifier of the sink.
+	ID string
+
+	// Type is the type of the sink, either webhook or file.
+	Type string
+
+	// Topics is the set of topics and keys the sink receives, in the same
+	// form as the topics of an event stream request.
+	Topics map[Topic][]string
+
+	// Namespace is the namespace of the events the sink receives, or "*" for
+	// all namespaces. Events that aren't namespaced are always delivered.
+	Namespace string
+
+	// Filter is an optional go-bexpr expression evaluated against each
+	// event. Events that don't match it, or whose payload lacks a field it
+	// selects, are not delivered.
+	Filter string
+
+	// Address is the URL events are POSTed to by webhook sinks.
+	Address string
+
+	// Path is the absolute path of the file events are appended to by file
+	// sinks. The file is written on whichever server is the leader, and must
+	// be within the event_sink_file_dir directory of its configuration.
+	Path string
+
+	// LatestIndex is the index of the last event checkpointed as delivered.
+	// It is set by the server.
+	LatestIndex uint64
+
+	// Raft indexes.
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// GetID implements the IDGetter interface required for pagination.
+func (e *EventSink) GetID() string {
+	return e.ID
+}
+
+// Canonicalize sets the defaults of the sink.
+func (e *EventSink) Canonicalize() {
+	if e.Namespace == "" {
+		e.Namespace = AllNamespacesSentinel
+	}
+	if len(e.Topics) == 0 {
+		e.Topics = map[Topic][]string{TopicAll: {string(TopicAll)}}
+	}
+}
+
+// Validate returns an error if the sink is invalid.
+func (e *EventSink) Validate() error {
+	var mErr *multierror.Error
+
+	if !validEventSinkID.MatchString(e.ID) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid ID %q, must match regex %s", e.ID, validEventSinkID))
+	}
+	if e.Namespace == "" {
+		mErr = multierror.Append(mErr, fmt.Errorf("missing namespace"))
+	}
+
+	if len(e.Topics) == 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("must specify at least one topic"))
+	}
+	for topic, keys := range e.Topics {
+		if topic == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("topic cannot be empty"))
+		}
+		if len(keys) == 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("topic %q must have at least one key", topic))
+		}
+	}
+
+	if e.Filter != "" {
+		if _, err := bexpr.CreateEvaluator(e.Filter); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid filter: %v", err))
+		}
+	}
+
+	switch e.Type {
+	case EventSinkWebhook:
+		u, err := url.Parse(e.Address)
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid address: %v", err))
+		} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("address must be an http or https URL"))
+		}
+		if e.Path != "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("path is not supported by webhook sinks"))
+		}
+	case EventSinkFile:
+		if !filepath.IsAbs(e.Path) {
+			mErr = multierror.Append(mErr, fmt.Errorf("path must be absolute"))
+		}
+		if e.Address != "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("address is not supported by file sinks"))
+		}
+	default:
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid type %q, must be %q or %q",
+			e.Type, EventSinkWebhook, EventSinkFile))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a deep copy of the sink.
+func (e *EventSink) Copy() *EventSink {
+	if e == nil {
+		return nil
+	}
+	ne := *e
+	if e.Topics != nil {
+		ne.Topics = make(map[Topic][]string, len(e.Topics))
+		for topic, keys := range e.Topics {
+			ne.Topics[topic] = slices.Clone(keys)
+		}
+	}
+	return &ne
+}
+
+// SameConfig returns true if both sinks deliver the same events to the same
+// destination, ignoring the delivery progress and raft indexes.
+func (e *EventSink) SameConfig(other *EventSink) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+	return e.ID == other.ID &&
+		e.Type == other.Type &&
+		e.Namespace == other.Namespace &&
+		e.Filter == other.Filter &&
+		e.Address == other.Address &&
+		e.Path == other.Path &&
+		maps.EqualFunc(e.Topics, other.Topics, slices.Equal)
+}
+
+// EventSinkStatus is the delivery status of an event sink, as reported by the
+// leader.
+type EventSinkStatus struct {
+	// ID is the ID of the sink.
+	ID string
+
+	// Type is the type of the sink.
+	Type string
+
+	// Status is one of pending, running or failing.
+	Status string
+
+	// DeliveredIndex is the index of the last event delivered to the sink.
+	// It may be ahead of CheckpointIndex until the next checkpoint.
+	DeliveredIndex uint64
+
+	// CheckpointIndex is the index of the last event checkpointed in raft as
+	// delivered.
+	CheckpointIndex uint64
+
+	// LatestIndex is the latest index applied by the leader.
+	LatestIndex uint64
+
+	// Lag is how many raft indexes the delivery trails the leader by. It is
+	// zero when every event available to the sink was delivered.
+	Lag uint64
+
+	// LastError is the error of the last failed delivery, cleared once a
+	// delivery succeeds.
+	LastError string
+
+	// LastDelivery is the time of the last successful delivery.
+	LastDelivery time.Time
+
+	// MissedFromIndex and MissedToIndex bound the raft indexes whose events
+	// may not have been delivered, because they were evicted from the event
+	// buffer before the delivery resumed. They are zero unless events were
+	// missed since the leader started delivering to the sink.
+	MissedFromIndex uint64
+	MissedToIndex   uint64
+}
+
+// EventSinkListRequest is used to list event sinks.
+type EventSinkListRequest struct {
+	QueryOptions
+}
+
+// EventSinkListResponse is the response to an event sink list request.
+type EventSinkListResponse struct {
+	Sinks []*EventSink
+	QueryMeta
+}
+
+// EventSinkSpecificRequest is used to make a request for a specific event
+// sink.
+type EventSinkSpecificRequest struct {
+	ID string
+	QueryOptions
+}
+
+// EventSinkResponse is the response to a specific event sink request.
+type EventSinkResponse struct {
+	Sink *EventSink
+	QueryMeta
+}
+
+// EventSinkStatusRequest is used to request the delivery status of an event
+// sink, or of all sinks if ID is empty.
+type EventSinkStatusRequest struct {
+	ID string
+	QueryOptions
+}
+
+// EventSinkStatusResponse is the response to an event sink status request.
+type EventSinkStatusResponse struct {
+	Statuses []*EventSinkStatus
+	QueryMeta
+}
+
+// EventSinkRegisterRequest is used to make a request to create or update an
+// event sink.
+type EventSinkRegisterRequest struct {
+	Sink *EventSink
+	WriteRequest
+}
+
+// EventSinkDeregisterRequest is used to make a request to delete event sinks.
+type EventSinkDeregisterRequest struct {
+	IDs []string
+	WriteRequest
+}
+
+// EventSinkCheckpointRequest is used by the leader to checkpoint the index of
+// the last event delivered to each sink, keyed by sink ID.
+type EventSinkCheckpointRequest struct {
+	Indexes map[string]uint64
+	WriteRequest
+}
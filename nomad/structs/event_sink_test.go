@@ -0,0 +1,126 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestEventSink_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		modify func(*EventSink)
+		errMsg string
+	}{
+		{
+			name:   "valid webhook",
+			modify: func(*EventSink) {},
+		},
+		{
+			name: "valid file",
+			modify: func(s *EventSink) {
+				s.Type = EventSinkFile
+				s.Address = ""
+				s.Path = "/var/lib/nomad/events.ndjson"
+			},
+		},
+		{
+			name:   "invalid id",
+			modify: func(s *EventSink) { s.ID = "audit sink" },
+			errMsg: `invalid ID "audit sink"`,
+		},
+		{
+			name:   "invalid type",
+			modify: func(s *EventSink) { s.Type = "kafka" },
+			errMsg: `invalid type "kafka"`,
+		},
+		{
+			name:   "webhook without URL",
+			modify: func(s *EventSink) { s.Address = "audit.example.com" },
+			errMsg: "address must be an http or https URL",
+		},
+		{
+			name: "relative file path",
+			modify: func(s *EventSink) {
+				s.Type = EventSinkFile
+				s.Address = ""
+				s.Path = "events.ndjson"
+			},
+			errMsg: "path must be absolute",
+		},
+		{
+			name:   "topic without keys",
+			modify: func(s *EventSink) { s.Topics = map[Topic][]string{TopicJob: nil} },
+			errMsg: `topic "Job" must have at least one key`,
+		},
+		{
+			name:   "valid filter",
+			modify: func(s *EventSink) { s.Filter = `Payload.Job.Meta.team == "payments"` },
+		},
+		{
+			name:   "invalid filter",
+			modify: func(s *EventSink) { s.Filter = `Payload.Job.Meta.team ==` },
+			errMsg: "invalid filter",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := &EventSink{
+				ID:      "audit",
+				Type:    EventSinkWebhook,
+				Address: "https://audit.example.com/nomad",
+			}
+			sink.Canonicalize()
+			tc.modify(sink)
+
+			err := sink.Validate()
+			if tc.errMsg == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestEventSink_Canonicalize(t *testing.T) {
+	ci.Parallel(t)
+
+	sink := &EventSink{ID: "audit"}
+	sink.Canonicalize()
+	must.Eq(t, AllNamespacesSentinel, sink.Namespace)
+	must.Eq(t, map[Topic][]string{TopicAll: {"*"}}, sink.Topics)
+}
+
+func TestEventSink_SameConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	sink := &EventSink{
+		ID:        "audit",
+		Type:      EventSinkWebhook,
+		Address:   "https://audit.example.com/nomad",
+		Namespace: "*",
+		Topics:    map[Topic][]string{TopicJob: {"web"}},
+	}
+
+	// The delivery progress isn't part of the configuration.
+	other := sink.Copy()
+	other.LatestIndex = 100
+	other.ModifyIndex = 100
+	must.True(t, sink.SameConfig(other))
+
+	other.Topics[TopicJob] = append(other.Topics[TopicJob], "api")
+	must.False(t, sink.SameConfig(other))
+	must.Eq(t, []string{"web"}, sink.Topics[TopicJob])
+
+	other = sink.Copy()
+	other.Filter = `Type == "JobRegistered"`
+	must.False(t, sink.SameConfig(other))
+}
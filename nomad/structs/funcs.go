@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/acl"
@@ -294,6 +295,33 @@ func ScoreFitSpread(node *Node, util *ComparableResources) float64 {
 	return score
 }
 
+// ScoreFitLoadAware computes a fit score blending the bin packing score of
+// the node, based on the resources reserved by allocations, with a score
+// favoring nodes with a low actual utilization. The usage weight, between 0
+// and 1, is the weight of the latter. Nodes without a recent utilization get
+// their bin packing score.
+// Score is in [0, 18]
+func ScoreFitLoadAware(node *Node, util *ComparableResources, usageWeight float64, now time.Time) float64 {
+	binPack := ScoreFitBinPack(node, util)
+	if node.Utilization == nil || node.Utilization.IsStale(now) {
+		return binPack
+	}
+
+	// Like the spread score, the usage score is 18 on an idle node and 0 on
+	// a fully utilized one.
+	freePctCpu := 1 - node.Utilization.CPUPercent/100
+	freePctRam := 1 - node.Utilization.MemoryPercent/100
+	usage := math.Pow(10, freePctCpu) + math.Pow(10, freePctRam) - 2
+
+	score := (1-usageWeight)*binPack + usageWeight*usage
+	if score > 18.0 {
+		score = 18.0
+	} else if score < 0 {
+		score = 0
+	}
+	return score
+}
+
 func CopySliceConstraints(s []*Constraint) []*Constraint {
 	l := len(s)
 	if l == 0 {
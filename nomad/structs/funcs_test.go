@@ -540,6 +540,53 @@ func TestAllocsFit_Devices(t *testing.T) {
 	require.True(fit)
 }
 
+func TestAllocsFit_CustomResources(t *testing.T) {
+	ci.Parallel(t)
+
+	n := node2k()
+	n.NodeResources.CustomResources = []*NodeCustomResource{
+		{Name: "license_seat", Count: 2},
+	}
+
+	alloc := func(count uint64) *Allocation {
+		return &Allocation{
+			AllocatedResources: &AllocatedResources{
+				Tasks: map[string]*AllocatedTaskResources{
+					"web": {
+						Cpu: AllocatedCpuResources{
+							CpuShares: 100,
+						},
+						Memory: AllocatedMemoryResources{
+							MemoryMB: 128,
+						},
+						CustomResources: map[string]uint64{"license_seat": count},
+					},
+				},
+			},
+		}
+	}
+
+	// Should fit both allocations
+	fit, _, used, err := AllocsFit(n, []*Allocation{alloc(1), alloc(1)}, nil, false)
+	must.NoError(t, err)
+	must.True(t, fit)
+	must.Eq(t, map[string]uint64{"license_seat": 2}, used.Flattened.CustomResources)
+
+	// Should not fit a third seat
+	fit, dim, _, err := AllocsFit(n, []*Allocation{alloc(1), alloc(2)}, nil, false)
+	must.NoError(t, err)
+	must.False(t, fit)
+	must.Eq(t, "custom resource: license_seat", dim)
+
+	// Should not fit a custom resource the node doesn't have
+	a := alloc(1)
+	a.AllocatedResources.Tasks["web"].CustomResources = map[string]uint64{"nfs_slot": 1}
+	fit, dim, _, err = AllocsFit(n, []*Allocation{a}, nil, false)
+	must.NoError(t, err)
+	must.False(t, fit)
+	must.Eq(t, "custom resource: nfs_slot", dim)
+}
+
 // Tests that AllocsFit detects volume collisions for volumes that have
 // exclusive access
 func TestAllocsFit_ExclusiveVolumes(t *testing.T) {
@@ -641,32 +688,7 @@ func TestAllocsFit_MemoryOversubscription(t *testing.T) {
 func TestScoreFitBinPack(t *testing.T) {
 	ci.Parallel(t)
 
-	node := &Node{}
-	node.NodeResources = &NodeResources{
-		Processors: NodeProcessorResources{
-			Topology: &numalib.Topology{
-				Distances: numalib.SLIT{[]numalib.Cost{10}},
-				Cores: []numalib.Core{{
-					ID:        0,
-					Grade:     numalib.Performance,
-					BaseSpeed: 4096,
-				}},
-			},
-		},
-		Memory: NodeMemoryResources{
-			MemoryMB: 8192,
-		},
-	}
-	node.NodeResources.Processors.Topology.SetNodes(idset.From[hw.NodeID]([]hw.NodeID{0}))
-	node.NodeResources.Compatibility()
-	node.ReservedResources = &NodeReservedResources{
-		Cpu: NodeReservedCpuResources{
-			CpuShares: 2048,
-		},
-		Memory: NodeReservedMemoryResources{
-			MemoryMB: 4096,
-		},
-	}
+	node := scoreFitTestNode()
 
 	cases := []struct {
 		name         string
@@ -718,6 +740,67 @@ func TestScoreFitBinPack(t *testing.T) {
 	}
 }
 
+// scoreFitTestNode returns a node with 4096 CPU shares and 8192MB of memory,
+// half of which are reserved.
+func scoreFitTestNode() *Node {
+	node := &Node{}
+	node.NodeResources = &NodeResources{
+		Processors: NodeProcessorResources{
+			Topology: &numalib.Topology{
+				Distances: numalib.SLIT{[]numalib.Cost{10}},
+				Cores: []numalib.Core{{
+					ID:        0,
+					Grade:     numalib.Performance,
+					BaseSpeed: 4096,
+				}},
+			},
+		},
+		Memory: NodeMemoryResources{
+			MemoryMB: 8192,
+		},
+	}
+	node.NodeResources.Processors.Topology.SetNodes(idset.From[hw.NodeID]([]hw.NodeID{0}))
+	node.NodeResources.Compatibility()
+	node.ReservedResources = &NodeReservedResources{
+		Cpu: NodeReservedCpuResources{
+			CpuShares: 2048,
+		},
+		Memory: NodeReservedMemoryResources{
+			MemoryMB: 4096,
+		},
+	}
+	return node
+}
+
+func TestScoreFitLoadAware(t *testing.T) {
+	ci.Parallel(t)
+
+	node := scoreFitTestNode()
+	now := time.Now()
+	util := &ComparableResources{Flattened: AllocatedTaskResources{
+		Cpu:    AllocatedCpuResources{CpuShares: 1024},
+		Memory: AllocatedMemoryResources{MemoryMB: 2048},
+	}}
+	binPackScore := ScoreFitBinPack(node, util)
+
+	// Without a utilization the bin packing score is used
+	must.Eq(t, binPackScore, ScoreFitLoadAware(node, util, 0.5, now))
+
+	// An idle node is favored over its reserved resources
+	node.Utilization = &NodeUtilization{UpdatedAt: now.Unix()}
+	must.Eq(t, 18, ScoreFitLoadAware(node, util, 1, now))
+	must.Between(t, binPackScore, ScoreFitLoadAware(node, util, 0.5, now), 18)
+
+	// A busy node is penalized
+	node.Utilization = &NodeUtilization{CPUPercent: 100, MemoryPercent: 100, UpdatedAt: now.Unix()}
+	must.Eq(t, 0, ScoreFitLoadAware(node, util, 1, now))
+	must.Eq(t, binPackScore/2, ScoreFitLoadAware(node, util, 0.5, now))
+
+	// A stale utilization is ignored
+	later := now.Add(2 * NodeUtilizationMaxAge)
+	must.Eq(t, binPackScore, ScoreFitLoadAware(node, util, 0.5, later))
+}
+
 func TestAllocsFit_MaxNodeAllocs(t *testing.T) {
 	ci.Parallel(t)
 	baseAlloc := &Allocation{
@@ -29,6 +29,10 @@ var (
 	errNegativeStopAfter   = errors.New("stop_after cannot be a negative duration")
 	errStopAfterNonService = errors.New("stop_after can only be used with service or batch job types")
 	errInvalidReconcile    = errors.New("reconcile option is invalid")
+
+	// Gang validation errors
+	errNegativeGangTimeout = errors.New("gang timeout cannot be a negative duration")
+	errGangJobType         = errors.New("gang can only be used with service or batch job types")
 )
 
 func NewDefaultDisconnectStrategy() *DisconnectStrategy {
@@ -137,3 +141,63 @@ func (ds *DisconnectStrategy) ReconcileStrategy() string {
 
 	return ds.Reconcile
 }
+
+// Gang configures all-or-nothing placement for a task group. The allocations
+// of every task group in a job sharing the same gang name are only placed
+// when all of them fit; otherwise none are placed and the evaluation blocks
+// until the whole gang can be placed.
+type Gang struct {
+	// Name identifies the gang. Task groups of a job with the same gang name
+	// are placed together. Defaults to the task group name.
+	Name string
+
+	// Timeout is how long the gang may wait for placement before the
+	// evaluation and any active deployment are failed. Zero waits forever.
+	Timeout time.Duration
+}
+
+func (g *Gang) Validate(job *Job) error {
+	if g == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+
+	if g.Timeout < 0 {
+		mErr = multierror.Append(mErr, errNegativeGangTimeout)
+	}
+
+	if job.Type != JobTypeService && job.Type != JobTypeBatch {
+		mErr = multierror.Append(mErr, errGangJobType)
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+func (g *Gang) Copy() *Gang {
+	if g == nil {
+		return nil
+	}
+
+	ng := new(Gang)
+	*ng = *g
+	return ng
+}
+
+func (g *Gang) Canonicalize(tg *TaskGroup) {
+	if g.Name == "" {
+		g.Name = tg.Name
+	}
+}
+
+// GangName returns the name of the gang the task group belongs to, or an
+// empty string if its allocations are placed independently.
+func (tg *TaskGroup) GangName() string {
+	if tg.Gang == nil {
+		return ""
+	}
+	if tg.Gang.Name == "" {
+		return tg.Name
+	}
+	return tg.Gang.Name
+}
@@ -116,6 +116,60 @@ func TestDisconnectStrategy_Validate(t *testing.T) {
 	}
 }
 
+func TestGang_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		gang    *Gang
+		jobType string
+		err     error
+	}{
+		{
+			name:    "negative-timeout",
+			gang:    &Gang{Timeout: -1 * time.Second},
+			jobType: JobTypeBatch,
+			err:     errNegativeGangTimeout,
+		},
+		{
+			name:    "system-job",
+			gang:    &Gang{},
+			jobType: JobTypeSystem,
+			err:     errGangJobType,
+		},
+		{
+			name:    "valid-configuration",
+			gang:    &Gang{Name: "train", Timeout: time.Minute},
+			jobType: JobTypeBatch,
+			err:     nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			job := testJob()
+			job.Type = c.jobType
+			err := c.gang.Validate(job)
+			if !errors.Is(err, c.err) {
+				t.Errorf("expected error %v, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestTaskGroup_GangName(t *testing.T) {
+	ci.Parallel(t)
+
+	tg := &TaskGroup{Name: "web"}
+	must.Eq(t, "", tg.GangName())
+
+	tg.Gang = &Gang{}
+	must.Eq(t, "web", tg.GangName())
+
+	tg.Gang.Name = "train"
+	must.Eq(t, "train", tg.GangName())
+}
+
 func TestReconcileStrategy(t *testing.T) {
 	ci.Parallel(t)
 
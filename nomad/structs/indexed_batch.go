@@ -0,0 +1,208 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// IndexedBatchIndexPending means the index waits for an allocation to be
+	// placed, either for the first time or after a failure.
+	IndexedBatchIndexPending = "pending"
+
+	// IndexedBatchIndexRunning means an allocation of the index is running.
+	IndexedBatchIndexRunning = "running"
+
+	// IndexedBatchIndexComplete means an allocation of the index completed
+	// successfully.
+	IndexedBatchIndexComplete = "complete"
+
+	// IndexedBatchIndexFailed means the allocations of the index failed more
+	// times than the backoff limit allows.
+	IndexedBatchIndexFailed = "failed"
+)
+
+// indexedBatchRescheduleInterval is the reschedule interval applied to indexed
+// batch task groups, long enough for every failure of an index recorded in the
+// reschedule tracker of its allocations to count against the backoff limit.
+const indexedBatchRescheduleInterval = time.Duration(math.MaxInt64)
+
+// IndexedBatch configures a batch task group to run a fixed number of indexed
+// completions. Each allocation index, exposed to tasks as NOMAD_ALLOC_INDEX,
+// must complete successfully once, at most Parallelism allocations run at
+// once, and failed indexes are rescheduled up to BackoffLimitPerIndex times.
+//
+// The count of the task group must equal Completions and the attempts of its
+// reschedule policy must equal BackoffLimitPerIndex. The failures of an index
+// count against the backoff limit whatever the interval of the reschedule
+// policy, while its delay settings still apply.
+type IndexedBatch struct {
+	// Completions is the number of indexes that must complete.
+	Completions int
+
+	// Parallelism is the maximum number of allocations of the task group
+	// running at once. Defaults to Completions.
+	Parallelism int
+
+	// BackoffLimitPerIndex is the number of times a failed index is
+	// rescheduled before it is marked failed.
+	BackoffLimitPerIndex int
+}
+
+func (b *IndexedBatch) Copy() *IndexedBatch {
+	if b == nil {
+		return nil
+	}
+	nb := new(IndexedBatch)
+	*nb = *b
+	return nb
+}
+
+func (b *IndexedBatch) Canonicalize() {
+	if b.Parallelism == 0 {
+		b.Parallelism = b.Completions
+	}
+}
+
+func (b *IndexedBatch) Validate(job *Job, tg *TaskGroup) error {
+	if b == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if job.Type != JobTypeBatch {
+		_ = multierror.Append(&mErr, fmt.Errorf("Indexed batch can only be used with %q scheduler", JobTypeBatch))
+	}
+	if b.Completions < 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Indexed batch completions must be at least 1"))
+	}
+	if b.Parallelism < 0 || b.Parallelism > b.Completions {
+		_ = multierror.Append(&mErr, fmt.Errorf("Indexed batch parallelism cannot be negative or greater than completions (%d)", b.Completions))
+	}
+	if b.BackoffLimitPerIndex < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Indexed batch backoff limit per index cannot be negative"))
+	}
+	if tg.Count != b.Completions {
+		_ = multierror.Append(&mErr, fmt.Errorf("Task group count (%d) must equal indexed batch completions (%d)", tg.Count, b.Completions))
+	}
+	if rp := tg.ReschedulePolicy; rp != nil {
+		if rp.Unlimited {
+			_ = multierror.Append(&mErr, fmt.Errorf("Indexed batch cannot be used with unlimited reschedule attempts"))
+		} else if rp.Attempts != b.BackoffLimitPerIndex {
+			_ = multierror.Append(&mErr, fmt.Errorf("Reschedule attempts (%d) must equal indexed batch backoff limit per index (%d)", rp.Attempts, b.BackoffLimitPerIndex))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// EffectiveReschedulePolicy returns the reschedule policy that applies to the
+// allocations of the task group. Indexed batch task groups count every failure
+// of an index against their backoff limit, whatever the interval of their
+// reschedule policy.
+func (tg *TaskGroup) EffectiveReschedulePolicy() *ReschedulePolicy {
+	if tg.IndexedBatch == nil || tg.ReschedulePolicy == nil {
+		return tg.ReschedulePolicy
+	}
+	rp := tg.ReschedulePolicy.Copy()
+	rp.Attempts = tg.IndexedBatch.BackoffLimitPerIndex
+	rp.Interval = indexedBatchRescheduleInterval
+	rp.Unlimited = false
+	return rp
+}
+
+// IndexedBatchIndexStatus is the status of an index of an indexed batch task
+// group.
+type IndexedBatchIndexStatus struct {
+	Index    uint
+	Status   string
+	Failures int
+}
+
+// IndexedBatchStatuses returns the status of each index of an indexed batch
+// task group, in index order, computed from the allocations of the current
+// version of the job. It returns nil if the task group isn't indexed.
+func (j *Job) IndexedBatchStatuses(tg *TaskGroup, allocs []*Allocation) []*IndexedBatchIndexStatus {
+	if tg == nil || tg.IndexedBatch == nil {
+		return nil
+	}
+
+	type counts struct{ complete, running, failed int }
+	byIndex := make([]counts, tg.IndexedBatch.Completions)
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != tg.Name || alloc.Job == nil ||
+			alloc.Job.Version != j.Version || alloc.Job.CreateIndex != j.CreateIndex {
+			continue
+		}
+		idx := alloc.Index()
+		if idx >= uint(len(byIndex)) {
+			continue
+		}
+		switch {
+		case alloc.ClientStatus == AllocClientStatusComplete:
+			byIndex[idx].complete++
+		case alloc.ClientStatus == AllocClientStatusFailed:
+			byIndex[idx].failed++
+		case !alloc.TerminalStatus():
+			byIndex[idx].running++
+		}
+	}
+
+	statuses := make([]*IndexedBatchIndexStatus, len(byIndex))
+	for i, c := range byIndex {
+		status := &IndexedBatchIndexStatus{Index: uint(i), Failures: c.failed}
+		switch {
+		case c.complete > 0:
+			status.Status = IndexedBatchIndexComplete
+		case c.running > 0:
+			status.Status = IndexedBatchIndexRunning
+		case c.failed > tg.IndexedBatch.BackoffLimitPerIndex:
+			status.Status = IndexedBatchIndexFailed
+		default:
+			status.Status = IndexedBatchIndexPending
+		}
+		statuses[i] = status
+	}
+	return statuses
+}
+
+// IndexedBatchStatusDescription returns the description of the outcome of a
+// finished job with indexed batch task groups: whether every index completed
+// or which indexes failed. It returns an empty string if the job has no
+// indexed batch task group.
+func (j *Job) IndexedBatchStatusDescription(allocs []*Allocation) string {
+	var indexed bool
+	var failed []string
+	for _, tg := range j.TaskGroups {
+		if tg.IndexedBatch == nil {
+			continue
+		}
+		indexed = true
+
+		var failedIndexes []string
+		for _, status := range j.IndexedBatchStatuses(tg, allocs) {
+			if status.Status != IndexedBatchIndexComplete {
+				failedIndexes = append(failedIndexes, strconv.FormatUint(uint64(status.Index), 10))
+			}
+		}
+		if len(failedIndexes) != 0 {
+			failed = append(failed, fmt.Sprintf("%s[%s]", tg.Name, strings.Join(failedIndexes, ",")))
+		}
+	}
+
+	switch {
+	case !indexed:
+		return ""
+	case len(failed) != 0:
+		return fmt.Sprintf("Indexed batch failed: indexes %s did not complete", strings.Join(failed, " "))
+	default:
+		return "Indexed batch succeeded: every index completed"
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestIndexedBatch_Canonicalize(t *testing.T) {
+	ci.Parallel(t)
+
+	rp := NewReschedulePolicy(JobTypeBatch)
+	tg := &TaskGroup{
+		Count:            5,
+		ReschedulePolicy: rp.Copy(),
+		IndexedBatch:     &IndexedBatch{Completions: 5, BackoffLimitPerIndex: 3},
+	}
+	tg.IndexedBatch.Canonicalize()
+
+	// The task group is left as submitted
+	must.Eq(t, 5, tg.IndexedBatch.Parallelism)
+	must.Eq(t, rp, tg.ReschedulePolicy)
+
+	// The failures of an index count against the backoff limit whatever the
+	// interval of the reschedule policy
+	effective := tg.EffectiveReschedulePolicy()
+	must.Eq(t, 3, effective.Attempts)
+	must.Eq(t, indexedBatchRescheduleInterval, effective.Interval)
+	must.Eq(t, rp.Delay, effective.Delay)
+
+	tg.IndexedBatch = nil
+	must.Eq(t, rp, tg.EffectiveReschedulePolicy())
+}
+
+func TestIndexedBatch_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Type = JobTypeBatch
+	tg := &TaskGroup{
+		Count:            4,
+		ReschedulePolicy: &ReschedulePolicy{Attempts: 1, Interval: time.Hour},
+	}
+	must.NoError(t, (&IndexedBatch{Completions: 4, Parallelism: 2, BackoffLimitPerIndex: 1}).Validate(job, tg))
+
+	err := (&IndexedBatch{Completions: 2, Parallelism: 3, BackoffLimitPerIndex: -1}).Validate(job, tg)
+	must.ErrorContains(t, err, "parallelism cannot be negative or greater than completions (2)")
+	must.ErrorContains(t, err, "backoff limit per index cannot be negative")
+	must.ErrorContains(t, err, "Task group count (4) must equal indexed batch completions (2)")
+	must.ErrorContains(t, err, "Reschedule attempts (1) must equal indexed batch backoff limit per index (-1)")
+
+	tg.ReschedulePolicy.Unlimited = true
+	err = (&IndexedBatch{Completions: 4, BackoffLimitPerIndex: 1}).Validate(job, tg)
+	must.ErrorContains(t, err, "Indexed batch cannot be used with unlimited reschedule attempts")
+
+	job.Type = JobTypeService
+	err = (&IndexedBatch{}).Validate(job, &TaskGroup{})
+	must.ErrorContains(t, err, `Indexed batch can only be used with "batch" scheduler`)
+	must.ErrorContains(t, err, "completions must be at least 1")
+}
+
+func TestJob_IndexedBatchStatuses(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Type = JobTypeBatch
+	tg := job.TaskGroups[0]
+	tg.IndexedBatch = &IndexedBatch{Completions: 5, Parallelism: 2, BackoffLimitPerIndex: 1}
+
+	alloc := func(index int, clientStatus, desiredStatus string) *Allocation {
+		return &Allocation{
+			Name:          fmt.Sprintf("%s.%s[%d]", job.ID, tg.Name, index),
+			JobID:         job.ID,
+			Job:           job,
+			TaskGroup:     tg.Name,
+			ClientStatus:  clientStatus,
+			DesiredStatus: desiredStatus,
+		}
+	}
+	allocs := []*Allocation{
+		// index 0 completed after a failure
+		alloc(0, AllocClientStatusFailed, AllocDesiredStatusStop),
+		alloc(0, AllocClientStatusComplete, AllocDesiredStatusRun),
+		// index 1 is running
+		alloc(1, AllocClientStatusRunning, AllocDesiredStatusRun),
+		// index 2 exceeded its backoff limit
+		alloc(2, AllocClientStatusFailed, AllocDesiredStatusStop),
+		alloc(2, AllocClientStatusFailed, AllocDesiredStatusRun),
+		// index 3 waits to be rescheduled
+		alloc(3, AllocClientStatusFailed, AllocDesiredStatusRun),
+	}
+
+	statuses := job.IndexedBatchStatuses(tg, allocs)
+	must.Eq(t, []*IndexedBatchIndexStatus{
+		{Index: 0, Status: IndexedBatchIndexComplete, Failures: 1},
+		{Index: 1, Status: IndexedBatchIndexRunning},
+		{Index: 2, Status: IndexedBatchIndexFailed, Failures: 2},
+		{Index: 3, Status: IndexedBatchIndexPending, Failures: 1},
+		{Index: 4, Status: IndexedBatchIndexPending},
+	}, statuses)
+
+	must.Eq(t, "Indexed batch failed: indexes web[1,2,3,4] did not complete",
+		job.IndexedBatchStatusDescription(allocs))
+
+	var complete []*Allocation
+	for i := range 5 {
+		complete = append(complete, alloc(i, AllocClientStatusComplete, AllocDesiredStatusRun))
+	}
+	must.Eq(t, "Indexed batch succeeded: every index completed",
+		job.IndexedBatchStatusDescription(complete))
+
+	tg.IndexedBatch = nil
+	must.Nil(t, job.IndexedBatchStatuses(tg, allocs))
+	must.Eq(t, "", job.IndexedBatchStatusDescription(allocs))
+}
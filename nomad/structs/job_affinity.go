@@ -0,0 +1,173 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// JobAffinityDefaultTopologyKey is the topology key used when none is
+	// set, so job affinities are evaluated per node.
+	JobAffinityDefaultTopologyKey = "${node.unique.id}"
+
+	// JobAffinityDefaultWeight is the weight of job affinities that aren't
+	// required when none is set.
+	JobAffinityDefaultWeight = 50
+)
+
+// JobAffinity places allocations relative to the allocations of another job:
+// in the same topology domain as them, or away from them for anti-affinity.
+// The topology domain of a node is the value of the topology key on the
+// node, such as its unique ID or a rack set in its metadata.
+//
+// A required job affinity filters out the nodes that don't satisfy it, while
+// other job affinities add a weighted score to the nodes that do.
+type JobAffinity struct {
+	// Namespace is the namespace of the other job. Defaults to the namespace
+	// of the job.
+	Namespace string
+
+	// JobID is the ID of the other job.
+	JobID string
+
+	// TaskGroup optionally restricts the allocations of the other job to the
+	// ones of the given task group.
+	TaskGroup string
+
+	// TopologyKey is the node attribute defining the topology domain, such
+	// as ${meta.rack}.
+	TopologyKey string
+
+	// Anti avoids the topology domains running allocations of the other job
+	// instead of preferring them.
+	Anti bool
+
+	// Required makes the job affinity a hard constraint.
+	Required bool
+
+	// Weight is the score given to nodes satisfying a job affinity that
+	// isn't required, between 1 and 100.
+	Weight int8
+}
+
+func (a *JobAffinity) Copy() *JobAffinity {
+	if a == nil {
+		return nil
+	}
+	na := *a
+	return &na
+}
+
+func (a *JobAffinity) Equal(o *JobAffinity) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	return *a == *o
+}
+
+func (a *JobAffinity) String() string {
+	kind := "affinity"
+	if a.Anti {
+		kind = "anti-affinity"
+	}
+	target := a.Namespace + "/" + a.JobID
+	if a.TaskGroup != "" {
+		target += "." + a.TaskGroup
+	}
+	if a.Required {
+		return fmt.Sprintf("job %s %s on %s", kind, target, a.TopologyKey)
+	}
+	return fmt.Sprintf("job %s %s on %s %d", kind, target, a.TopologyKey, a.Weight)
+}
+
+// Canonicalize sets the defaults of the job affinity. The namespace is the
+// namespace of the job the affinity is set on.
+func (a *JobAffinity) Canonicalize(namespace string) {
+	if a.Namespace == "" {
+		a.Namespace = namespace
+	}
+	if a.TopologyKey == "" {
+		a.TopologyKey = JobAffinityDefaultTopologyKey
+	}
+	if !a.Required && a.Weight == 0 {
+		a.Weight = JobAffinityDefaultWeight
+	}
+}
+
+func (a *JobAffinity) Validate() error {
+	var mErr multierror.Error
+	if a.JobID == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Job affinity job ID must be set"))
+	}
+	if !strings.HasPrefix(a.TopologyKey, "${") || !strings.HasSuffix(a.TopologyKey, "}") {
+		_ = multierror.Append(&mErr, fmt.Errorf("Job affinity topology key %q must be a node attribute such as ${meta.rack}", a.TopologyKey))
+	}
+	switch {
+	case a.Required && a.Weight != 0:
+		_ = multierror.Append(&mErr, fmt.Errorf("Job affinity weight can't be set on a required job affinity"))
+	case !a.Required && (a.Weight < 1 || a.Weight > 100):
+		_ = multierror.Append(&mErr, fmt.Errorf("Job affinity weight must be between 1 and 100"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Matches returns whether the allocation is one of the allocations the job
+// affinity is relative to.
+func (a *JobAffinity) Matches(alloc *Allocation) bool {
+	return alloc.Namespace == a.Namespace && alloc.JobID == a.JobID &&
+		(a.TaskGroup == "" || alloc.TaskGroup == a.TaskGroup)
+}
+
+// CopySliceJobAffinities returns a deep copy of the given job affinities.
+func CopySliceJobAffinities(s []*JobAffinity) []*JobAffinity {
+	if s == nil {
+		return nil
+	}
+	c := make([]*JobAffinity, len(s))
+	for i, a := range s {
+		c[i] = a.Copy()
+	}
+	return c
+}
+
+// TaskGroupJobAffinities returns the job affinities of the task group,
+// including the ones set on the job.
+func (j *Job) TaskGroupJobAffinities(tg *TaskGroup) []*JobAffinity {
+	if j == nil {
+		if tg == nil {
+			return nil
+		}
+		return tg.JobAffinities
+	}
+	if tg == nil || len(tg.JobAffinities) == 0 {
+		return j.JobAffinities
+	}
+	if len(j.JobAffinities) == 0 {
+		return tg.JobAffinities
+	}
+	return append(slices.Clone(j.JobAffinities), tg.JobAffinities...)
+}
+
+// JobAffinityNamespaces returns the namespaces, other than the namespace of
+// the job, of the jobs its job affinities are relative to.
+func (j *Job) JobAffinityNamespaces() []string {
+	var namespaces []string
+	add := func(affinities []*JobAffinity) {
+		for _, a := range affinities {
+			if a.Namespace != "" && a.Namespace != j.Namespace && !slices.Contains(namespaces, a.Namespace) {
+				namespaces = append(namespaces, a.Namespace)
+			}
+		}
+	}
+	add(j.JobAffinities)
+	for _, tg := range j.TaskGroups {
+		add(tg.JobAffinities)
+	}
+	return namespaces
+}
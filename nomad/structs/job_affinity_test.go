@@ -0,0 +1,56 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobAffinity_Canonicalize(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Namespace = "web"
+	job.JobAffinities = []*JobAffinity{{JobID: "api"}}
+	job.TaskGroups[0].JobAffinities = []*JobAffinity{
+		{Namespace: "batch", JobID: "noisy", Anti: true, Required: true, TopologyKey: "${meta.rack}"},
+	}
+	job.Canonicalize()
+
+	must.Eq(t, &JobAffinity{
+		Namespace:   "web",
+		JobID:       "api",
+		TopologyKey: JobAffinityDefaultTopologyKey,
+		Weight:      JobAffinityDefaultWeight,
+	}, job.JobAffinities[0])
+	must.Eq(t, &JobAffinity{
+		Namespace:   "batch",
+		JobID:       "noisy",
+		TopologyKey: "${meta.rack}",
+		Anti:        true,
+		Required:    true,
+	}, job.TaskGroups[0].JobAffinities[0])
+
+	must.Len(t, 2, job.TaskGroupJobAffinities(job.TaskGroups[0]))
+	must.Eq(t, []string{"batch"}, job.JobAffinityNamespaces())
+}
+
+func TestJobAffinity_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	affinity := &JobAffinity{JobID: "api"}
+	affinity.Canonicalize("default")
+	must.NoError(t, affinity.Validate())
+
+	err := (&JobAffinity{TopologyKey: "rack", Weight: 101}).Validate()
+	must.ErrorContains(t, err, "Job affinity job ID must be set")
+	must.ErrorContains(t, err, `Job affinity topology key "rack" must be a node attribute`)
+	must.ErrorContains(t, err, "Job affinity weight must be between 1 and 100")
+
+	err = (&JobAffinity{JobID: "api", TopologyKey: "${meta.rack}", Required: true, Weight: 50}).Validate()
+	must.ErrorContains(t, err, "Job affinity weight can't be set on a required job affinity")
+}
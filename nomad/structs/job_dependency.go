@@ -0,0 +1,158 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// JobDependencyStatusComplete requires every allocation of the upstream
+	// job to have completed successfully.
+	JobDependencyStatusComplete = "complete"
+
+	// JobDependencyStatusFailed requires at least one allocation of the
+	// upstream job to have failed or been lost.
+	JobDependencyStatusFailed = "failed"
+
+	// JobDependencyStatusDead only requires the upstream job to be dead,
+	// whatever the outcome of its allocations.
+	JobDependencyStatusDead = "dead"
+)
+
+// JobDependency holds the evaluations of a batch or sysbatch job until
+// another job in the same namespace reaches a terminal status.
+type JobDependency struct {
+	// JobID is the ID of the upstream job.
+	JobID string
+
+	// Status is the terminal status the upstream job must reach. Defaults
+	// to complete.
+	Status string
+}
+
+func (d *JobDependency) Copy() *JobDependency {
+	if d == nil {
+		return nil
+	}
+	nd := new(JobDependency)
+	*nd = *d
+	return nd
+}
+
+func CopySliceJobDependencies(s []*JobDependency) []*JobDependency {
+	if s == nil {
+		return nil
+	}
+	c := make([]*JobDependency, len(s))
+	for i, d := range s {
+		c[i] = d.Copy()
+	}
+	return c
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.Status == "" {
+		d.Status = JobDependencyStatusComplete
+	}
+}
+
+func (d *JobDependency) Validate() error {
+	if d.JobID == "" {
+		return errors.New("Missing upstream job ID")
+	}
+	switch d.Status {
+	case "", JobDependencyStatusComplete, JobDependencyStatusFailed, JobDependencyStatusDead:
+	default:
+		return fmt.Errorf("Invalid status %q for dependency on job %q: must be one of %q, %q or %q",
+			d.Status, d.JobID, JobDependencyStatusComplete, JobDependencyStatusFailed, JobDependencyStatusDead)
+	}
+	return nil
+}
+
+func (d *JobDependency) String() string {
+	return fmt.Sprintf("%s (%s)", d.JobID, d.Status)
+}
+
+// Satisfied returns whether the upstream job and its allocations reached the
+// status the dependency requires. If not, it returns a human readable reason
+// the dependent job is waiting.
+func (d *JobDependency) Satisfied(upstream *Job, allocs []*Allocation) (bool, string) {
+	if upstream == nil {
+		return false, fmt.Sprintf("waiting for job %q to be registered", d.JobID)
+	}
+	if upstream.Status != JobStatusDead {
+		return false, fmt.Sprintf("waiting for job %q to be %s (currently %s)", d.JobID, d.Status, upstream.Status)
+	}
+
+	if d.Status == JobDependencyStatusDead {
+		return true, ""
+	}
+	if upstream.Stop {
+		return false, fmt.Sprintf("job %q was stopped before it was %s", d.JobID, d.Status)
+	}
+
+	// Only the latest allocation of each replacement chain of the current
+	// version of the upstream job counts.
+	var complete, failed, total int
+	for _, alloc := range allocs {
+		if alloc.NextAllocation != "" || alloc.Job == nil || alloc.Job.Version != upstream.Version {
+			continue
+		}
+		total++
+		switch alloc.ClientStatus {
+		case AllocClientStatusComplete:
+			complete++
+		case AllocClientStatusFailed, AllocClientStatusLost:
+			failed++
+		}
+	}
+
+	switch d.Status {
+	case JobDependencyStatusFailed:
+		if failed == 0 {
+			return false, fmt.Sprintf("job %q is dead but none of its allocations failed", d.JobID)
+		}
+	default:
+		if total == 0 || complete != total {
+			return false, fmt.Sprintf("job %q is dead but %d of %d allocations did not complete",
+				d.JobID, total-complete, total)
+		}
+	}
+	return true, ""
+}
+
+// validateDependencies checks the dependencies of the job that can be
+// verified without looking at other jobs.
+func (j *Job) validateDependencies() error {
+	var mErr multierror.Error
+	if j.Type != JobTypeBatch && j.Type != JobTypeSysBatch {
+		_ = multierror.Append(&mErr, fmt.Errorf(
+			"Dependencies can only be used with %q or %q scheduler", JobTypeBatch, JobTypeSysBatch))
+	}
+
+	seen := make([]string, 0, len(j.DependsOn))
+	for i, d := range j.DependsOn {
+		if d == nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Dependency %d is nil", i+1))
+			continue
+		}
+		if err := d.Validate(); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Dependency %d validation failed: %v", i+1, err))
+			continue
+		}
+		if d.JobID == j.ID {
+			_ = multierror.Append(&mErr, errors.New("Job cannot depend on itself"))
+		}
+		if slices.Contains(seen, d.JobID) {
+			_ = multierror.Append(&mErr, fmt.Errorf("Duplicate dependency on job %q", d.JobID))
+		}
+		seen = append(seen, d.JobID)
+	}
+	return mErr.ErrorOrNil()
+}
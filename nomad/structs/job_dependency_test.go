@@ -0,0 +1,149 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobDependency_Satisfied(t *testing.T) {
+	ci.Parallel(t)
+
+	upstream := func(status string, stop bool) *Job {
+		job := testJob()
+		job.Type = JobTypeBatch
+		job.Status = status
+		job.Stop = stop
+		return job
+	}
+	alloc := func(job *Job, status string, next string) *Allocation {
+		return &Allocation{Job: job, ClientStatus: status, NextAllocation: next}
+	}
+
+	dead := upstream(JobStatusDead, false)
+	oldVersion := dead.Copy()
+	oldVersion.Version = dead.Version + 1
+
+	cases := []struct {
+		name     string
+		status   string
+		upstream *Job
+		allocs   []*Allocation
+		expect   bool
+		reason   string
+	}{
+		{
+			name:   "not registered",
+			status: JobDependencyStatusComplete,
+			reason: "to be registered",
+		},
+		{
+			name:     "running",
+			status:   JobDependencyStatusComplete,
+			upstream: upstream(JobStatusRunning, false),
+			reason:   "currently running",
+		},
+		{
+			name:     "complete",
+			status:   JobDependencyStatusComplete,
+			upstream: dead,
+			allocs: []*Allocation{
+				alloc(dead, AllocClientStatusFailed, "replaced"),
+				alloc(dead, AllocClientStatusComplete, ""),
+				alloc(oldVersion, AllocClientStatusFailed, ""),
+			},
+			expect: true,
+		},
+		{
+			name:     "complete with failures",
+			status:   JobDependencyStatusComplete,
+			upstream: dead,
+			allocs: []*Allocation{
+				alloc(dead, AllocClientStatusComplete, ""),
+				alloc(dead, AllocClientStatusFailed, ""),
+			},
+			reason: "1 of 2 allocations did not complete",
+		},
+		{
+			name:     "complete without allocations",
+			status:   JobDependencyStatusComplete,
+			upstream: dead,
+			reason:   "0 of 0 allocations",
+		},
+		{
+			name:     "complete stopped",
+			status:   JobDependencyStatusComplete,
+			upstream: upstream(JobStatusDead, true),
+			reason:   "was stopped",
+		},
+		{
+			name:     "failed",
+			status:   JobDependencyStatusFailed,
+			upstream: dead,
+			allocs: []*Allocation{
+				alloc(dead, AllocClientStatusComplete, ""),
+				alloc(dead, AllocClientStatusLost, ""),
+			},
+			expect: true,
+		},
+		{
+			name:     "failed without failures",
+			status:   JobDependencyStatusFailed,
+			upstream: dead,
+			allocs:   []*Allocation{alloc(dead, AllocClientStatusComplete, "")},
+			reason:   "none of its allocations failed",
+		},
+		{
+			name:     "dead stopped",
+			status:   JobDependencyStatusDead,
+			upstream: upstream(JobStatusDead, true),
+			expect:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dep := &JobDependency{JobID: "upstream", Status: tc.status}
+			ok, reason := dep.Satisfied(tc.upstream, tc.allocs)
+			must.Eq(t, tc.expect, ok)
+			if tc.expect {
+				must.Eq(t, "", reason)
+			} else {
+				must.StrContains(t, reason, tc.reason)
+			}
+		})
+	}
+}
+
+func TestJob_Validate_DependsOn(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Type = JobTypeService
+	job.DependsOn = []*JobDependency{{JobID: "upstream", Status: JobDependencyStatusComplete}}
+	must.ErrorContains(t, job.Validate(), "Dependencies can only be used with")
+
+	job = testJob()
+	job.Type = JobTypeBatch
+	job.DependsOn = []*JobDependency{
+		{JobID: job.ID},
+		{JobID: "upstream", Status: "succeeded"},
+		{JobID: "other"},
+		{JobID: "other"},
+	}
+	err := job.Validate()
+	must.ErrorContains(t, err, "Job cannot depend on itself")
+	must.ErrorContains(t, err, `Invalid status "succeeded"`)
+	must.ErrorContains(t, err, `Duplicate dependency on job "other"`)
+
+	job = testJob()
+	job.Type = JobTypeBatch
+	job.DependsOn = []*JobDependency{{JobID: "upstream"}}
+	job.Canonicalize()
+	must.NoError(t, job.Validate())
+	must.Eq(t, JobDependencyStatusComplete, job.DependsOn[0].Status)
+}
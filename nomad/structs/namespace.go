@@ -3,6 +3,24 @@
 
 package structs
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// DefaultVariablesHistoryLimit is the number of prior versions kept for
+	// each variable when the namespace does not configure a limit.
+	DefaultVariablesHistoryLimit = 10
+
+	// maxVariablesHistoryLimit bounds the number of prior versions that can be
+	// kept for each variable.
+	maxVariablesHistoryLimit = 100
+)
+
 // NamespaceVaultConfiguration stores configuration about permissions to Vault
 // clusters for a namespace, for use with Nomad Enterprise.
 type NamespaceVaultConfiguration struct {
@@ -46,3 +64,55 @@ type NamespaceConsulConfiguration struct {
 	// This field cannot be used with Allowed.
 	Denied []string
 }
+
+// NamespaceVariablesConfiguration stores configuration about how the history
+// of the variables in a namespace is kept.
+type NamespaceVariablesConfiguration struct {
+	// HistoryLimit is the number of prior versions kept for each variable. A
+	// value of zero uses DefaultVariablesHistoryLimit and a negative value
+	// disables the history.
+	HistoryLimit int
+
+	// HistoryRetention is how long a prior version is kept after it has been
+	// replaced. A value of zero keeps prior versions until they exceed the
+	// HistoryLimit.
+	HistoryRetention time.Duration
+}
+
+// Limit returns the number of prior versions kept for each variable. It
+// handles nil objects.
+func (c *NamespaceVariablesConfiguration) Limit() int {
+	switch {
+	case c == nil || c.HistoryLimit == 0:
+		return DefaultVariablesHistoryLimit
+	case c.HistoryLimit < 0:
+		return 0
+	default:
+		return c.HistoryLimit
+	}
+}
+
+// Retention returns how long prior versions are kept. A zero value means
+// versions are not purged by age. It handles nil objects.
+func (c *NamespaceVariablesConfiguration) Retention() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.HistoryRetention
+}
+
+func (c *NamespaceVariablesConfiguration) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	if c.HistoryLimit > maxVariablesHistoryLimit {
+		mErr = multierror.Append(mErr, fmt.Errorf(
+			"history limit must not be greater than %d", maxVariablesHistoryLimit))
+	}
+	if c.HistoryRetention < 0 {
+		mErr = multierror.Append(mErr, errors.New("history retention must not be negative"))
+	}
+	return mErr.ErrorOrNil()
+}
@@ -627,6 +627,9 @@ func (n *NodeRegisterRequest) Validate() error {
 			return fmt.Errorf("node is not allowed to register in node pool %q", NodePoolAll)
 		}
 	}
+	if err := ValidateNodeTaints(n.Node.Taints); err != nil {
+		return fmt.Errorf("invalid node taints: %v", err)
+	}
 
 	return nil
 }
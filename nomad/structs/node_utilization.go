@@ -0,0 +1,107 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// NodeUtilizationChangeThreshold is the change, in percentage points, of
+	// any value of the utilization of a node above which a new utilization is
+	// written to the state.
+	NodeUtilizationChangeThreshold = 5.0
+
+	// NodeUtilizationRefreshInterval is the age above which the utilization
+	// of a node is written to the state even if it hasn't changed.
+	NodeUtilizationRefreshInterval = 5 * time.Minute
+
+	// NodeUtilizationMaxAge is the age above which the utilization of a node
+	// is ignored by the scheduler.
+	NodeUtilizationMaxAge = 3 * NodeUtilizationRefreshInterval
+)
+
+// NodeUtilization is a compact summary of the actual utilization of a node,
+// published periodically by its client. Percentages are between 0 and 100.
+type NodeUtilization struct {
+	// CPUPercent is the percentage of the CPU of the node in use.
+	CPUPercent float64
+
+	// MemoryPercent is the percentage of the memory of the node in use.
+	MemoryPercent float64
+
+	// CPUPressure, MemoryPressure and IOPressure are the pressure stall
+	// information of the node: the percentage of time over the last 10
+	// seconds during which tasks were stalled waiting on the resource. They
+	// are zero on nodes without PSI support.
+	CPUPressure    float64
+	MemoryPressure float64
+	IOPressure     float64
+
+	// UpdatedAt is the server time the utilization was received at.
+	UpdatedAt int64
+}
+
+func (u *NodeUtilization) Copy() *NodeUtilization {
+	if u == nil {
+		return nil
+	}
+	nu := *u
+	return &nu
+}
+
+func (u *NodeUtilization) Validate() error {
+	var mErr multierror.Error
+	for name, value := range map[string]float64{
+		"CPU percent":     u.CPUPercent,
+		"memory percent":  u.MemoryPercent,
+		"CPU pressure":    u.CPUPressure,
+		"memory pressure": u.MemoryPressure,
+		"IO pressure":     u.IOPressure,
+	} {
+		if math.IsNaN(value) || value < 0 || value > 100 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Utilization %s must be between 0 and 100, got %v", name, value))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// MaxPressure returns the highest pressure of any resource of the node.
+func (u *NodeUtilization) MaxPressure() float64 {
+	return max(u.CPUPressure, u.MemoryPressure, u.IOPressure)
+}
+
+// IsStale returns whether the utilization is too old to be used by the
+// scheduler.
+func (u *NodeUtilization) IsStale(now time.Time) bool {
+	return now.Sub(time.Unix(u.UpdatedAt, 0)) > NodeUtilizationMaxAge
+}
+
+// NeedsUpdate returns whether the new utilization must be written to the
+// state in place of the utilization u, either because u is getting old or
+// because any value changed by more than NodeUtilizationChangeThreshold.
+func (u *NodeUtilization) NeedsUpdate(o *NodeUtilization, now time.Time) bool {
+	switch {
+	case u == nil || o == nil:
+		return u != o
+	case now.Sub(time.Unix(u.UpdatedAt, 0)) >= NodeUtilizationRefreshInterval:
+		return true
+	}
+	for _, delta := range []float64{
+		u.CPUPercent - o.CPUPercent,
+		u.MemoryPercent - o.MemoryPercent,
+		u.CPUPressure - o.CPUPressure,
+		u.MemoryPressure - o.MemoryPressure,
+		u.IOPressure - o.IOPressure,
+	} {
+		if math.Abs(delta) >= NodeUtilizationChangeThreshold {
+			return true
+		}
+	}
+	return false
+}
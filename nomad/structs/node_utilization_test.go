@@ -0,0 +1,74 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeUtilization_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, (&NodeUtilization{CPUPercent: 100, IOPressure: 12.5}).Validate())
+
+	err := (&NodeUtilization{MemoryPercent: 101, CPUPressure: -1}).Validate()
+	must.ErrorContains(t, err, "Utilization memory percent must be between 0 and 100")
+	must.ErrorContains(t, err, "Utilization CPU pressure must be between 0 and 100")
+}
+
+func TestNodeUtilization_NeedsUpdate(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().Truncate(time.Second)
+	current := &NodeUtilization{CPUPercent: 40, MemoryPressure: 10, UpdatedAt: now.Unix()}
+
+	cases := []struct {
+		name    string
+		current *NodeUtilization
+		new     *NodeUtilization
+		now     time.Time
+		exp     bool
+	}{
+		{
+			name: "first utilization",
+			new:  &NodeUtilization{},
+			now:  now,
+			exp:  true,
+		},
+		{
+			name:    "small change",
+			current: current,
+			new:     &NodeUtilization{CPUPercent: 43, MemoryPressure: 8},
+			now:     now,
+			exp:     false,
+		},
+		{
+			name:    "large change",
+			current: current,
+			new:     &NodeUtilization{CPUPercent: 40, MemoryPressure: 15},
+			now:     now,
+			exp:     true,
+		},
+		{
+			name:    "refresh",
+			current: current,
+			new:     &NodeUtilization{CPUPercent: 40, MemoryPressure: 10},
+			now:     now.Add(NodeUtilizationRefreshInterval),
+			exp:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.exp, tc.current.NeedsUpdate(tc.new, tc.now))
+		})
+	}
+
+	must.False(t, current.IsStale(now.Add(NodeUtilizationMaxAge)))
+	must.True(t, current.IsStale(now.Add(NodeUtilizationMaxAge+time.Second)))
+}
@@ -6,6 +6,7 @@ package structs
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net/netip"
 	"time"
 
@@ -209,6 +210,11 @@ const (
 	// SchedulerAlgorithmSpread indicates that the scheduler should spread
 	// allocations as evenly as possible over the available hardware.
 	SchedulerAlgorithmSpread SchedulerAlgorithm = "spread"
+
+	// SchedulerAlgorithmLoadAware indicates that the scheduler should pack
+	// allocations like binpack, while also taking into account the actual
+	// utilization published by clients as configured by LoadAwareConfig.
+	SchedulerAlgorithmLoadAware SchedulerAlgorithm = "load-aware"
 )
 
 // SchedulerConfiguration is the config for controlling scheduler behavior
@@ -233,6 +239,18 @@ type SchedulerConfiguration struct {
 	// during leadership transitions.
 	PauseEvalBroker bool `hcl:"pause_eval_broker"`
 
+	// FairShareConfig specifies how the evaluation broker shares scheduling
+	// between namespaces.
+	FairShareConfig FairShareConfig `hcl:"fair_share_config"`
+
+	// RebalanceConfig specifies whether running service allocations are
+	// periodically migrated to pack them onto fewer nodes.
+	RebalanceConfig RebalanceConfig `hcl:"rebalance_config"`
+
+	// LoadAwareConfig specifies how the load-aware scheduler algorithm uses
+	// the actual utilization of nodes.
+	LoadAwareConfig LoadAwareConfig `hcl:"load_aware_config"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -244,6 +262,7 @@ func (s *SchedulerConfiguration) Copy() *SchedulerConfiguration {
 	}
 
 	ns := *s
+	ns.FairShareConfig.NamespaceWeights = maps.Clone(s.FairShareConfig.NamespaceWeights)
 	return &ns
 }
 
@@ -287,11 +306,26 @@ func (s *SchedulerConfiguration) Validate() error {
 	}
 
 	switch s.SchedulerAlgorithm {
-	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread:
+	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread, SchedulerAlgorithmLoadAware:
 	default:
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	for namespace, weight := range s.FairShareConfig.NamespaceWeights {
+		if weight < 1 {
+			return fmt.Errorf("invalid fair share weight for namespace %q: %d must be at least 1", namespace, weight)
+		}
+	}
+
+	if s.RebalanceConfig.MaxMigrationsPerHour < 0 {
+		return fmt.Errorf("invalid rebalance max migrations per hour: %d must not be negative",
+			s.RebalanceConfig.MaxMigrationsPerHour)
+	}
+
+	if err := s.LoadAwareConfig.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -313,6 +347,177 @@ type SchedulerSetConfigurationResponse struct {
 	WriteMeta
 }
 
+// FairShareConfig specifies whether the evaluation broker dequeues
+// evaluations fairly across namespaces, and the share of each namespace.
+type FairShareConfig struct {
+	// Enabled specifies if evaluations of equal priority are dequeued in
+	// proportion to the weight of their namespace rather than in the order
+	// they were created.
+	Enabled bool `hcl:"enabled"`
+
+	// NamespaceWeights is the relative share of each namespace. Namespaces
+	// without a weight have a weight of 1.
+	NamespaceWeights map[string]int `hcl:"namespace_weights"`
+}
+
+// NamespaceWeight returns the fair share weight of the namespace.
+func (c *FairShareConfig) NamespaceWeight(namespace string) int {
+	if weight, ok := c.NamespaceWeights[namespace]; ok && weight > 0 {
+		return weight
+	}
+	return 1
+}
+
+// DefaultRebalanceMaxMigrationsPerHour is the number of allocations the
+// rebalancer migrates per hour when RebalanceConfig.MaxMigrationsPerHour is
+// not set.
+const DefaultRebalanceMaxMigrationsPerHour = 10
+
+// RebalanceConfig specifies whether the rebalancer core job migrates running
+// service allocations from lightly used nodes onto nodes where they pack
+// more tightly.
+type RebalanceConfig struct {
+	// Enabled specifies if the rebalancer runs.
+	Enabled bool `hcl:"enabled"`
+
+	// DryRun specifies if the rebalancer only logs the migrations it would
+	// make instead of making them.
+	DryRun bool `hcl:"dry_run"`
+
+	// MaxMigrationsPerHour caps the number of allocations the rebalancer
+	// migrates in any hour. Defaults to DefaultRebalanceMaxMigrationsPerHour
+	// when zero.
+	MaxMigrationsPerHour int `hcl:"max_migrations_per_hour"`
+}
+
+// EffectiveMaxMigrationsPerHour returns the number of allocations the
+// rebalancer may migrate per hour.
+func (c *RebalanceConfig) EffectiveMaxMigrationsPerHour() int {
+	if c.MaxMigrationsPerHour > 0 {
+		return c.MaxMigrationsPerHour
+	}
+	return DefaultRebalanceMaxMigrationsPerHour
+}
+
+const (
+	// DefaultLoadAwareUsageWeight is the weight of the actual utilization of
+	// nodes in their score when LoadAwareConfig.UsageWeight is not set.
+	DefaultLoadAwareUsageWeight = 50
+
+	// DefaultLoadAwarePressureThreshold is the pressure above which nodes
+	// are infeasible for best-effort jobs when
+	// LoadAwareConfig.PressureThreshold is not set.
+	DefaultLoadAwarePressureThreshold = 25
+
+	// DefaultLoadAwareBestEffortPriority is the priority at or below which
+	// jobs are best-effort when LoadAwareConfig.BestEffortPriority is not
+	// set.
+	DefaultLoadAwareBestEffortPriority = 30
+)
+
+// LoadAwareConfig specifies how the load-aware scheduler algorithm uses the
+// actual utilization published by clients. Nodes without a recent
+// utilization are scored like with the binpack algorithm.
+type LoadAwareConfig struct {
+	// UsageWeight is the percentage of the score of a node based on its
+	// actual CPU and memory utilization, the rest being its binpack score.
+	// Defaults to DefaultLoadAwareUsageWeight when zero.
+	UsageWeight int `hcl:"usage_weight"`
+
+	// PressureThreshold is the pressure stall percentage of any resource
+	// above which nodes are infeasible for best-effort jobs. Defaults to
+	// DefaultLoadAwarePressureThreshold when zero, and a value of 100
+	// disables the check.
+	PressureThreshold int `hcl:"pressure_threshold"`
+
+	// BestEffortPriority is the priority at or below which jobs are
+	// best-effort. Defaults to DefaultLoadAwareBestEffortPriority when zero.
+	BestEffortPriority int `hcl:"best_effort_priority"`
+}
+
+// EffectiveUsageWeight returns the weight, between 0 and 1, of the actual
+// utilization of nodes in their score.
+func (c *LoadAwareConfig) EffectiveUsageWeight() float64 {
+	if c.UsageWeight > 0 {
+		return float64(c.UsageWeight) / 100
+	}
+	return DefaultLoadAwareUsageWeight / 100.0
+}
+
+// EffectivePressureThreshold returns the pressure above which nodes are
+// infeasible for best-effort jobs.
+func (c *LoadAwareConfig) EffectivePressureThreshold() float64 {
+	if c.PressureThreshold > 0 {
+		return float64(c.PressureThreshold)
+	}
+	return DefaultLoadAwarePressureThreshold
+}
+
+// IsBestEffort returns whether jobs with the given priority are best-effort.
+func (c *LoadAwareConfig) IsBestEffort(priority int) bool {
+	if c.BestEffortPriority > 0 {
+		return priority <= c.BestEffortPriority
+	}
+	return priority <= DefaultLoadAwareBestEffortPriority
+}
+
+func (c *LoadAwareConfig) Validate() error {
+	if c.UsageWeight < 0 || c.UsageWeight > 100 {
+		return fmt.Errorf("invalid load-aware usage weight: %d must be between 0 and 100", c.UsageWeight)
+	}
+	if c.PressureThreshold < 0 || c.PressureThreshold > 100 {
+		return fmt.Errorf("invalid load-aware pressure threshold: %d must be between 0 and 100", c.PressureThreshold)
+	}
+	if c.BestEffortPriority < 0 {
+		return fmt.Errorf("invalid load-aware best-effort priority: %d must not be negative", c.BestEffortPriority)
+	}
+	return nil
+}
+
+// RebalanceReport describes the migrations the rebalancer makes, or would
+// make, to pack allocations onto fewer nodes.
+type RebalanceReport struct {
+	// Migrations are the allocations to migrate, in the order they are
+	// considered.
+	Migrations []*RebalanceMigration
+
+	// MigrationsUsed is the number of migrations already made by the
+	// rebalancer within the last hour.
+	MigrationsUsed int
+
+	// MaxMigrationsPerHour is the hourly cap the report was computed with.
+	MaxMigrationsPerHour int
+}
+
+// RebalanceMigration is a single allocation the rebalancer moves off its
+// node.
+type RebalanceMigration struct {
+	AllocID   string
+	Namespace string
+	JobID     string
+	TaskGroup string
+
+	// NodeID is the node the allocation is running on and TargetNodeID the
+	// node expected to receive it. The scheduler prefers the target node for
+	// the replacement, but places it on another node if the target no longer
+	// fits by then.
+	NodeID       string
+	TargetNodeID string
+
+	// NodeScore and TargetNodeScore are the normalized bin packing scores of
+	// the current node before the migration and of the target node after it.
+	NodeScore       float64
+	TargetNodeScore float64
+}
+
+// SchedulerRebalanceReportResponse is the response object used to report the
+// migrations the rebalancer would make.
+type SchedulerRebalanceReportResponse struct {
+	Report *RebalanceReport
+
+	QueryMeta
+}
+
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
 type PreemptionConfig struct {
 	// SystemSchedulerEnabled specifies if preemption is enabled for system jobs
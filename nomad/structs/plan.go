@@ -351,4 +351,21 @@ type PlanAnnotations struct {
 
 	// PreemptedAllocs is the set of allocations to be preempted to make the placement successful.
 	PreemptedAllocs []*AllocListStub
+
+	// SpreadViolations are the spreads with a max_skew that are left skewed
+	// beyond their maximum once the plan is applied.
+	SpreadViolations []*SpreadViolation
+}
+
+// SpreadViolation describes a spread with a max_skew whose allocations are
+// skewed beyond the maximum, for example after a drain removed allocations
+// that could not be placed elsewhere without exceeding it.
+type SpreadViolation struct {
+	TaskGroup string
+	Attribute string
+	MaxSkew   int
+	Skew      int
+
+	// Counts is the number of allocations using each value of the attribute.
+	Counts map[string]uint64
 }
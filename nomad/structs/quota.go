@@ -0,0 +1,500 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// maxQuotaDescriptionLength is the maximum length allowed for a quota
+	// specification description.
+	maxQuotaDescriptionLength = 256
+)
+
+var (
+	// validQuotaName is the rule used to validate a quota specification name.
+	validQuotaName = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
+)
+
+// QuotaSpec specifies the allowed resource usage for the namespaces that
+// reference it. Each limit applies to a particular region.
+type QuotaSpec struct {
+	// Name is the name of the quota specification. It must be unique.
+	Name string
+
+	// Description is the human-friendly description of the quota.
+	Description string
+
+	// Limits is the set of quota limits encapsulated by this quota
+	// specification. At most one limit may be defined per region.
+	Limits []*QuotaLimit
+
+	// Hash is the hash of the quota specification which is used to
+	// efficiently diff when we replicate quotas across regions.
+	Hash []byte
+
+	// Raft indexes.
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// GetID implements the IDGetter interface required for pagination.
+func (q *QuotaSpec) GetID() string {
+	return q.Name
+}
+
+// Validate returns an error if the quota specification is invalid.
+func (q *QuotaSpec) Validate() error {
+	var mErr *multierror.Error
+
+	if !validQuotaName.MatchString(q.Name) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid name %q, must match regex %s", q.Name, validQuotaName))
+	}
+	if len(q.Description) > maxQuotaDescriptionLength {
+		mErr = multierror.Append(mErr, fmt.Errorf("description longer than %d", maxQuotaDescriptionLength))
+	}
+	if len(q.Limits) == 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("must specify at least one limit"))
+	}
+
+	regions := make(map[string]struct{}, len(q.Limits))
+	for i, limit := range q.Limits {
+		if limit == nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("limit %d is empty", i))
+			continue
+		}
+		if _, ok := regions[limit.Region]; ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("duplicate limit for region %q", limit.Region))
+		}
+		regions[limit.Region] = struct{}{}
+
+		if err := limit.Validate(); err != nil {
+			mErr = multierror.Append(mErr, multierror.Prefix(err, fmt.Sprintf("limit %d:", i)))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a deep copy of the quota specification.
+func (q *QuotaSpec) Copy() *QuotaSpec {
+	if q == nil {
+		return nil
+	}
+
+	nq := new(QuotaSpec)
+	*nq = *q
+
+	if q.Limits != nil {
+		nq.Limits = make([]*QuotaLimit, len(q.Limits))
+		for i, limit := range q.Limits {
+			nq.Limits[i] = limit.Copy()
+		}
+	}
+
+	nq.Hash = make([]byte, len(q.Hash))
+	copy(nq.Hash, q.Hash)
+
+	return nq
+}
+
+// Stub implements support for pagination.
+func (q *QuotaSpec) Stub() (*QuotaSpec, error) {
+	return q, nil
+}
+
+// SetHash is used to compute and set the hash of the quota specification and
+// each of its limits.
+func (q *QuotaSpec) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, _ = hash.Write([]byte(q.Name))
+	_, _ = hash.Write([]byte(q.Description))
+	for _, limit := range q.Limits {
+		_, _ = hash.Write(limit.SetHash())
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	q.Hash = hashVal
+	return hashVal
+}
+
+// RegionLimit returns the limit that applies to the given region, or nil if
+// the quota specification doesn't limit the region.
+func (q *QuotaSpec) RegionLimit(region string) *QuotaLimit {
+	for _, limit := range q.Limits {
+		if limit.Region == region {
+			return limit
+		}
+	}
+	return nil
+}
+
+// QuotaLimit describes the resource limit in a particular region.
+type QuotaLimit struct {
+	// Region is the region in which this limit has affect.
+	Region string
+
+	// RegionLimit is the quota limit that applies to any allocation within a
+	// referencing namespace in the region. A value of zero is treated as
+	// unlimited and a negative value is treated as fully disallowed.
+	RegionLimit *QuotaResources
+
+	// Hash is the hash of the limit and is used to key the quota usage of the
+	// limit.
+	Hash []byte
+}
+
+// Validate returns an error if the quota limit is invalid.
+func (q *QuotaLimit) Validate() error {
+	var mErr *multierror.Error
+	if q.Region == "" {
+		mErr = multierror.Append(mErr, fmt.Errorf("must specify a region"))
+	}
+	if q.RegionLimit == nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("must specify a region limit"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a deep copy of the quota limit.
+func (q *QuotaLimit) Copy() *QuotaLimit {
+	if q == nil {
+		return nil
+	}
+
+	nq := new(QuotaLimit)
+	*nq = *q
+	nq.RegionLimit = q.RegionLimit.Copy()
+	nq.Hash = make([]byte, len(q.Hash))
+	copy(nq.Hash, q.Hash)
+	return nq
+}
+
+// SetHash is used to compute and set the hash of the quota limit.
+func (q *QuotaLimit) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, _ = hash.Write([]byte(q.Region))
+	if r := q.RegionLimit; r != nil {
+		_, _ = hash.Write([]byte(strconv.Itoa(r.CPU)))
+		_, _ = hash.Write([]byte(strconv.Itoa(r.Cores)))
+		_, _ = hash.Write([]byte(strconv.Itoa(r.MemoryMB)))
+		_, _ = hash.Write([]byte(strconv.Itoa(r.MemoryMaxMB)))
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	q.Hash = hashVal
+	return hashVal
+}
+
+// HashKey returns the key under which the usage of the limit is tracked in
+// QuotaUsage.Used.
+func (q *QuotaLimit) HashKey() string {
+	return base64.StdEncoding.EncodeToString(q.Hash)
+}
+
+// QuotaResources is the set of resources that can be limited by a quota. When
+// used as a limit, a value of zero is treated as unlimited and a negative
+// value is treated as fully disallowed.
+type QuotaResources struct {
+	CPU         int
+	Cores       int
+	MemoryMB    int
+	MemoryMaxMB int
+}
+
+// Copy returns a copy of the quota resources.
+func (q *QuotaResources) Copy() *QuotaResources {
+	if q == nil {
+		return nil
+	}
+	nq := *q
+	return &nq
+}
+
+// Add adds the resources of the delta to q.
+func (q *QuotaResources) Add(delta *QuotaResources) {
+	if delta == nil {
+		return
+	}
+	q.CPU += delta.CPU
+	q.Cores += delta.Cores
+	q.MemoryMB += delta.MemoryMB
+	q.MemoryMaxMB += delta.MemoryMaxMB
+}
+
+// Subtract removes the resources of the delta from q.
+func (q *QuotaResources) Subtract(delta *QuotaResources) {
+	if delta == nil {
+		return
+	}
+	q.CPU -= delta.CPU
+	q.Cores -= delta.Cores
+	q.MemoryMB -= delta.MemoryMB
+	q.MemoryMaxMB -= delta.MemoryMaxMB
+}
+
+// Exceeds returns the dimensions of the limit q that adding delta to the used
+// resources would go over. Dimensions that delta doesn't increase are never
+// reported, so that allocations can always be stopped while over the limit.
+func (q *QuotaResources) Exceeds(used, delta *QuotaResources) []string {
+	if q == nil || delta == nil {
+		return nil
+	}
+	if used == nil {
+		used = new(QuotaResources)
+	}
+
+	var exhausted []string
+	check := func(name string, limit, used, delta int) {
+		if limit == 0 || delta <= 0 {
+			return
+		}
+		if needed := used + delta; limit < 0 || needed > limit {
+			exhausted = append(exhausted,
+				fmt.Sprintf("%s exhausted (%d needed > %d limit)", name, needed, max(limit, 0)))
+		}
+	}
+
+	check("cpu", q.CPU, used.CPU, delta.CPU)
+	check("cores", q.Cores, used.Cores, delta.Cores)
+	check("memory", q.MemoryMB, used.MemoryMB, delta.MemoryMB)
+	check("memory_max", q.MemoryMaxMB, used.MemoryMaxMB, delta.MemoryMaxMB)
+	return exhausted
+}
+
+// AllocQuotaResources returns the resources of the allocation that count
+// against a quota. CPU reserved as whole cores only counts against the cores
+// limit.
+func AllocQuotaResources(alloc *Allocation) *QuotaResources {
+	used := new(QuotaResources)
+	if alloc.AllocatedResources != nil {
+		for _, task := range alloc.AllocatedResources.Tasks {
+			if n := len(task.Cpu.ReservedCores); n > 0 {
+				used.Cores += n
+			} else {
+				used.CPU += int(task.Cpu.CpuShares)
+			}
+			used.MemoryMB += int(task.Memory.MemoryMB)
+			used.MemoryMaxMB += int(max(task.Memory.MemoryMaxMB, task.Memory.MemoryMB))
+		}
+		return used
+	}
+
+	// COMPAT: allocations created before AllocatedResources only carry the
+	// legacy resources.
+	for _, task := range alloc.TaskResources {
+		used.Add(taskQuotaResources(task))
+	}
+	return used
+}
+
+// TaskGroupQuotaResources returns the resources a single allocation of the
+// task group counts against a quota.
+func TaskGroupQuotaResources(tg *TaskGroup) *QuotaResources {
+	used := new(QuotaResources)
+	for _, task := range tg.Tasks {
+		used.Add(taskQuotaResources(task.Resources))
+	}
+	return used
+}
+
+func taskQuotaResources(r *Resources) *QuotaResources {
+	if r == nil {
+		return nil
+	}
+	used := &QuotaResources{
+		MemoryMB:    r.MemoryMB,
+		MemoryMaxMB: max(r.MemoryMaxMB, r.MemoryMB),
+	}
+	if r.Cores > 0 {
+		used.Cores = r.Cores
+	} else {
+		used.CPU = r.CPU
+	}
+	return used
+}
+
+// QuotaUsageDelta returns the change in quota usage that applying the plan
+// would cause for allocations in namespaces matched by inQuota. The existing
+// function is used to look up the current version of an allocation.
+func (p *Plan) QuotaUsageDelta(inQuota func(namespace string) bool,
+	existing func(allocID string) (*Allocation, error)) (*QuotaResources, error) {
+
+	delta := new(QuotaResources)
+	release := func(allocID string) error {
+		alloc, err := existing(allocID)
+		if err != nil {
+			return err
+		}
+		if alloc != nil && !alloc.TerminalStatus() && inQuota(alloc.Namespace) {
+			delta.Subtract(AllocQuotaResources(alloc))
+		}
+		return nil
+	}
+
+	for _, allocs := range p.NodeUpdate {
+		for _, alloc := range allocs {
+			if err := release(alloc.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, allocs := range p.NodePreemptions {
+		for _, alloc := range allocs {
+			if err := release(alloc.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, allocs := range p.NodeAllocation {
+		for _, alloc := range allocs {
+			if !inQuota(alloc.Namespace) {
+				continue
+			}
+
+			// In-place updates replace the usage of the existing allocation
+			if err := release(alloc.ID); err != nil {
+				return nil, err
+			}
+			if !alloc.TerminalStatus() {
+				delta.Add(AllocQuotaResources(alloc))
+			}
+		}
+	}
+
+	return delta, nil
+}
+
+// QuotaUsage is the resource usage of a quota specification.
+type QuotaUsage struct {
+	// Name is the name of the quota specification.
+	Name string
+
+	// Used is the usage of each limit in the local region, keyed by the
+	// base64 encoded hash of the limit.
+	Used map[string]*QuotaLimit
+
+	// Raft indexes.
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// NewQuotaUsage returns an empty usage for the limits of the quota
+// specification that apply to the given region.
+func NewQuotaUsage(spec *QuotaSpec, region string) *QuotaUsage {
+	usage := &QuotaUsage{
+		Name: spec.Name,
+		Used: make(map[string]*QuotaLimit),
+	}
+	if limit := spec.RegionLimit(region); limit != nil {
+		usage.Used[limit.HashKey()] = &QuotaLimit{
+			Region:      limit.Region,
+			RegionLimit: new(QuotaResources),
+			Hash:        limit.Hash,
+		}
+	}
+	return usage
+}
+
+// GetID implements the IDGetter interface required for pagination.
+func (q *QuotaUsage) GetID() string {
+	return q.Name
+}
+
+// Copy returns a deep copy of the quota usage.
+func (q *QuotaUsage) Copy() *QuotaUsage {
+	if q == nil {
+		return nil
+	}
+
+	nq := new(QuotaUsage)
+	*nq = *q
+	if q.Used != nil {
+		nq.Used = make(map[string]*QuotaLimit, len(q.Used))
+		for k, v := range q.Used {
+			nq.Used[k] = v.Copy()
+		}
+	}
+	return nq
+}
+
+// Stub implements support for pagination.
+func (q *QuotaUsage) Stub() (*QuotaUsage, error) {
+	return q, nil
+}
+
+// QuotaSpecListRequest is used to list quota specifications or usages.
+type QuotaSpecListRequest struct {
+	QueryOptions
+}
+
+// QuotaSpecListResponse is the response to a quota specification list
+// request.
+type QuotaSpecListResponse struct {
+	Quotas []*QuotaSpec
+	QueryMeta
+}
+
+// QuotaSpecSpecificRequest is used to make a request for a specific quota
+// specification or usage.
+type QuotaSpecSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleQuotaSpecResponse is the response to a specific quota specification
+// request.
+type SingleQuotaSpecResponse struct {
+	Quota *QuotaSpec
+	QueryMeta
+}
+
+// QuotaUsageListResponse is the response to a quota usage list request.
+type QuotaUsageListResponse struct {
+	Usages []*QuotaUsage
+	QueryMeta
+}
+
+// SingleQuotaUsageResponse is the response to a specific quota usage request.
+type SingleQuotaUsageResponse struct {
+	Usage *QuotaUsage
+	QueryMeta
+}
+
+// QuotaSpecUpsertRequest is used to make a request to insert or update quota
+// specifications.
+type QuotaSpecUpsertRequest struct {
+	Quotas []*QuotaSpec
+	WriteRequest
+}
+
+// QuotaSpecDeleteRequest is used to make a request to delete quota
+// specifications.
+type QuotaSpecDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
@@ -0,0 +1,145 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestQuotaSpec_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	limit := func(region string) *QuotaLimit {
+		return &QuotaLimit{Region: region, RegionLimit: &QuotaResources{CPU: 100}}
+	}
+
+	testCases := []struct {
+		name   string
+		spec   *QuotaSpec
+		expErr string
+	}{
+		{
+			name: "valid",
+			spec: &QuotaSpec{Name: "web", Limits: []*QuotaLimit{limit("global"), limit("eu")}},
+		},
+		{
+			name:   "invalid name",
+			spec:   &QuotaSpec{Name: "web/api", Limits: []*QuotaLimit{limit("global")}},
+			expErr: "invalid name",
+		},
+		{
+			name:   "no limits",
+			spec:   &QuotaSpec{Name: "web"},
+			expErr: "at least one limit",
+		},
+		{
+			name:   "duplicate region",
+			spec:   &QuotaSpec{Name: "web", Limits: []*QuotaLimit{limit("global"), limit("global")}},
+			expErr: "duplicate limit for region",
+		},
+		{
+			name:   "missing region limit",
+			spec:   &QuotaSpec{Name: "web", Limits: []*QuotaLimit{{Region: "global"}}},
+			expErr: "must specify a region limit",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spec.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestQuotaSpec_SetHash(t *testing.T) {
+	ci.Parallel(t)
+
+	spec := &QuotaSpec{
+		Name: "web",
+		Limits: []*QuotaLimit{{
+			Region:      "global",
+			RegionLimit: &QuotaResources{CPU: 100},
+		}},
+	}
+	orig := spec.SetHash()
+	must.NotNil(t, spec.Limits[0].Hash)
+
+	spec.Limits[0].RegionLimit.CPU = 200
+	must.NotEq(t, orig, spec.SetHash())
+}
+
+func TestQuotaResources_Exceeds(t *testing.T) {
+	ci.Parallel(t)
+
+	limit := &QuotaResources{CPU: 1000, MemoryMB: -1}
+
+	// Unlimited and unchanged dimensions are never exhausted.
+	must.Len(t, 0, limit.Exceeds(&QuotaResources{CPU: 500}, &QuotaResources{CPU: 500, Cores: 4}))
+
+	// Stopping allocations is allowed while over the limit.
+	must.Len(t, 0, limit.Exceeds(&QuotaResources{CPU: 2000, MemoryMB: 100}, &QuotaResources{CPU: -500}))
+
+	must.Eq(t, []string{"cpu exhausted (1001 needed > 1000 limit)"},
+		limit.Exceeds(&QuotaResources{CPU: 500}, &QuotaResources{CPU: 501}))
+	must.Eq(t, []string{"memory exhausted (10 needed > 0 limit)"},
+		limit.Exceeds(nil, &QuotaResources{MemoryMB: 10}))
+}
+
+func TestPlan_QuotaUsageDelta(t *testing.T) {
+	ci.Parallel(t)
+
+	resources := func(cpu, mem int64) *AllocatedResources {
+		return &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web": {
+					Cpu:    AllocatedCpuResources{CpuShares: cpu},
+					Memory: AllocatedMemoryResources{MemoryMB: mem},
+				},
+			},
+		}
+	}
+
+	running := &Allocation{ID: "running", Namespace: "a", AllocatedResources: resources(500, 256)}
+	updated := &Allocation{ID: "updated", Namespace: "a", AllocatedResources: resources(500, 256)}
+	other := &Allocation{ID: "other", Namespace: "b", AllocatedResources: resources(500, 256)}
+	existing := map[string]*Allocation{
+		running.ID: running,
+		updated.ID: updated,
+		other.ID:   other,
+	}
+
+	inPlace := updated.Copy()
+	inPlace.AllocatedResources = resources(1000, 512)
+
+	plan := &Plan{
+		NodeUpdate: map[string][]*Allocation{
+			"n1": {running, other},
+		},
+		NodeAllocation: map[string][]*Allocation{
+			"n1": {
+				inPlace,
+				{ID: "new", Namespace: "a", AllocatedResources: resources(100, 128)},
+				{ID: "new-other", Namespace: "b", AllocatedResources: resources(100, 128)},
+			},
+		},
+	}
+
+	delta, err := plan.QuotaUsageDelta(
+		func(ns string) bool { return ns == "a" },
+		func(id string) (*Allocation, error) { return existing[id], nil })
+	must.NoError(t, err)
+	must.Eq(t, &QuotaResources{
+		CPU:         -500 + 500 + 100,
+		MemoryMB:    -256 + 256 + 128,
+		MemoryMaxMB: -256 + 256 + 128,
+	}, delta)
+}
@@ -0,0 +1,450 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// DefaultReservationTTL is the time to live of a reservation that doesn't
+	// set one.
+	DefaultReservationTTL = 24 * time.Hour
+
+	// maxReservationDescriptionLength is the maximum length allowed for a
+	// reservation description.
+	maxReservationDescriptionLength = 256
+)
+
+var (
+	// validReservationName is the rule used to validate a reservation name.
+	validReservationName = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
+)
+
+// Reservation holds capacity of a node pool for future jobs. When it is
+// written the capacity is placed on nodes of the pool as virtual allocations,
+// which the scheduler counts against every job that isn't a consumer of the
+// reservation. Allocations of consumers placed after the reservation was
+// created draw down the capacity held on their node.
+type Reservation struct {
+	// Name is the name of the reservation. It must be unique.
+	Name string
+
+	// Description is the human-friendly description of the reservation.
+	Description string
+
+	// NodePool is the node pool the capacity is reserved in.
+	NodePool string
+
+	// Resources is the capacity to reserve in the node pool.
+	Resources *ReservationResources
+
+	// Consumers selects the jobs allowed to use the reserved capacity.
+	Consumers *ReservationConsumers
+
+	// TTL is how long the reservation lasts after it was last written.
+	TTL time.Duration
+
+	// ExpiresAt is the time after which the reservation no longer holds
+	// capacity. It is set by the server each time the reservation is written.
+	ExpiresAt time.Time
+
+	// Placements is the capacity held on each node of the pool, keyed by
+	// node ID. It is set by the server when the reservation is created or its
+	// resources or node pool change.
+	Placements map[string]*ReservationPlacement
+
+	// Usage is the capacity held and consumed across the node pool. It is
+	// computed by the server when the reservation is read and isn't stored.
+	Usage *ReservationUsage
+
+	// Raft indexes.
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ReservationResources is the capacity of a reservation. Cores are converted
+// to CPU shares of the nodes they are placed on.
+type ReservationResources struct {
+	CPU      int
+	Cores    int
+	MemoryMB int
+}
+
+// ReservationConsumers selects the jobs allowed to use the capacity of a
+// reservation. A job is a consumer if its ID or namespace is listed, or if
+// its priority is at least MinPriority.
+type ReservationConsumers struct {
+	Namespaces  []string
+	Jobs        []string
+	MinPriority int
+}
+
+// ReservationPlacement is the capacity of a reservation held on a node.
+type ReservationPlacement struct {
+	CPU      int64
+	MemoryMB int64
+}
+
+// ReservationUsage is the capacity of a reservation across its node pool,
+// and how much of it consumers use.
+type ReservationUsage struct {
+	ReservedCPU      int64
+	ReservedMemoryMB int64
+	UsedCPU          int64
+	UsedMemoryMB     int64
+}
+
+// GetID implements the IDGetter interface required for pagination.
+func (r *Reservation) GetID() string {
+	return r.Name
+}
+
+// Stub implements support for pagination.
+func (r *Reservation) Stub() (*Reservation, error) {
+	return r, nil
+}
+
+// Canonicalize sets the defaults of the reservation.
+func (r *Reservation) Canonicalize() {
+	if r.NodePool == "" {
+		r.NodePool = NodePoolDefault
+	}
+	if r.TTL == 0 {
+		r.TTL = DefaultReservationTTL
+	}
+}
+
+// Validate returns an error if the reservation is invalid.
+func (r *Reservation) Validate() error {
+	var mErr *multierror.Error
+
+	if !validReservationName.MatchString(r.Name) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid name %q, must match regex %s", r.Name, validReservationName))
+	}
+	if len(r.Description) > maxReservationDescriptionLength {
+		mErr = multierror.Append(mErr, fmt.Errorf("description longer than %d", maxReservationDescriptionLength))
+	}
+	if r.NodePool == "" {
+		mErr = multierror.Append(mErr, fmt.Errorf("missing node pool"))
+	}
+	if r.TTL <= 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("ttl must be positive"))
+	}
+
+	if res := r.Resources; res == nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("missing resources"))
+	} else {
+		if res.CPU < 0 || res.Cores < 0 || res.MemoryMB < 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("resources cannot be negative"))
+		}
+		if res.CPU == 0 && res.Cores == 0 && res.MemoryMB == 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("must reserve cpu, cores or memory"))
+		}
+	}
+
+	if c := r.Consumers; c == nil || (len(c.Namespaces) == 0 && len(c.Jobs) == 0 && c.MinPriority == 0) {
+		mErr = multierror.Append(mErr, fmt.Errorf("must set namespaces, jobs or min_priority of consumers"))
+	} else if c.MinPriority < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("consumers min_priority cannot be negative"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a deep copy of the reservation.
+func (r *Reservation) Copy() *Reservation {
+	if r == nil {
+		return nil
+	}
+	nr := new(Reservation)
+	*nr = *r
+
+	if r.Resources != nil {
+		res := *r.Resources
+		nr.Resources = &res
+	}
+	if r.Consumers != nil {
+		nr.Consumers = &ReservationConsumers{
+			Namespaces:  slices.Clone(r.Consumers.Namespaces),
+			Jobs:        slices.Clone(r.Consumers.Jobs),
+			MinPriority: r.Consumers.MinPriority,
+		}
+	}
+	if r.Placements != nil {
+		nr.Placements = make(map[string]*ReservationPlacement, len(r.Placements))
+		for nodeID, p := range r.Placements {
+			np := *p
+			nr.Placements[nodeID] = &np
+		}
+	}
+	if r.Usage != nil {
+		usage := *r.Usage
+		nr.Usage = &usage
+	}
+	return nr
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface and allows
+// Reservation.TTL to be unmarshalled from a duration string.
+func (r *Reservation) UnmarshalJSON(data []byte) (err error) {
+	type Alias Reservation
+	aux := &struct {
+		TTL any
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+
+	if err = json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	switch v := aux.TTL.(type) {
+	case string:
+		if v != "" {
+			if r.TTL, err = time.ParseDuration(v); err != nil {
+				return err
+			}
+		}
+	case float64:
+		r.TTL = time.Duration(v)
+	}
+	return nil
+}
+
+// Expired returns whether the reservation no longer holds capacity at the
+// given time.
+func (r *Reservation) Expired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && now.After(r.ExpiresAt)
+}
+
+// SamePlacement returns whether the reservation is placed the same as the
+// other reservation, because they reserve the same resources in the same
+// node pool.
+func (r *Reservation) SamePlacement(other *Reservation) bool {
+	if other == nil || r.NodePool != other.NodePool {
+		return false
+	}
+	if r.Resources == nil || other.Resources == nil {
+		return r.Resources == other.Resources
+	}
+	return *r.Resources == *other.Resources
+}
+
+// Consumes returns whether the job with the given namespace, ID and priority
+// can use the capacity of the reservation.
+func (r *Reservation) Consumes(namespace, jobID string, priority int) bool {
+	c := r.Consumers
+	if c == nil {
+		return false
+	}
+	return slices.Contains(c.Jobs, jobID) ||
+		slices.Contains(c.Namespaces, namespace) ||
+		(c.MinPriority > 0 && priority >= c.MinPriority)
+}
+
+// ConsumesJob returns whether the job can use the capacity of the
+// reservation.
+func (r *Reservation) ConsumesJob(job *Job) bool {
+	return job != nil && r.Consumes(job.Namespace, job.ID, job.Priority)
+}
+
+// consumesAlloc returns whether the allocation draws down the capacity of
+// the reservation. Only running allocations of consumers placed after the
+// reservation was created count, so capacity consumers already used isn't
+// held twice.
+func (r *Reservation) consumesAlloc(alloc *Allocation) bool {
+	if alloc.ClientTerminalStatus() || alloc.AllocatedResources == nil {
+		return false
+	}
+	if alloc.CreateIndex != 0 && alloc.CreateIndex <= r.CreateIndex {
+		return false
+	}
+	priority := 0
+	if alloc.Job != nil {
+		priority = alloc.Job.Priority
+	}
+	return r.Consumes(alloc.Namespace, alloc.JobID, priority)
+}
+
+// NodeUsage returns the capacity the reservation places on the node and how
+// much of it the allocations of consumers on the node use.
+func (r *Reservation) NodeUsage(nodeID string, allocs []*Allocation) *ReservationUsage {
+	p, ok := r.Placements[nodeID]
+	if !ok {
+		return &ReservationUsage{}
+	}
+
+	var cpu, mem int64
+	for _, alloc := range allocs {
+		if alloc.NodeID != "" && alloc.NodeID != nodeID {
+			continue
+		}
+		if !r.consumesAlloc(alloc) {
+			continue
+		}
+		cr := alloc.AllocatedResources.Comparable()
+		cpu += cr.Flattened.Cpu.CpuShares
+		mem += cr.Flattened.Memory.MemoryMB
+	}
+
+	return &ReservationUsage{
+		ReservedCPU:      p.CPU,
+		ReservedMemoryMB: p.MemoryMB,
+		UsedCPU:          min(cpu, p.CPU),
+		UsedMemoryMB:     min(mem, p.MemoryMB),
+	}
+}
+
+// Add adds the delta to the usage.
+func (u *ReservationUsage) Add(delta *ReservationUsage) {
+	u.ReservedCPU += delta.ReservedCPU
+	u.ReservedMemoryMB += delta.ReservedMemoryMB
+	u.UsedCPU += delta.UsedCPU
+	u.UsedMemoryMB += delta.UsedMemoryMB
+}
+
+// Held returns the capacity still held for consumers.
+func (u *ReservationUsage) Held() *ReservationPlacement {
+	return &ReservationPlacement{
+		CPU:      u.ReservedCPU - u.UsedCPU,
+		MemoryMB: u.ReservedMemoryMB - u.UsedMemoryMB,
+	}
+}
+
+// ReservationsHeldOnNode returns the capacity the reservations still hold for
+// their consumers on the node, given the allocations on the node. It is the
+// virtual allocation counted against the node for jobs that cannot use the
+// reservations.
+func ReservationsHeldOnNode(reservations []*Reservation, nodeID string, allocs []*Allocation) *ReservationPlacement {
+	held := new(ReservationPlacement)
+	for _, res := range reservations {
+		h := res.NodeUsage(nodeID, allocs).Held()
+		held.CPU += h.CPU
+		held.MemoryMB += h.MemoryMB
+	}
+	return held
+}
+
+// PlaceReservation places the resources of the reservation on the given
+// nodes and sets its placements. Nodes are filled in order, each with as much
+// of the remaining resources as it has free after its allocations and the
+// capacity held on it by the other reservations. It returns an error if the
+// nodes don't have enough free capacity.
+func PlaceReservation(r *Reservation, nodes []*Node,
+	allocsByNode map[string][]*Allocation, others []*Reservation) error {
+
+	remCPU := int64(r.Resources.CPU)
+	remCores := int64(r.Resources.Cores)
+	remMem := int64(r.Resources.MemoryMB)
+
+	placements := make(map[string]*ReservationPlacement)
+	for _, node := range nodes {
+		if remCPU == 0 && remCores == 0 && remMem == 0 {
+			break
+		}
+		if node.NodeResources == nil {
+			continue
+		}
+
+		available := node.NodeResources.Comparable()
+		available.Subtract(node.ReservedResources.Comparable())
+		freeCPU := available.Flattened.Cpu.CpuShares
+		freeMem := available.Flattened.Memory.MemoryMB
+
+		allocs := allocsByNode[node.ID]
+		for _, alloc := range allocs {
+			if alloc.ClientTerminalStatus() || alloc.AllocatedResources == nil {
+				continue
+			}
+			cr := alloc.AllocatedResources.Comparable()
+			freeCPU -= cr.Flattened.Cpu.CpuShares
+			freeMem -= cr.Flattened.Memory.MemoryMB
+		}
+		held := ReservationsHeldOnNode(others, node.ID, allocs)
+		freeCPU -= held.CPU
+		freeMem -= held.MemoryMB
+
+		p := new(ReservationPlacement)
+		if topology := node.NodeResources.Processors.Topology; remCores > 0 &&
+			topology != nil && topology.NumCores() > 0 {
+			perCore := int64(topology.TotalCompute()) / int64(topology.NumCores())
+			if perCore > 0 {
+				cores := min(remCores, max(freeCPU, 0)/perCore)
+				p.CPU += cores * perCore
+				freeCPU -= cores * perCore
+				remCores -= cores
+			}
+		}
+		cpu := min(remCPU, max(freeCPU, 0))
+		p.CPU += cpu
+		remCPU -= cpu
+
+		mem := min(remMem, max(freeMem, 0))
+		p.MemoryMB = mem
+		remMem -= mem
+
+		if p.CPU > 0 || p.MemoryMB > 0 {
+			placements[node.ID] = p
+		}
+	}
+
+	if remCPU > 0 || remCores > 0 || remMem > 0 {
+		return fmt.Errorf("node pool %q lacks free capacity for %d MHz cpu, %d cores and %d MB memory",
+			r.NodePool, remCPU, remCores, remMem)
+	}
+	r.Placements = placements
+	return nil
+}
+
+// NodeIDs returns the sorted IDs of the nodes the reservation holds capacity
+// on.
+func (r *Reservation) NodeIDs() []string {
+	return slices.Sorted(maps.Keys(r.Placements))
+}
+
+// ReservationListRequest is used to list reservations.
+type ReservationListRequest struct {
+	QueryOptions
+}
+
+// ReservationListResponse is the response to a reservation list request.
+type ReservationListResponse struct {
+	Reservations []*Reservation
+	QueryMeta
+}
+
+// ReservationSpecificRequest is used to make a request for a specific
+// reservation.
+type ReservationSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleReservationResponse is the response to a specific reservation
+// request.
+type SingleReservationResponse struct {
+	Reservation *Reservation
+	QueryMeta
+}
+
+// ReservationUpsertRequest is used to make a request to insert or update
+// reservations.
+type ReservationUpsertRequest struct {
+	Reservations []*Reservation
+	WriteRequest
+}
+
+// ReservationDeleteRequest is used to make a request to delete reservations.
+type ReservationDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
@@ -0,0 +1,145 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestReservation_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	res := &Reservation{
+		Name:      "launch",
+		Resources: &ReservationResources{Cores: 200, MemoryMB: 512 * 1024},
+		Consumers: &ReservationConsumers{Namespaces: []string{"launch"}},
+	}
+	res.Canonicalize()
+	must.Eq(t, NodePoolDefault, res.NodePool)
+	must.Eq(t, DefaultReservationTTL, res.TTL)
+	must.NoError(t, res.Validate())
+
+	err := (&Reservation{
+		Name:      "launch!",
+		NodePool:  NodePoolDefault,
+		TTL:       -time.Hour,
+		Resources: &ReservationResources{CPU: -1},
+		Consumers: &ReservationConsumers{},
+	}).Validate()
+	must.ErrorContains(t, err, `invalid name "launch!"`)
+	must.ErrorContains(t, err, "ttl must be positive")
+	must.ErrorContains(t, err, "resources cannot be negative")
+	must.ErrorContains(t, err, "must set namespaces, jobs or min_priority of consumers")
+}
+
+func TestReservation_Consumes(t *testing.T) {
+	ci.Parallel(t)
+
+	res := &Reservation{
+		Consumers: &ReservationConsumers{
+			Namespaces:  []string{"launch"},
+			Jobs:        []string{"checkout"},
+			MinPriority: 80,
+		},
+	}
+	must.True(t, res.Consumes("launch", "web", 50))
+	must.True(t, res.Consumes(DefaultNamespace, "checkout", 50))
+	must.True(t, res.Consumes(DefaultNamespace, "web", 90))
+	must.False(t, res.Consumes(DefaultNamespace, "web", 50))
+
+	res.Consumers.MinPriority = 0
+	must.False(t, res.Consumes(DefaultNamespace, "web", 0))
+}
+
+func TestReservation_NodeUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	node := MockNode()
+	res := &Reservation{
+		Consumers:   &ReservationConsumers{Jobs: []string{"checkout"}},
+		Placements:  map[string]*ReservationPlacement{node.ID: {CPU: 1000, MemoryMB: 1024}},
+		CreateIndex: 10,
+	}
+
+	alloc := func(jobID string, createIndex uint64) *Allocation {
+		a := MockAlloc()
+		a.NodeID = node.ID
+		a.JobID = jobID
+		a.CreateIndex = createIndex
+		return a
+	}
+	allocs := []*Allocation{
+		alloc("checkout", 20),
+		alloc("checkout", 5), // placed before the reservation
+		alloc("web", 20),     // not a consumer
+	}
+
+	usage := res.NodeUsage(node.ID, allocs)
+	must.Eq(t, &ReservationUsage{
+		ReservedCPU:      1000,
+		ReservedMemoryMB: 1024,
+		UsedCPU:          500,
+		UsedMemoryMB:     256,
+	}, usage)
+	must.Eq(t, &ReservationPlacement{CPU: 500, MemoryMB: 768},
+		ReservationsHeldOnNode([]*Reservation{res}, node.ID, allocs))
+
+	// Usage is capped at the capacity placed on the node.
+	allocs = append(allocs, alloc("checkout", 30), alloc("checkout", 40))
+	must.Eq(t, &ReservationPlacement{CPU: 0, MemoryMB: 256},
+		ReservationsHeldOnNode([]*Reservation{res}, node.ID, allocs))
+
+	must.Eq(t, &ReservationUsage{}, res.NodeUsage("other", allocs))
+}
+
+func TestPlaceReservation(t *testing.T) {
+	ci.Parallel(t)
+
+	n1, n2 := MockNode(), MockNode()
+	alloc := MockAlloc()
+	alloc.NodeID = n1.ID
+	allocsByNode := map[string][]*Allocation{n1.ID: {alloc}}
+
+	// Each node has 13900 MHz of cpu, made of 3500 MHz cores, and 7936 MB of
+	// memory free before allocations.
+	res := &Reservation{
+		Name:      "launch",
+		NodePool:  NodePoolDefault,
+		Resources: &ReservationResources{Cores: 4, MemoryMB: 10000},
+	}
+	must.NoError(t, PlaceReservation(res, []*Node{n1, n2}, allocsByNode, nil))
+	must.Eq(t, map[string]*ReservationPlacement{
+		n1.ID: {CPU: 3 * 3500, MemoryMB: 7936 - 256},
+		n2.ID: {CPU: 3500, MemoryMB: 10000 - 7680},
+	}, res.Placements)
+	must.Eq(t, []string{min(n1.ID, n2.ID), max(n1.ID, n2.ID)}, res.NodeIDs())
+
+	// Capacity held by other reservations isn't free.
+	other := &Reservation{
+		Name:      "other",
+		NodePool:  NodePoolDefault,
+		Resources: &ReservationResources{MemoryMB: 8000},
+	}
+	err := PlaceReservation(other, []*Node{n1, n2}, allocsByNode, []*Reservation{res})
+	must.ErrorContains(t, err, `node pool "default" lacks free capacity for 0 MHz cpu, 0 cores and 2384 MB memory`)
+	must.Nil(t, other.Placements)
+}
+
+func TestReservation_UnmarshalJSON(t *testing.T) {
+	ci.Parallel(t)
+
+	var res Reservation
+	must.NoError(t, json.Unmarshal([]byte(`{"Name": "launch", "TTL": "72h"}`), &res))
+	must.Eq(t, 72*time.Hour, res.TTL)
+
+	must.NoError(t, json.Unmarshal([]byte(`{"Name": "launch", "TTL": 3600000000000}`), &res))
+	must.Eq(t, time.Hour, res.TTL)
+
+	must.Error(t, json.Unmarshal([]byte(`{"Name": "launch", "TTL": "soon"}`), &res))
+}
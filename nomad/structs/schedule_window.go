@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// ScheduleWindow restricts the placement of the allocations of a batch or
+// sysbatch job to recurring time windows, such as nights or weekends.
+// Evaluations processed outside the window are deferred until it next opens.
+// Allocations already running are not affected when the window closes.
+type ScheduleWindow struct {
+	// Cron is the cron expression of the times the window opens.
+	Cron string
+
+	// Duration is how long the window stays open once it opens.
+	Duration time.Duration
+
+	// TimeZone is the IANA time zone the cron expression is evaluated in.
+	// Defaults to UTC.
+	TimeZone string
+}
+
+func (w *ScheduleWindow) Copy() *ScheduleWindow {
+	if w == nil {
+		return nil
+	}
+	nw := new(ScheduleWindow)
+	*nw = *w
+	return nw
+}
+
+func (w *ScheduleWindow) Validate() error {
+	var mErr multierror.Error
+
+	if w.Cron == "" {
+		_ = multierror.Append(&mErr, errors.New("Must specify a cron expression"))
+	} else if _, err := cronexpr.Parse(w.Cron); err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid cron expression %q: %v", w.Cron, err))
+	}
+
+	if w.Duration <= 0 {
+		_ = multierror.Append(&mErr, errors.New("Duration must be greater than zero"))
+	}
+
+	if w.TimeZone != "" {
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Invalid time zone %q: %v", w.TimeZone, err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// GetLocation returns the time zone the window is evaluated in.
+func (w *ScheduleWindow) GetLocation() (*time.Location, error) {
+	if w.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(w.TimeZone)
+}
+
+// Next returns when the window that contains or follows the given time opens
+// and closes. The window is open at the given time if opens is not after it.
+func (w *ScheduleWindow) Next(from time.Time) (opens, closes time.Time, err error) {
+	location, err := w.GetLocation()
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time zone in schedule window: %w", err)
+	}
+
+	// The earliest window to open after from-Duration is the only one that
+	// may still be open at from.
+	opens, err = CronParseNext(from.In(location).Add(-w.Duration), w.Cron)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if opens.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf("cron expression %q never matches", w.Cron)
+	}
+	return opens, opens.Add(w.Duration), nil
+}
+
+// IsOpen returns whether the window is open at the given time, along with
+// when it next opens if it isn't.
+func (w *ScheduleWindow) IsOpen(now time.Time) (bool, time.Time, error) {
+	opens, _, err := w.Next(now)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	if opens.After(now) {
+		return false, opens, nil
+	}
+	return true, time.Time{}, nil
+}
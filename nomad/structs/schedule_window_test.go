@@ -0,0 +1,165 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestScheduleWindow_Next(t *testing.T) {
+	ci.Parallel(t)
+
+	parse := func(t *testing.T, val string) time.Time {
+		t.Helper()
+		out, err := time.Parse(time.RFC3339, val)
+		must.NoError(t, err)
+		return out
+	}
+
+	// Nightly window from 22:00 to 06:00.
+	nightly := &ScheduleWindow{Cron: "0 22 * * *", Duration: 8 * time.Hour}
+
+	cases := []struct {
+		name         string
+		window       *ScheduleWindow
+		now          string
+		expectOpen   bool
+		expectOpens  string
+		expectCloses string
+	}{
+		{
+			name:         "before window",
+			window:       nightly,
+			now:          "2024-07-01T12:00:00Z",
+			expectOpens:  "2024-07-01T22:00:00Z",
+			expectCloses: "2024-07-02T06:00:00Z",
+		},
+		{
+			name:         "window opens",
+			window:       nightly,
+			now:          "2024-07-01T22:00:00Z",
+			expectOpen:   true,
+			expectOpens:  "2024-07-01T22:00:00Z",
+			expectCloses: "2024-07-02T06:00:00Z",
+		},
+		{
+			name:         "within window across midnight",
+			window:       nightly,
+			now:          "2024-07-02T03:00:00Z",
+			expectOpen:   true,
+			expectOpens:  "2024-07-01T22:00:00Z",
+			expectCloses: "2024-07-02T06:00:00Z",
+		},
+		{
+			name:         "window closes",
+			window:       nightly,
+			now:          "2024-07-02T06:00:00Z",
+			expectOpens:  "2024-07-02T22:00:00Z",
+			expectCloses: "2024-07-03T06:00:00Z",
+		},
+		{
+			name: "time zone",
+			window: &ScheduleWindow{
+				Cron:     "0 22 * * *",
+				Duration: 8 * time.Hour,
+				TimeZone: "America/New_York",
+			},
+			now:          "2024-07-01T12:00:00Z",
+			expectOpens:  "2024-07-02T02:00:00Z",
+			expectCloses: "2024-07-02T10:00:00Z",
+		},
+		{
+			name:         "weekend",
+			window:       &ScheduleWindow{Cron: "0 0 * * SAT", Duration: 48 * time.Hour},
+			now:          "2024-07-07T18:00:00Z", // Sunday
+			expectOpen:   true,
+			expectOpens:  "2024-07-06T00:00:00Z",
+			expectCloses: "2024-07-08T00:00:00Z",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := parse(t, tc.now)
+
+			opens, closes, err := tc.window.Next(now)
+			must.NoError(t, err)
+			must.Eq(t, parse(t, tc.expectOpens), opens.UTC())
+			must.Eq(t, parse(t, tc.expectCloses), closes.UTC())
+
+			open, next, err := tc.window.IsOpen(now)
+			must.NoError(t, err)
+			must.Eq(t, tc.expectOpen, open)
+			if !tc.expectOpen {
+				must.Eq(t, opens, next)
+			}
+		})
+	}
+}
+
+func TestScheduleWindow_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		window *ScheduleWindow
+		err    string
+	}{
+		{
+			name:   "valid",
+			window: &ScheduleWindow{Cron: "0 22 * * *", Duration: time.Hour, TimeZone: "Europe/Paris"},
+		},
+		{
+			name:   "missing cron",
+			window: &ScheduleWindow{Duration: time.Hour},
+			err:    "Must specify a cron expression",
+		},
+		{
+			name:   "invalid cron",
+			window: &ScheduleWindow{Cron: "nope", Duration: time.Hour},
+			err:    "Invalid cron expression",
+		},
+		{
+			name:   "missing duration",
+			window: &ScheduleWindow{Cron: "0 22 * * *"},
+			err:    "Duration must be greater than zero",
+		},
+		{
+			name:   "invalid time zone",
+			window: &ScheduleWindow{Cron: "0 22 * * *", Duration: time.Hour, TimeZone: "Mars/Olympus"},
+			err:    "Invalid time zone",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.window.Validate()
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestJob_Validate_ScheduleWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	window := &ScheduleWindow{Cron: "0 22 * * *", Duration: time.Hour}
+
+	job := testJob()
+	job.Type = JobTypeService
+	job.ScheduleWindow = window
+	must.ErrorContains(t, job.Validate(), "Schedule window can only be used with")
+
+	job = testJob()
+	job.Type = JobTypeBatch
+	job.ScheduleWindow = &ScheduleWindow{Cron: "0 22 * * *"}
+	must.ErrorContains(t, job.Validate(), "Schedule window validation failed")
+}
@@ -134,6 +134,17 @@ const (
 	HostVolumeRegisterRequestType             MessageType = 75
 	HostVolumeDeleteRequestType               MessageType = 76
 	TaskGroupHostVolumeClaimDeleteRequestType MessageType = 77
+	QuotaSpecUpsertRequestType                MessageType = 78
+	QuotaSpecDeleteRequestType                MessageType = 79
+	NodeUpdateTaintsRequestType               MessageType = 80
+	NodeUpdateUtilizationRequestType          MessageType = 81
+	ReservationUpsertRequestType              MessageType = 82
+	ReservationDeleteRequestType              MessageType = 83
+	VarHistoryPurgeRequestType                MessageType = 84
+	VarExpireRequestType                      MessageType = 85
+	EventSinkRegisterRequestType              MessageType = 86
+	EventSinkDeregisterRequestType            MessageType = 87
+	EventSinkCheckpointRequestType            MessageType = 88
 
 	// NOTE: MessageTypes are shared between CE and ENT. If you need to add a
 	// new type, check that ENT is not already using that value.
@@ -702,6 +713,29 @@ type NodeUpdateEligibilityRequest struct {
 	WriteRequest
 }
 
+// NodeUpdateTaintsRequest is used for replacing the taints of a node
+type NodeUpdateTaintsRequest struct {
+	NodeID string
+	Taints []*NodeTaint
+
+	// NodeEvent is the event added to the node
+	NodeEvent *NodeEvent
+
+	// UpdatedAt represents server time of receiving request
+	UpdatedAt int64
+
+	WriteRequest
+}
+
+// NodeUpdateUtilizationRequest is used by clients to publish the utilization
+// of their host
+type NodeUpdateUtilizationRequest struct {
+	NodeID      string
+	Utilization *NodeUtilization
+
+	WriteRequest
+}
+
 // NodeEvaluateRequest is used to re-evaluate the node
 type NodeEvaluateRequest struct {
 	NodeID string
@@ -849,6 +883,9 @@ type JobPlanRequest struct {
 	Diff bool // Toggles an annotated diff
 	// PolicyOverride is set when the user is attempting to override any policies
 	PolicyOverride bool
+	// Explain toggles recording what happened to every node visited by the
+	// scheduler while placing allocations
+	Explain bool
 	WriteRequest
 }
 
@@ -1499,6 +1536,14 @@ type NodeEligibilityUpdateResponse struct {
 	WriteMeta
 }
 
+// NodeTaintsUpdateResponse is used to respond to a node taints update
+type NodeTaintsUpdateResponse struct {
+	NodeModifyIndex uint64
+	EvalIDs         []string
+	EvalCreateIndex uint64
+	WriteMeta
+}
+
 // NodeAllocsResponse is used to return allocs for a single node
 type NodeAllocsResponse struct {
 	Allocs []*Allocation
@@ -1603,6 +1648,11 @@ type JobPlanResponse struct {
 	// FailedTGAllocs is the placement failures per task group.
 	FailedTGAllocs map[string]*AllocMetric
 
+	// Placements explains the node each allocation of the plan was placed
+	// on, sorted by allocation name. It is only set when the plan was asked
+	// to explain placements.
+	Placements []*PlacementExplanation
+
 	// JobModifyIndex is the modification index of the job. The value can be
 	// used when running `nomad run` to ensure that the Job wasn’t modified
 	// since the last plan. If the job is being created, the value is zero.
@@ -2067,6 +2117,13 @@ type Node struct {
 	// NodePool is the node pool the node belongs to.
 	NodePool string
 
+	// Taints keep the allocations that don't tolerate them off the node.
+	Taints []*NodeTaint
+
+	// Utilization is the actual utilization of the node last published by
+	// the client.
+	Utilization *NodeUtilization
+
 	// ComputedClass is a unique id that identifies nodes with a common set of
 	// attributes and capabilities.
 	ComputedClass string
@@ -2233,6 +2290,8 @@ func (n *Node) Copy() *Node {
 	nn.ReservedResources = nn.ReservedResources.Copy()
 	nn.Links = maps.Clone(nn.Links)
 	nn.Meta = maps.Clone(nn.Meta)
+	nn.Taints = CopySliceNodeTaints(nn.Taints)
+	nn.Utilization = nn.Utilization.Copy()
 	nn.DrainStrategy = nn.DrainStrategy.Copy()
 	nn.Events = helper.CopySlice(n.Events)
 	nn.Drivers = helper.DeepCopyMap(n.Drivers)
@@ -2374,6 +2433,10 @@ type Resources struct {
 	Devices     ResourceDevices
 	NUMA        *NUMA
 	SecretsMB   int
+
+	// CustomResources are the custom resources of the node requested by
+	// the task.
+	CustomResources ResourceCustomResources
 }
 
 const (
@@ -2441,6 +2504,17 @@ func (r *Resources) Validate() error {
 		devices.Insert(d.Name)
 	}
 
+	// Ensure custom resources are valid
+	customResources := set.New[string](len(r.CustomResources))
+	for i, c := range r.CustomResources {
+		if err := c.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("custom resource %d failed validation: %v", i+1, err))
+		}
+		if !customResources.Insert(c.Name) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("custom resource %q requested more than once", c.Name))
+		}
+	}
+
 	// Ensure each numa bound device matches a device requested for task
 	if r.NUMA != nil {
 		for _, numaDevice := range r.NUMA.Devices {
@@ -2498,6 +2572,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.SecretsMB != 0 {
 		r.SecretsMB = other.SecretsMB
 	}
+	if len(other.CustomResources) != 0 {
+		r.CustomResources = other.CustomResources
+	}
 }
 
 // Equal Resources.
@@ -2518,7 +2595,8 @@ func (r *Resources) Equal(o *Resources) bool {
 		r.IOPS == o.IOPS &&
 		r.Networks.Equal(&o.Networks) &&
 		r.Devices.Equal(&o.Devices) &&
-		r.SecretsMB == o.SecretsMB
+		r.SecretsMB == o.SecretsMB &&
+		r.CustomResources.Equal(o.CustomResources)
 }
 
 // ResourceDevices are part of Resources.
@@ -2578,6 +2656,9 @@ func (r *Resources) Canonicalize() {
 	if len(r.Devices) == 0 {
 		r.Devices = nil
 	}
+	if len(r.CustomResources) == 0 {
+		r.CustomResources = nil
+	}
 
 	for _, n := range r.Networks {
 		n.Canonicalize()
@@ -2618,6 +2699,8 @@ func (r *Resources) Copy() *Resources {
 		Devices:     r.Devices.Copy(),
 		NUMA:        r.NUMA.Copy(),
 		SecretsMB:   r.SecretsMB,
+
+		CustomResources: r.CustomResources.Copy(),
 	}
 }
 
@@ -2670,6 +2753,15 @@ func (r *Resources) Add(delta *Resources) {
 
 		r.Devices[idx].Count += dd.Count
 	}
+
+	for _, dc := range delta.CustomResources {
+		idx := slices.IndexFunc(r.CustomResources, func(c *RequestedCustomResource) bool { return c.Name == dc.Name })
+		if idx < 0 {
+			r.CustomResources = append(r.CustomResources, dc.Copy())
+			continue
+		}
+		r.CustomResources[idx].Count += dc.Count
+	}
 }
 
 // GoString returns the string representation of the Resources struct.
@@ -3176,6 +3268,10 @@ type NodeResources struct {
 	// to select dynamic ports from across all networks.
 	MinDynamicPort int
 	MaxDynamicPort int
+
+	// CustomResources are the named countable resources declared in the
+	// client configuration.
+	CustomResources []*NodeCustomResource
 }
 
 func (n *NodeResources) Copy() *NodeResources {
@@ -3204,6 +3300,8 @@ func (n *NodeResources) Copy() *NodeResources {
 		}
 	}
 
+	newN.CustomResources = CopySliceNodeCustomResources(n.CustomResources)
+
 	// COMPAT remove in 1.10+
 	// apply compatibility fixups covering node topology
 	newN.Compatibility()
@@ -3232,7 +3330,8 @@ func (n *NodeResources) Comparable() *ComparableResources {
 			Memory: AllocatedMemoryResources{
 				MemoryMB: n.Memory.MemoryMB,
 			},
-			Networks: n.Networks,
+			Networks:        n.Networks,
+			CustomResources: nodeCustomResourceCounts(n.CustomResources),
 		},
 		Shared: AllocatedSharedResources{
 			DiskMB: n.Disk.DiskMB,
@@ -3258,6 +3357,12 @@ func (n *NodeResources) Merge(o *NodeResources) {
 		n.Devices = o.Devices
 	}
 
+	// Custom resources are fingerprinted all at once, so an empty slice
+	// removes them while a nil one leaves them unchanged.
+	if o.CustomResources != nil {
+		n.CustomResources = o.CustomResources
+	}
+
 	if len(o.NodeNetworks) != 0 {
 		for _, nw := range o.NodeNetworks {
 			if i, nnw := lookupNetworkByDevice(n.NodeNetworks, nw.Device); nnw != nil {
@@ -3313,6 +3418,10 @@ func (n *NodeResources) Equal(o *NodeResources) bool {
 		return false
 	}
 
+	if !NodeCustomResourcesEqual(n.CustomResources, o.CustomResources) {
+		return false
+	}
+
 	return true
 }
 
@@ -3876,6 +3985,10 @@ type AllocatedTaskResources struct {
 	Memory   AllocatedMemoryResources
 	Networks Networks
 	Devices  []*AllocatedDeviceResource
+
+	// CustomResources are the number of units allocated of each custom
+	// resource of the node.
+	CustomResources map[string]uint64
 }
 
 func (a *AllocatedTaskResources) Copy() *AllocatedTaskResources {
@@ -3897,6 +4010,8 @@ func (a *AllocatedTaskResources) Copy() *AllocatedTaskResources {
 		}
 	}
 
+	newA.CustomResources = maps.Clone(a.CustomResources)
+
 	return newA
 }
 
@@ -3932,6 +4047,7 @@ func (a *AllocatedTaskResources) Add(delta *AllocatedTaskResources) {
 			a.Devices[idx].Add(d)
 		}
 	}
+	a.CustomResources = addCustomResourceCounts(a.CustomResources, delta.CustomResources)
 }
 
 func (a *AllocatedTaskResources) Max(other *AllocatedTaskResources) {
@@ -3961,6 +4077,7 @@ func (a *AllocatedTaskResources) Max(other *AllocatedTaskResources) {
 			a.Devices[idx].Add(d)
 		}
 	}
+	a.CustomResources = maxCustomResourceCounts(a.CustomResources, other.CustomResources)
 }
 
 // Comparable turns AllocatedTaskResources into ComparableResources
@@ -3979,11 +4096,12 @@ func (a *AllocatedTaskResources) Comparable() *ComparableResources {
 		},
 	}
 	ret.Flattened.Networks = append(ret.Flattened.Networks, a.Networks...)
+	ret.Flattened.CustomResources = maps.Clone(a.CustomResources)
 	return ret
 }
 
-// Subtract only subtracts CPU and Memory resources. Network utilization
-// is managed separately in NetworkIndex
+// Subtract only subtracts CPU, Memory and custom resources. Network
+// utilization is managed separately in NetworkIndex
 func (a *AllocatedTaskResources) Subtract(delta *AllocatedTaskResources) {
 	if delta == nil {
 		return
@@ -3991,6 +4109,7 @@ func (a *AllocatedTaskResources) Subtract(delta *AllocatedTaskResources) {
 
 	a.Cpu.Subtract(&delta.Cpu)
 	a.Memory.Subtract(&delta.Memory)
+	subtractCustomResourceCounts(a.CustomResources, delta.CustomResources)
 }
 
 // AllocatedSharedResources are the set of resources allocated to a task group.
@@ -4265,6 +4384,10 @@ func (c *ComparableResources) Superset(other *ComparableResources) (bool, string
 	if c.Shared.DiskMB < other.Shared.DiskMB {
 		return false, "disk"
 	}
+
+	if ok, name := customResourcesSuperset(c.Flattened.CustomResources, other.Flattened.CustomResources); !ok {
+		return false, "custom resource: " + name
+	}
 	return true, ""
 }
 
@@ -4281,6 +4404,10 @@ const (
 	JobTypeBatch    = "batch"
 	JobTypeSystem   = "system"
 	JobTypeSysBatch = "sysbatch"
+
+	// JobTypeWorkflow is a batch job whose task groups are the steps of a
+	// DAG, each placed once the steps it runs after have completed.
+	JobTypeWorkflow = "workflow"
 )
 
 const (
@@ -4447,6 +4574,14 @@ type Job struct {
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
 
+	// Tolerations allow all the task groups to be placed on and kept on
+	// nodes with matching taints.
+	Tolerations []*Toleration
+
+	// JobAffinities place all the task groups relative to the allocations
+	// of other jobs.
+	JobAffinities []*JobAffinity
+
 	// TaskGroups are the collections of task groups that this job needs
 	// to run. Each task group is an atomic unit of scheduling and placement.
 	TaskGroups []*TaskGroup
@@ -4464,6 +4599,14 @@ type Job struct {
 	// for dispatching.
 	ParameterizedJob *ParameterizedJobConfig
 
+	// ScheduleWindow restricts the placement of the job's allocations to
+	// recurring time windows.
+	ScheduleWindow *ScheduleWindow
+
+	// DependsOn holds the job's evaluations until the listed jobs reach a
+	// terminal status.
+	DependsOn []*JobDependency
+
 	// Dispatched is used to identify if the Job has been dispatched from a
 	// parameterized job.
 	Dispatched bool
@@ -4672,11 +4815,29 @@ func (j *Job) Canonicalize() {
 		j.Spreads = nil
 	}
 
+	if len(j.Tolerations) == 0 {
+		j.Tolerations = nil
+	}
+	for _, t := range j.Tolerations {
+		if t != nil {
+			t.Canonicalize()
+		}
+	}
+
 	// Ensure the job is in a namespace.
 	if j.Namespace == "" {
 		j.Namespace = DefaultNamespace
 	}
 
+	if len(j.JobAffinities) == 0 {
+		j.JobAffinities = nil
+	}
+	for _, a := range j.JobAffinities {
+		if a != nil {
+			a.Canonicalize(j.Namespace)
+		}
+	}
+
 	if len(j.Datacenters) == 0 {
 		j.Datacenters = []string{"*"}
 	}
@@ -4696,6 +4857,15 @@ func (j *Job) Canonicalize() {
 	if j.Periodic != nil {
 		j.Periodic.Canonicalize()
 	}
+
+	if len(j.DependsOn) == 0 {
+		j.DependsOn = nil
+	}
+	for _, d := range j.DependsOn {
+		if d != nil {
+			d.Canonicalize()
+		}
+	}
 }
 
 // Copy returns a deep copy of the Job. It is expected that callers use recover.
@@ -4709,6 +4879,8 @@ func (j *Job) Copy() *Job {
 	nj.Datacenters = slices.Clone(j.Datacenters)
 	nj.Constraints = CopySliceConstraints(j.Constraints)
 	nj.Affinities = CopySliceAffinities(j.Affinities)
+	nj.Tolerations = CopySliceTolerations(j.Tolerations)
+	nj.JobAffinities = CopySliceJobAffinities(j.JobAffinities)
 	nj.Multiregion = j.Multiregion.Copy()
 	nj.UI = j.UI.Copy()
 	nj.VersionTag = j.VersionTag.Copy()
@@ -4724,6 +4896,8 @@ func (j *Job) Copy() *Job {
 	nj.Periodic = j.Periodic.Copy()
 	nj.Meta = maps.Clone(j.Meta)
 	nj.ParameterizedJob = j.ParameterizedJob.Copy()
+	nj.ScheduleWindow = j.ScheduleWindow.Copy()
+	nj.DependsOn = CopySliceJobDependencies(j.DependsOn)
 	return nj
 }
 
@@ -4751,7 +4925,7 @@ func (j *Job) Validate() error {
 		mErr.Errors = append(mErr.Errors, errors.New("Job must be in a namespace"))
 	}
 	switch j.Type {
-	case JobTypeCore, JobTypeService, JobTypeBatch, JobTypeSystem, JobTypeSysBatch:
+	case JobTypeCore, JobTypeService, JobTypeBatch, JobTypeSystem, JobTypeSysBatch, JobTypeWorkflow:
 	case "":
 		mErr.Errors = append(mErr.Errors, errors.New("Missing job type"))
 	default:
@@ -4802,6 +4976,20 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	for idx, toleration := range j.Tolerations {
+		if err := toleration.Validate(); err != nil {
+			outer := fmt.Errorf("Toleration %d validation failed: %s", idx+1, err)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
+	}
+
+	for idx, affinity := range j.JobAffinities {
+		if err := affinity.Validate(); err != nil {
+			outer := fmt.Errorf("Job affinity %d validation failed: %s", idx+1, err)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
+	}
+
 	const MaxDescriptionCharacters = 1000
 	if j.UI != nil {
 		if len(j.UI.Description) > MaxDescriptionCharacters {
@@ -4845,6 +5033,11 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	// Validate the task groups of workflow jobs form a DAG
+	if err := j.validateWorkflow(); err != nil {
+		_ = multierror.Append(&mErr, err)
+	}
+
 	// Validate periodic is only used with batch or sysbatch jobs.
 	if j.IsPeriodic() && j.Periodic.Enabled {
 		if j.Type != JobTypeBatch && j.Type != JobTypeSysBatch {
@@ -4876,6 +5069,26 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	// Validate the schedule window is only used with batch or sysbatch jobs.
+	if j.ScheduleWindow != nil {
+		if j.Type != JobTypeBatch && j.Type != JobTypeSysBatch {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf(
+				"Schedule window can only be used with %q or %q scheduler", JobTypeBatch, JobTypeSysBatch,
+			))
+		}
+
+		if err := j.ScheduleWindow.Validate(); err != nil {
+			outer := fmt.Errorf("Schedule window validation failed: %v", err)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
+	}
+
+	if len(j.DependsOn) != 0 {
+		if err := j.validateDependencies(); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -5533,6 +5746,10 @@ type Namespace struct {
 	VaultConfiguration  *NamespaceVaultConfiguration
 	ConsulConfiguration *NamespaceConsulConfiguration
 
+	// VariablesConfiguration is the namespace configuration for the history
+	// kept for its variables.
+	VariablesConfiguration *NamespaceVariablesConfiguration
+
 	// Meta is the set of metadata key/value pairs that attached to the namespace
 	Meta map[string]string
 
@@ -5619,6 +5836,16 @@ func (n *Namespace) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid consul configuration: %v", e))
 	}
 
+	err = n.VariablesConfiguration.Validate()
+	switch e := err.(type) {
+	case *multierror.Error:
+		for _, vErr := range e.Errors {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid variables configuration: %v", vErr))
+		}
+	case error:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid variables configuration: %v", e))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -5678,6 +5905,11 @@ func (n *Namespace) SetHash() []byte {
 		}
 	}
 
+	if n.VariablesConfiguration != nil {
+		_, _ = hash.Write([]byte(strconv.Itoa(n.VariablesConfiguration.HistoryLimit)))
+		_, _ = hash.Write([]byte(n.VariablesConfiguration.HistoryRetention.String()))
+	}
+
 	// sort keys to ensure hash stability when meta is stored later
 	var keys []string
 	for k := range n.Meta {
@@ -5729,6 +5961,11 @@ func (n *Namespace) Copy() *Namespace {
 		nc.Allowed = slices.Clone(n.ConsulConfiguration.Allowed)
 		nc.Denied = slices.Clone(n.ConsulConfiguration.Denied)
 	}
+	if n.VariablesConfiguration != nil {
+		nv := new(NamespaceVariablesConfiguration)
+		*nv = *n.VariablesConfiguration
+		nc.VariablesConfiguration = nv
+	}
 
 	if n.Meta != nil {
 		nc.Meta = make(map[string]string, len(n.Meta))
@@ -6521,7 +6758,7 @@ func NewRestartPolicy(jobType string) *RestartPolicy {
 	case JobTypeService, JobTypeSystem:
 		rp := DefaultServiceJobRestartPolicy
 		return &rp
-	case JobTypeBatch:
+	case JobTypeBatch, JobTypeWorkflow:
 		rp := DefaultBatchJobRestartPolicy
 		return &rp
 	}
@@ -6732,7 +6969,7 @@ func NewReschedulePolicy(jobType string) *ReschedulePolicy {
 	case JobTypeService:
 		rp := DefaultServiceJobReschedulePolicy
 		return &rp
-	case JobTypeBatch:
+	case JobTypeBatch, JobTypeWorkflow:
 		rp := DefaultBatchJobReschedulePolicy
 		return &rp
 	}
@@ -6814,6 +7051,10 @@ type TaskGroup struct {
 	// Migrate is used to control the migration strategy for this task group
 	Migrate *MigrateStrategy
 
+	// DisruptionBudget limits how many allocations of this task group
+	// drains, preemption and restarts can disrupt at once.
+	DisruptionBudget *DisruptionBudget
+
 	// Constraints can be specified at a task group level and apply to
 	// all the tasks contained.
 	Constraints []*Constraint
@@ -6828,6 +7069,18 @@ type TaskGroup struct {
 	// disconnection between them.
 	Disconnect *DisconnectStrategy
 
+	// Gang, if set, places the task group together with the other task
+	// groups of the same gang on an all-or-nothing basis.
+	Gang *Gang
+
+	// After lists the task groups of a workflow job that must complete
+	// before the allocations of this task group are placed.
+	After []string
+
+	// IndexedBatch, if set, runs the task group of a batch job as a fixed
+	// number of indexed completions.
+	IndexedBatch *IndexedBatch
+
 	// Tasks are the collection of tasks that this task group needs to run
 	Tasks []*Task
 
@@ -6850,6 +7103,14 @@ type TaskGroup struct {
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
 
+	// Tolerations allow the task group to be placed on and kept on nodes
+	// with matching taints.
+	Tolerations []*Toleration
+
+	// JobAffinities place the task group relative to the allocations of
+	// other jobs.
+	JobAffinities []*JobAffinity
+
 	// Networks are the network configuration for the task group. This can be
 	// overridden in the task.
 	Networks Networks
@@ -6894,9 +7155,15 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.Constraints = CopySliceConstraints(ntg.Constraints)
 	ntg.RestartPolicy = ntg.RestartPolicy.Copy()
 	ntg.Disconnect = ntg.Disconnect.Copy()
+	ntg.Gang = ntg.Gang.Copy()
+	ntg.After = slices.Clone(ntg.After)
+	ntg.IndexedBatch = ntg.IndexedBatch.Copy()
+	ntg.DisruptionBudget = ntg.DisruptionBudget.Copy()
 	ntg.ReschedulePolicy = ntg.ReschedulePolicy.Copy()
 	ntg.Affinities = CopySliceAffinities(ntg.Affinities)
 	ntg.Spreads = CopySliceSpreads(ntg.Spreads)
+	ntg.Tolerations = CopySliceTolerations(ntg.Tolerations)
+	ntg.JobAffinities = CopySliceJobAffinities(ntg.JobAffinities)
 	ntg.Volumes = CopyMapVolumeRequest(ntg.Volumes)
 	ntg.Scaling = ntg.Scaling.Copy()
 	ntg.Consul = ntg.Consul.Copy()
@@ -6958,6 +7225,28 @@ func (tg *TaskGroup) Canonicalize(job *Job) {
 		tg.Spreads = nil
 	}
 
+	if len(tg.Tolerations) == 0 {
+		tg.Tolerations = nil
+	}
+	for _, t := range tg.Tolerations {
+		if t != nil {
+			t.Canonicalize()
+		}
+	}
+
+	if len(tg.JobAffinities) == 0 {
+		tg.JobAffinities = nil
+	}
+	for _, a := range tg.JobAffinities {
+		if a != nil {
+			a.Canonicalize(job.Namespace)
+		}
+	}
+
+	if len(tg.After) == 0 {
+		tg.After = nil
+	}
+
 	// Set the default restart policy.
 	if tg.RestartPolicy == nil {
 		tg.RestartPolicy = NewRestartPolicy(job.Type)
@@ -6971,6 +7260,14 @@ func (tg *TaskGroup) Canonicalize(job *Job) {
 		tg.Disconnect.Canonicalize()
 	}
 
+	if tg.Gang != nil {
+		tg.Gang.Canonicalize(tg)
+	}
+
+	if tg.IndexedBatch != nil {
+		tg.IndexedBatch.Canonicalize()
+	}
+
 	// Canonicalize Migrate for service jobs
 	if job.Type == JobTypeService && tg.Migrate == nil {
 		tg.Migrate = DefaultMigrateStrategy()
@@ -7054,6 +7351,18 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	if tg.Gang != nil {
+		if err := tg.Gang.Validate(j); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+
+	if tg.IndexedBatch != nil {
+		if err := tg.IndexedBatch.Validate(j, tg); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+
 	for idx, constr := range tg.Constraints {
 		if err := constr.Validate(); err != nil {
 			outer := fmt.Errorf("Constraint %d validation failed: %s", idx+1, err)
@@ -7073,6 +7382,20 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	for idx, toleration := range tg.Tolerations {
+		if err := toleration.Validate(); err != nil {
+			outer := fmt.Errorf("Toleration %d validation failed: %s", idx+1, err)
+			mErr = multierror.Append(mErr, outer)
+		}
+	}
+
+	for idx, affinity := range tg.JobAffinities {
+		if err := affinity.Validate(); err != nil {
+			outer := fmt.Errorf("Job affinity %d validation failed: %s", idx+1, err)
+			mErr = multierror.Append(mErr, outer)
+		}
+	}
+
 	if tg.RestartPolicy != nil {
 		if err := tg.RestartPolicy.Validate(); err != nil {
 			mErr = multierror.Append(mErr, err)
@@ -7142,6 +7465,15 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	if tg.DisruptionBudget != nil {
+		if err := tg.DisruptionBudget.Validate(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Disruption budget validation failed: %v", err))
+		} else if tg.DisruptionBudget.MinHealthy > tg.Count && !(j.IsMultiregion() && tg.Count == 0) {
+			mErr = multierror.Append(mErr, fmt.Errorf("Disruption budget min_healthy is greater than task group count (%d > %d)",
+				tg.DisruptionBudget.MinHealthy, tg.Count))
+		}
+	}
+
 	// Check that there is only one leader task if any
 	tasks := make(map[string]int)
 	leaderTasks := 0
@@ -7535,6 +7867,13 @@ func (tg *TaskGroup) Warnings(j *Job) error {
 		}
 	}
 
+	// Check the disruption budget allows any disruption
+	if b := tg.DisruptionBudget; b != nil && tg.Count > 0 && b.MinHealthy == tg.Count {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("Disruption budget min_healthy is equal to task group count (%d). "+
+				"Node drains, preemption and job restarts will not be able to disrupt any allocation.", tg.Count))
+	}
+
 	if tg.MaxClientDisconnect != nil {
 		mErr.Errors = append(mErr.Errors, errors.New("MaxClientDisconnect is deprecated and ignored in favor of Disconnect.LostAfter"))
 	}
@@ -10110,10 +10449,36 @@ type Spread struct {
 	// SpreadTarget is used to describe desired percentages for each attribute value
 	SpreadTarget []*SpreadTarget
 
+	// MaxSkew turns the spread into a topology spread constraint. When set,
+	// the number of allocations using any value of the attribute may exceed
+	// the number using the least used value by at most MaxSkew.
+	MaxSkew int
+
+	// WhenUnsatisfiable controls what happens when a placement would exceed
+	// MaxSkew. It is one of the SpreadWhenUnsatisfiable* constants.
+	WhenUnsatisfiable string
+
 	// Memoized string representation
 	str string
 }
 
+const (
+	// SpreadWhenUnsatisfiableBlock filters out nodes that would exceed the
+	// maximum skew, so the placement fails if no other node is available.
+	// This is the default.
+	SpreadWhenUnsatisfiableBlock = "block"
+
+	// SpreadWhenUnsatisfiableScheduleAnyway only uses the spread to score
+	// nodes, so placements may exceed the maximum skew.
+	SpreadWhenUnsatisfiableScheduleAnyway = "schedule_anyway"
+)
+
+// HasMaxSkew returns true if the spread must be enforced as a feasibility
+// check instead of only affecting node scores.
+func (s *Spread) HasMaxSkew() bool {
+	return s.MaxSkew > 0 && s.WhenUnsatisfiable != SpreadWhenUnsatisfiableScheduleAnyway
+}
+
 func (s *Spread) Equal(o *Spread) bool {
 	if s == nil || o == nil {
 		return s == o
@@ -10123,6 +10488,10 @@ func (s *Spread) Equal(o *Spread) bool {
 		return false
 	case s.Weight != o.Weight:
 		return false
+	case s.MaxSkew != o.MaxSkew:
+		return false
+	case s.WhenUnsatisfiable != o.WhenUnsatisfiable:
+		return false
 	case !slices.EqualFunc(s.SpreadTarget, o.SpreadTarget, func(a, b *SpreadTarget) bool { return a.Equal(b) }):
 		return false
 	}
@@ -10170,6 +10539,9 @@ func (s *Spread) String() string {
 		return s.str
 	}
 	s.str = fmt.Sprintf("%s %s %v", s.Attribute, s.SpreadTarget, s.Weight)
+	if s.MaxSkew > 0 {
+		s.str += fmt.Sprintf(" max_skew=%d", s.MaxSkew)
+	}
 	return s.str
 }
 
@@ -10200,6 +10572,22 @@ func (s *Spread) Validate() error {
 	if sumPercent > 100 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Sum of spread target percentages must not be greater than 100%%; got %d%%", sumPercent))
 	}
+	if s.MaxSkew < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Spread max_skew must not be negative"))
+	}
+	if s.MaxSkew > 0 && len(s.SpreadTarget) > 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Spread max_skew can not be combined with targets"))
+	}
+	switch s.WhenUnsatisfiable {
+	case "":
+	case SpreadWhenUnsatisfiableBlock, SpreadWhenUnsatisfiableScheduleAnyway:
+		if s.MaxSkew == 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("Spread when_unsatisfiable requires max_skew"))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Spread when_unsatisfiable must be %q or %q; got %q",
+			SpreadWhenUnsatisfiableBlock, SpreadWhenUnsatisfiableScheduleAnyway, s.WhenUnsatisfiable))
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -10626,6 +11014,10 @@ type NodeScoreMeta struct {
 	NodeID    string
 	Scores    map[string]float64
 	NormScore float64
+
+	// Labels holds explanations of the scores given by scoring plugins,
+	// keyed by scorer name.
+	Labels map[string]string
 }
 
 func (s *NodeScoreMeta) Copy() *NodeScoreMeta {
@@ -10634,6 +11026,7 @@ func (s *NodeScoreMeta) Copy() *NodeScoreMeta {
 	}
 	ns := new(NodeScoreMeta)
 	*ns = *s
+	ns.Labels = maps.Clone(s.Labels)
 	return ns
 }
 
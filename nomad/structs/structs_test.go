@@ -6341,6 +6341,59 @@ func TestSpread_Validate(t *testing.T) {
 			err:  nil,
 			name: "Valid spread",
 		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   -1,
+			},
+			err:  fmt.Errorf("Spread max_skew must not be negative"),
+			name: "Negative max_skew",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   1,
+				SpreadTarget: []*SpreadTarget{
+					{
+						Value:   "dc1",
+						Percent: 25,
+					},
+				},
+			},
+			err:  fmt.Errorf("Spread max_skew can not be combined with targets"),
+			name: "max_skew with targets",
+		},
+		{
+			spread: &Spread{
+				Attribute:         "${node.datacenter}",
+				Weight:            50,
+				WhenUnsatisfiable: SpreadWhenUnsatisfiableBlock,
+			},
+			err:  fmt.Errorf("Spread when_unsatisfiable requires max_skew"),
+			name: "when_unsatisfiable without max_skew",
+		},
+		{
+			spread: &Spread{
+				Attribute:         "${node.datacenter}",
+				Weight:            50,
+				MaxSkew:           1,
+				WhenUnsatisfiable: "sometimes",
+			},
+			err:  fmt.Errorf("Spread when_unsatisfiable must be \"block\" or \"schedule_anyway\""),
+			name: "Invalid when_unsatisfiable",
+		},
+		{
+			spread: &Spread{
+				Attribute:         "${node.datacenter}",
+				Weight:            50,
+				MaxSkew:           1,
+				WhenUnsatisfiable: SpreadWhenUnsatisfiableBlock,
+			},
+			err:  nil,
+			name: "Valid max_skew spread",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -7458,3 +7511,17 @@ func TestTaskIdentity_Canonicalize(t *testing.T) {
 	must.True(t, task.Identities[1].Env)
 	must.False(t, task.Identities[1].File)
 }
+
+func TestNodeScoreMeta_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	meta := &NodeScoreMeta{
+		NodeID: "node",
+		Labels: map[string]string{"plugin": "rack a"},
+	}
+	c := meta.Copy()
+	must.Eq(t, meta, c)
+
+	c.Labels["plugin"] = "rack b"
+	must.Eq(t, "rack a", meta.Labels["plugin"])
+}
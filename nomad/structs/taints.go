@@ -0,0 +1,293 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// TaintEffectNoSchedule prevents allocations that don't tolerate the
+	// taint from being placed on the node.
+	TaintEffectNoSchedule = "NoSchedule"
+
+	// TaintEffectPreferNoSchedule makes the scheduler avoid the node for
+	// allocations that don't tolerate the taint, without excluding it.
+	TaintEffectPreferNoSchedule = "PreferNoSchedule"
+
+	// TaintEffectNoExecute prevents allocations that don't tolerate the taint
+	// from being placed on the node, and migrates the allocations already
+	// running on it that don't tolerate it.
+	TaintEffectNoExecute = "NoExecute"
+)
+
+const (
+	// TolerationOperatorEqual tolerates taints with the same key and value.
+	TolerationOperatorEqual = "Equal"
+
+	// TolerationOperatorExists tolerates taints with the same key, whatever
+	// their value.
+	TolerationOperatorExists = "Exists"
+)
+
+// NodeTaint marks a node so that only the allocations that tolerate it are
+// placed or kept on the node.
+type NodeTaint struct {
+	Key    string `hcl:"key"`
+	Value  string `hcl:"value"`
+	Effect string `hcl:"effect"`
+}
+
+func (t *NodeTaint) Copy() *NodeTaint {
+	if t == nil {
+		return nil
+	}
+	nt := *t
+	return &nt
+}
+
+func (t *NodeTaint) Equal(o *NodeTaint) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	return *t == *o
+}
+
+// String returns the taint in the key=value:Effect form used by the CLI.
+func (t *NodeTaint) String() string {
+	if t.Value == "" {
+		return fmt.Sprintf("%s:%s", t.Key, t.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+}
+
+func (t *NodeTaint) Validate() error {
+	var mErr multierror.Error
+	if t.Key == "" {
+		_ = multierror.Append(&mErr, errors.New("Taint key must be set"))
+	} else if strings.ContainsAny(t.Key, "=:") {
+		_ = multierror.Append(&mErr, fmt.Errorf("Taint key %q must not contain '=' or ':'", t.Key))
+	}
+	if strings.Contains(t.Value, ":") {
+		_ = multierror.Append(&mErr, fmt.Errorf("Taint value %q must not contain ':'", t.Value))
+	}
+	switch t.Effect {
+	case TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid taint effect %q", t.Effect))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ParseNodeTaint parses a taint in the key=value:Effect or key:Effect form.
+func ParseNodeTaint(s string) (*NodeTaint, error) {
+	keyValue, effect, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, fmt.Errorf("taint %q must be in the key=value:Effect form", s)
+	}
+	key, value, _ := strings.Cut(keyValue, "=")
+	taint := &NodeTaint{Key: key, Value: value, Effect: effect}
+	if err := taint.Validate(); err != nil {
+		return nil, err
+	}
+	return taint, nil
+}
+
+// CopySliceNodeTaints returns a deep copy of the given taints.
+func CopySliceNodeTaints(s []*NodeTaint) []*NodeTaint {
+	if s == nil {
+		return nil
+	}
+	c := make([]*NodeTaint, len(s))
+	for i, t := range s {
+		c[i] = t.Copy()
+	}
+	return c
+}
+
+// NodeTaintsEqual returns whether both sets of taints are equal, regardless
+// of their order.
+func NodeTaintsEqual(a, b []*NodeTaint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, t := range a {
+		if !slices.ContainsFunc(b, t.Equal) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateNodeTaints validates the taints and ensures a node doesn't have the
+// same taint twice.
+func ValidateNodeTaints(taints []*NodeTaint) error {
+	var mErr multierror.Error
+	for i, t := range taints {
+		if err := t.Validate(); err != nil {
+			_ = multierror.Append(&mErr, err)
+			continue
+		}
+		if slices.ContainsFunc(taints[:i], func(o *NodeTaint) bool {
+			return o.Key == t.Key && o.Effect == t.Effect
+		}) {
+			_ = multierror.Append(&mErr, fmt.Errorf("Duplicate taint %q with effect %q", t.Key, t.Effect))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Toleration allows allocations to be placed or kept on nodes with matching
+// taints.
+type Toleration struct {
+	// Key is the key of the taints tolerated. An empty key with the Exists
+	// operator tolerates every taint.
+	Key string
+
+	// Operator is either Equal, to tolerate taints with the given value, or
+	// Exists, to tolerate taints with any value.
+	Operator string
+
+	// Value is the value of the taints tolerated with the Equal operator.
+	Value string
+
+	// Effect is the effect of the taints tolerated. An empty effect
+	// tolerates every effect.
+	Effect string
+}
+
+func (t *Toleration) Copy() *Toleration {
+	if t == nil {
+		return nil
+	}
+	nt := *t
+	return &nt
+}
+
+func (t *Toleration) Equal(o *Toleration) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	return *t == *o
+}
+
+func (t *Toleration) String() string {
+	s := t.Key
+	if t.Operator == TolerationOperatorEqual {
+		s = fmt.Sprintf("%s=%s", t.Key, t.Value)
+	}
+	if t.Effect != "" {
+		s = fmt.Sprintf("%s:%s", s, t.Effect)
+	}
+	return s
+}
+
+func (t *Toleration) Canonicalize() {
+	if t.Operator == "" {
+		t.Operator = TolerationOperatorEqual
+	}
+}
+
+func (t *Toleration) Validate() error {
+	var mErr multierror.Error
+	switch t.Operator {
+	case TolerationOperatorEqual:
+		if t.Key == "" {
+			_ = multierror.Append(&mErr, fmt.Errorf("Toleration key must be set with the %q operator", t.Operator))
+		}
+	case TolerationOperatorExists:
+		if t.Value != "" {
+			_ = multierror.Append(&mErr, fmt.Errorf("Toleration value must not be set with the %q operator", t.Operator))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid toleration operator %q", t.Operator))
+	}
+	switch t.Effect {
+	case "", TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid toleration effect %q", t.Effect))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Tolerates returns whether the toleration matches the taint.
+func (t *Toleration) Tolerates(taint *NodeTaint) bool {
+	if t.Effect != "" && t.Effect != taint.Effect {
+		return false
+	}
+	switch t.Operator {
+	case TolerationOperatorExists:
+		return t.Key == "" || t.Key == taint.Key
+	default:
+		return t.Key == taint.Key && t.Value == taint.Value
+	}
+}
+
+// CopySliceTolerations returns a deep copy of the given tolerations.
+func CopySliceTolerations(s []*Toleration) []*Toleration {
+	if s == nil {
+		return nil
+	}
+	c := make([]*Toleration, len(s))
+	for i, t := range s {
+		c[i] = t.Copy()
+	}
+	return c
+}
+
+// UntoleratedTaints returns the taints of the node with one of the given
+// effects that none of the tolerations match.
+func UntoleratedTaints(taints []*NodeTaint, tolerations []*Toleration, effects ...string) []*NodeTaint {
+	var untolerated []*NodeTaint
+	for _, taint := range taints {
+		if !slices.Contains(effects, taint.Effect) {
+			continue
+		}
+		if !slices.ContainsFunc(tolerations, func(t *Toleration) bool { return t.Tolerates(taint) }) {
+			untolerated = append(untolerated, taint)
+		}
+	}
+	return untolerated
+}
+
+// HasNoExecuteTaints returns whether the node has taints that evict the
+// allocations that don't tolerate them.
+func (n *Node) HasNoExecuteTaints() bool {
+	return slices.ContainsFunc(n.Taints, func(t *NodeTaint) bool {
+		return t.Effect == TaintEffectNoExecute
+	})
+}
+
+// TaskGroupTolerations returns the tolerations of the task group, including
+// the ones set on the job.
+func (j *Job) TaskGroupTolerations(tg *TaskGroup) []*Toleration {
+	if j == nil {
+		if tg == nil {
+			return nil
+		}
+		return tg.Tolerations
+	}
+	if tg == nil || len(tg.Tolerations) == 0 {
+		return j.Tolerations
+	}
+	if len(j.Tolerations) == 0 {
+		return tg.Tolerations
+	}
+	return append(slices.Clone(j.Tolerations), tg.Tolerations...)
+}
+
+// ToleratesNoExecuteTaints returns whether the allocation can stay on a node
+// with the given taints.
+func (a *Allocation) ToleratesNoExecuteTaints(taints []*NodeTaint) bool {
+	if len(taints) == 0 || a.Job == nil {
+		return true
+	}
+	tg := a.Job.LookupTaskGroup(a.TaskGroup)
+	return len(UntoleratedTaints(taints, a.Job.TaskGroupTolerations(tg), TaintEffectNoExecute)) == 0
+}
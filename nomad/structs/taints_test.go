@@ -0,0 +1,174 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestParseNodeTaint(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		input string
+		exp   *NodeTaint
+		err   string
+	}{
+		{
+			input: "dedicated=gpu:NoSchedule",
+			exp:   &NodeTaint{Key: "dedicated", Value: "gpu", Effect: TaintEffectNoSchedule},
+		},
+		{
+			input: "maintenance:NoExecute",
+			exp:   &NodeTaint{Key: "maintenance", Effect: TaintEffectNoExecute},
+		},
+		{
+			input: "dedicated=gpu",
+			err:   "must be in the key=value:Effect form",
+		},
+		{
+			input: "=gpu:NoSchedule",
+			err:   "Taint key must be set",
+		},
+		{
+			input: "dedicated=gpu:Evict",
+			err:   `Invalid taint effect "Evict"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			taint, err := ParseNodeTaint(tc.input)
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, taint)
+			must.Eq(t, tc.input, taint.String())
+		})
+	}
+}
+
+func TestValidateNodeTaints(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, ValidateNodeTaints([]*NodeTaint{
+		{Key: "dedicated", Value: "gpu", Effect: TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "gpu", Effect: TaintEffectNoExecute},
+	}))
+
+	err := ValidateNodeTaints([]*NodeTaint{
+		{Key: "dedicated", Value: "gpu", Effect: TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "db", Effect: TaintEffectNoSchedule},
+	})
+	must.ErrorContains(t, err, `Duplicate taint "dedicated" with effect "NoSchedule"`)
+}
+
+func TestToleration_Tolerates(t *testing.T) {
+	ci.Parallel(t)
+
+	taint := &NodeTaint{Key: "dedicated", Value: "gpu", Effect: TaintEffectNoSchedule}
+
+	cases := []struct {
+		name       string
+		toleration *Toleration
+		exp        bool
+	}{
+		{
+			name:       "equal",
+			toleration: &Toleration{Key: "dedicated", Operator: TolerationOperatorEqual, Value: "gpu"},
+			exp:        true,
+		},
+		{
+			name:       "equal other value",
+			toleration: &Toleration{Key: "dedicated", Operator: TolerationOperatorEqual, Value: "db"},
+		},
+		{
+			name:       "exists",
+			toleration: &Toleration{Key: "dedicated", Operator: TolerationOperatorExists},
+			exp:        true,
+		},
+		{
+			name:       "exists other key",
+			toleration: &Toleration{Key: "maintenance", Operator: TolerationOperatorExists},
+		},
+		{
+			name:       "exists any key",
+			toleration: &Toleration{Operator: TolerationOperatorExists},
+			exp:        true,
+		},
+		{
+			name: "same effect",
+			toleration: &Toleration{Key: "dedicated", Operator: TolerationOperatorExists,
+				Effect: TaintEffectNoSchedule},
+			exp: true,
+		},
+		{
+			name: "other effect",
+			toleration: &Toleration{Key: "dedicated", Operator: TolerationOperatorExists,
+				Effect: TaintEffectNoExecute},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.NoError(t, tc.toleration.Validate())
+			must.Eq(t, tc.exp, tc.toleration.Tolerates(taint))
+		})
+	}
+}
+
+func TestToleration_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	toleration := &Toleration{Key: "dedicated", Value: "gpu"}
+	toleration.Canonicalize()
+	must.Eq(t, TolerationOperatorEqual, toleration.Operator)
+	must.NoError(t, toleration.Validate())
+
+	err := (&Toleration{Operator: TolerationOperatorEqual}).Validate()
+	must.ErrorContains(t, err, "Toleration key must be set")
+
+	err = (&Toleration{Key: "dedicated", Operator: TolerationOperatorExists, Value: "gpu"}).Validate()
+	must.ErrorContains(t, err, "Toleration value must not be set")
+
+	err = (&Toleration{Key: "dedicated", Operator: "In", Effect: "Evict"}).Validate()
+	must.ErrorContains(t, err, `Invalid toleration operator "In"`)
+	must.ErrorContains(t, err, `Invalid toleration effect "Evict"`)
+}
+
+func TestAllocation_ToleratesNoExecuteTaints(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Tolerations = []*Toleration{
+		{Key: "maintenance", Operator: TolerationOperatorExists, Effect: TaintEffectNoExecute},
+	}
+	tg := job.TaskGroups[0]
+	tg.Tolerations = []*Toleration{
+		{Key: "dedicated", Operator: TolerationOperatorEqual, Value: "gpu"},
+	}
+	alloc := &Allocation{Job: job, TaskGroup: tg.Name}
+
+	must.Len(t, 2, job.TaskGroupTolerations(tg))
+
+	must.True(t, alloc.ToleratesNoExecuteTaints(nil))
+	must.True(t, alloc.ToleratesNoExecuteTaints([]*NodeTaint{
+		{Key: "maintenance", Effect: TaintEffectNoExecute},
+		{Key: "dedicated", Value: "gpu", Effect: TaintEffectNoExecute},
+		{Key: "other", Effect: TaintEffectNoSchedule},
+	}))
+	must.False(t, alloc.ToleratesNoExecuteTaints([]*NodeTaint{
+		{Key: "dedicated", Value: "db", Effect: TaintEffectNoExecute},
+	}))
+
+	node := &Node{Taints: []*NodeTaint{{Key: "other", Effect: TaintEffectNoSchedule}}}
+	must.False(t, node.HasNoExecuteTaints())
+	node.Taints = append(node.Taints, &NodeTaint{Key: "maintenance", Effect: TaintEffectNoExecute})
+	must.True(t, node.HasNoExecuteTaints())
+}
@@ -46,6 +46,28 @@ const (
 	// Reply: VariablesRenewLockResponse
 	VariablesRenewLockRPCMethod = "Variables.RenewLock"
 
+	// VariablesHistoryRPCMethod is the RPC method for listing the current
+	// and prior versions of a variable according to its namespace and path.
+	//
+	// Args: VariablesHistoryRequest
+	// Reply: VariablesHistoryResponse
+	VariablesHistoryRPCMethod = "Variables.History"
+
+	// VariablesPurgeHistoryRPCMethod is the RPC method used by the core
+	// scheduler to delete prior versions of variables that fall outside of
+	// their namespace's retention.
+	//
+	// Args: VariablesPurgeHistoryRequest
+	// Reply: GenericResponse
+	VariablesPurgeHistoryRPCMethod = "Variables.PurgeHistory"
+
+	// VariablesExpireRPCMethod is the RPC method used by the core scheduler to
+	// delete variables that have reached their expire time.
+	//
+	// Args: VariablesExpireRequest
+	// Reply: GenericResponse
+	VariablesExpireRPCMethod = "Variables.Expire"
+
 	// maxVariableSize is the maximum size of the unencrypted contents of a
 	// variable. This size is deliberately set low and is not configurable, to
 	// discourage DoS'ing the cluster
@@ -67,13 +89,14 @@ const (
 )
 
 var (
-	errNoPath             = errors.New("missing path")
-	errNoNamespace        = errors.New("missing namespace")
-	errNoLock             = errors.New("missing lock ID")
-	errWildCardNamespace  = errors.New("can not target wildcard (\"*\")namespace")
-	errQuotaExhausted     = errors.New("variables are limited to 64KiB in total size")
-	errNegativeDelayOrTTL = errors.New("Lock delay and TTL must be positive")
-	errInvalidTTL         = errors.New("TTL must be between 10 seconds and 24 hours")
+	errNoPath              = errors.New("missing path")
+	errNoNamespace         = errors.New("missing namespace")
+	errNoLock              = errors.New("missing lock ID")
+	errWildCardNamespace   = errors.New("can not target wildcard (\"*\")namespace")
+	errQuotaExhausted      = errors.New("variables are limited to 64KiB in total size")
+	errNegativeDelayOrTTL  = errors.New("Lock delay and TTL must be positive")
+	errInvalidTTL          = errors.New("TTL must be between 10 seconds and 24 hours")
+	errNegativeVariableTTL = errors.New("variable TTL must not be negative")
 )
 
 // VariableMetadata is the metadata envelope for a Variable, it is the list
@@ -86,6 +109,14 @@ type VariableMetadata struct {
 	// Lock represents a variable which is used for locking functionality.
 	Lock *VariableLock `json:",omitempty"`
 
+	// TTL is an optional duration after which the variable expires and is
+	// deleted. It sets the ExpireTime each time the variable is written.
+	TTL time.Duration `json:",omitempty"`
+
+	// ExpireTime is the unix nano of the time at which the variable expires.
+	// It is set by the server and is zero for variables that never expire.
+	ExpireTime int64 `json:",omitempty"`
+
 	CreateIndex uint64
 	CreateTime  int64
 	ModifyIndex uint64
@@ -303,6 +334,12 @@ func (sv VariableMetadata) Equal(vm2 VariableMetadata) bool {
 	if sv.ModifyTime != vm2.ModifyTime {
 		return false
 	}
+	if sv.TTL != vm2.TTL {
+		return false
+	}
+	if sv.ExpireTime != vm2.ExpireTime {
+		return false
+	}
 	return sv.Lock.Equal(vm2.Lock)
 }
 
@@ -384,6 +421,10 @@ func (vd VariableDecrypted) Validate() error {
 		return err
 	}
 
+	if vd.TTL < 0 {
+		return errNegativeVariableTTL
+	}
+
 	if vd.Lock != nil {
 		return vd.Lock.Validate()
 	}
@@ -408,6 +449,10 @@ func (vd VariableDecrypted) ValidateForLock() error {
 		return err
 	}
 
+	if vd.TTL < 0 {
+		return errNegativeVariableTTL
+	}
+
 	return vd.Lock.Validate()
 }
 
@@ -456,6 +501,21 @@ func (vd *VariableDecrypted) Canonicalize() {
 	}
 }
 
+// SetExpireTime sets the ExpireTime of a variable that is written at the given
+// unix nano time, according to its TTL.
+func (vm *VariableMetadata) SetExpireTime(now int64) {
+	vm.ExpireTime = 0
+	if vm.TTL > 0 {
+		vm.ExpireTime = now + vm.TTL.Nanoseconds()
+	}
+}
+
+// IsExpired returns whether the variable has an expire time that is at or
+// before the given time.
+func (vm *VariableMetadata) IsExpired(now time.Time) bool {
+	return vm.ExpireTime != 0 && vm.ExpireTime <= now.UnixNano()
+}
+
 // Copy returns a fully hydrated copy of VariableMetadata that can be
 // manipulated while ensuring the original is not touched.
 func (sv *VariableMetadata) Copy() *VariableMetadata {
@@ -522,6 +582,77 @@ func (svq *VariablesQuota) Copy() *VariablesQuota {
 	return nq
 }
 
+// VariableVersion is a prior version of a variable. It is written to the
+// variable's history when the variable is overwritten or deleted, and is
+// identified by the ModifyIndex the variable had while this version was
+// current. The lock is never kept in the history.
+type VariableVersion struct {
+	VariableEncrypted
+
+	// ArchiveIndex and ArchiveTime track when this version was replaced.
+	ArchiveIndex uint64
+	ArchiveTime  int64
+}
+
+// NewVariableVersion creates the history entry for a variable that is being
+// replaced at the given index and time.
+func NewVariableVersion(sv *VariableEncrypted, idx uint64, now int64) *VariableVersion {
+	version := &VariableVersion{
+		VariableEncrypted: sv.Copy(),
+		ArchiveIndex:      idx,
+		ArchiveTime:       now,
+	}
+	version.Lock = nil
+	return version
+}
+
+// Version returns the version number, which is the ModifyIndex of the
+// variable while this version was current.
+func (vv *VariableVersion) Version() uint64 {
+	return vv.ModifyIndex
+}
+
+// Stub returns the metadata of the version, as used by history listings.
+func (vv *VariableVersion) Stub() *VariableVersionMetadata {
+	return &VariableVersionMetadata{
+		VariableMetadata: vv.VariableMetadata,
+		ArchiveIndex:     vv.ArchiveIndex,
+		ArchiveTime:      vv.ArchiveTime,
+	}
+}
+
+// Copy returns a deep copy of the version.
+func (vv *VariableVersion) Copy() *VariableVersion {
+	if vv == nil {
+		return nil
+	}
+	return &VariableVersion{
+		VariableEncrypted: vv.VariableEncrypted.Copy(),
+		ArchiveIndex:      vv.ArchiveIndex,
+		ArchiveTime:       vv.ArchiveTime,
+	}
+}
+
+// VariableVersionMetadata is the list object for a version of a variable. The
+// current version of a variable has a zero ArchiveIndex.
+type VariableVersionMetadata struct {
+	VariableMetadata
+	ArchiveIndex uint64
+	ArchiveTime  int64
+}
+
+// IsCurrent returns whether this is the current version of the variable.
+func (vm *VariableVersionMetadata) IsCurrent() bool {
+	return vm.ArchiveIndex == 0
+}
+
+// VariableVersionID identifies a single prior version of a variable.
+type VariableVersionID struct {
+	Namespace string
+	Path      string
+	Version   uint64
+}
+
 // ---------------------------------------
 // RPC and FSM request/response objects
 
@@ -541,6 +672,12 @@ const (
 	// VarOpLockRelease is the variable operation used when attempting to
 	// release a held variable lock.
 	VarOpLockRelease VarOp = "lock-release"
+
+	// VarOpRekey is the variable operation used by the core scheduler to
+	// re-encrypt a variable, or one of its prior versions, with the active
+	// root key. The version to update is selected by the ModifyIndex of the
+	// request and no new version is added to the variable's history.
+	VarOpRekey VarOp = "rekey"
 )
 
 // VarOpResult constants give possible operations results from a transaction.
@@ -662,6 +799,11 @@ type VariablesListResponse struct {
 
 type VariablesReadRequest struct {
 	Path string
+
+	// Version is an optional version of the variable to read, as returned by
+	// the Variables.History RPC. If zero, the current version is read.
+	Version uint64
+
 	QueryOptions
 }
 
@@ -670,6 +812,34 @@ type VariablesReadResponse struct {
 	QueryMeta
 }
 
+// VariablesHistoryRequest is used to list the versions of a variable.
+type VariablesHistoryRequest struct {
+	Path string
+	QueryOptions
+}
+
+// VariablesHistoryResponse lists the versions of a variable, newest first.
+// The current version, if the variable exists, is always the first one.
+type VariablesHistoryResponse struct {
+	Versions []*VariableVersionMetadata
+	QueryMeta
+}
+
+// VariablesPurgeHistoryRequest is used by the core scheduler to delete prior
+// versions of variables.
+type VariablesPurgeHistoryRequest struct {
+	Versions []*VariableVersionID
+	WriteRequest
+}
+
+// VariablesExpireRequest is used to delete expired variables. Each variable
+// is identified by the version it had when it was found expired, so that
+// variables written since then are kept.
+type VariablesExpireRequest struct {
+	Variables []*VariableVersionID
+	WriteRequest
+}
+
 // VariablesRenewLockRequest is used to renew the lease on a lock. This request
 // behaves like a write because the renewal needs to be forwarded to the leader
 // where the timers and lock work is kept.
@@ -127,6 +127,28 @@ func TestVariableMetadata_Equal(t *testing.T) {
 			},
 			expectedOutput: false,
 		},
+		{
+			name: "expire time unequal",
+			inputMetadata: VariableMetadata{
+				Namespace:   "default",
+				Path:        "custom/test/path",
+				TTL:         time.Hour,
+				ExpireTime:  1687251876 + int64(time.Hour),
+				CreateIndex: 10,
+				CreateTime:  1687251815,
+				ModifyIndex: 100,
+				ModifyTime:  1687251876,
+			},
+			inputMetadataFn: VariableMetadata{
+				Namespace:   "default",
+				Path:        "custom/test/path",
+				CreateIndex: 10,
+				CreateTime:  1687251815,
+				ModifyIndex: 100,
+				ModifyTime:  1687251876,
+			},
+			expectedOutput: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -136,6 +158,24 @@ func TestVariableMetadata_Equal(t *testing.T) {
 	}
 }
 
+func TestVariableMetadata_Expiry(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+
+	vm := VariableMetadata{TTL: time.Minute}
+	vm.SetExpireTime(now.UnixNano())
+	must.Eq(t, now.Add(time.Minute).UnixNano(), vm.ExpireTime)
+	must.False(t, vm.IsExpired(now))
+	must.True(t, vm.IsExpired(now.Add(time.Minute)))
+
+	// Writing the variable without a TTL removes its expiry.
+	vm.TTL = 0
+	vm.SetExpireTime(now.UnixNano())
+	must.Zero(t, vm.ExpireTime)
+	must.False(t, vm.IsExpired(now.Add(time.Hour)))
+}
+
 func TestVariableMetadata_Copy(t *testing.T) {
 	ci.Parallel(t)
 
@@ -318,6 +358,10 @@ func TestStructs_VariableDecrypted_Validate(t *testing.T) {
 			must.Error(t, err, must.Sprintf("should get error for: %s", tc.path))
 		}
 	}
+
+	sv.Path = "a/b/c"
+	sv.TTL = -time.Second
+	must.ErrorIs(t, sv.Validate(), errNegativeVariableTTL)
 }
 
 func TestStructs_VariablesRenewLockRequest_Validate(t *testing.T) {
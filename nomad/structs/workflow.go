@@ -0,0 +1,238 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// WorkflowStepPending means the step waits for its upstream steps to
+	// complete before its allocations are placed.
+	WorkflowStepPending = "pending"
+
+	// WorkflowStepRunning means the allocations of the step are placed and
+	// not all of them have completed.
+	WorkflowStepRunning = "running"
+
+	// WorkflowStepComplete means every allocation of the step completed
+	// successfully.
+	WorkflowStepComplete = "complete"
+
+	// WorkflowStepFailed means an allocation of the step failed or was lost
+	// and wasn't replaced.
+	WorkflowStepFailed = "failed"
+)
+
+// IsWorkflow returns whether the job is a workflow, whose task groups are the
+// steps of a DAG.
+func (j *Job) IsWorkflow() bool {
+	return j != nil && j.Type == JobTypeWorkflow
+}
+
+// HasWorkflowDownstream returns whether a step of the workflow job runs after
+// the given task group.
+func (j *Job) HasWorkflowDownstream(group string) bool {
+	if !j.IsWorkflow() {
+		return false
+	}
+	for _, tg := range j.TaskGroups {
+		if slices.Contains(tg.After, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkflowStep is the status of a step of a workflow job.
+type WorkflowStep struct {
+	// Name is the name of the task group of the step.
+	Name string
+
+	// After is the list of steps that must complete before the step runs.
+	After []string
+
+	// Count is the number of allocations the step must complete.
+	Count int
+
+	// Complete is the number of allocations of the step that completed
+	// successfully.
+	Complete int
+
+	// Status is one of the WorkflowStep* statuses.
+	Status string
+}
+
+// WorkflowSteps returns the status of each step of the workflow job, in the
+// order of its task groups, computed from the allocations of the current
+// version of the job.
+func (j *Job) WorkflowSteps(allocs []*Allocation) []*WorkflowStep {
+	type counts struct{ total, complete, failed, running int }
+	byGroup := make(map[string]*counts, len(j.TaskGroups))
+	for _, tg := range j.TaskGroups {
+		byGroup[tg.Name] = &counts{}
+	}
+
+	for _, alloc := range allocs {
+		if alloc.NextAllocation != "" || alloc.Job == nil ||
+			alloc.Job.Version != j.Version || alloc.Job.CreateIndex != j.CreateIndex {
+			continue
+		}
+		c, ok := byGroup[alloc.TaskGroup]
+		if !ok {
+			continue
+		}
+		c.total++
+		switch alloc.ClientStatus {
+		case AllocClientStatusComplete:
+			c.complete++
+		case AllocClientStatusFailed, AllocClientStatusLost:
+			c.failed++
+		default:
+			if !alloc.TerminalStatus() {
+				c.running++
+			}
+		}
+	}
+
+	steps := make([]*WorkflowStep, 0, len(j.TaskGroups))
+	for _, tg := range j.TaskGroups {
+		c := byGroup[tg.Name]
+		step := &WorkflowStep{
+			Name:     tg.Name,
+			After:    slices.Clone(tg.After),
+			Count:    tg.Count,
+			Complete: c.complete,
+		}
+		switch {
+		case c.complete >= tg.Count:
+			step.Status = WorkflowStepComplete
+		case c.failed > 0 && c.running == 0:
+			step.Status = WorkflowStepFailed
+		case c.total > 0:
+			step.Status = WorkflowStepRunning
+		default:
+			step.Status = WorkflowStepPending
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// WorkflowStepStatuses returns the status of each step of the workflow job,
+// keyed by task group name, computed from the allocations of the current
+// version of the job.
+func (j *Job) WorkflowStepStatuses(allocs []*Allocation) map[string]string {
+	steps := j.WorkflowSteps(allocs)
+	statuses := make(map[string]string, len(steps))
+	for _, step := range steps {
+		statuses[step.Name] = step.Status
+	}
+	return statuses
+}
+
+// WorkflowStepReady returns whether every upstream step of the task group is
+// complete, given the statuses returned by WorkflowStepStatuses.
+func WorkflowStepReady(tg *TaskGroup, statuses map[string]string) bool {
+	for _, upstream := range tg.After {
+		if statuses[upstream] != WorkflowStepComplete {
+			return false
+		}
+	}
+	return true
+}
+
+// validateWorkflow checks that the task groups of a workflow job form a DAG,
+// and that only workflow jobs order their task groups.
+func (j *Job) validateWorkflow() error {
+	var mErr multierror.Error
+
+	if !j.IsWorkflow() {
+		for _, tg := range j.TaskGroups {
+			if len(tg.After) != 0 {
+				_ = multierror.Append(&mErr, fmt.Errorf(
+					"Task group %q: after can only be used with %q scheduler", tg.Name, JobTypeWorkflow))
+			}
+		}
+		return mErr.ErrorOrNil()
+	}
+
+	groups := make(map[string]*TaskGroup, len(j.TaskGroups))
+	for _, tg := range j.TaskGroups {
+		groups[tg.Name] = tg
+	}
+	for _, tg := range j.TaskGroups {
+		for i, upstream := range tg.After {
+			switch {
+			case upstream == tg.Name:
+				_ = multierror.Append(&mErr, fmt.Errorf("Task group %q cannot run after itself", tg.Name))
+			case groups[upstream] == nil:
+				_ = multierror.Append(&mErr, fmt.Errorf(
+					"Task group %q runs after unknown task group %q", tg.Name, upstream))
+			case slices.Contains(tg.After[:i], upstream):
+				_ = multierror.Append(&mErr, fmt.Errorf(
+					"Task group %q runs after task group %q more than once", tg.Name, upstream))
+			}
+		}
+	}
+	if mErr.ErrorOrNil() != nil {
+		return mErr.ErrorOrNil()
+	}
+
+	// Detect cycles with a depth first search, keeping the current path to
+	// report the cycle found.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(groups))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			start := slices.Index(path, name)
+			return fmt.Errorf("Workflow task groups form a cycle: %s",
+				strings.Join(append(slices.Clone(path[start:]), name), " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, upstream := range groups[name].After {
+			if err := visit(upstream); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for _, tg := range j.TaskGroups {
+		if err := visit(tg.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JobWorkflowStepsRequest is used to get the status of the steps of a
+// workflow job.
+type JobWorkflowStepsRequest struct {
+	JobID string
+	QueryOptions
+}
+
+// JobWorkflowStepsResponse is used to return the status of the steps of a
+// workflow job.
+type JobWorkflowStepsResponse struct {
+	// Steps is nil if the job doesn't exist.
+	Steps []*WorkflowStep
+	QueryMeta
+}
@@ -0,0 +1,136 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// testWorkflowJob returns a workflow job whose task groups run after the
+// given task groups, keyed by name.
+func testWorkflowJob(steps map[string][]string) *Job {
+	job := testJob()
+	job.Type = JobTypeWorkflow
+	job.Update = UpdateStrategy{}
+	tmpl := job.TaskGroups[0]
+	tmpl.Update = nil
+	tmpl.Migrate = nil
+	tmpl.Count = 1
+	job.TaskGroups = nil
+	for _, name := range []string{"extract", "transform", "load", "report"} {
+		after, ok := steps[name]
+		if !ok {
+			continue
+		}
+		tg := tmpl.Copy()
+		tg.Name = name
+		tg.After = after
+		job.TaskGroups = append(job.TaskGroups, tg)
+	}
+	return job
+}
+
+func TestJob_Validate_Workflow(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name  string
+		steps map[string][]string
+		err   string
+	}{
+		{
+			name: "valid",
+			steps: map[string][]string{
+				"extract":   nil,
+				"transform": {"extract"},
+				"load":      {"extract", "transform"},
+			},
+		},
+		{
+			name:  "unknown",
+			steps: map[string][]string{"extract": nil, "load": {"transform"}},
+			err:   `Task group "load" runs after unknown task group "transform"`,
+		},
+		{
+			name:  "itself",
+			steps: map[string][]string{"extract": {"extract"}},
+			err:   `Task group "extract" cannot run after itself`,
+		},
+		{
+			name:  "duplicate",
+			steps: map[string][]string{"extract": nil, "load": {"extract", "extract"}},
+			err:   `Task group "load" runs after task group "extract" more than once`,
+		},
+		{
+			name: "cycle",
+			steps: map[string][]string{
+				"extract":   {"load"},
+				"transform": {"extract"},
+				"load":      {"transform"},
+			},
+			err: "Workflow task groups form a cycle: extract -> load -> transform -> extract",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := testWorkflowJob(tc.steps).Validate()
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+
+	job := testJob()
+	job.Type = JobTypeBatch
+	job.TaskGroups[0].After = []string{"other"}
+	must.ErrorContains(t, job.Validate(), "after can only be used with")
+}
+
+func TestJob_WorkflowStepStatuses(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testWorkflowJob(map[string][]string{
+		"extract":   nil,
+		"transform": {"extract"},
+		"load":      {"transform"},
+		"report":    {"extract"},
+	})
+	job.TaskGroups[0].Count = 2
+
+	alloc := func(group, status, next string) *Allocation {
+		return &Allocation{Job: job, TaskGroup: group, ClientStatus: status, NextAllocation: next}
+	}
+	older := job.Copy()
+	older.Version = job.Version + 1
+
+	allocs := []*Allocation{
+		alloc("extract", AllocClientStatusComplete, ""),
+		alloc("extract", AllocClientStatusFailed, "replacement"),
+		alloc("extract", AllocClientStatusComplete, ""),
+		alloc("transform", AllocClientStatusRunning, ""),
+		alloc("report", AllocClientStatusFailed, ""),
+		{Job: older, TaskGroup: "load", ClientStatus: AllocClientStatusComplete},
+	}
+
+	statuses := job.WorkflowStepStatuses(allocs)
+	must.Eq(t, map[string]string{
+		"extract":   WorkflowStepComplete,
+		"transform": WorkflowStepRunning,
+		"load":      WorkflowStepPending,
+		"report":    WorkflowStepFailed,
+	}, statuses)
+
+	must.True(t, WorkflowStepReady(job.LookupTaskGroup("extract"), statuses))
+	must.True(t, WorkflowStepReady(job.LookupTaskGroup("transform"), statuses))
+	must.False(t, WorkflowStepReady(job.LookupTaskGroup("load"), statuses))
+
+	must.True(t, job.HasWorkflowDownstream("extract"))
+	must.False(t, job.HasWorkflowDownstream("load"))
+}
@@ -40,6 +40,8 @@ type variableTimers interface {
 	CreateVariableLockTTLTimer(structs.VariableEncrypted)
 	RemoveVariableLockTTLTimer(structs.VariableEncrypted)
 	RenewTTLTimer(structs.VariableEncrypted) error
+	UpdateVariableExpiryTimer(structs.VariableMetadata)
+	RemoveVariableExpiryTimer(structs.VariableMetadata)
 }
 
 // Variables encapsulates the variables RPC endpoint which is
@@ -89,6 +91,14 @@ func (sv *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.V
 	if !sv.srv.peersCache.ServersMeetMinimumVersion(sv.srv.Region(), minVersionKeyring, true) {
 		return fmt.Errorf("all servers must be running version %v or later to apply variables", minVersionKeyring)
 	}
+	if args.Op == structs.VarOpRekey &&
+		!sv.srv.peersCache.ServersMeetMinimumVersion(sv.srv.Region(), minVersionVariablesHistory, true) {
+		return fmt.Errorf("all servers must be running version %v or later to rekey variable versions", minVersionVariablesHistory)
+	}
+	if args.Var.TTL != 0 &&
+		!sv.srv.peersCache.ServersMeetMinimumVersion(sv.srv.Region(), minVersionVariablesTTL, true) {
+		return fmt.Errorf("all servers must be running version %v or later to apply variables with a TTL", minVersionVariablesTTL)
+	}
 
 	// Perform the ACL resolution.
 	aclObj, err := sv.srv.ResolveACL(args)
@@ -117,13 +127,24 @@ func (sv *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.V
 		now := time.Now().UnixNano()
 		ev.CreateTime = now // existing will override if it exists
 		ev.ModifyTime = now
+		ev.SetExpireTime(now)
+
+	case structs.VarOpRekey:
+		// Rekeying only replaces the encrypted data, so the metadata of the
+		// version is kept as is.
+		ev, err = sv.encrypt(args.Var)
+		if err != nil {
+			return fmt.Errorf("variable error: encrypt: %w", err)
+		}
 
 	case structs.VarOpDelete, structs.VarOpDeleteCAS:
+		// The ModifyTime records when the deleted version was archived.
 		ev = &structs.VariableEncrypted{
 			VariableMetadata: structs.VariableMetadata{
 				Namespace:   args.Var.Namespace,
 				Path:        args.Var.Path,
 				ModifyIndex: args.Var.ModifyIndex,
+				ModifyTime:  time.Now().UnixNano(),
 			},
 		}
 	}
@@ -159,6 +180,13 @@ func (sv *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.V
 		case structs.VarOpLockRelease:
 			sv.timers.RemoveVariableLockTTLTimer(ev.Copy())
 		}
+
+		switch args.Op {
+		case structs.VarOpSet, structs.VarOpCAS, structs.VarOpLockAcquire:
+			sv.timers.UpdateVariableExpiryTimer(ev.VariableMetadata)
+		case structs.VarOpDelete, structs.VarOpDeleteCAS:
+			sv.timers.RemoveVariableExpiryTimer(ev.VariableMetadata)
+		}
 	}
 
 	return nil
@@ -187,6 +215,12 @@ func hasOperationPermissions(aclObj *acl.ACL, namespace, path string, op structs
 		if !hasPerm(acl.VariablesCapabilityDestroy) {
 			return structs.ErrPermissionDenied
 		}
+
+	case structs.VarOpRekey:
+		// Rekeying is only performed by the core scheduler.
+		if !aclObj.IsManagement() {
+			return structs.ErrPermissionDenied
+		}
 	default:
 		return fmt.Errorf("svPreApply: unexpected VarOp received: %q", op)
 	}
@@ -231,6 +265,12 @@ func canonicalizeAndValidate(args *structs.VariablesApplyRequest) error {
 			return errNoPath
 		}
 
+	case structs.VarOpRekey:
+		// Variables that only hold a lock have no items, so only the path
+		// is validated.
+		args.Var.Canonicalize()
+		return structs.ValidatePath(args.Var.Path)
+
 	case structs.VarOpLockRelease:
 		if args.Var == nil || args.Var.Lock == nil ||
 			args.Var.Lock.ID == "" {
@@ -347,6 +387,10 @@ func (sv *Variables) Read(args *structs.VariablesReadRequest, reply *structs.Var
 		return structs.ErrPermissionDenied
 	}
 
+	if args.Version != 0 {
+		return sv.readVersion(args, reply, aclObj)
+	}
+
 	// Setup the blocking query
 	opts := blockingOptions{
 		queryOpts: &args.QueryOptions,
@@ -381,6 +425,194 @@ func (sv *Variables) Read(args *structs.VariablesReadRequest, reply *structs.Var
 	return sv.srv.blockingRPC(&opts)
 }
 
+// readVersion is used to get a specific version of a variable, which is either
+// its current version or one of its prior versions.
+func (sv *Variables) readVersion(args *structs.VariablesReadRequest,
+	reply *structs.VariablesReadResponse, aclObj *acl.ACL) error {
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			current, err := s.GetVariable(ws, args.RequestNamespace(), args.Path)
+			if err != nil {
+				return err
+			}
+
+			var out *structs.VariableEncrypted
+			if current != nil && current.ModifyIndex == args.Version {
+				out = current
+			} else {
+				version, err := s.GetVariableVersion(ws, args.RequestNamespace(), args.Path, args.Version)
+				if err != nil {
+					return err
+				}
+				if version != nil {
+					out = &version.VariableEncrypted
+				}
+			}
+
+			reply.Data = nil
+			if out == nil {
+				return sv.srv.setReplyQueryMeta(s, state.TableVariablesHistory, &reply.QueryMeta)
+			}
+
+			dv, err := sv.decrypt(out)
+			if err != nil {
+				return err
+			}
+			ov := dv.Copy()
+			if !aclObj.IsManagement() {
+				ov.Lock = nil
+			}
+			reply.Data = &ov
+			return sv.srv.setReplyQueryMeta(s, state.TableVariablesHistory, &reply.QueryMeta)
+		}}
+	return sv.srv.blockingRPC(&opts)
+}
+
+// History is used to list the current and prior versions of a variable.
+func (sv *Variables) History(args *structs.VariablesHistoryRequest, reply *structs.VariablesHistoryResponse) error {
+
+	authErr := sv.srv.Authenticate(sv.ctx, args)
+	if done, err := sv.srv.forward(structs.VariablesHistoryRPCMethod, args, args, reply); done {
+		return err
+	}
+	sv.srv.MeasureRPCRate("variables", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "variables", "history"}, time.Now())
+
+	aclObj, err := sv.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowVariableOperation(args.RequestNamespace(), args.Path, acl.PolicyList,
+		auth.IdentityToACLClaim(args.GetIdentity(), sv.srv.State())) {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			current, err := s.GetVariable(ws, args.RequestNamespace(), args.Path)
+			if err != nil {
+				return err
+			}
+			versions, err := s.GetVariableVersions(ws, args.RequestNamespace(), args.Path)
+			if err != nil {
+				return err
+			}
+
+			reply.Versions = make([]*structs.VariableVersionMetadata, 0, len(versions)+1)
+			if current != nil {
+				reply.Versions = append(reply.Versions, &structs.VariableVersionMetadata{
+					VariableMetadata: current.VariableMetadata,
+				})
+			}
+			for _, version := range versions {
+				reply.Versions = append(reply.Versions, version.Stub())
+			}
+			if !aclObj.IsManagement() {
+				for _, version := range reply.Versions {
+					version.Lock = nil
+				}
+			}
+
+			// Creating a variable doesn't write to its history, so the
+			// index is the latest of both tables.
+			index, err := s.Index(state.TableVariables)
+			if err != nil {
+				return err
+			}
+			historyIndex, err := s.Index(state.TableVariablesHistory)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index, historyIndex)
+			sv.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return sv.srv.blockingRPC(&opts)
+}
+
+// PurgeHistory is used by the core scheduler to delete prior versions of
+// variables that fall outside of their namespace's retention.
+func (sv *Variables) PurgeHistory(args *structs.VariablesPurgeHistoryRequest, reply *structs.GenericResponse) error {
+
+	authErr := sv.srv.Authenticate(sv.ctx, args)
+	if done, err := sv.srv.forward(structs.VariablesPurgeHistoryRPCMethod, args, args, reply); done {
+		return err
+	}
+	sv.srv.MeasureRPCRate("variables", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "variables", "purge_history"}, time.Now())
+
+	if aclObj, err := sv.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !sv.srv.peersCache.ServersMeetMinimumVersion(sv.srv.Region(), minVersionVariablesHistory, true) {
+		return fmt.Errorf("all servers must be running version %v or later to purge variable versions", minVersionVariablesHistory)
+	}
+
+	if len(args.Versions) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one variable version to purge")
+	}
+
+	_, index, err := sv.srv.raftApply(structs.VarHistoryPurgeRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// Expire is used by the core scheduler to delete variables that have reached
+// their expire time.
+func (sv *Variables) Expire(args *structs.VariablesExpireRequest, reply *structs.GenericResponse) error {
+
+	authErr := sv.srv.Authenticate(sv.ctx, args)
+	if done, err := sv.srv.forward(structs.VariablesExpireRPCMethod, args, args, reply); done {
+		return err
+	}
+	sv.srv.MeasureRPCRate("variables", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "variables", "expire"}, time.Now())
+
+	if aclObj, err := sv.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !sv.srv.peersCache.ServersMeetMinimumVersion(sv.srv.Region(), minVersionVariablesTTL, true) {
+		return fmt.Errorf("all servers must be running version %v or later to expire variables", minVersionVariablesTTL)
+	}
+
+	if len(args.Variables) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one variable to expire")
+	}
+
+	_, index, err := sv.srv.raftApply(structs.VarExpireRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
 // List is used to list variables held within state. It supports single
 // and wildcard namespace listings.
 func (sv *Variables) List(
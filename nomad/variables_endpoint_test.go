@@ -13,6 +13,7 @@ import (
 
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
@@ -1696,3 +1697,126 @@ func TestVariablesEndpoint_RenewLock(t *testing.T) {
 		must.NoError(t, err)
 	})
 }
+
+func TestVariablesEndpoint_History(t *testing.T) {
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, nil)
+	defer shutdown()
+	testutil.WaitForKeyring(t, srv.RPC, srv.Region())
+	codec := rpcClient(t, srv)
+
+	// Write two versions of the variable, the first one is archived.
+	sv := mock.Variable()
+	var versions []uint64
+	for _, value := range []string{"first", "second"} {
+		sv.Items = structs.VariableItems{"value": value}
+		applyReq := &structs.VariablesApplyRequest{
+			Op:           structs.VarOpSet,
+			Var:          sv,
+			WriteRequest: structs.WriteRequest{Region: srv.Region()},
+		}
+		var applyResp structs.VariablesApplyResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, applyReq, &applyResp))
+		versions = append(versions, applyResp.Output.ModifyIndex)
+	}
+
+	historyReq := &structs.VariablesHistoryRequest{
+		Path: sv.Path,
+		QueryOptions: structs.QueryOptions{
+			Region:    srv.Region(),
+			Namespace: sv.Namespace,
+		},
+	}
+	var historyResp structs.VariablesHistoryResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesHistoryRPCMethod, historyReq, &historyResp))
+	must.Len(t, 2, historyResp.Versions)
+	must.True(t, historyResp.Versions[0].IsCurrent())
+	must.Eq(t, versions[1], historyResp.Versions[0].ModifyIndex)
+	must.False(t, historyResp.Versions[1].IsCurrent())
+	must.Eq(t, versions[0], historyResp.Versions[1].ModifyIndex)
+	must.Eq(t, versions[1], historyResp.Versions[1].ArchiveIndex)
+
+	// Both the current and the prior version can be read by version.
+	for i, value := range []string{"first", "second"} {
+		readReq := &structs.VariablesReadRequest{
+			Path:    sv.Path,
+			Version: versions[i],
+			QueryOptions: structs.QueryOptions{
+				Region:    srv.Region(),
+				Namespace: sv.Namespace,
+			},
+		}
+		var readResp structs.VariablesReadResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp))
+		must.NotNil(t, readResp.Data)
+		must.Eq(t, value, readResp.Data.Items["value"])
+	}
+
+	// An unknown version isn't found.
+	readReq := &structs.VariablesReadRequest{
+		Path:    sv.Path,
+		Version: versions[0] - 1,
+		QueryOptions: structs.QueryOptions{
+			Region:    srv.Region(),
+			Namespace: sv.Namespace,
+		},
+	}
+	var readResp structs.VariablesReadResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp))
+	must.Nil(t, readResp.Data)
+}
+
+func TestVariablesEndpoint_Apply_TTL(t *testing.T) {
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, nil)
+	defer shutdown()
+	testutil.WaitForKeyring(t, srv.RPC, srv.Region())
+	codec := rpcClient(t, srv)
+
+	apply := func(sv *structs.VariableDecrypted) *structs.VariablesApplyResponse {
+		t.Helper()
+		applyReq := &structs.VariablesApplyRequest{
+			Op:           structs.VarOpSet,
+			Var:          sv,
+			WriteRequest: structs.WriteRequest{Region: srv.Region()},
+		}
+		var applyResp structs.VariablesApplyResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, applyReq, &applyResp))
+		return &applyResp
+	}
+
+	// Writing a variable without a TTL removes the expiry it was written
+	// with before.
+	permanent := mock.Variable()
+	permanent.Path = "permanent"
+	permanent.TTL = time.Hour
+	resp := apply(permanent)
+	must.Positive(t, resp.Output.ExpireTime)
+	must.Eq(t, 1, srv.variableExpiryTimer.TimerNum())
+
+	permanent.TTL = 0
+	resp = apply(permanent)
+	must.Zero(t, resp.Output.ExpireTime)
+	must.Eq(t, 0, srv.variableExpiryTimer.TimerNum())
+
+	// The leader deletes the variable once its TTL expires.
+	expiring := mock.Variable()
+	expiring.Path = "expiring"
+	expiring.TTL = 100 * time.Millisecond
+	resp = apply(expiring)
+	must.Positive(t, resp.Output.ExpireTime)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			out, err := srv.State().GetVariable(nil, expiring.Namespace, expiring.Path)
+			must.NoError(t, err)
+			return out == nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(50*time.Millisecond),
+	), must.Sprint("expected variable to expire"))
+
+	out, err := srv.State().GetVariable(nil, permanent.Namespace, permanent.Path)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+}
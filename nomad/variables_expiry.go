@@ -0,0 +1,92 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// restoreVariableExpiryTimers iterates the stored variables and creates an
+// expiry timer for each variable written with a TTL. This is used during
+// leadership establishment to populate the in-memory timers.
+func (s *Server) restoreVariableExpiryTimers() error {
+	varIterator, err := s.fsm.State().Variables(nil)
+	if err != nil {
+		return fmt.Errorf("failed to list variables for expiry restore: %v", err)
+	}
+
+	for raw := varIterator.Next(); raw != nil; raw = varIterator.Next() {
+		if variable, ok := raw.(*structs.VariableEncrypted); ok && variable.ExpireTime != 0 {
+			s.UpdateVariableExpiryTimer(variable.VariableMetadata)
+		}
+	}
+
+	return nil
+}
+
+// UpdateVariableExpiryTimer creates or resets the expiry timer of a variable
+// according to its expire time, or removes the timer if the variable no longer
+// expires.
+func (s *Server) UpdateVariableExpiryTimer(variable structs.VariableMetadata) {
+	timerID := structs.NewNamespacedID(variable.Path, variable.Namespace).String()
+
+	if variable.ExpireTime == 0 {
+		s.variableExpiryTimer.StopAndRemove(timerID)
+		return
+	}
+
+	namespace, path := variable.Namespace, variable.Path
+	ttl := max(0, time.Until(time.Unix(0, variable.ExpireTime)))
+	s.variableExpiryTimer.Create(timerID, ttl, func() {
+		s.variableExpiryTimer.Delete(timerID)
+		s.expireVariable(namespace, path)
+	})
+}
+
+// RemoveVariableExpiryTimer stops the expiry timer of a variable that has been
+// deleted.
+func (s *Server) RemoveVariableExpiryTimer(variable structs.VariableMetadata) {
+	timerID := structs.NewNamespacedID(variable.Path, variable.Namespace).String()
+	s.variableExpiryTimer.StopAndRemove(timerID)
+}
+
+// expireVariable deletes a variable once its expire time is reached. The
+// variable is read again so that a variable written since the timer was set
+// is not deleted. Failures are left to the variable expired GC.
+func (s *Server) expireVariable(namespace, path string) {
+	variable, err := s.fsm.State().GetVariable(nil, namespace, path)
+	if err != nil {
+		s.logger.Error("variable expiry lookup failed",
+			"namespace", namespace, "path", path, "error", err)
+		return
+	}
+	if variable == nil || !variable.IsExpired(time.Now()) {
+		return
+	}
+
+	if !s.peersCache.ServersMeetMinimumVersion(s.Region(), minVersionVariablesTTL, true) {
+		return
+	}
+
+	s.logger.Debug("variable expired", "namespace", namespace, "path", path)
+
+	req := structs.VariablesExpireRequest{
+		Variables: []*structs.VariableVersionID{{
+			Namespace: namespace,
+			Path:      path,
+			Version:   variable.ModifyIndex,
+		}},
+		WriteRequest: structs.WriteRequest{
+			Region:    s.Region(),
+			Namespace: namespace,
+		},
+	}
+	if _, _, err := s.raftApply(structs.VarExpireRequestType, req); err != nil {
+		s.logger.Error("variable expiry failed",
+			"namespace", namespace, "path", path, "error", err)
+	}
+}
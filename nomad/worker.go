@@ -646,6 +646,12 @@ func (w *Worker) ServersMeetMinimumVersion(minVersion *version.Version, checkFai
 	return w.srv.peersCache.ServersMeetMinimumVersion(w.srv.Region(), minVersion, checkFailedServers)
 }
 
+// NodeScorers returns the scoring plugins loaded by the server so that the
+// schedulers can use them to rank nodes.
+func (w *Worker) NodeScorers() []sstructs.NodeScorer {
+	return w.srv.nodeScorers
+}
+
 // SubmitPlan is used to submit a plan for consideration. This allows
 // the worker to act as the planner for the scheduler.
 func (w *Worker) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, sstructs.State, error) {
@@ -23,6 +23,9 @@ const (
 
 	// PluginTypeDevice implements the device plugin interface
 	PluginTypeDevice = "device"
+
+	// PluginTypeScoring implements the scoring plugin interface
+	PluginTypeScoring = "scoring"
 )
 
 var (
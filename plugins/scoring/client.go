@@ -0,0 +1,42 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scoring
+
+import (
+	"context"
+
+	"github.com/LK4D4/joincontext"
+	"github.com/hashicorp/nomad/helper/pluginutils/grpcutils"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/scoring/proto"
+)
+
+// scoringPluginClient implements the client side of a remote scoring plugin,
+// using gRPC to communicate to the remote plugin.
+type scoringPluginClient struct {
+	// basePluginClient is embedded to give access to the base plugin methods.
+	*base.BasePluginClient
+
+	client proto.ScoringPluginClient
+
+	// doneCtx is closed when the plugin exits
+	doneCtx context.Context
+}
+
+// Score asks the plugin to score a candidate placement. If the context is
+// cancelled, the error will be propagated.
+func (s *scoringPluginClient) Score(ctx context.Context, req *ScoreRequest) (*ScoreResponse, error) {
+	// Join the passed context and the shutdown context
+	joinedCtx, _ := joincontext.Join(ctx, s.doneCtx)
+
+	resp, err := s.client.Score(joinedCtx, convertStructScoreRequest(req))
+	if err != nil {
+		return nil, grpcutils.HandleReqCtxGrpcErr(err, ctx, s.doneCtx)
+	}
+
+	return &ScoreResponse{
+		Score: resp.GetScore(),
+		Label: resp.GetLabel(),
+	}, nil
+}
@@ -0,0 +1,32 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scoring
+
+import (
+	"context"
+
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+type ScoreFn func(context.Context, *ScoreRequest) (*ScoreResponse, error)
+
+// MockScoringPlugin is used for testing.
+// Each function can be set as a closure to make assertions about how data
+// is passed through the base plugin layer.
+type MockScoringPlugin struct {
+	*base.MockPlugin
+	ScoreF ScoreFn
+}
+
+func (p *MockScoringPlugin) Score(ctx context.Context, req *ScoreRequest) (*ScoreResponse, error) {
+	return p.ScoreF(ctx, req)
+}
+
+// StaticScore returns a ScoreFn that always returns the given score and
+// label.
+func StaticScore(score float64, label string) ScoreFn {
+	return func(context.Context, *ScoreRequest) (*ScoreResponse, error) {
+		return &ScoreResponse{Score: score, Label: label}, nil
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scoring
+
+import (
+	"context"
+
+	log "github.com/hashicorp/go-hclog"
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/plugins/base"
+	bproto "github.com/hashicorp/nomad/plugins/base/proto"
+	"github.com/hashicorp/nomad/plugins/scoring/proto"
+	"google.golang.org/grpc"
+)
+
+// PluginScoring wraps a ScoringPlugin and implements go-plugins GRPCPlugin
+// interface to expose the interface over gRPC.
+type PluginScoring struct {
+	plugin.NetRPCUnsupportedPlugin
+	Impl ScoringPlugin
+}
+
+func (p *PluginScoring) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterScoringPluginServer(s, &scoringPluginServer{
+		impl:   p.Impl,
+		broker: broker,
+	})
+	return nil
+}
+
+func (p *PluginScoring) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &scoringPluginClient{
+		doneCtx: ctx,
+		client:  proto.NewScoringPluginClient(c),
+		BasePluginClient: &base.BasePluginClient{
+			Client:  bproto.NewBasePluginClient(c),
+			DoneCtx: ctx,
+		},
+	}, nil
+}
+
+// Serve is used to serve a scoring plugin
+func Serve(impl ScoringPlugin, logger log.Logger) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: base.Handshake,
+		Plugins: map[string]plugin.Plugin{
+			base.PluginTypeBase:    &base.PluginBase{Impl: impl},
+			base.PluginTypeScoring: &PluginScoring{Impl: impl},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+		Logger:     logger,
+	})
+}
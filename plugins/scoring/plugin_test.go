@@ -0,0 +1,119 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scoring
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/shoenig/test/must"
+)
+
+func dispenseMock(t *testing.T, mock *MockScoringPlugin) ScoringPlugin {
+	client, server := plugin.TestPluginGRPCConn(t, true, map[string]plugin.Plugin{
+		base.PluginTypeBase:    &base.PluginBase{Impl: mock},
+		base.PluginTypeScoring: &PluginScoring{Impl: mock},
+	})
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	raw, err := client.Dispense(base.PluginTypeScoring)
+	must.NoError(t, err)
+
+	impl, ok := raw.(ScoringPlugin)
+	must.True(t, ok)
+	return impl
+}
+
+func TestScoringPlugin_PluginInfo(t *testing.T) {
+	ci.Parallel(t)
+
+	mock := &MockScoringPlugin{
+		MockPlugin: &base.MockPlugin{
+			PluginInfoF: func() (*base.PluginInfoResponse, error) {
+				return &base.PluginInfoResponse{
+					Type:              base.PluginTypeScoring,
+					PluginApiVersions: []string{ApiVersion010},
+					PluginVersion:     "v0.1.0",
+					Name:              "mock_scoring",
+				}, nil
+			},
+		},
+	}
+	impl := dispenseMock(t, mock)
+
+	resp, err := impl.PluginInfo()
+	must.NoError(t, err)
+	must.Eq(t, base.PluginTypeScoring, resp.Type)
+	must.Eq(t, "mock_scoring", resp.Name)
+	must.Eq(t, []string{ApiVersion010}, resp.PluginApiVersions)
+}
+
+func TestScoringPlugin_Score(t *testing.T) {
+	ci.Parallel(t)
+
+	req := &ScoreRequest{
+		Job: &Job{
+			Namespace: "default",
+			ID:        "example",
+			Name:      "example",
+			Type:      "service",
+			Priority:  50,
+			NodePool:  "default",
+			Meta:      map[string]string{"team": "web"},
+		},
+		TaskGroup: &TaskGroup{
+			Name:  "web",
+			Count: 3,
+			Tasks: []*Task{{
+				Name:     "server",
+				Driver:   "docker",
+				Config:   []byte(`{"image":"nginx"}`),
+				CPU:      500,
+				MemoryMB: 256,
+			}},
+		},
+		Node: &Node{
+			ID:         "node-1",
+			Name:       "client-1",
+			Datacenter: "dc1",
+			NodeClass:  "large",
+			NodePool:   "default",
+			Attributes: map[string]string{"kernel.name": "linux"},
+			Meta:       map[string]string{"rack": "r1"},
+		},
+		RankedNode: &RankedNode{
+			Scores:         []float64{0.5, -0.25},
+			ProposedAllocs: 4,
+		},
+	}
+
+	var received *ScoreRequest
+	mock := &MockScoringPlugin{
+		MockPlugin: &base.MockPlugin{},
+		ScoreF: func(_ context.Context, r *ScoreRequest) (*ScoreResponse, error) {
+			received = r
+			return &ScoreResponse{Score: 0.75, Label: "cheap"}, nil
+		},
+	}
+	impl := dispenseMock(t, mock)
+
+	resp, err := impl.Score(context.Background(), req)
+	must.NoError(t, err)
+	must.Eq(t, &ScoreResponse{Score: 0.75, Label: "cheap"}, resp)
+	must.Eq(t, req, received)
+
+	// Errors from the plugin are returned to the caller
+	mock.ScoreF = func(context.Context, *ScoreRequest) (*ScoreResponse, error) {
+		return nil, errors.New("pricing API unavailable")
+	}
+	_, err = impl.Score(context.Background(), req)
+	must.ErrorContains(t, err, "pricing API unavailable")
+}
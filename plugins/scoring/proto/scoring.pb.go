@@ -0,0 +1,663 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: plugins/scoring/proto/scoring.proto
+
+package proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ScoreRequest describes a candidate placement.
+type ScoreRequest struct {
+	// job is the job being placed.
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// task_group is the task group being placed.
+	TaskGroup *TaskGroup `protobuf:"bytes,2,opt,name=task_group,json=taskGroup,proto3" json:"task_group,omitempty"`
+	// node is the candidate node.
+	Node *Node `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	// ranked_node carries the state of the node in the ranking pipeline.
+	RankedNode           *RankedNode `protobuf:"bytes,4,opt,name=ranked_node,json=rankedNode,proto3" json:"ranked_node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ScoreRequest) Reset()         { *m = ScoreRequest{} }
+func (m *ScoreRequest) String() string { return proto.CompactTextString(m) }
+func (*ScoreRequest) ProtoMessage()    {}
+func (*ScoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5250b34a83817cea, []int{0}
+}
+
+func (m *ScoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScoreRequest.Unmarshal(m, b)
+}
+func (m *ScoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScoreRequest.Marshal(b, m, deterministic)
+}
+func (m *ScoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScoreRequest.Merge(m, src)
+}
+func (m *ScoreRequest) XXX_Size() int {
+	return xxx_messageInfo_ScoreRequest.Size(m)
+}
+func (m *ScoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScoreRequest proto.InternalMessageInfo
+
+func (m *ScoreRequest) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *ScoreRequest) GetTaskGroup() *TaskGroup {
+	if m != nil {
+		return m.TaskGroup
+	}
+	return nil
+}
+
+func (m *ScoreRequest) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *ScoreRequest) GetRankedNode() *RankedNode {
+	if m != nil {
+		return m.RankedNode
+	}
+	return nil
+}
+
+// ScoreResponse is the score of a candidate placement.
+type ScoreResponse struct {
+	// score is a value between -1 and 1. Positive values make the node more
+	// likely to be selected and negative values less likely. Values outside of
+	// the range are clamped.
+	Score float64 `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	// label is a short human readable explanation of the score, shown along
+	// with the score in the allocation metrics.
+	Label                string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScoreResponse) Reset()         { *m = ScoreResponse{} }
+func (m *ScoreResponse) String() string { return proto.CompactTextString(m) }
+func (*ScoreResponse) ProtoMessage()    {}
+func (*ScoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5250b34a83817cea, []int{1}
+}
+
+func (m *ScoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScoreResponse.Unmarshal(m, b)
+}
+func (m *ScoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScoreResponse.Marshal(b, m, deterministic)
+}
+func (m *ScoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScoreResponse.Merge(m, src)
+}
+func (m *ScoreResponse) XXX_Size() int {
+	return xxx_messageInfo_ScoreResponse.Size(m)
+}
+func (m *ScoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScoreResponse proto.InternalMessageInfo
+
+func (m *ScoreResponse) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *ScoreResponse) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+// Job is the subset of a job exposed to scoring plugins.
+type Job struct {
+	Namespace            string            `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Id                   string            `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string            `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string            `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Priority             int64             `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	NodePool             string            `protobuf:"bytes,6,opt,name=node_pool,json=nodePool,proto3" json:"node_pool,omitempty"`
+	Meta                 map[string]string `protobuf:"bytes,7,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Job) Reset()         { *m = Job{} }
+func (m *Job) String() string { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()    {}
+func (*Job) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5250b34a83817cea, []int{2}
+}
+
+func (m *Job) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Job.Unmarshal(m, b)
+}
+func (m *Job) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Job.Marshal(b, m, deterministic)
+}
+func (m *Job) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Job.Merge(m, src)
+}
+func (m *Job) XXX_Size() int {
+	return xxx_messageInfo_Job.Size(m)
+}
+func (m *Job) XXX_DiscardUnknown() {
+	xxx_messageInfo_Job.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Job proto.InternalMessageInfo
+
+func (m *Job) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Job) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Job) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Job) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Job) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Job) GetNodePool() string {
+	if m != nil {
+		return m.NodePool
+	}
+	return ""
+}
+
+func (m *Job) GetMeta() map[string]string {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+// TaskGroup is the subset of a task group exposed to scoring plugins.
+type TaskGroup struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count                int64             `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Meta                 map[string]string `protobuf:"bytes,3,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Tasks                []*Task           `protobuf:"bytes,4,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TaskGroup) Reset()         { *m = TaskGroup{} }
+func (m *TaskGroup) String() string { return proto.CompactTextString(m) }
+func (*TaskGroup) ProtoMessage()    {}
+func (*TaskGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5250b34a83817cea, []int{3}
+}
+
+func (m *TaskGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskGroup.Unmarshal(m, b)
+}
+func (m *TaskGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskGroup.Marshal(b, m, deterministic)
+}
+func (m *TaskGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskGroup.Merge(m, src)
+}
+func (m *TaskGroup) XXX_Size() int {
+	return xxx_messageInfo_TaskGroup.Size(m)
+}
+func (m *TaskGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskGroup proto.InternalMessageInfo
+
+func (m *TaskGroup) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TaskGroup) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *TaskGroup) GetMeta() map[string]string {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *TaskGroup) GetTasks() []*Task {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+// Task is the subset of a task exposed to scoring plugins.
+type Task struct {
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Driver string `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"`
+	// config is the driver configuration of the task, encoded as JSON.
+	Config []byte `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	// cpu is the requested CPU in MHz.
+	Cpu int64 `protobuf:"varint,4,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	// memory_mb is the requested memory in MB.
+	MemoryMb             int64    `protobuf:"varint,5,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Task) Reset()         { *m = Task{} }
+func (m *Task) String() string { return proto.CompactTextString(m) }
+func (*Task) ProtoMessage()    {}
+func (*Task) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5250b34a83817cea, []int{4}
+}
+
+func (m *Task) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Task.Unmarshal(m, b)
+}
+func (m *Task) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Task.Marshal(b, m, deterministic)
+}
+func (m *Task) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Task.Merge(m, src)
+}
+func (m *Task) XXX_Size() int {
+	return xxx_messageInfo_Task.Size(m)
+}
+func (m *Task) XXX_DiscardUnknown() {
+	xxx_messageInfo_Task.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Task proto.InternalMessageInfo
+
+func (m *Task) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Task) GetDriver() string {
+	if m != nil {
+		return m.Driver
+	}
+	return ""
+}
+
+func (m *Task) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *Task) GetCpu() int64 {
+	if m != nil {
+		return m.Cpu
+	}
+	return 0
+}
+
+func (m *Task) GetMemoryMb() int64 {
+	if m != nil {
+		return m.MemoryMb
+	}
+	return 0
+}
+
+// Node is the subset of a node exposed to scoring plugins.
+type Node struct {
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Datacenter           string            `protobuf:"bytes,3,opt,name=datacenter,proto3" json:"datacenter,omitempty"`
+	NodeClass            string            `protobuf:"bytes,4,opt,name=node_class,json=nodeClass,proto3" json:"node_class,omitempty"`
+	NodePool             string            `protobuf:"bytes,5,opt,name=node_pool,json=nodePool,proto3" json:"node_pool,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Meta                 map[string]string `protobuf:"bytes,7,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Node) Reset()         { *m = Node{} }
+func (m *Node) String() string { return proto.CompactTextString(m) }
+func (*Node) ProtoMessage()    {}
+func (*Node) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5250b34a83817cea, []int{5}
+}
+
+func (m *Node) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node.Unmarshal(m, b)
+}
+func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node.Marshal(b, m, deterministic)
+}
+func (m *Node) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node.Merge(m, src)
+}
+func (m *Node) XXX_Size() int {
+	return xxx_messageInfo_Node.Size(m)
+}
+func (m *Node) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node proto.InternalMessageInfo
+
+func (m *Node) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Node) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Node) GetDatacenter() string {
+	if m != nil {
+		return m.Datacenter
+	}
+	return ""
+}
+
+func (m *Node) GetNodeClass() string {
+	if m != nil {
+		return m.NodeClass
+	}
+	return ""
+}
+
+func (m *Node) GetNodePool() string {
+	if m != nil {
+		return m.NodePool
+	}
+	return ""
+}
+
+func (m *Node) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Node) GetMeta() map[string]string {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+// RankedNode is the state of the candidate node in the ranking pipeline
+// when the plugin is invoked.
+type RankedNode struct {
+	// scores are the non-zero scores appended by the built-in scorers.
+	Scores []float64 `protobuf:"fixed64,1,rep,packed,name=scores,proto3" json:"scores,omitempty"`
+	// proposed_allocs is the number of allocations the node would be running
+	// if the placement is made, not counting the placement itself.
+	ProposedAllocs       int64    `protobuf:"varint,2,opt,name=proposed_allocs,json=proposedAllocs,proto3" json:"proposed_allocs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RankedNode) Reset()         { *m = RankedNode{} }
+func (m *RankedNode) String() string { return proto.CompactTextString(m) }
+func (*RankedNode) ProtoMessage()    {}
+func (*RankedNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5250b34a83817cea, []int{6}
+}
+
+func (m *RankedNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RankedNode.Unmarshal(m, b)
+}
+func (m *RankedNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RankedNode.Marshal(b, m, deterministic)
+}
+func (m *RankedNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RankedNode.Merge(m, src)
+}
+func (m *RankedNode) XXX_Size() int {
+	return xxx_messageInfo_RankedNode.Size(m)
+}
+func (m *RankedNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_RankedNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RankedNode proto.InternalMessageInfo
+
+func (m *RankedNode) GetScores() []float64 {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+func (m *RankedNode) GetProposedAllocs() int64 {
+	if m != nil {
+		return m.ProposedAllocs
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ScoreRequest)(nil), "hashicorp.nomad.plugins.scoring.ScoreRequest")
+	proto.RegisterType((*ScoreResponse)(nil), "hashicorp.nomad.plugins.scoring.ScoreResponse")
+	proto.RegisterType((*Job)(nil), "hashicorp.nomad.plugins.scoring.Job")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.scoring.Job.MetaEntry")
+	proto.RegisterType((*TaskGroup)(nil), "hashicorp.nomad.plugins.scoring.TaskGroup")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.scoring.TaskGroup.MetaEntry")
+	proto.RegisterType((*Task)(nil), "hashicorp.nomad.plugins.scoring.Task")
+	proto.RegisterType((*Node)(nil), "hashicorp.nomad.plugins.scoring.Node")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.scoring.Node.AttributesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.scoring.Node.MetaEntry")
+	proto.RegisterType((*RankedNode)(nil), "hashicorp.nomad.plugins.scoring.RankedNode")
+}
+
+func init() {
+	proto.RegisterFile("plugins/scoring/proto/scoring.proto", fileDescriptor_5250b34a83817cea)
+}
+
+var fileDescriptor_5250b34a83817cea = []byte{
+	// 658 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x5d, 0x6f, 0xd3, 0x4a,
+	0x10, 0xbd, 0xfe, 0x4a, 0xeb, 0x69, 0x6f, 0x7b, 0xb5, 0xaa, 0xae, 0xac, 0xde, 0x0b, 0x44, 0x01,
+	0x44, 0x04, 0xc2, 0x95, 0xca, 0x37, 0x15, 0x0f, 0x6d, 0x85, 0x80, 0x8a, 0xa2, 0x6a, 0x0b, 0x2f,
+	0xbc, 0x44, 0x6b, 0x7b, 0x49, 0x4d, 0x1c, 0xaf, 0xd9, 0x5d, 0x57, 0xf2, 0xcf, 0xe0, 0xb5, 0xff,
+	0x93, 0x77, 0xb4, 0xe3, 0x4d, 0x9a, 0x06, 0xa4, 0x84, 0x3e, 0x65, 0xe6, 0xc4, 0x67, 0xbc, 0x67,
+	0xe6, 0x78, 0x16, 0x6e, 0x57, 0x45, 0x3d, 0xcc, 0x4b, 0xb5, 0xa3, 0x52, 0x21, 0xf3, 0x72, 0xb8,
+	0x53, 0x49, 0xa1, 0xc5, 0x24, 0x8b, 0x31, 0x23, 0xb7, 0xce, 0x98, 0x3a, 0xcb, 0x53, 0x21, 0xab,
+	0xb8, 0x14, 0x63, 0x96, 0xc5, 0x96, 0x14, 0xdb, 0xc7, 0x7a, 0x17, 0x2e, 0xac, 0x9f, 0xa6, 0x42,
+	0x72, 0xca, 0xbf, 0xd5, 0x5c, 0x69, 0xf2, 0x14, 0xbc, 0xaf, 0x22, 0x89, 0x9c, 0xae, 0xd3, 0x5f,
+	0xdb, 0xbd, 0x13, 0x2f, 0xe0, 0xc7, 0x47, 0x22, 0xa1, 0x86, 0x40, 0xde, 0x01, 0x68, 0xa6, 0x46,
+	0x83, 0xa1, 0x14, 0x75, 0x15, 0xb9, 0x48, 0xbf, 0xbf, 0x90, 0xfe, 0x91, 0xa9, 0xd1, 0x1b, 0xc3,
+	0xa0, 0xa1, 0x9e, 0x84, 0xe4, 0x05, 0xf8, 0xa5, 0xc8, 0x78, 0xe4, 0x61, 0x91, 0xbb, 0x0b, 0x8b,
+	0x7c, 0x10, 0x19, 0xa7, 0x48, 0x21, 0xef, 0x61, 0x4d, 0xb2, 0x72, 0xc4, 0xb3, 0x01, 0x56, 0xf0,
+	0xb1, 0xc2, 0x83, 0x85, 0x15, 0x28, 0x72, 0xb0, 0x0e, 0xc8, 0x69, 0xdc, 0xdb, 0x83, 0xbf, 0x6d,
+	0x6f, 0x54, 0x25, 0x4a, 0xc5, 0xc9, 0x16, 0x04, 0x86, 0xc2, 0xb1, 0x3d, 0x0e, 0x6d, 0x13, 0x83,
+	0x16, 0x2c, 0xe1, 0x05, 0xaa, 0x0e, 0x69, 0x9b, 0xf4, 0xbe, 0xbb, 0xe0, 0x1d, 0x89, 0x84, 0xfc,
+	0x0f, 0x61, 0xc9, 0xc6, 0x5c, 0x55, 0x2c, 0x6d, 0x79, 0x21, 0xbd, 0x04, 0xc8, 0x06, 0xb8, 0x79,
+	0x66, 0x89, 0x6e, 0x9e, 0x11, 0x02, 0xbe, 0xf9, 0x13, 0xb5, 0x87, 0x14, 0x63, 0x83, 0xe9, 0xa6,
+	0x6a, 0xd5, 0x84, 0x14, 0x63, 0xb2, 0x0d, 0xab, 0x95, 0xcc, 0x85, 0xcc, 0x75, 0x13, 0x05, 0x5d,
+	0xa7, 0xef, 0xd1, 0x69, 0x4e, 0xfe, 0x83, 0xd0, 0xa8, 0x1f, 0x54, 0x42, 0x14, 0x51, 0x07, 0x49,
+	0xab, 0x06, 0x38, 0x11, 0xa2, 0x20, 0x07, 0xe0, 0x8f, 0xb9, 0x66, 0xd1, 0x4a, 0xd7, 0xeb, 0xaf,
+	0xed, 0xc6, 0xcb, 0x0c, 0x38, 0x3e, 0xe6, 0x9a, 0xbd, 0x2e, 0xb5, 0x6c, 0x28, 0x72, 0xb7, 0x9f,
+	0x41, 0x38, 0x85, 0xc8, 0x3f, 0xe0, 0x8d, 0x78, 0x63, 0x95, 0x99, 0xd0, 0xf4, 0xe3, 0x9c, 0x15,
+	0x35, 0x9f, 0xf4, 0x03, 0x93, 0x97, 0xee, 0x73, 0xa7, 0xf7, 0xc3, 0x81, 0x70, 0x3a, 0xf2, 0xa9,
+	0x56, 0x67, 0x46, 0xeb, 0x16, 0x04, 0xa9, 0xa8, 0x4b, 0x8d, 0x5c, 0x8f, 0xb6, 0x09, 0x79, 0x6b,
+	0x0f, 0xed, 0xe1, 0xa1, 0x1f, 0x2f, 0x6f, 0xab, 0xf9, 0xa3, 0x93, 0x3d, 0x08, 0x8c, 0xd1, 0x54,
+	0xe4, 0x77, 0xbd, 0xa5, 0xcc, 0x65, 0x4a, 0xd1, 0x96, 0x73, 0x7d, 0xdd, 0x0d, 0xf8, 0xa6, 0xce,
+	0x6f, 0x15, 0xff, 0x0b, 0x9d, 0x4c, 0xe6, 0xe7, 0x5c, 0x5a, 0x9a, 0xcd, 0x0c, 0x9e, 0x8a, 0xf2,
+	0x4b, 0x3e, 0x44, 0x2f, 0xac, 0x53, 0x9b, 0x99, 0xf7, 0xa6, 0x55, 0x8d, 0x66, 0xf0, 0xa8, 0x09,
+	0xcd, 0xbc, 0xc7, 0x7c, 0x2c, 0x64, 0x33, 0x18, 0x27, 0x13, 0x33, 0xb4, 0xc0, 0x71, 0xd2, 0xbb,
+	0xf0, 0xc0, 0x37, 0x66, 0xb6, 0x4e, 0x73, 0x7e, 0x71, 0x9a, 0x3b, 0x73, 0x96, 0x9b, 0x00, 0x19,
+	0xd3, 0x2c, 0xe5, 0xa5, 0xe6, 0xd2, 0x7a, 0x70, 0x06, 0x21, 0x37, 0x00, 0xd0, 0x59, 0x69, 0xc1,
+	0x94, 0xb2, 0x7e, 0x44, 0xaf, 0x1d, 0x1a, 0xe0, 0xaa, 0xf1, 0x82, 0x39, 0xe3, 0x7d, 0x02, 0x60,
+	0x5a, 0xcb, 0x3c, 0xa9, 0x35, 0x57, 0x51, 0x07, 0xdb, 0xff, 0x64, 0xa9, 0x6f, 0x3b, 0xde, 0x9f,
+	0xf2, 0xda, 0x51, 0xce, 0x14, 0x22, 0x87, 0x57, 0xfc, 0xbc, 0xb3, 0x5c, 0xc1, 0x79, 0x43, 0xbf,
+	0x82, 0xcd, 0xb9, 0x77, 0xfc, 0xc9, 0x78, 0xaf, 0xef, 0x8b, 0x63, 0x80, 0xcb, 0xd5, 0x63, 0x26,
+	0x8e, 0x0b, 0x45, 0x45, 0x4e, 0xd7, 0xeb, 0x3b, 0xd4, 0x66, 0xe4, 0x1e, 0x6c, 0x56, 0x52, 0x54,
+	0x42, 0xf1, 0x6c, 0xc0, 0x8a, 0x42, 0xa4, 0xca, 0x7e, 0x1d, 0x1b, 0x13, 0x78, 0x1f, 0xd1, 0xdd,
+	0xa6, 0xdd, 0x57, 0x79, 0x39, 0x3c, 0x41, 0xd5, 0xe4, 0x0c, 0x82, 0x53, 0x5c, 0x51, 0x0f, 0x17,
+	0xf6, 0x65, 0xf6, 0x12, 0xd8, 0x8e, 0x97, 0x7d, 0xbc, 0xdd, 0x8b, 0xbd, 0xbf, 0x0e, 0x56, 0x3e,
+	0x07, 0x78, 0xe3, 0x24, 0x1d, 0xfc, 0x79, 0xf4, 0x73, 0x00, 0x20, 0xe7, 0x2b, 0x4c, 0x9f, 0x06,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ScoringPluginClient is the client API for ScoringPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ScoringPluginClient interface {
+	// Score is called by the scheduler for every node ranked as a candidate
+	// for a placement. The returned score is added to the node's scores
+	// before they are normalized.
+	Score(ctx context.Context, in *ScoreRequest, opts ...grpc.CallOption) (*ScoreResponse, error)
+}
+
+type scoringPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScoringPluginClient(cc grpc.ClientConnInterface) ScoringPluginClient {
+	return &scoringPluginClient{cc}
+}
+
+func (c *scoringPluginClient) Score(ctx context.Context, in *ScoreRequest, opts ...grpc.CallOption) (*ScoreResponse, error) {
+	out := new(ScoreResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.scoring.ScoringPlugin/Score", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoringPluginServer is the server API for ScoringPlugin service.
+type ScoringPluginServer interface {
+	// Score is called by the scheduler for every node ranked as a candidate
+	// for a placement. The returned score is added to the node's scores
+	// before they are normalized.
+	Score(context.Context, *ScoreRequest) (*ScoreResponse, error)
+}
+
+// UnimplementedScoringPluginServer can be embedded to have forward compatible implementations.
+type UnimplementedScoringPluginServer struct {
+}
+
+func (*UnimplementedScoringPluginServer) Score(ctx context.Context, req *ScoreRequest) (*ScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Score not implemented")
+}
+
+func RegisterScoringPluginServer(s *grpc.Server, srv ScoringPluginServer) {
+	s.RegisterService(&_ScoringPlugin_serviceDesc, srv)
+}
+
+func _ScoringPlugin_Score_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoringPluginServer).Score(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.scoring.ScoringPlugin/Score",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoringPluginServer).Score(ctx, req.(*ScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ScoringPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.nomad.plugins.scoring.ScoringPlugin",
+	HandlerType: (*ScoringPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Score",
+			Handler:    _ScoringPlugin_Score_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugins/scoring/proto/scoring.proto",
+}
@@ -0,0 +1,96 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scoring
+
+import (
+	"context"
+
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+// ScoringPlugin is the interface for a plugin that contributes a score to
+// the ranking of candidate nodes during scheduling. Scoring plugins run on the
+// servers and are called once for every feasible node considered for a
+// placement, so implementations should answer quickly and must not block.
+type ScoringPlugin interface {
+	base.BasePlugin
+
+	// Score returns the score of placing the task group on the node.
+	Score(ctx context.Context, req *ScoreRequest) (*ScoreResponse, error)
+}
+
+// ScoreRequest describes a candidate placement.
+type ScoreRequest struct {
+	Job        *Job
+	TaskGroup  *TaskGroup
+	Node       *Node
+	RankedNode *RankedNode
+}
+
+// ScoreResponse is the score of a candidate placement.
+type ScoreResponse struct {
+	// Score is between -1 and 1. Positive values make the node more likely
+	// to be selected and negative values less likely. Values outside of the
+	// range are clamped by the scheduler.
+	Score float64
+
+	// Label is a short human readable explanation of the score, shown along
+	// with the score in the allocation metrics.
+	Label string
+}
+
+// Job is the subset of a job exposed to scoring plugins.
+type Job struct {
+	Namespace string
+	ID        string
+	Name      string
+	Type      string
+	Priority  int
+	NodePool  string
+	Meta      map[string]string
+}
+
+// TaskGroup is the subset of a task group exposed to scoring plugins.
+type TaskGroup struct {
+	Name  string
+	Count int
+	Meta  map[string]string
+	Tasks []*Task
+}
+
+// Task is the subset of a task exposed to scoring plugins.
+type Task struct {
+	Name   string
+	Driver string
+
+	// Config is the driver configuration of the task encoded as JSON.
+	Config []byte
+
+	// CPU is the requested CPU in MHz and MemoryMB the requested memory.
+	CPU      int
+	MemoryMB int
+}
+
+// Node is the subset of a node exposed to scoring plugins.
+type Node struct {
+	ID         string
+	Name       string
+	Datacenter string
+	NodeClass  string
+	NodePool   string
+	Attributes map[string]string
+	Meta       map[string]string
+}
+
+// RankedNode is the state of the candidate node in the ranking pipeline when
+// the plugin is called.
+type RankedNode struct {
+	// Scores are the non-zero scores given to the node by the built-in
+	// scorers.
+	Scores []float64
+
+	// ProposedAllocs is the number of allocations the node would be running
+	// if the placement is made, not counting the placement itself.
+	ProposedAllocs int
+}
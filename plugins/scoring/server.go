@@ -0,0 +1,30 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scoring
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/hashicorp/nomad/plugins/scoring/proto"
+)
+
+// scoringPluginServer wraps a scoring plugin and exposes it via gRPC.
+type scoringPluginServer struct {
+	broker *plugin.GRPCBroker
+	impl   ScoringPlugin
+}
+
+func (s *scoringPluginServer) Score(ctx context.Context, req *proto.ScoreRequest) (*proto.ScoreResponse, error) {
+	resp, err := s.impl.Score(ctx, convertProtoScoreRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ScoreResponse{
+		Score: resp.Score,
+		Label: resp.Label,
+	}, nil
+}
@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scoring
+
+import (
+	"github.com/hashicorp/nomad/plugins/scoring/proto"
+)
+
+// convertStructScoreRequest converts a score request to its protobuf
+// representation.
+func convertStructScoreRequest(in *ScoreRequest) *proto.ScoreRequest {
+	if in == nil {
+		return nil
+	}
+
+	out := &proto.ScoreRequest{}
+	if j := in.Job; j != nil {
+		out.Job = &proto.Job{
+			Namespace: j.Namespace,
+			Id:        j.ID,
+			Name:      j.Name,
+			Type:      j.Type,
+			Priority:  int64(j.Priority),
+			NodePool:  j.NodePool,
+			Meta:      j.Meta,
+		}
+	}
+	if tg := in.TaskGroup; tg != nil {
+		out.TaskGroup = &proto.TaskGroup{
+			Name:  tg.Name,
+			Count: int64(tg.Count),
+			Meta:  tg.Meta,
+			Tasks: make([]*proto.Task, 0, len(tg.Tasks)),
+		}
+		for _, t := range tg.Tasks {
+			out.TaskGroup.Tasks = append(out.TaskGroup.Tasks, &proto.Task{
+				Name:     t.Name,
+				Driver:   t.Driver,
+				Config:   t.Config,
+				Cpu:      int64(t.CPU),
+				MemoryMb: int64(t.MemoryMB),
+			})
+		}
+	}
+	if n := in.Node; n != nil {
+		out.Node = &proto.Node{
+			Id:         n.ID,
+			Name:       n.Name,
+			Datacenter: n.Datacenter,
+			NodeClass:  n.NodeClass,
+			NodePool:   n.NodePool,
+			Attributes: n.Attributes,
+			Meta:       n.Meta,
+		}
+	}
+	if rn := in.RankedNode; rn != nil {
+		out.RankedNode = &proto.RankedNode{
+			Scores:         rn.Scores,
+			ProposedAllocs: int64(rn.ProposedAllocs),
+		}
+	}
+	return out
+}
+
+// convertProtoScoreRequest converts a protobuf score request to its struct
+// representation.
+func convertProtoScoreRequest(in *proto.ScoreRequest) *ScoreRequest {
+	if in == nil {
+		return nil
+	}
+
+	out := &ScoreRequest{}
+	if j := in.GetJob(); j != nil {
+		out.Job = &Job{
+			Namespace: j.Namespace,
+			ID:        j.Id,
+			Name:      j.Name,
+			Type:      j.Type,
+			Priority:  int(j.Priority),
+			NodePool:  j.NodePool,
+			Meta:      j.Meta,
+		}
+	}
+	if tg := in.GetTaskGroup(); tg != nil {
+		out.TaskGroup = &TaskGroup{
+			Name:  tg.Name,
+			Count: int(tg.Count),
+			Meta:  tg.Meta,
+			Tasks: make([]*Task, 0, len(tg.Tasks)),
+		}
+		for _, t := range tg.Tasks {
+			out.TaskGroup.Tasks = append(out.TaskGroup.Tasks, &Task{
+				Name:     t.Name,
+				Driver:   t.Driver,
+				Config:   t.Config,
+				CPU:      int(t.Cpu),
+				MemoryMB: int(t.MemoryMb),
+			})
+		}
+	}
+	if n := in.GetNode(); n != nil {
+		out.Node = &Node{
+			ID:         n.Id,
+			Name:       n.Name,
+			Datacenter: n.Datacenter,
+			NodeClass:  n.NodeClass,
+			NodePool:   n.NodePool,
+			Attributes: n.Attributes,
+			Meta:       n.Meta,
+		}
+	}
+	if rn := in.GetRankedNode(); rn != nil {
+		out.RankedNode = &RankedNode{
+			Scores:         rn.Scores,
+			ProposedAllocs: int(rn.ProposedAllocs),
+		}
+	}
+	return out
+}
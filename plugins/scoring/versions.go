@@ -0,0 +1,9 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package scoring
+
+const (
+	// ApiVersion010 is the initial API version for the scoring plugins
+	ApiVersion010 = "v0.1.0"
+)
@@ -131,6 +131,70 @@ func BenchmarkServiceScheduler(b *testing.B) {
 
 }
 
+// BenchmarkSystemScheduler_NodeUpdate compares reconciling every node of a
+// system job with reconciling only the node that triggered the evaluation,
+// when a node joins clusters of increasing size.
+func BenchmarkSystemScheduler_NodeUpdate(b *testing.B) {
+
+	clusterSizes := []int{1000, 5000, 10000}
+
+	for _, clusterSize := range clusterSizes {
+		h := tests.NewHarness(b)
+		upsertNodes(h, clusterSize, 50)
+
+		job := mock.SystemJob()
+		job.Datacenters = []string{"dc-1", "dc-2"}
+		eval := upsertJob(h, job)
+		must.NoError(b, h.Process(scheduler.NewSystemScheduler, eval))
+		must.Len(b, 1, h.Plans)
+		must.MapLen(b, clusterSize, h.Plans[0].NodeAllocation)
+
+		// Add a node and don't submit the plans, so each run places the
+		// system job on the new node.
+		upsertNodes(h, 1, 50)
+		iter, err := h.State.Nodes(nil)
+		must.NoError(b, err)
+		var node *structs.Node
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			n := raw.(*structs.Node)
+			if _, ok := h.Plans[0].NodeAllocation[n.ID]; !ok {
+				node = n
+			}
+		}
+		must.NotNil(b, node)
+		h.SetNoSubmit()
+
+		for _, nodeScoped := range []bool{false, true} {
+			nodeEval := &structs.Evaluation{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    job.Priority,
+				TriggeredBy: structs.EvalTriggerNodeUpdate,
+				JobID:       job.ID,
+				Status:      structs.EvalStatusPending,
+			}
+			name := fmt.Sprintf("%d nodes all nodes", clusterSize)
+			if nodeScoped {
+				nodeEval.NodeID = node.ID
+				name = fmt.Sprintf("%d nodes single node", clusterSize)
+			}
+
+			h.Plans = nil
+			must.NoError(b, h.Process(scheduler.NewSystemScheduler, nodeEval))
+			must.Len(b, 1, h.Plans)
+			must.MapLen(b, 1, h.Plans[0].NodeAllocation)
+			must.MapContainsKey(b, h.Plans[0].NodeAllocation, node.ID)
+
+			b.Run(name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					err := h.Process(scheduler.NewSystemScheduler, nodeEval)
+					must.NoError(b, err)
+				}
+			})
+		}
+	}
+}
+
 func upsertJob(h *tests.Harness, job *structs.Job) *structs.Evaluation {
 	err := h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job)
 	if err != nil {
@@ -100,6 +100,7 @@ type EvalContext struct {
 	logger      log.Logger
 	metrics     *structs.AllocMetric
 	eligibility *EvalEligibility
+	explain     bool
 }
 
 // NewEvalContext constructs a new EvalContext
@@ -134,8 +135,20 @@ func (e *EvalContext) SetState(s sstructs.State) {
 	e.state = s
 }
 
+// SetExplain sets whether the metrics record what happened to every node
+// visited while placing an allocation.
+func (e *EvalContext) SetExplain(explain bool) {
+	e.explain = explain
+	if explain {
+		e.metrics.EnableExplain()
+	}
+}
+
 func (e *EvalContext) Reset() {
 	e.metrics = new(structs.AllocMetric)
+	if e.explain {
+		e.metrics.EnableExplain()
+	}
 }
 
 func (e *EvalContext) ProposedAllocs(nodeID string) ([]*structs.Allocation, error) {
@@ -13,6 +13,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-version"
@@ -36,6 +37,8 @@ const (
 	FilterConstraintDrivers                        = "missing drivers"
 	FilterConstraintDevices                        = "missing devices"
 	FilterConstraintSecrets                        = "missing secrets provider"
+	FilterConstraintTaintTemplate                  = "untolerated taint %s"
+	FilterConstraintLoadPressure                   = "node under resource pressure"
 	FilterConstraintsCSIPluginTopology             = "did not meet topology requirement"
 )
 
@@ -204,6 +207,79 @@ func (s *SecretsProviderChecker) hasSecrets(option *structs.Node) bool {
 	return true
 }
 
+// TaintChecker is a FeasibilityChecker which returns whether the task group
+// tolerates the NoSchedule and NoExecute taints of a node.
+type TaintChecker struct {
+	ctx         Context
+	tolerations []*structs.Toleration
+}
+
+func NewTaintChecker(ctx Context) *TaintChecker {
+	return &TaintChecker{ctx: ctx}
+}
+
+// SetTolerations sets the tolerations of the task group, including the ones
+// set on the job.
+func (c *TaintChecker) SetTolerations(tolerations []*structs.Toleration) {
+	c.tolerations = tolerations
+}
+
+func (c *TaintChecker) Feasible(option *structs.Node) bool {
+	untolerated := structs.UntoleratedTaints(option.Taints, c.tolerations,
+		structs.TaintEffectNoSchedule, structs.TaintEffectNoExecute)
+	if len(untolerated) == 0 {
+		return true
+	}
+	c.ctx.Metrics().FilterNode(option, fmt.Sprintf(FilterConstraintTaintTemplate, untolerated[0]))
+	return false
+}
+
+// LoadPressureChecker is a FeasibilityChecker which filters out the nodes
+// under resource pressure for best-effort jobs, when the load-aware scheduler
+// algorithm is used. The pressure is taken from the utilization published by
+// the node, which changes over time, so the checker must not be cached by
+// computed node class.
+type LoadPressureChecker struct {
+	ctx        Context
+	enabled    bool
+	bestEffort bool
+	config     structs.LoadAwareConfig
+	priority   int
+}
+
+func NewLoadPressureChecker(ctx Context) *LoadPressureChecker {
+	return &LoadPressureChecker{ctx: ctx}
+}
+
+func (c *LoadPressureChecker) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
+	c.enabled = schedConfig.EffectiveSchedulerAlgorithm() == structs.SchedulerAlgorithmLoadAware
+	c.config = structs.LoadAwareConfig{}
+	if schedConfig != nil {
+		c.config = schedConfig.LoadAwareConfig
+	}
+	c.bestEffort = c.config.IsBestEffort(c.priority)
+}
+
+func (c *LoadPressureChecker) SetJob(job *structs.Job) {
+	c.priority = job.Priority
+	c.bestEffort = c.config.IsBestEffort(c.priority)
+}
+
+func (c *LoadPressureChecker) Feasible(option *structs.Node) bool {
+	if !c.enabled || !c.bestEffort {
+		return true
+	}
+	util := option.Utilization
+	if util == nil || util.IsStale(time.Now()) {
+		return true
+	}
+	if util.MaxPressure() <= c.config.EffectivePressureThreshold() {
+		return true
+	}
+	c.ctx.Metrics().FilterNode(option, FilterConstraintLoadPressure)
+	return false
+}
+
 // HostVolumeChecker is a FeasibilityChecker which returns whether a node has
 // the host volumes necessary to schedule a task group.
 type HostVolumeChecker struct {
@@ -1378,6 +1454,11 @@ func (w *FeasibilityWrapper) Next() *structs.Node {
 	evalElig := w.ctx.Eligibility()
 	metrics := w.ctx.Metrics()
 
+	// When explaining placements, every node runs the checks as if its
+	// computed class was escaped, so the exact check it fails is recorded
+	// instead of its class being ineligible.
+	explain := metrics.Explaining()
+
 OUTER:
 	for {
 		// Get the next option from the source
@@ -1388,7 +1469,11 @@ OUTER:
 
 		// Check if the job has been marked as eligible or ineligible.
 		jobEscaped, jobUnknown := false, false
-		switch evalElig.JobStatus(option.ComputedClass) {
+		jobStatus := evalElig.JobStatus(option.ComputedClass)
+		if explain {
+			jobStatus = EvalComputedClassEscaped
+		}
+		switch jobStatus {
 		case EvalComputedClassIneligible:
 			// Fast path the ineligible case
 			metrics.FilterNode(option, "computed class ineligible")
@@ -1420,7 +1505,11 @@ OUTER:
 
 		// Check if the task group has been marked as eligible or ineligible.
 		tgEscaped, tgUnknown := false, false
-		switch evalElig.TaskGroupStatus(w.tg, option.ComputedClass) {
+		tgStatus := evalElig.TaskGroupStatus(w.tg, option.ComputedClass)
+		if explain {
+			tgStatus = EvalComputedClassEscaped
+		}
+		switch tgStatus {
 		case EvalComputedClassIneligible:
 			// Fast path the ineligible case
 			metrics.FilterNode(option, "computed class ineligible")
@@ -144,6 +144,130 @@ func TestSecretsChecker(t *testing.T) {
 	}
 }
 
+func TestTaintChecker(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := MockContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	nodes[1].Taints = []*structs.NodeTaint{
+		{Key: "dedicated", Value: "gpu", Effect: structs.TaintEffectNoSchedule},
+	}
+	nodes[2].Taints = []*structs.NodeTaint{
+		{Key: "dedicated", Value: "gpu", Effect: structs.TaintEffectPreferNoSchedule},
+		{Key: "maintenance", Effect: structs.TaintEffectNoExecute},
+	}
+
+	tolerateGPU := &structs.Toleration{Key: "dedicated", Operator: structs.TolerationOperatorEqual, Value: "gpu"}
+	tolerateAll := &structs.Toleration{Operator: structs.TolerationOperatorExists}
+
+	cases := []struct {
+		name        string
+		tolerations []*structs.Toleration
+		exp         []bool
+	}{
+		{
+			name: "no tolerations",
+			exp:  []bool{true, false, false},
+		},
+		{
+			name:        "tolerate gpu",
+			tolerations: []*structs.Toleration{tolerateGPU},
+			exp:         []bool{true, true, false},
+		},
+		{
+			name:        "tolerate all",
+			tolerations: []*structs.Toleration{tolerateAll},
+			exp:         []bool{true, true, true},
+		},
+	}
+
+	checker := NewTaintChecker(ctx)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker.SetTolerations(tc.tolerations)
+			for i, node := range nodes {
+				must.Eq(t, tc.exp[i], checker.Feasible(node), must.Sprintf("node %d", i))
+			}
+		})
+	}
+	must.MapContainsKey(t, ctx.Metrics().ConstraintFiltered, "untolerated taint maintenance:NoExecute")
+}
+
+func TestLoadPressureChecker(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := MockContext(t)
+	now := time.Now()
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	nodes[1].Utilization = &structs.NodeUtilization{CPUPressure: 10, UpdatedAt: now.Unix()}
+	nodes[2].Utilization = &structs.NodeUtilization{MemoryPressure: 40, UpdatedAt: now.Unix()}
+	nodes[3].Utilization = &structs.NodeUtilization{IOPressure: 40,
+		UpdatedAt: now.Add(-2 * structs.NodeUtilizationMaxAge).Unix()}
+
+	loadAware := &structs.SchedulerConfiguration{
+		SchedulerAlgorithm: structs.SchedulerAlgorithmLoadAware,
+	}
+
+	cases := []struct {
+		name        string
+		schedConfig *structs.SchedulerConfiguration
+		priority    int
+		exp         []bool
+	}{
+		{
+			name:        "binpack",
+			schedConfig: &structs.SchedulerConfiguration{},
+			priority:    10,
+			exp:         []bool{true, true, true, true},
+		},
+		{
+			name:        "best-effort",
+			schedConfig: loadAware,
+			priority:    10,
+			exp:         []bool{true, true, false, true},
+		},
+		{
+			name:        "not best-effort",
+			schedConfig: loadAware,
+			priority:    structs.JobDefaultPriority,
+			exp:         []bool{true, true, true, true},
+		},
+		{
+			name: "lower threshold",
+			schedConfig: &structs.SchedulerConfiguration{
+				SchedulerAlgorithm: structs.SchedulerAlgorithmLoadAware,
+				LoadAwareConfig:    structs.LoadAwareConfig{PressureThreshold: 5},
+			},
+			priority: 10,
+			exp:      []bool{true, false, false, true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := mock.Job()
+			job.Priority = tc.priority
+
+			checker := NewLoadPressureChecker(ctx)
+			checker.SetJob(job)
+			checker.SetSchedulerConfiguration(tc.schedConfig)
+			for i, node := range nodes {
+				must.Eq(t, tc.exp[i], checker.Feasible(node), must.Sprintf("node %d", i))
+			}
+		})
+	}
+	must.MapContainsKey(t, ctx.Metrics().ConstraintFiltered, FilterConstraintLoadPressure)
+}
+
 func TestHostVolumeChecker_Static(t *testing.T) {
 	ci.Parallel(t)
 
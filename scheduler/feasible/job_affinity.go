@@ -0,0 +1,257 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package feasible
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// FilterConstraintJobAffinityTemplate is the filter reason used when a
+	// node doesn't satisfy a required job affinity.
+	FilterConstraintJobAffinityTemplate = "missing %s"
+
+	// FilterConstraintJobAntiAffinityTemplate is the filter reason used when
+	// a node doesn't satisfy a required job anti-affinity.
+	FilterConstraintJobAntiAffinityTemplate = "conflicting %s"
+)
+
+// jobAffinityDomains tracks the topology domains running allocations
+// targeted by a job affinity.
+type jobAffinityDomains struct {
+	affinity *structs.JobAffinity
+
+	// domains are the values of the topology key on the nodes running the
+	// targeted allocations, once the plan is applied.
+	domains map[string]struct{}
+}
+
+// newJobAffinityDomains finds the topology domains running allocations
+// targeted by the job affinity. The allocations of the other job are read
+// from the state and updated with the allocations placed and stopped by the
+// plan. The nodes are memoized in the given map.
+func newJobAffinityDomains(ctx Context, affinity *structs.JobAffinity,
+	nodes map[string]*structs.Node) (*jobAffinityDomains, error) {
+
+	allocs, err := ctx.State().AllocsByJob(nil, affinity.Namespace, affinity.JobID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocations of job %q: %w", affinity.JobID, err)
+	}
+
+	// Map the running allocations to their node, applying the plan
+	allocNodes := make(map[string]string, len(allocs))
+	for _, alloc := range allocs {
+		if !alloc.TerminalStatus() && affinity.Matches(alloc) {
+			allocNodes[alloc.ID] = alloc.NodeID
+		}
+	}
+	if plan := ctx.Plan(); plan != nil {
+		for _, updates := range plan.NodeUpdate {
+			for _, alloc := range updates {
+				delete(allocNodes, alloc.ID)
+			}
+		}
+		for _, placed := range plan.NodeAllocation {
+			for _, alloc := range placed {
+				if !alloc.TerminalStatus() && affinity.Matches(alloc) {
+					allocNodes[alloc.ID] = alloc.NodeID
+				}
+			}
+		}
+	}
+
+	d := &jobAffinityDomains{
+		affinity: affinity,
+		domains:  make(map[string]struct{}),
+	}
+	for _, nodeID := range allocNodes {
+		node, ok := nodes[nodeID]
+		if !ok {
+			node, err = ctx.State().NodeByID(nil, nodeID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get node %q: %w", nodeID, err)
+			}
+			nodes[nodeID] = node
+		}
+		if value, ok := getProperty(node, affinity.TopologyKey); ok {
+			d.domains[value] = struct{}{}
+		}
+	}
+	return d, nil
+}
+
+// contains returns whether the node is in a topology domain running
+// allocations targeted by the job affinity.
+func (d *jobAffinityDomains) contains(node *structs.Node) bool {
+	value, ok := getProperty(node, d.affinity.TopologyKey)
+	if !ok {
+		return false
+	}
+	_, ok = d.domains[value]
+	return ok
+}
+
+// satisfiedBy returns whether the node is in a topology domain running
+// allocations targeted by an affinity, or in none for an anti-affinity.
+func (d *jobAffinityDomains) satisfiedBy(node *structs.Node) bool {
+	return d.contains(node) != d.affinity.Anti
+}
+
+// jobAffinitiesDomains finds the topology domains of each job affinity that
+// is required or not, depending on the given flag. The nodes are memoized in
+// the given map. Errors are logged and the job affinity ignored.
+func jobAffinitiesDomains(ctx Context, affinities []*structs.JobAffinity,
+	required bool, nodes map[string]*structs.Node) []*jobAffinityDomains {
+
+	var out []*jobAffinityDomains
+	for _, affinity := range affinities {
+		if affinity.Required != required {
+			continue
+		}
+		d, err := newJobAffinityDomains(ctx, affinity, nodes)
+		if err != nil {
+			ctx.Logger().Error("failed to evaluate job affinity", "affinity", affinity, "error", err)
+			continue
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// JobAffinityIterator is a FeasibleIterator which filters out the nodes that
+// don't satisfy the required job affinities of the task group. Unlike the
+// checkers of the feasibility wrapper, it depends on the allocations of
+// other jobs so its results can't be cached by computed node class.
+type JobAffinityIterator struct {
+	ctx    Context
+	source FeasibleIterator
+	job    *structs.Job
+
+	// nodes memoizes the nodes running targeted allocations
+	nodes map[string]*structs.Node
+
+	required []*jobAffinityDomains
+}
+
+// NewJobAffinityIterator creates a JobAffinityIterator from a source.
+func NewJobAffinityIterator(ctx Context, source FeasibleIterator) *JobAffinityIterator {
+	return &JobAffinityIterator{
+		ctx:    ctx,
+		source: source,
+		nodes:  make(map[string]*structs.Node),
+	}
+}
+
+func (iter *JobAffinityIterator) SetJob(job *structs.Job) {
+	iter.job = job
+}
+
+func (iter *JobAffinityIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	// The domains are found for every placement as placements of the plan
+	// can change them
+	affinities := iter.job.TaskGroupJobAffinities(tg)
+	iter.required = jobAffinitiesDomains(iter.ctx, affinities, true, iter.nodes)
+}
+
+func (iter *JobAffinityIterator) Next() *structs.Node {
+OUTER:
+	for {
+		option := iter.source.Next()
+
+		// Hot path if there is nothing to check
+		if option == nil || len(iter.required) == 0 {
+			return option
+		}
+
+		for _, d := range iter.required {
+			if !d.satisfiedBy(option) {
+				template := FilterConstraintJobAffinityTemplate
+				if d.affinity.Anti {
+					template = FilterConstraintJobAntiAffinityTemplate
+				}
+				iter.ctx.Metrics().FilterNode(option, fmt.Sprintf(template, d.affinity))
+				continue OUTER
+			}
+		}
+		return option
+	}
+}
+
+func (iter *JobAffinityIterator) Reset() {
+	iter.source.Reset()
+}
+
+// JobAffinityScoreIterator is a RankIterator which scores nodes according to
+// the job affinities of the task group that aren't required. Like the node
+// affinity score, the score is the sum of the weights of the job affinities
+// whose topology domains contain the node, negated for anti-affinities,
+// normalized by the sum of all weights.
+type JobAffinityScoreIterator struct {
+	ctx    Context
+	source RankIterator
+	job    *structs.Job
+
+	// nodes memoizes the nodes running targeted allocations
+	nodes map[string]*structs.Node
+
+	preferred []*jobAffinityDomains
+	sumWeight float64
+}
+
+// NewJobAffinityScoreIterator creates a JobAffinityScoreIterator from a
+// source.
+func NewJobAffinityScoreIterator(ctx Context, source RankIterator) *JobAffinityScoreIterator {
+	return &JobAffinityScoreIterator{
+		ctx:    ctx,
+		source: source,
+		nodes:  make(map[string]*structs.Node),
+	}
+}
+
+func (iter *JobAffinityScoreIterator) SetJob(job *structs.Job) {
+	iter.job = job
+}
+
+func (iter *JobAffinityScoreIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	affinities := iter.job.TaskGroupJobAffinities(tg)
+	iter.preferred = jobAffinitiesDomains(iter.ctx, affinities, false, iter.nodes)
+	iter.sumWeight = 0
+	for _, d := range iter.preferred {
+		iter.sumWeight += float64(d.affinity.Weight)
+	}
+}
+
+func (iter *JobAffinityScoreIterator) hasAffinities() bool {
+	return len(iter.preferred) > 0
+}
+
+func (iter *JobAffinityScoreIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || !iter.hasAffinities() {
+		return option
+	}
+
+	score := 0.0
+	for _, d := range iter.preferred {
+		if !d.contains(option.Node) {
+			continue
+		}
+		if d.affinity.Anti {
+			score -= float64(d.affinity.Weight)
+		} else {
+			score += float64(d.affinity.Weight)
+		}
+	}
+
+	normScore := score / iter.sumWeight
+	option.Scores = append(option.Scores, normScore)
+	iter.ctx.Metrics().ScoreNode(option.Node, "job-affinity", normScore)
+	return option
+}
+
+func (iter *JobAffinityScoreIterator) Reset() {
+	iter.source.Reset()
+}
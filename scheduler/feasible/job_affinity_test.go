@@ -0,0 +1,153 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package feasible
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobAffinityIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := MockContext(t)
+	nodes := topologySpreadNodes(t, store)
+
+	// The other job runs on the first node of zone a, and has a stopped
+	// allocation in zone b.
+	other := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 150, nil, other))
+	running := mock.AllocForNode(nodes[0])
+	running.Job, running.JobID = other, other.ID
+	stopped := mock.AllocForNode(nodes[2])
+	stopped.Job, stopped.JobID = other, other.ID
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 200,
+		[]*structs.Allocation{running, stopped}))
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+
+	cases := []struct {
+		name     string
+		affinity *structs.JobAffinity
+		expZones []string
+		expNodes int
+		reason   string
+	}{
+		{
+			name:     "same node",
+			affinity: &structs.JobAffinity{JobID: other.ID, Required: true},
+			expNodes: 1,
+		},
+		{
+			name: "same zone",
+			affinity: &structs.JobAffinity{JobID: other.ID, Required: true,
+				TopologyKey: "${meta.zone}"},
+			expZones: []string{"a", "a"},
+			reason:   "missing job affinity default/" + other.ID + " on ${meta.zone}",
+		},
+		{
+			name: "other zones",
+			affinity: &structs.JobAffinity{JobID: other.ID, Required: true, Anti: true,
+				TopologyKey: "${meta.zone}"},
+			expZones: []string{"b", "b", "c", "c"},
+			reason:   "conflicting job anti-affinity default/" + other.ID + " on ${meta.zone}",
+		},
+		{
+			name: "other task group",
+			affinity: &structs.JobAffinity{JobID: other.ID, TaskGroup: "db", Required: true,
+				Anti: true, TopologyKey: "${meta.zone}"},
+			expZones: []string{"a", "a", "b", "b", "c", "c"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx.Reset()
+			job := job.Copy()
+			job.JobAffinities = []*structs.JobAffinity{tc.affinity}
+			job.Canonicalize()
+
+			iter := NewJobAffinityIterator(ctx, NewStaticIterator(ctx, nodes))
+			iter.SetJob(job)
+			iter.SetTaskGroup(tg)
+
+			out := collectFeasible(iter)
+			if tc.expNodes != 0 {
+				must.Len(t, tc.expNodes, out)
+				must.Eq(t, nodes[0].ID, out[0].ID)
+				return
+			}
+			zones := make([]string, len(out))
+			for i, node := range out {
+				zones[i] = node.Meta["zone"]
+			}
+			must.SliceContainsAll(t, tc.expZones, zones)
+			if tc.reason != "" {
+				must.Eq(t, 6-len(tc.expZones), ctx.Metrics().ConstraintFiltered[tc.reason])
+			}
+		})
+	}
+
+	// Allocations of the other job placed by the plan are taken into account
+	ctx.Reset()
+	placed := mock.AllocForNode(nodes[4])
+	placed.Job, placed.JobID = other, other.ID
+	ctx.Plan().NodeAllocation[nodes[4].ID] = []*structs.Allocation{placed}
+	ctx.Plan().NodeUpdate[nodes[0].ID] = []*structs.Allocation{running}
+
+	job = job.Copy()
+	job.JobAffinities = []*structs.JobAffinity{
+		{JobID: other.ID, Required: true, TopologyKey: "${meta.zone}"},
+	}
+	job.Canonicalize()
+	iter := NewJobAffinityIterator(ctx, NewStaticIterator(ctx, nodes))
+	iter.SetJob(job)
+	iter.SetTaskGroup(tg)
+
+	out := collectFeasible(iter)
+	must.Len(t, 2, out)
+	must.Eq(t, "c", out[0].Meta["zone"])
+	must.Eq(t, "c", out[1].Meta["zone"])
+}
+
+func TestJobAffinityScoreIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := MockContext(t)
+	nodes := topologySpreadNodes(t, store)
+
+	// The cache runs in zone a and the noisy job in zone b
+	cache, noisy := mock.Job(), mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 150, nil, cache))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 151, nil, noisy))
+	allocs := []*structs.Allocation{mock.AllocForNode(nodes[0]), mock.AllocForNode(nodes[2])}
+	allocs[0].Job, allocs[0].JobID = cache, cache.ID
+	allocs[1].Job, allocs[1].JobID = noisy, noisy.ID
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 200, allocs))
+
+	job := mock.Job()
+	job.JobAffinities = []*structs.JobAffinity{
+		{JobID: cache.ID, TopologyKey: "${meta.zone}", Weight: 60},
+		{JobID: noisy.ID, TopologyKey: "${meta.zone}", Anti: true, Weight: 40},
+	}
+	job.Canonicalize()
+
+	static := NewStaticIterator(ctx, nodes)
+	iter := NewJobAffinityScoreIterator(ctx, NewFeasibleRankIterator(ctx, static))
+	iter.SetJob(job)
+	iter.SetTaskGroup(job.TaskGroups[0])
+
+	scores := make(map[string]float64)
+	for _, option := range collectRanked(iter) {
+		must.Len(t, 1, option.Scores)
+		scores[option.Node.Meta["zone"]] = option.Scores[0]
+	}
+	must.Eq(t, map[string]float64{"a": 0.6, "b": -0.4, "c": 0}, scores)
+}
@@ -4,6 +4,7 @@
 package feasible
 
 import (
+	"fmt"
 	"maps"
 	"math"
 	"sort"
@@ -129,6 +130,14 @@ type Preemptor struct {
 	// currentAllocs is the candidate set used to find preemptible allocations
 	currentAllocs []*structs.Allocation
 
+	// budgetHealthy caches the number of healthy allocations of task groups
+	// with a disruption budget, keyed by disruptionBudgetKey
+	budgetHealthy map[string]int
+
+	// budgetBlocked is the set of task groups whose disruption budget kept
+	// their allocations on the node from being preempted
+	budgetBlocked map[string]struct{}
+
 	// ctx is the context from the scheduler stack
 	ctx Context
 }
@@ -139,6 +148,8 @@ func NewPreemptor(jobPriority int, ctx Context, jobID *structs.NamespacedID) *Pr
 		jobPriority:        jobPriority,
 		jobID:              jobID,
 		allocDetails:       make(map[string]*allocInfo),
+		budgetHealthy:      make(map[string]int),
+		budgetBlocked:      make(map[string]struct{}),
 		ctx:                ctx,
 	}
 }
@@ -156,6 +167,8 @@ func (p *Preemptor) Copy() *Preemptor {
 		jobID:                  p.jobID,
 		nodeRemainingResources: p.nodeRemainingResources.Copy(),
 		currentAllocs:          helper.CopySlice(p.currentAllocs),
+		budgetHealthy:          p.budgetHealthy,
+		budgetBlocked:          maps.Clone(p.budgetBlocked),
 		ctx:                    p.ctx,
 	}
 }
@@ -171,10 +184,31 @@ func (p *Preemptor) SetNode(node *structs.Node) {
 	p.nodeRemainingResources = nodeRemainingResources
 }
 
+// SetBudgetHealthy sets the cache of healthy allocation counts of task groups
+// with a disruption budget, so it can be shared by the preemptors of all the
+// nodes considered for a placement.
+func (p *Preemptor) SetBudgetHealthy(healthy map[string]int) {
+	if healthy != nil {
+		p.budgetHealthy = healthy
+	}
+}
+
+// SetHeldCapacity sets the capacity reservations hold on the node for jobs
+// other than the one being placed. Preempting allocations cannot free it, so
+// it is subtracted from the resources remaining on the node.
+func (p *Preemptor) SetHeldCapacity(held *structs.ReservationPlacement) {
+	if held == nil || p.nodeRemainingResources == nil {
+		return
+	}
+	p.nodeRemainingResources.Flattened.Cpu.CpuShares -= held.CPU
+	p.nodeRemainingResources.Flattened.Memory.MemoryMB -= held.MemoryMB
+}
+
 // SetCandidates initializes the candidate set from which preemptions are chosen
 func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 	// Reset candidate set
 	p.currentAllocs = []*structs.Allocation{}
+	budgetCandidates := make(map[string]int)
 	for _, alloc := range allocs {
 		// Ignore any allocations of the job being placed
 		// This filters out any previous allocs of the job, and any new allocs in the plan
@@ -187,11 +221,65 @@ func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 		if tg != nil && tg.Migrate != nil {
 			maxParallel = tg.Migrate.MaxParallel
 		}
+
+		// Only offer as many allocations of a task group as its disruption
+		// budget allows to be preempted
+		if tg != nil && tg.DisruptionBudget != nil {
+			key := disruptionBudgetKey(alloc)
+			if budgetCandidates[key] >= p.allowedPreemptions(alloc, tg) {
+				p.blockPreemption(alloc, key)
+				continue
+			}
+			budgetCandidates[key]++
+		}
+
 		p.allocDetails[alloc.ID] = &allocInfo{maxParallel: maxParallel, resources: alloc.AllocatedResources.Comparable()}
 		p.currentAllocs = append(p.currentAllocs, alloc)
 	}
 }
 
+// allowedPreemptions returns how many allocations of the task group can be
+// preempted without violating its disruption budget, accounting for the
+// allocations the plan already preempts.
+func (p *Preemptor) allowedPreemptions(alloc *structs.Allocation, tg *structs.TaskGroup) int {
+	key := disruptionBudgetKey(alloc)
+	healthy, ok := p.budgetHealthy[key]
+	if !ok {
+		allocs, err := p.ctx.State().AllocsByJob(nil, alloc.Namespace, alloc.JobID, false)
+		if err != nil {
+			p.ctx.Logger().Error("failed to look up allocations for disruption budget",
+				"job_id", alloc.JobID, "namespace", alloc.Namespace, "error", err)
+			return 0
+		}
+		for _, a := range allocs {
+			if a.TaskGroup == tg.Name && a.DisruptionHealthy() {
+				healthy++
+			}
+		}
+		p.budgetHealthy[key] = healthy
+	}
+	return tg.DisruptionBudget.AllowedDisruptions(tg.Count, healthy) - p.getNumPreemptions(alloc)
+}
+
+// blockPreemption records that the disruption budget of the task group kept
+// the allocation from being preempted, if it could have been otherwise.
+func (p *Preemptor) blockPreemption(alloc *structs.Allocation, key string) {
+	if p.jobPriority-alloc.Job.Priority < 10 {
+		return
+	}
+	if _, ok := p.budgetBlocked[key]; ok {
+		return
+	}
+	p.budgetBlocked[key] = struct{}{}
+	p.ctx.Metrics().BlockPreemption(key)
+}
+
+// disruptionBudgetKey identifies the task group of an allocation in the
+// metrics of preemptions blocked by disruption budgets.
+func disruptionBudgetKey(alloc *structs.Allocation) string {
+	return fmt.Sprintf("%s/%s/%s", alloc.Namespace, alloc.JobID, alloc.TaskGroup)
+}
+
 // SetPreemptions initializes a map tracking existing counts of preempted allocations
 // per job/task group. This is used while scoring preemption options
 func (p *Preemptor) SetPreemptions(allocs []*structs.Allocation) {
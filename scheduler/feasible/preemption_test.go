@@ -1367,3 +1367,57 @@ func TestPreemption_Normal(t *testing.T) {
 		})
 	}
 }
+
+func TestPreemptor_DisruptionBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := MockContext(t)
+
+	job := mock.Job()
+	job.Priority = 30
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{MinHealthy: 2}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	var allocs []*structs.Allocation
+	for range 3 {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+
+	// Only one allocation of the task group can be preempted, and the
+	// blocked preemption of the others is recorded once per node.
+	jobID := structs.NewNamespacedID("other", structs.DefaultNamespace)
+	preemptor := NewPreemptor(100, ctx, &jobID)
+	preemptor.SetPreemptions(nil)
+	preemptor.SetCandidates(allocs)
+	must.Len(t, 1, preemptor.currentAllocs)
+	preemptor.SetCandidates(allocs)
+	must.Eq(t, map[string]int{disruptionBudgetKey(allocs[0]): 1}, ctx.Metrics().PreemptionBlocked)
+
+	// No more allocations can be preempted once the plan preempts one.
+	preemptor = NewPreemptor(100, ctx, &jobID)
+	preemptor.SetPreemptions(allocs[:1])
+	preemptor.SetCandidates(allocs[1:])
+	must.Len(t, 0, preemptor.currentAllocs)
+	must.Eq(t, map[string]int{disruptionBudgetKey(allocs[0]): 2}, ctx.Metrics().PreemptionBlocked)
+
+	// Preemptors sharing the healthy counts only look them up once.
+	healthy := make(map[string]int)
+	preemptor = NewPreemptor(100, ctx, &jobID)
+	preemptor.SetBudgetHealthy(healthy)
+	preemptor.SetPreemptions(nil)
+	preemptor.SetCandidates(allocs)
+	must.Eq(t, map[string]int{disruptionBudgetKey(allocs[0]): 3}, healthy)
+
+	healthy[disruptionBudgetKey(allocs[0])] = 2
+	preemptor = NewPreemptor(100, ctx, &jobID)
+	preemptor.SetBudgetHealthy(healthy)
+	preemptor.SetPreemptions(nil)
+	preemptor.SetCandidates(allocs)
+	must.Len(t, 0, preemptor.currentAllocs)
+}
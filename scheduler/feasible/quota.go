@@ -0,0 +1,132 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package feasible
+
+import (
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// QuotaIterator is a FeasibleIterator which returns no nodes if placing the
+// task group would exceed the quota attached to the job's namespace. The
+// usage includes the allocations already placed or stopped by the in-flight
+// plan, so it must be the last feasibility iterator before ranking.
+type QuotaIterator struct {
+	ctx    Context
+	source FeasibleIterator
+
+	// quota and limit are the quota attached to the job's namespace and its
+	// limit for the local region. limit is nil when there is nothing to
+	// enforce.
+	quota     string
+	namespace string
+	limit     *structs.QuotaLimit
+
+	tg *structs.TaskGroup
+
+	// checked and exhausted cache the result of the quota check until the
+	// iterator is reset.
+	checked   bool
+	exhausted bool
+}
+
+// NewQuotaIterator creates a QuotaIterator that enforces the quota attached
+// to the namespace of the job being scheduled.
+func NewQuotaIterator(ctx Context, source FeasibleIterator) FeasibleIterator {
+	return &QuotaIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *QuotaIterator) SetJob(job *structs.Job) {
+	iter.quota = ""
+	iter.namespace = job.Namespace
+	iter.limit = nil
+	iter.checked = false
+
+	ns, err := iter.ctx.State().NamespaceByName(nil, job.Namespace)
+	if err != nil {
+		iter.ctx.Logger().Named("quota").Error("failed to lookup namespace", "namespace", job.Namespace, "error", err)
+		return
+	}
+	if ns == nil || ns.Quota == "" {
+		return
+	}
+
+	spec, err := iter.ctx.State().QuotaSpecByName(nil, ns.Quota)
+	if err != nil {
+		iter.ctx.Logger().Named("quota").Error("failed to lookup quota", "quota", ns.Quota, "error", err)
+		return
+	}
+	if spec == nil {
+		return
+	}
+
+	iter.quota = spec.Name
+	iter.limit = spec.RegionLimit(iter.ctx.State().Config().Region)
+}
+
+func (iter *QuotaIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+	iter.checked = false
+}
+
+func (iter *QuotaIterator) Next() *structs.Node {
+	if iter.limit == nil || iter.tg == nil {
+		return iter.source.Next()
+	}
+
+	if !iter.checked {
+		iter.checked = true
+		iter.exhausted = false
+
+		if dimensions := iter.exhaustedDimensions(); len(dimensions) > 0 {
+			iter.exhausted = true
+			iter.ctx.Metrics().ExhaustQuota(dimensions)
+			iter.ctx.Eligibility().SetQuotaLimitReached(iter.quota)
+		}
+	}
+
+	if iter.exhausted {
+		return nil
+	}
+	return iter.source.Next()
+}
+
+// exhaustedDimensions returns the quota dimensions that placing another
+// instance of the task group would exceed.
+func (iter *QuotaIterator) exhaustedDimensions() []string {
+	logger := iter.ctx.Logger().Named("quota")
+
+	used := new(structs.QuotaResources)
+	usage, err := iter.ctx.State().QuotaUsageByName(nil, iter.quota)
+	if err != nil {
+		logger.Error("failed to lookup quota usage", "quota", iter.quota, "error", err)
+		return nil
+	}
+	if usage != nil {
+		if existing, ok := usage.Used[iter.limit.HashKey()]; ok && existing.RegionLimit != nil {
+			used = existing.RegionLimit.Copy()
+		}
+	}
+
+	// Only the job's namespace is considered since a plan only ever touches
+	// the allocations of a single job.
+	inQuota := func(namespace string) bool { return namespace == iter.namespace }
+	planned, err := iter.ctx.Plan().QuotaUsageDelta(inQuota, func(allocID string) (*structs.Allocation, error) {
+		return iter.ctx.State().AllocByID(nil, allocID)
+	})
+	if err != nil {
+		logger.Error("failed to compute planned quota usage", "quota", iter.quota, "error", err)
+		return nil
+	}
+	used.Add(planned)
+
+	return iter.limit.RegionLimit.Exceeds(used, structs.TaskGroupQuotaResources(iter.tg))
+}
+
+func (iter *QuotaIterator) Reset() {
+	iter.checked = false
+	iter.source.Reset()
+}
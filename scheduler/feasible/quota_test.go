@@ -0,0 +1,81 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package feasible
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestQuotaIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := MockContext(t)
+
+	spec := mock.QuotaSpec()
+	spec.Limits[0].RegionLimit.CPU = 1200
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 100, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, store.UpsertNamespaces(101, []*structs.Namespace{ns}))
+
+	nodes := []*structs.Node{mock.Node(), mock.Node()}
+	job := mock.Job()
+	job.Namespace = ns.Name
+	tg := job.TaskGroups[0]
+
+	// An existing allocation uses 500 of the 1200 CPU.
+	alloc := mock.Alloc()
+	alloc.Namespace = ns.Name
+	alloc.Job = job
+	alloc.JobID = job.ID
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, job))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 103, []*structs.Allocation{alloc}))
+
+	static := NewStaticIterator(ctx, nodes)
+	iter := NewQuotaIterator(ctx, static)
+	iter.(ContextualIterator).SetJob(job)
+	iter.(ContextualIterator).SetTaskGroup(tg)
+
+	must.Len(t, 2, collectFeasible(iter))
+	must.Eq(t, "", ctx.Eligibility().QuotaLimitReached())
+
+	// A planned allocation uses another 500, so one more doesn't fit.
+	planned := alloc.Copy()
+	planned.ID = uuid.Generate()
+	ctx.Plan().NodeAllocation[nodes[0].ID] = []*structs.Allocation{planned}
+
+	static.Reset()
+	iter.Reset()
+	must.Len(t, 0, collectFeasible(iter))
+	must.Eq(t, spec.Name, ctx.Eligibility().QuotaLimitReached())
+	must.Eq(t, []string{"cpu exhausted (1500 needed > 1200 limit)"}, ctx.Metrics().QuotaExhausted)
+
+	// Stopping the existing allocation in the same plan frees the quota.
+	ctx.Plan().NodeUpdate[alloc.NodeID] = []*structs.Allocation{alloc}
+
+	static.Reset()
+	iter.Reset()
+	must.Len(t, 2, collectFeasible(iter))
+}
+
+func TestQuotaIterator_NoQuota(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := MockContext(t)
+	nodes := []*structs.Node{mock.Node(), mock.Node()}
+
+	iter := NewQuotaIterator(ctx, NewStaticIterator(ctx, nodes))
+	job := mock.Job()
+	iter.(ContextualIterator).SetJob(job)
+	iter.(ContextualIterator).SetTaskGroup(job.TaskGroups[0])
+
+	must.Len(t, 2, collectFeasible(iter))
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"slices"
+	"time"
 
 	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/client/lib/idset"
@@ -23,6 +24,12 @@ const (
 	binPackingMaxFitScore = 18.0
 )
 
+// NormalizedScoreFitBinPack returns the bin packing score of the node with
+// the given utilization, normalized the same way as by the BinPackIterator.
+func NormalizedScoreFitBinPack(node *structs.Node, util *structs.ComparableResources) float64 {
+	return structs.ScoreFitBinPack(node, util) / binPackingMaxFitScore
+}
+
 // Rank is used to provide a score and various ranking metadata
 // along with a node when iterating. This state can be modified as
 // various rank methods are applied.
@@ -167,6 +174,15 @@ type BinPackIterator struct {
 	taskGroup              *structs.TaskGroup
 	memoryOversubscription bool
 	scoreFit               func(*structs.Node, *structs.ComparableResources) float64
+
+	// reservations are the capacity reservations the job cannot consume,
+	// whose held capacity counts against the nodes they are placed on.
+	reservations []*structs.Reservation
+
+	// budgetHealthy caches the number of healthy allocations of task groups
+	// with a disruption budget for the preemptors of all nodes, keyed by
+	// disruptionBudgetKey
+	budgetHealthy map[string]int
 }
 
 // NewBinPackIterator returns a BinPackIterator which tries to fit tasks
@@ -182,12 +198,28 @@ func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority i
 		// SetSchedulerConfiguration.
 		memoryOversubscription: false,
 		scoreFit:               structs.ScoreFitBinPack,
+		budgetHealthy:          make(map[string]int),
 	}
 }
 
 func (iter *BinPackIterator) SetJob(job *structs.Job) {
 	iter.priority = job.Priority
 	iter.jobId = job.NamespacedID()
+	iter.budgetHealthy = make(map[string]int)
+
+	iter.reservations = nil
+	reservations, err := iter.ctx.State().Reservations(nil)
+	if err != nil {
+		iter.ctx.Logger().Named("binpack").Error("failed retrieving reservations", "error", err)
+		return
+	}
+	now := time.Now()
+	for raw := reservations.Next(); raw != nil; raw = reservations.Next() {
+		res := raw.(*structs.Reservation)
+		if !res.Expired(now) && !res.ConsumesJob(job) {
+			iter.reservations = append(iter.reservations, res)
+		}
+	}
 }
 
 func (iter *BinPackIterator) SetTaskGroup(taskGroup *structs.TaskGroup) {
@@ -198,8 +230,15 @@ func (iter *BinPackIterator) SetSchedulerConfiguration(schedConfig *structs.Sche
 	// Set scoring function.
 	algorithm := schedConfig.EffectiveSchedulerAlgorithm()
 	scoreFn := structs.ScoreFitBinPack
-	if algorithm == structs.SchedulerAlgorithmSpread {
+	switch algorithm {
+	case structs.SchedulerAlgorithmSpread:
 		scoreFn = structs.ScoreFitSpread
+	case structs.SchedulerAlgorithmLoadAware:
+		usageWeight := schedConfig.LoadAwareConfig.EffectiveUsageWeight()
+		now := time.Now()
+		scoreFn = func(node *structs.Node, util *structs.ComparableResources) float64 {
+			return structs.ScoreFitLoadAware(node, util, usageWeight, now)
+		}
 	}
 	iter.scoreFit = scoreFn
 
@@ -278,6 +317,7 @@ NEXTNODE:
 		// Initialize preemptor with node
 		preemptor := NewPreemptor(iter.priority, iter.ctx, &iter.jobId)
 		preemptor.SetNode(option.Node)
+		preemptor.SetBudgetHealthy(iter.budgetHealthy)
 
 		// Count the number of existing preemptions
 		allPreemptions := iter.ctx.Plan().NodePreemptions
@@ -379,6 +419,7 @@ NEXTNODE:
 					MemoryMB: safemath.Add(
 						int64(task.Resources.MemoryMB), int64(task.Resources.SecretsMB)),
 				},
+				CustomResources: task.Resources.CustomResources.Counts(),
 			}
 			if iter.memoryOversubscription {
 				taskResources.Memory.MemoryMaxMB = safemath.Add(
@@ -738,6 +779,23 @@ NEXTNODE:
 		// Check if these allocations fit, if they do not, simply skip this node
 		fit, dim, util, _ := structs.AllocsFit(option.Node, proposed, netIdx, false)
 		netIdx.Release()
+
+		// Count the capacity reservations hold on the node for other jobs as
+		// used. Reserved capacity cannot be preempted, so preemption has to
+		// free room for both the task group and the held capacity.
+		var held *structs.ReservationPlacement
+		if len(iter.reservations) > 0 {
+			held = structs.ReservationsHeldOnNode(iter.reservations, option.Node.ID, current)
+		}
+		if fit && held != nil {
+			var superset bool
+			util, superset, dim = addHeldCapacity(option.Node, util, held)
+			if !superset {
+				fit = false
+				dim = "reserved " + dim
+			}
+		}
+
 		if !fit {
 			// Skip the node if evictions are not enabled
 			if !iter.evict {
@@ -750,6 +808,7 @@ NEXTNODE:
 
 			// Initialize preemptor with candidate set
 			preemptor.SetCandidates(current)
+			preemptor.SetHeldCapacity(held)
 
 			preemptedAllocs := preemptor.PreemptForTaskGroup(total)
 			allocsToPreempt = append(allocsToPreempt, preemptedAllocs...)
@@ -761,6 +820,26 @@ NEXTNODE:
 				continue
 			}
 		}
+
+		// Preempting allocations that consume a reservation increases the
+		// capacity it holds, so check that the held capacity still fits once
+		// the preempted allocations are gone.
+		if held != nil && len(allocsToPreempt) > 0 {
+			remaining := make([]*structs.Allocation, 0, len(proposed))
+			used := new(structs.ComparableResources)
+			for _, alloc := range proposed {
+				if alloc.ClientTerminalStatus() || slices.Contains(allocsToPreempt, alloc) {
+					continue
+				}
+				remaining = append(remaining, alloc)
+				used.Add(alloc.AllocatedResources.Comparable())
+			}
+			heldAfter := structs.ReservationsHeldOnNode(iter.reservations, option.Node.ID, remaining)
+			if _, superset, dimension := addHeldCapacity(option.Node, used, heldAfter); !superset {
+				iter.ctx.Metrics().ExhaustedNode(option.Node, "reserved "+dimension)
+				continue
+			}
+		}
 		if len(allocsToPreempt) > 0 {
 			option.PreemptedAllocs = allocsToPreempt
 		}
@@ -786,6 +865,26 @@ func (iter *BinPackIterator) Reset() {
 	iter.source.Reset()
 }
 
+// addHeldCapacity returns the utilization of the node with the capacity held
+// by reservations added, and whether it still fits the node. If it doesn't,
+// the exhausted dimension is returned.
+func addHeldCapacity(node *structs.Node, util *structs.ComparableResources,
+	held *structs.ReservationPlacement) (*structs.ComparableResources, bool, string) {
+
+	if held.CPU == 0 && held.MemoryMB == 0 {
+		return util, true, ""
+	}
+
+	util = util.Copy()
+	util.Flattened.Cpu.CpuShares += held.CPU
+	util.Flattened.Memory.MemoryMB += held.MemoryMB
+
+	available := node.NodeResources.Comparable()
+	available.Subtract(node.ReservedResources.Comparable())
+	superset, dimension := available.Superset(util)
+	return util, superset, dimension
+}
+
 // JobAntiAffinityIterator is used to apply an anti-affinity to allocating
 // along side other allocations from this job. This is used to help distribute
 // load across the cluster.
@@ -901,6 +1000,49 @@ func (iter *NodeReschedulingPenaltyIterator) Reset() {
 	iter.source.Reset()
 }
 
+// NodeTaintIterator is used to apply a penalty to nodes with PreferNoSchedule
+// taints that the task group doesn't tolerate.
+type NodeTaintIterator struct {
+	ctx         Context
+	source      RankIterator
+	tolerations []*structs.Toleration
+}
+
+// NewNodeTaintIterator is used to create a NodeTaintIterator that avoids
+// nodes with untolerated PreferNoSchedule taints.
+func NewNodeTaintIterator(ctx Context, source RankIterator) *NodeTaintIterator {
+	return &NodeTaintIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+// SetTolerations sets the tolerations of the task group, including the ones
+// set on the job.
+func (iter *NodeTaintIterator) SetTolerations(tolerations []*structs.Toleration) {
+	iter.tolerations = tolerations
+}
+
+func (iter *NodeTaintIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil {
+		return nil
+	}
+
+	untolerated := structs.UntoleratedTaints(option.Node.Taints, iter.tolerations,
+		structs.TaintEffectPreferNoSchedule)
+	if len(untolerated) != 0 {
+		option.Scores = append(option.Scores, -1)
+		iter.ctx.Metrics().ScoreNode(option.Node, "node-taints", -1)
+	}
+
+	return option
+}
+
+func (iter *NodeTaintIterator) Reset() {
+	iter.source.Reset()
+}
+
 // NodeAffinityIterator is used to resolve any affinity rules in the job or task group,
 // and apply a weighted score to nodes if they match.
 type NodeAffinityIterator struct {
@@ -2315,6 +2315,44 @@ func TestNodeAntiAffinity_PenaltyNodes(t *testing.T) {
 
 }
 
+func TestNodeTaintIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := MockContext(t)
+	node1 := &structs.Node{
+		ID: uuid.Generate(),
+		Taints: []*structs.NodeTaint{
+			{Key: "dedicated", Value: "gpu", Effect: structs.TaintEffectPreferNoSchedule},
+		},
+	}
+	node2 := &structs.Node{
+		ID: uuid.Generate(),
+	}
+
+	nodes := []*RankedNode{{Node: node1}, {Node: node2}}
+	static := NewStaticRankIterator(ctx, nodes)
+	taintIter := NewNodeTaintIterator(ctx, static)
+	scoreNorm := NewScoreNormalizationIterator(ctx, taintIter)
+
+	out := collectRanked(scoreNorm)
+	must.Len(t, 2, out)
+	must.Eq(t, node1.ID, out[0].Node.ID)
+	must.Eq(t, -1.0, out[0].FinalScore)
+	must.Eq(t, node2.ID, out[1].Node.ID)
+	must.Eq(t, 0.0, out[1].FinalScore)
+
+	// Tolerating the taint removes the penalty
+	nodes = []*RankedNode{{Node: node1}, {Node: node2}}
+	static = NewStaticRankIterator(ctx, nodes)
+	taintIter = NewNodeTaintIterator(ctx, static)
+	taintIter.SetTolerations([]*structs.Toleration{
+		{Key: "dedicated", Operator: structs.TolerationOperatorExists},
+	})
+	out = collectRanked(NewScoreNormalizationIterator(ctx, taintIter))
+	must.Len(t, 2, out)
+	must.Eq(t, 0.0, out[0].FinalScore)
+}
+
 func TestScoreNormalizationIterator(t *testing.T) {
 	// Test normalized scores when there is more than one scorer
 	_, ctx := MockContext(t)
@@ -0,0 +1,89 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package feasible
+
+import (
+	"math"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	sstructs "github.com/hashicorp/nomad/scheduler/structs"
+)
+
+// NodeScorerIterator is used to apply the scores of external node scorers,
+// such as scoring plugins, to the ranked nodes. Scorers that fail or return a
+// score of zero do not contribute to the normalized score of the node.
+type NodeScorerIterator struct {
+	ctx     Context
+	source  RankIterator
+	scorers []sstructs.NodeScorer
+	job     *structs.Job
+	tg      *structs.TaskGroup
+}
+
+// NewNodeScorerIterator is used to create a NodeScorerIterator that applies
+// the scores of the given scorers.
+func NewNodeScorerIterator(ctx Context, source RankIterator) *NodeScorerIterator {
+	return &NodeScorerIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *NodeScorerIterator) SetScorers(scorers []sstructs.NodeScorer) {
+	iter.scorers = scorers
+}
+
+func (iter *NodeScorerIterator) SetJob(job *structs.Job) {
+	iter.job = job
+}
+
+func (iter *NodeScorerIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+}
+
+func (iter *NodeScorerIterator) Reset() {
+	iter.source.Reset()
+}
+
+func (iter *NodeScorerIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || len(iter.scorers) == 0 {
+		return option
+	}
+
+	proposed, err := option.ProposedAllocs(iter.ctx)
+	if err != nil {
+		iter.ctx.Logger().Named("node_scorer").Error("failed to retrieve proposed allocations",
+			"error", err)
+		return option
+	}
+
+	req := &sstructs.NodeScoreRequest{
+		Job:            iter.job,
+		TaskGroup:      iter.tg,
+		Node:           option.Node,
+		Scores:         option.Scores,
+		ProposedAllocs: len(proposed),
+	}
+	for _, scorer := range iter.scorers {
+		score, label, err := scorer.ScoreNode(req)
+		if err != nil {
+			iter.ctx.Logger().Named("node_scorer").Warn("failed to score node",
+				"scorer", scorer.Name(), "node_id", option.Node.ID, "error", err)
+			continue
+		}
+		if math.IsNaN(score) {
+			score = 0
+		}
+		score = math.Max(-1, math.Min(1, score))
+		if score != 0 {
+			option.Scores = append(option.Scores, score)
+		}
+		iter.ctx.Metrics().ScoreNode(option.Node, scorer.Name(), score)
+		if label != "" {
+			iter.ctx.Metrics().LabelNode(option.Node, scorer.Name(), label)
+		}
+	}
+	return option
+}
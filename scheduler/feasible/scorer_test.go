@@ -0,0 +1,109 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package feasible
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	sstructs "github.com/hashicorp/nomad/scheduler/structs"
+	"github.com/shoenig/test/must"
+)
+
+// testNodeScorer is a NodeScorer that returns the score and label configured
+// for each node.
+type testNodeScorer struct {
+	name   string
+	scores map[string]float64
+	labels map[string]string
+	err    error
+
+	requests []*sstructs.NodeScoreRequest
+}
+
+func (s *testNodeScorer) Name() string { return s.name }
+
+func (s *testNodeScorer) ScoreNode(req *sstructs.NodeScoreRequest) (float64, string, error) {
+	s.requests = append(s.requests, req)
+	if s.err != nil {
+		return 0, "", s.err
+	}
+	return s.scores[req.Node.ID], s.labels[req.Node.ID], nil
+}
+
+func TestNodeScorerIterator(t *testing.T) {
+	ci.Parallel(t)
+	_, ctx := MockContext(t)
+
+	nodes := []*RankedNode{
+		{Node: mock.Node(), Scores: []float64{0.5}},
+		{Node: mock.Node(), Scores: []float64{0.5}},
+		{Node: mock.Node(), Scores: []float64{0.5}},
+	}
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+
+	scorer := &testNodeScorer{
+		name: "plugin.cost",
+		scores: map[string]float64{
+			nodes[0].Node.ID: 1,
+			nodes[1].Node.ID: -3, // clamped to -1
+		},
+		labels: map[string]string{
+			nodes[0].Node.ID: "spot price $0.02/h",
+		},
+	}
+	failing := &testNodeScorer{name: "plugin.broken", err: errors.New("unavailable")}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	nodeScorers := NewNodeScorerIterator(ctx, static)
+	nodeScorers.SetScorers([]sstructs.NodeScorer{scorer, failing})
+	nodeScorers.SetJob(job)
+	nodeScorers.SetTaskGroup(tg)
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, nodeScorers)
+	out := collectRanked(scoreNorm)
+	must.Len(t, 3, out)
+
+	must.Eq(t, 0.75, out[0].FinalScore)
+	must.Eq(t, -0.25, out[1].FinalScore)
+
+	// A zero score does not count towards the normalized score
+	must.Eq(t, 0.5, out[2].FinalScore)
+	must.Len(t, 1, out[2].Scores)
+
+	// Failing scorers are skipped but still called for every node
+	must.Len(t, 3, failing.requests)
+	must.Len(t, 3, scorer.requests)
+	must.Eq(t, job, scorer.requests[0].Job)
+	must.Eq(t, tg, scorer.requests[0].TaskGroup)
+
+	ctx.Metrics().PopulateScoreMetaData()
+	var meta *structs.NodeScoreMeta
+	for _, m := range ctx.Metrics().ScoreMetaData {
+		if m.NodeID == nodes[0].Node.ID {
+			meta = m
+		}
+	}
+	must.NotNil(t, meta)
+	must.Eq(t, 1.0, meta.Scores["plugin.cost"])
+	must.Eq(t, "spot price $0.02/h", meta.Labels["plugin.cost"])
+	must.MapNotContainsKey(t, meta.Scores, "plugin.broken")
+}
+
+func TestNodeScorerIterator_NoScorers(t *testing.T) {
+	ci.Parallel(t)
+	_, ctx := MockContext(t)
+
+	nodes := []*RankedNode{{Node: mock.Node(), Scores: []float64{0.5}}}
+	static := NewStaticRankIterator(ctx, nodes)
+	nodeScorers := NewNodeScorerIterator(ctx, static)
+
+	out := collectRanked(nodeScorers)
+	must.Len(t, 1, out)
+	must.Eq(t, []float64{0.5}, out[0].Scores)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/nomad/nomad/structs"
+	sstructs "github.com/hashicorp/nomad/scheduler/structs"
 )
 
 const (
@@ -51,6 +52,7 @@ type GenericStack struct {
 
 	wrappedChecks        *FeasibilityWrapper
 	quota                FeasibleIterator
+	job                  *structs.Job
 	jobVersion           *uint64
 	jobNamespace         string
 	jobID                string
@@ -62,16 +64,23 @@ type GenericStack struct {
 	taskGroupCSIVolumes  *CSIVolumeChecker
 	taskGroupNetwork     *NetworkChecker
 	taskGroupSecrets     *SecretsProviderChecker
+	taskGroupTaints      *TaintChecker
+	loadPressure         *LoadPressureChecker
 
 	distinctHostsConstraint    *DistinctHostsIterator
 	distinctPropertyConstraint *DistinctPropertyIterator
+	topologySpread             *TopologySpreadIterator
+	jobAffinity                *JobAffinityIterator
 	binPack                    *BinPackIterator
 	jobAntiAff                 *JobAntiAffinityIterator
 	nodeReschedulingPenalty    *NodeReschedulingPenaltyIterator
+	nodeTaints                 *NodeTaintIterator
 	limit                      *LimitIterator
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
+	jobAffinityScore           *JobAffinityScoreIterator
 	spread                     *SpreadIterator
+	nodeScorers                *NodeScorerIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -82,6 +91,7 @@ func (s *GenericStack) SetNodes(baseNodes []*structs.Node) {
 
 	// Update the set of base nodes
 	s.source.SetNodes(baseNodes)
+	s.topologySpread.SetNodes(baseNodes)
 
 	// Apply a limit function. This is to avoid scanning *every* possible node.
 	// For batch jobs we only need to evaluate 2 options and depend on the
@@ -105,16 +115,22 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 
 	jobVer := job.Version
 	s.jobVersion = &jobVer
+	s.job = job
 	s.jobNamespace = job.Namespace
 	s.jobID = job.ID
 
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctHostsConstraint.SetJob(job)
 	s.distinctPropertyConstraint.SetJob(job)
+	s.topologySpread.SetJob(job)
+	s.jobAffinity.SetJob(job)
 	s.binPack.SetJob(job)
+	s.loadPressure.SetJob(job)
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
+	s.jobAffinityScore.SetJob(job)
 	s.spread.SetJob(job)
+	s.nodeScorers.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
@@ -129,6 +145,13 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 // on the node pool being used.
 func (s *GenericStack) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
 	s.binPack.SetSchedulerConfiguration(schedConfig)
+	s.loadPressure.SetSchedulerConfiguration(schedConfig)
+}
+
+// SetNodeScorers sets the external scorers, such as scoring plugins, that
+// contribute to the ranking of nodes.
+func (s *GenericStack) SetNodeScorers(scorers []sstructs.NodeScorer) {
+	s.nodeScorers.SetScorers(scorers)
 }
 
 func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *RankedNode {
@@ -166,8 +189,11 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 		s.taskGroupNetwork.SetNetwork(tg.Networks[0])
 	}
 	s.taskGroupSecrets.SetSecrets(tgConstr.Secrets)
+	s.taskGroupTaints.SetTolerations(s.job.TaskGroupTolerations(tg))
 	s.distinctHostsConstraint.SetTaskGroup(tg)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
+	s.topologySpread.SetTaskGroup(tg)
+	s.jobAffinity.SetTaskGroup(tg)
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.binPack.SetTaskGroup(tg)
 	if options != nil {
@@ -177,10 +203,13 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	if options != nil {
 		s.nodeReschedulingPenalty.SetPenaltyNodes(options.PenaltyNodeIDs)
 	}
+	s.nodeTaints.SetTolerations(s.job.TaskGroupTolerations(tg))
 	s.nodeAffinity.SetTaskGroup(tg)
+	s.jobAffinityScore.SetTaskGroup(tg)
 	s.spread.SetTaskGroup(tg)
+	s.nodeScorers.SetTaskGroup(tg)
 
-	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() {
+	if s.nodeAffinity.hasAffinities() || s.jobAffinityScore.hasAffinities() || s.spread.hasSpreads() {
 		// scoring spread across all nodes has quadratic behavior, so
 		// we need to consider a subset of nodes to keep evaluaton times
 		// reasonable but enough to ensure spread is correct. this
@@ -209,6 +238,7 @@ type SystemStack struct {
 	ctx    Context
 	source *StaticIterator
 
+	job                  *structs.Job
 	jobNamespace         string
 	jobID                string
 	wrappedChecks        *FeasibilityWrapper
@@ -221,9 +251,13 @@ type SystemStack struct {
 	taskGroupCSIVolumes  *CSIVolumeChecker
 	taskGroupNetwork     *NetworkChecker
 	taskGroupSecrets     *SecretsProviderChecker
+	taskGroupTaints      *TaintChecker
+	loadPressure         *LoadPressureChecker
 
 	distinctPropertyConstraint *DistinctPropertyIterator
+	jobAffinity                *JobAffinityIterator
 	binPack                    *BinPackIterator
+	nodeScorers                *NodeScorerIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -264,6 +298,12 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// Filter on task group secrets
 	s.taskGroupSecrets = NewSecretsProviderChecker(ctx, nil)
 
+	// Filter on node taints the task group doesn't tolerate
+	s.taskGroupTaints = NewTaintChecker(ctx)
+
+	// Filter out nodes under resource pressure for best-effort jobs
+	s.loadPressure = NewLoadPressureChecker(ctx)
+
 	// Create the feasibility wrapper which wraps all feasibility checks in
 	// which feasibility checking can be skipped if the computed node class has
 	// previously been marked as eligible or ineligible. Generally this will be
@@ -275,22 +315,27 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 		s.taskGroupDevices,
 		s.taskGroupNetwork,
 		s.taskGroupSecrets,
+		s.taskGroupTaints,
 	}
 	avail := []FeasibilityChecker{
 		s.taskGroupHostVolumes,
 		s.taskGroupCSIVolumes,
+		s.loadPressure,
 	}
 	s.wrappedChecks = NewFeasibilityWrapper(ctx, s.source, jobs, tgs, avail)
 
 	// Filter on distinct property constraints.
 	s.distinctPropertyConstraint = NewDistinctPropertyIterator(ctx, s.wrappedChecks)
 
+	// Filter on required job affinities.
+	s.jobAffinity = NewJobAffinityIterator(ctx, s.distinctPropertyConstraint)
+
 	// Create the quota iterator to determine if placements would result in
 	// the quota attached to the namespace of the job to go over.
 	// Note: the quota iterator must be the last feasibility iterator before
 	// we upgrade to ranking, or our quota usage will include ineligible
 	// nodes!
-	s.quota = NewQuotaIterator(ctx, s.distinctPropertyConstraint)
+	s.quota = NewQuotaIterator(ctx, s.jobAffinity)
 
 	// Upgrade from feasible to rank iterator
 	rankSource := NewFeasibleRankIterator(ctx, s.quota)
@@ -316,8 +361,11 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// Create binpack iterator
 	s.binPack = NewBinPackIterator(ctx, rankSource, enablePreemption, 0)
 
+	// Apply the scores of external scorers
+	s.nodeScorers = NewNodeScorerIterator(ctx, s.binPack)
+
 	// Apply score normalization
-	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.binPack)
+	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.nodeScorers)
 	return s
 }
 
@@ -327,11 +375,15 @@ func (s *SystemStack) SetNodes(baseNodes []*structs.Node) {
 }
 
 func (s *SystemStack) SetJob(job *structs.Job) {
+	s.job = job
 	s.jobNamespace = job.Namespace
 	s.jobID = job.ID
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctPropertyConstraint.SetJob(job)
+	s.jobAffinity.SetJob(job)
 	s.binPack.SetJob(job)
+	s.loadPressure.SetJob(job)
+	s.nodeScorers.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
@@ -346,6 +398,13 @@ func (s *SystemStack) SetJob(job *structs.Job) {
 // on the node pool being used.
 func (s *SystemStack) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
 	s.binPack.SetSchedulerConfiguration(schedConfig)
+	s.loadPressure.SetSchedulerConfiguration(schedConfig)
+}
+
+// SetNodeScorers sets the external scorers, such as scoring plugins, that
+// contribute to the ranking of nodes.
+func (s *SystemStack) SetNodeScorers(scorers []sstructs.NodeScorer) {
+	s.nodeScorers.SetScorers(scorers)
 }
 
 func (s *SystemStack) Select(tg *structs.TaskGroup, options *SelectOptions) *RankedNode {
@@ -372,9 +431,12 @@ func (s *SystemStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ran
 		s.taskGroupNetwork.SetNetwork(tg.Networks[0])
 	}
 	s.taskGroupSecrets.SetSecrets(tgConstr.Secrets)
+	s.taskGroupTaints.SetTolerations(s.job.TaskGroupTolerations(tg))
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
+	s.jobAffinity.SetTaskGroup(tg)
 	s.binPack.SetTaskGroup(tg)
+	s.nodeScorers.SetTaskGroup(tg)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
 		contextual.SetTaskGroup(tg)
@@ -425,6 +487,12 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Filter on task group secrets
 	s.taskGroupSecrets = NewSecretsProviderChecker(ctx, nil)
 
+	// Filter on node taints the task group doesn't tolerate
+	s.taskGroupTaints = NewTaintChecker(ctx)
+
+	// Filter out nodes under resource pressure for best-effort jobs
+	s.loadPressure = NewLoadPressureChecker(ctx)
+
 	// Create the feasibility wrapper which wraps all feasibility checks in
 	// which feasibility checking can be skipped if the computed node class has
 	// previously been marked as eligible or ineligible. Generally this will be
@@ -436,10 +504,12 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 		s.taskGroupDevices,
 		s.taskGroupNetwork,
 		s.taskGroupSecrets,
+		s.taskGroupTaints,
 	}
 	avail := []FeasibilityChecker{
 		s.taskGroupHostVolumes,
 		s.taskGroupCSIVolumes,
+		s.loadPressure,
 	}
 	s.wrappedChecks = NewFeasibilityWrapper(ctx, s.source, jobs, tgs, avail)
 
@@ -449,12 +519,18 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Filter on distinct property constraints.
 	s.distinctPropertyConstraint = NewDistinctPropertyIterator(ctx, s.distinctHostsConstraint)
 
+	// Filter on spreads with a max_skew.
+	s.topologySpread = NewTopologySpreadIterator(ctx, s.distinctPropertyConstraint)
+
+	// Filter on required job affinities.
+	s.jobAffinity = NewJobAffinityIterator(ctx, s.topologySpread)
+
 	// Create the quota iterator to determine if placements would result in
 	// the quota attached to the namespace of the job to go over.
 	// Note: the quota iterator must be the last feasibility iterator before
 	// we upgrade to ranking, or our quota usage will include ineligible
 	// nodes!
-	s.quota = NewQuotaIterator(ctx, s.distinctPropertyConstraint)
+	s.quota = NewQuotaIterator(ctx, s.jobAffinity)
 
 	// Upgrade from feasible to rank iterator
 	rankSource := NewFeasibleRankIterator(ctx, s.quota)
@@ -471,17 +547,27 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// node where the allocation failed previously
 	s.nodeReschedulingPenalty = NewNodeReschedulingPenaltyIterator(ctx, s.jobAntiAff)
 
+	// Apply node taints penalty. This tries to avoid placing on a node with
+	// PreferNoSchedule taints the task group doesn't tolerate
+	s.nodeTaints = NewNodeTaintIterator(ctx, s.nodeReschedulingPenalty)
+
 	// Apply scores based on affinity block
-	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeReschedulingPenalty)
+	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeTaints)
+
+	// Apply scores based on job affinities that aren't required
+	s.jobAffinityScore = NewJobAffinityScoreIterator(ctx, s.nodeAffinity)
 
 	// Apply scores based on spread block
-	s.spread = NewSpreadIterator(ctx, s.nodeAffinity)
+	s.spread = NewSpreadIterator(ctx, s.jobAffinityScore)
 
 	// Add the preemption options scoring iterator
 	preemptionScorer := NewPreemptionScoringIterator(ctx, s.spread)
 
+	// Apply the scores of external scorers, such as scoring plugins
+	s.nodeScorers = NewNodeScorerIterator(ctx, preemptionScorer)
+
 	// Normalizes scores by averaging them across various scorers
-	s.scoreNorm = NewScoreNormalizationIterator(ctx, preemptionScorer)
+	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.nodeScorers)
 
 	// Apply a limit function. This is to avoid scanning *every* possible node.
 	s.limit = NewLimitIterator(ctx, s.scoreNorm, 2, skipScoreThreshold, maxSkip)
@@ -0,0 +1,226 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package feasible
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// TopologySpreadIterator is a FeasibleIterator which enforces spread blocks
+// with a max_skew. A node is filtered out if placing the allocation on it
+// would make the number of allocations of the task group using the node's
+// attribute value exceed the number using the least used value by more than
+// max_skew.
+//
+// The values considered are those of the base nodes that satisfy the job and
+// task group constraints, so values with no allocations yet count towards the
+// skew.
+type TopologySpreadIterator struct {
+	ctx    Context
+	source FeasibleIterator
+	job    *structs.Job
+	tg     *structs.TaskGroup
+
+	// nodes are the base nodes used to find the attribute values
+	nodes []*structs.Node
+
+	// jobSpreads are the spreads with a max_skew set at the job level
+	jobSpreads []*structs.Spread
+
+	// groupSkewSets is a memoized map from task group to the skew sets of
+	// its spreads with a max_skew
+	groupSkewSets map[string][]*skewSet
+
+	hasSkews bool
+}
+
+// skewSet tracks the allocations of a task group across the values of a
+// spread attribute.
+type skewSet struct {
+	spread *structs.Spread
+	pset   *propertySet
+
+	// domains are the attribute values of the feasible base nodes
+	domains map[string]struct{}
+}
+
+// NewTopologySpreadIterator creates a TopologySpreadIterator from a source.
+func NewTopologySpreadIterator(ctx Context, source FeasibleIterator) *TopologySpreadIterator {
+	return &TopologySpreadIterator{
+		ctx:           ctx,
+		source:        source,
+		groupSkewSets: make(map[string][]*skewSet),
+	}
+}
+
+// SetNodes sets the base nodes used to find the values of spread attributes.
+func (iter *TopologySpreadIterator) SetNodes(nodes []*structs.Node) {
+	iter.nodes = nodes
+	iter.groupSkewSets = make(map[string][]*skewSet)
+}
+
+func (iter *TopologySpreadIterator) SetJob(job *structs.Job) {
+	iter.job = job
+	iter.jobSpreads = nil
+	for _, spread := range job.Spreads {
+		if spread.HasMaxSkew() {
+			iter.jobSpreads = append(iter.jobSpreads, spread)
+		}
+	}
+	iter.groupSkewSets = make(map[string][]*skewSet)
+}
+
+func (iter *TopologySpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+
+	if _, ok := iter.groupSkewSets[tg.Name]; !ok {
+		spreads := slices.Clone(iter.jobSpreads)
+		for _, spread := range tg.Spreads {
+			if spread.HasMaxSkew() {
+				spreads = append(spreads, spread)
+			}
+		}
+
+		sets := make([]*skewSet, 0, len(spreads))
+		for _, spread := range spreads {
+			pset := NewPropertySet(iter.ctx, iter.job)
+			pset.SetTargetAttribute(spread.Attribute, tg.Name)
+			sets = append(sets, &skewSet{
+				spread:  spread,
+				pset:    pset,
+				domains: spreadDomains(iter.ctx, iter.job, tg, iter.nodes, spread.Attribute),
+			})
+		}
+		iter.groupSkewSets[tg.Name] = sets
+	}
+
+	iter.hasSkews = len(iter.groupSkewSets[tg.Name]) != 0
+}
+
+// spreadDomains returns the values of the attribute on the nodes that satisfy
+// the job and task group constraints.
+func spreadDomains(ctx Context, job *structs.Job, tg *structs.TaskGroup,
+	nodes []*structs.Node, attribute string) map[string]struct{} {
+
+	constraints := append([]*structs.Constraint{}, job.Constraints...)
+	constraints = append(constraints, TaskGroupConstraints(tg).Constraints...)
+	checker := NewConstraintChecker(ctx, constraints)
+
+	domains := make(map[string]struct{})
+NODES:
+	for _, node := range nodes {
+		value, ok := getProperty(node, attribute)
+		if !ok {
+			continue
+		}
+		for _, c := range constraints {
+			if !checker.meetsConstraint(c, node) {
+				continue NODES
+			}
+		}
+		domains[value] = struct{}{}
+	}
+	return domains
+}
+
+func (iter *TopologySpreadIterator) Next() *structs.Node {
+	for {
+		option := iter.source.Next()
+
+		// Hot path if there is nothing to check
+		if option == nil || !iter.hasSkews {
+			return option
+		}
+
+		if iter.satisfiesSkews(option) {
+			return option
+		}
+	}
+}
+
+// satisfiesSkews returns whether placing on the option keeps every spread
+// within its max_skew. If not the option is filtered.
+func (iter *TopologySpreadIterator) satisfiesSkews(option *structs.Node) bool {
+	for _, set := range iter.groupSkewSets[iter.tg.Name] {
+		if ok, reason := set.satisfies(option); !ok {
+			iter.ctx.Metrics().FilterNode(option, reason)
+			return false
+		}
+	}
+	return true
+}
+
+func (iter *TopologySpreadIterator) Reset() {
+	iter.source.Reset()
+
+	for _, sets := range iter.groupSkewSets {
+		for _, set := range sets {
+			set.pset.PopulateProposed()
+		}
+	}
+}
+
+// satisfies returns whether placing an allocation on the node keeps the skew
+// within the maximum, and the reason if it doesn't.
+func (s *skewSet) satisfies(option *structs.Node) (bool, string) {
+	attribute := s.spread.Attribute
+	value, ok := getProperty(option, attribute)
+	if !ok {
+		return false, fmt.Sprintf("missing property %q", attribute)
+	}
+
+	used := s.pset.GetCombinedUseMap()
+	skew := skewWith(used, s.domains, value)
+	if skew > s.spread.MaxSkew {
+		return false, fmt.Sprintf("spread max_skew: %s=%s skew %d > %d",
+			attribute, value, skew, s.spread.MaxSkew)
+	}
+	return true, ""
+}
+
+// skewWith returns the skew across the domains that would result from adding
+// an allocation to the given value: the number of allocations using the value
+// minus the number using the least used domain.
+func skewWith(used map[string]uint64, domains map[string]struct{}, value string) int {
+	least := used[value]
+	for domain := range domains {
+		least = min(least, used[domain])
+	}
+	return int(used[value] + 1 - least)
+}
+
+// SpreadSkew returns the skew of the allocations of the task group across the
+// values of the spread attribute once the plan of the context is applied,
+// along with the number of allocations using each value. The values
+// considered are those of the given nodes that satisfy the job and task group
+// constraints, and any value already used by an allocation.
+func SpreadSkew(ctx Context, job *structs.Job, tg *structs.TaskGroup,
+	nodes []*structs.Node, spread *structs.Spread) (int, map[string]uint64) {
+
+	pset := NewPropertySet(ctx, job)
+	pset.SetTargetAttribute(spread.Attribute, tg.Name)
+	used := pset.GetCombinedUseMap()
+
+	counts := make(map[string]uint64)
+	for domain := range spreadDomains(ctx, job, tg, nodes, spread.Attribute) {
+		counts[domain] = used[domain]
+	}
+	for value, count := range used {
+		counts[value] = count
+	}
+	if len(counts) == 0 {
+		return 0, counts
+	}
+
+	least, most := uint64(math.MaxUint64), uint64(0)
+	for _, count := range counts {
+		least = min(least, count)
+		most = max(most, count)
+	}
+	return int(most - least), counts
+}
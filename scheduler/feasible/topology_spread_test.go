@@ -0,0 +1,158 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package feasible
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// topologySpreadNodes returns two nodes in each of the zones a, b and c.
+func topologySpreadNodes(t *testing.T, store *state.StateStore) []*structs.Node {
+	nodes := make([]*structs.Node, 0, 6)
+	for i, zone := range []string{"a", "a", "b", "b", "c", "c"} {
+		node := mock.Node()
+		node.Meta["zone"] = zone
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func TestTopologySpreadIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := MockContext(t)
+	nodes := topologySpreadNodes(t, store)
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{{
+		Attribute: "${meta.zone}",
+		Weight:    50,
+		MaxSkew:   1,
+	}}
+
+	// Zone a already has an allocation and zone b has one proposed, so only
+	// zone c can take the next allocation.
+	place := func(node *structs.Node) {
+		ctx.Plan().NodeAllocation[node.ID] = append(ctx.Plan().NodeAllocation[node.ID],
+			&structs.Allocation{
+				Namespace: job.Namespace,
+				JobID:     job.ID,
+				Job:       job,
+				TaskGroup: tg.Name,
+				ID:        uuid.Generate(),
+				NodeID:    node.ID,
+			})
+	}
+	place(nodes[0])
+	place(nodes[2])
+
+	static := NewStaticIterator(ctx, nodes)
+	iter := NewTopologySpreadIterator(ctx, static)
+	iter.SetNodes(nodes)
+	iter.SetJob(job)
+	iter.SetTaskGroup(tg)
+
+	out := collectFeasible(iter)
+	must.Len(t, 2, out)
+	must.Eq(t, "c", out[0].Meta["zone"])
+	must.Eq(t, "c", out[1].Meta["zone"])
+	must.Eq(t, 4, ctx.Metrics().ConstraintFiltered["spread max_skew: ${meta.zone}=a skew 2 > 1"]+
+		ctx.Metrics().ConstraintFiltered["spread max_skew: ${meta.zone}=b skew 2 > 1"])
+
+	// Once zone c is used every zone is feasible again.
+	place(nodes[4])
+	static.Reset()
+	iter.Reset()
+	out = collectFeasible(iter)
+	must.Len(t, 6, out)
+}
+
+func TestTopologySpreadIterator_Constraints(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := MockContext(t)
+	nodes := topologySpreadNodes(t, store)
+
+	// Zone c is excluded by a constraint so it is not a domain of the spread
+	// and does not hold back the other zones.
+	job := mock.Job()
+	job.Constraints = append(job.Constraints, &structs.Constraint{
+		LTarget: "${meta.zone}",
+		RTarget: "c",
+		Operand: "!=",
+	})
+	job.Spreads = []*structs.Spread{{
+		Attribute: "${meta.zone}",
+		Weight:    50,
+		MaxSkew:   1,
+	}}
+	tg := job.TaskGroups[0]
+
+	ctx.Plan().NodeAllocation[nodes[0].ID] = []*structs.Allocation{{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		Job:       job,
+		TaskGroup: tg.Name,
+		ID:        uuid.Generate(),
+		NodeID:    nodes[0].ID,
+	}}
+
+	static := NewStaticIterator(ctx, nodes)
+	iter := NewTopologySpreadIterator(ctx, static)
+	iter.SetNodes(nodes)
+	iter.SetJob(job)
+	iter.SetTaskGroup(tg)
+
+	out := collectFeasible(iter)
+	for _, node := range out {
+		must.NotEq(t, "a", node.Meta["zone"])
+	}
+	must.Len(t, 4, out)
+}
+
+func TestTopologySpreadIterator_ScheduleAnyway(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := MockContext(t)
+	nodes := topologySpreadNodes(t, store)
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{{
+		Attribute:         "${meta.zone}",
+		Weight:            50,
+		MaxSkew:           1,
+		WhenUnsatisfiable: structs.SpreadWhenUnsatisfiableScheduleAnyway,
+	}}
+	ctx.Plan().NodeAllocation[nodes[0].ID] = []*structs.Allocation{{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		Job:       job,
+		TaskGroup: tg.Name,
+		ID:        uuid.Generate(),
+		NodeID:    nodes[0].ID,
+	}}
+
+	static := NewStaticIterator(ctx, nodes)
+	iter := NewTopologySpreadIterator(ctx, static)
+	iter.SetNodes(nodes)
+	iter.SetJob(job)
+	iter.SetTaskGroup(tg)
+
+	out := collectFeasible(iter)
+	must.Len(t, 6, out)
+
+	skew, counts := SpreadSkew(ctx, job, tg, nodes, tg.Spreads[0])
+	must.Eq(t, 1, skew)
+	must.Eq(t, map[string]uint64{"a": 1, "b": 0, "c": 0}, counts)
+}
@@ -5,6 +5,7 @@ package scheduler
 
 import (
 	"fmt"
+	"maps"
 	"runtime/debug"
 	"slices"
 	"sort"
@@ -68,12 +69,31 @@ type GenericScheduler struct {
 	// before being rescheduled
 	followUpEvals []*structs.Evaluation
 
+	// windowEval is the eval that makes the placements deferred until the
+	// job's schedule window opens
+	windowEval *structs.Evaluation
+
+	// dependencyBlocked is the reason the placements are held until the
+	// jobs the job depends on reach their required status
+	dependencyBlocked string
+
 	deployment *structs.Deployment
 
 	blocked         *structs.Evaluation
 	failedTGAllocs  map[string]*structs.AllocMetric
 	queuedAllocs    map[string]int
 	planAnnotations *structs.PlanAnnotations
+
+	// unsatisfiedGangs is the set of gangs with a task group that failed to
+	// place, and gangMetrics the metrics of those failed placements. The
+	// allocations of unsatisfied gangs are recomputed without placing any of
+	// the gang's task groups.
+	unsatisfiedGangs map[string]struct{}
+	gangMetrics      map[string]*structs.AllocMetric
+
+	// gangTimedOut is the name of a gang still unsatisfiable once its
+	// timeout expired.
+	gangTimedOut string
 }
 
 // NewServiceScheduler is a factory function to instantiate a new service scheduler
@@ -100,6 +120,20 @@ func NewBatchScheduler(logger log.Logger, eventsCh chan<- interface{}, state sst
 	return s
 }
 
+// NewWorkflowScheduler is a factory function to instantiate a new workflow
+// scheduler, a batch scheduler that places the allocations of each task group
+// of the job once the task groups it runs after have completed
+func NewWorkflowScheduler(logger log.Logger, eventsCh chan<- interface{}, state sstructs.State, planner sstructs.Planner) sstructs.Scheduler {
+	s := &GenericScheduler{
+		logger:   logger.Named("workflow_sched"),
+		eventsCh: eventsCh,
+		state:    state,
+		planner:  planner,
+		batch:    true,
+	}
+	return s
+}
+
 // Process is used to handle a single evaluation
 func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 
@@ -125,7 +159,10 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect:
+		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect,
+		structs.EvalTriggerGangTimeout, structs.EvalTriggerRebalance,
+		structs.EvalTriggerScheduleWindow, structs.EvalTriggerJobDependency,
+		structs.EvalTriggerWorkflowStep, structs.EvalTriggerIndexedBatch:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
@@ -169,8 +206,23 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		return s.planner.ReblockEval(newEval)
 	}
 
+	// Fail the evaluation if a gang is still unsatisfiable after its timeout
+	if s.gangTimedOut != "" {
+		desc := fmt.Sprintf("gang %q not satisfiable before timeout", s.gangTimedOut)
+		return setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
+			s.failedTGAllocs, s.planAnnotations, structs.EvalStatusFailed, desc, s.queuedAllocs,
+			s.deployment.GetID())
+	}
+
+	// Hold the evaluation until the job's dependencies are met
+	if s.dependencyBlocked != "" {
+		return setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
+			s.failedTGAllocs, s.planAnnotations, structs.EvalStatusDependencyBlocked, s.dependencyBlocked,
+			s.queuedAllocs, s.deployment.GetID())
+	}
+
 	// Update the status to complete
-	return setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
+	return setStatus(s.logger, s.planner, s.eval, s.windowEval, s.blocked,
 		s.failedTGAllocs, s.planAnnotations, structs.EvalStatusComplete, "", s.queuedAllocs,
 		s.deployment.GetID())
 }
@@ -209,41 +261,30 @@ func (s *GenericScheduler) process() (bool, error) {
 		return false, fmt.Errorf("failed to get job %q: %v", s.eval.JobID, err)
 	}
 
-	numTaskGroups := 0
-	stopped := s.job.Stopped()
-	if !stopped {
-		numTaskGroups = len(s.job.TaskGroups)
-	}
-	s.queuedAllocs = make(map[string]int, numTaskGroups)
-	s.followUpEvals = nil
-
-	// Create a plan
-	s.plan = s.eval.MakePlan(s.job)
-
-	if !s.batch {
-		// Get any existing deployment
-		s.deployment, err = s.state.LatestDeploymentByJobID(ws, s.eval.Namespace, s.eval.JobID)
-		if err != nil {
-			return false, fmt.Errorf("failed to get job deployment %q: %v", s.eval.JobID, err)
+	// Compute the job allocations, recomputing them without placing the
+	// gangs found unsatisfiable until every remaining gang is placed in full
+	s.unsatisfiedGangs = nil
+	s.gangMetrics = nil
+	s.gangTimedOut = ""
+	s.dependencyBlocked = ""
+	for {
+		if err := s.computeJobPlan(ws); err != nil {
+			return false, err
+		}
+		if !s.markUnsatisfiedGangs() {
+			break
 		}
-		s.deployment = s.deployment.Copy() // may mutate in reconciler
 	}
 
-	// Reset the failed allocations
-	s.failedTGAllocs = nil
-
-	// Create an evaluation context
-	s.ctx = feasible.NewEvalContext(s.eventsCh, s.state, s.plan, s.logger)
-
-	// Construct the placement stack
-	s.stack = feasible.NewGenericStack(s.batch, s.ctx)
-	if !s.job.Stopped() {
-		s.setJob(s.job)
+	// Fail the gangs whose timeout expired without them being placed
+	if err := s.handleGangTimeout(); err != nil {
+		s.logger.Error("failed to handle gang timeout", "error", err)
+		return false, err
 	}
 
-	// Compute the target job allocations
-	if err := s.computeJobAllocs(); err != nil {
-		s.logger.Error("failed to compute job allocations", "error", err)
+	// Report the spreads left skewed beyond their max_skew
+	if err := s.computeSpreadViolations(); err != nil {
+		s.logger.Error("failed to compute spread violations", "error", err)
 		return false, err
 	}
 
@@ -255,12 +296,18 @@ func (s *GenericScheduler) process() (bool, error) {
 	if s.eval.Status != structs.EvalStatusBlocked &&
 		len(s.failedTGAllocs) != 0 &&
 		s.blocked == nil &&
+		s.gangTimedOut == "" &&
 		(len(s.followUpEvals) == 0 || time.Now().After(s.eval.WaitUntil)) {
 		if err := s.createBlockedEval(false); err != nil {
 			s.logger.Error("failed to make blocked eval", "error", err)
 			return false, err
 		}
 		s.logger.Debug("failed to place all allocations, blocked eval created", "blocked_eval_id", s.blocked.ID)
+
+		if err := s.createGangTimeoutEval(); err != nil {
+			s.logger.Error("failed to make gang timeout eval", "error", err)
+			return false, err
+		}
 	}
 
 	// If the plan is a no-op, we can bail. If AnnotatePlan is set submit the plan
@@ -318,6 +365,188 @@ func (s *GenericScheduler) process() (bool, error) {
 	return true, nil
 }
 
+// computeJobPlan creates a new plan and evaluation context and computes the
+// job allocations into them.
+func (s *GenericScheduler) computeJobPlan(ws memdb.WatchSet) error {
+	var err error
+	numTaskGroups := 0
+	if !s.job.Stopped() {
+		numTaskGroups = len(s.job.TaskGroups)
+	}
+	s.queuedAllocs = make(map[string]int, numTaskGroups)
+	s.followUpEvals = nil
+
+	// Create a plan
+	s.plan = s.eval.MakePlan(s.job)
+
+	if !s.batch {
+		// Get any existing deployment
+		s.deployment, err = s.state.LatestDeploymentByJobID(ws, s.eval.Namespace, s.eval.JobID)
+		if err != nil {
+			return fmt.Errorf("failed to get job deployment %q: %v", s.eval.JobID, err)
+		}
+		s.deployment = s.deployment.Copy() // may mutate in reconciler
+	}
+
+	// Reset the failed allocations
+	s.failedTGAllocs = nil
+
+	// Create an evaluation context
+	s.ctx = feasible.NewEvalContext(s.eventsCh, s.state, s.plan, s.logger)
+	s.ctx.SetExplain(s.eval.ExplainPlan)
+
+	// Construct the placement stack
+	s.stack = feasible.NewGenericStack(s.batch, s.ctx)
+	s.stack.SetNodeScorers(s.planner.NodeScorers())
+	if !s.job.Stopped() {
+		s.setJob(s.job)
+	}
+
+	// Compute the target job allocations
+	if err := s.computeJobAllocs(); err != nil {
+		s.logger.Error("failed to compute job allocations", "error", err)
+		return err
+	}
+	return nil
+}
+
+// markUnsatisfiedGangs adds the gangs with a task group that failed to place
+// to the unsatisfied gangs, keeping the metrics of the failed placements. It
+// returns true if a new gang was found unsatisfiable, in which case the job
+// allocations must be recomputed without placing that gang.
+func (s *GenericScheduler) markUnsatisfiedGangs() bool {
+	if s.job.Stopped() {
+		return false
+	}
+
+	found := make(map[string]struct{})
+	for _, tg := range s.job.TaskGroups {
+		gang := tg.GangName()
+		if gang == "" {
+			continue
+		}
+		if _, ok := s.unsatisfiedGangs[gang]; ok {
+			continue
+		}
+		metric, ok := s.failedTGAllocs[tg.Name]
+		if !ok {
+			continue
+		}
+
+		if s.gangMetrics == nil {
+			s.gangMetrics = make(map[string]*structs.AllocMetric)
+		}
+		s.gangMetrics[tg.Name] = metric
+		found[gang] = struct{}{}
+	}
+	if len(found) == 0 {
+		return false
+	}
+
+	if s.unsatisfiedGangs == nil {
+		s.unsatisfiedGangs = make(map[string]struct{}, len(found))
+	}
+	for gang := range found {
+		s.logger.Debug("gang not satisfiable, placing none of its allocations", "gang", gang)
+		s.unsatisfiedGangs[gang] = struct{}{}
+	}
+	return true
+}
+
+// failGangPlacement records a placement skipped because its task group
+// belongs to an unsatisfiable gang.
+func (s *GenericScheduler) failGangPlacement(tg *structs.TaskGroup, missing reconciler.PlacementResult) {
+	if s.failedTGAllocs == nil {
+		s.failedTGAllocs = make(map[string]*structs.AllocMetric)
+	}
+
+	if metric, ok := s.failedTGAllocs[tg.Name]; ok {
+		metric.CoalescedFailures += 1
+		metric.ExhaustResources(tg)
+	} else {
+		// Report why the placement failed when this task group is the one
+		// that didn't fit, rather than only that its gang didn't
+		metric = &structs.AllocMetric{}
+		if failed, ok := s.gangMetrics[tg.Name]; ok {
+			metric = failed.Copy()
+			metric.CoalescedFailures = 0
+		}
+		metric.GangUnsatisfiable = tg.GangName()
+		s.failedTGAllocs[tg.Name] = metric
+	}
+
+	// Keep the reschedule tracker of the allocation being replaced so that
+	// the reschedule is retried by the blocked eval
+	if prev := missing.PreviousAllocation(); prev != nil && missing.IsRescheduling() {
+		markFailedToReschedule(s.plan, prev, s.job)
+	}
+}
+
+// createGangTimeoutEval creates a follow up eval for when the earliest
+// timeout of the unsatisfiable gangs expires.
+func (s *GenericScheduler) createGangTimeoutEval() error {
+	if s.eval.TriggeredBy == structs.EvalTriggerGangTimeout {
+		return nil
+	}
+
+	var timeout time.Duration
+	for _, tg := range s.job.TaskGroups {
+		if _, ok := s.unsatisfiedGangs[tg.GangName()]; !ok || tg.Gang.Timeout == 0 {
+			continue
+		}
+		if timeout == 0 || tg.Gang.Timeout < timeout {
+			timeout = tg.Gang.Timeout
+		}
+	}
+	if timeout == 0 {
+		return nil
+	}
+
+	eval := &structs.Evaluation{
+		ID:                uuid.Generate(),
+		Namespace:         s.job.Namespace,
+		Priority:          s.eval.Priority,
+		Type:              s.job.Type,
+		TriggeredBy:       structs.EvalTriggerGangTimeout,
+		JobID:             s.job.ID,
+		JobModifyIndex:    s.job.ModifyIndex,
+		Status:            structs.EvalStatusPending,
+		StatusDescription: sstructs.DescGangTimeoutFollowupEval,
+		WaitUntil:         time.Now().Add(timeout),
+		PreviousEval:      s.eval.ID,
+	}
+	if err := s.planner.CreateEval(eval); err != nil {
+		return err
+	}
+	s.logger.Debug("gang not satisfiable, timeout eval created", "timeout_eval_id", eval.ID)
+	return nil
+}
+
+// handleGangTimeout fails the evaluation and any active deployment when a
+// gang timeout eval finds a gang of the same job version still
+// unsatisfiable. The blocked eval created before the timeout remains, so the
+// gang is still placed if capacity becomes available later.
+func (s *GenericScheduler) handleGangTimeout() error {
+	if s.eval.TriggeredBy != structs.EvalTriggerGangTimeout ||
+		len(s.unsatisfiedGangs) == 0 ||
+		s.eval.JobModifyIndex != s.job.ModifyIndex {
+		return nil
+	}
+
+	gangs := slices.Sorted(maps.Keys(s.unsatisfiedGangs))
+	s.gangTimedOut = gangs[0]
+	s.logger.Warn("gang not satisfiable before timeout", "gang", s.gangTimedOut)
+
+	if s.deployment != nil && s.deployment.Active() {
+		s.plan.DeploymentUpdates = append(s.plan.DeploymentUpdates, &structs.DeploymentStatusUpdate{
+			DeploymentID:      s.deployment.ID,
+			Status:            structs.DeploymentStatusFailed,
+			StatusDescription: structs.DeploymentStatusDescriptionGangTimeout,
+		})
+	}
+	return nil
+}
+
 // computeJobAllocs is used to reconcile differences between the job,
 // existing allocations and node status to update the allocations.
 func (s *GenericScheduler) computeJobAllocs() error {
@@ -360,6 +589,28 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		s.logger.Debug("reconciled current state with desired state", result.Fields()...)
 	}
 
+	// Hold the placements of the workflow steps that run after steps that
+	// haven't completed yet
+	if s.job.IsWorkflow() && !s.job.Stopped() {
+		statuses := s.job.WorkflowStepStatuses(allocs)
+		result.Place = slices.DeleteFunc(result.Place, func(p reconciler.AllocPlaceResult) bool {
+			tg := p.TaskGroup()
+			if structs.WorkflowStepReady(tg, statuses) {
+				return false
+			}
+			if desired := result.DesiredTGUpdates[tg.Name]; desired != nil && desired.Place > 0 {
+				desired.Place--
+			}
+			return true
+		})
+	}
+
+	// Hold the placements of indexed batch task groups beyond their
+	// parallelism
+	if s.batch && !s.job.Stopped() {
+		limitIndexedBatchPlacements(allocs, result)
+	}
+
 	s.planAnnotations = &structs.PlanAnnotations{
 		DesiredTGUpdates: result.DesiredTGUpdates,
 	}
@@ -435,9 +686,72 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		s.queuedAllocs[p.TaskGroup().Name] += 1
 		destructive = append(destructive, p)
 	}
+
+	// Leave the placements queued until the job's dependencies are met
+	met, reason, err := jobDependenciesMet(s.state, s.job)
+	if err != nil {
+		return err
+	}
+	if !met {
+		s.dependencyBlocked = reason
+		return nil
+	}
+
+	// Leave the placements queued until the job's schedule window opens
+	windowEval, err := scheduleWindowEval(s.logger, s.state, s.planner, s.eval, s.job, s.windowEval)
+	if err != nil {
+		return err
+	}
+	if windowEval != nil {
+		s.windowEval = windowEval
+		return nil
+	}
 	return s.computePlacements(destructive, place, result.TaskGroupAllocNameIndexes)
 }
 
+// computeSpreadViolations adds to the plan annotations the spreads with a
+// max_skew whose skew exceeds the maximum once the plan is applied. Skews
+// are normally prevented at placement time, so violations are the result of
+// allocations being stopped or migrated, such as by a node drain, without
+// enough capacity to rebalance them.
+func (s *GenericScheduler) computeSpreadViolations() error {
+	if s.job == nil || s.job.Stopped() || s.planAnnotations == nil {
+		return nil
+	}
+
+	var nodes []*structs.Node
+	for _, tg := range s.job.TaskGroups {
+		for _, spread := range slices.Concat(s.job.Spreads, tg.Spreads) {
+			if spread.MaxSkew == 0 {
+				continue
+			}
+			if nodes == nil {
+				var err error
+				nodes, _, _, err = readyNodesInDCsAndPool(s.state, s.job.Datacenters, s.job.NodePool)
+				if err != nil {
+					return fmt.Errorf("failed to get ready nodes: %v", err)
+				}
+			}
+
+			skew, counts := feasible.SpreadSkew(s.ctx, s.job, tg, nodes, spread)
+			if skew <= spread.MaxSkew {
+				continue
+			}
+			s.logger.Warn("spread max_skew exceeded", "task_group", tg.Name,
+				"attribute", spread.Attribute, "max_skew", spread.MaxSkew, "skew", skew)
+			s.planAnnotations.SpreadViolations = append(s.planAnnotations.SpreadViolations,
+				&structs.SpreadViolation{
+					TaskGroup: tg.Name,
+					Attribute: spread.Attribute,
+					MaxSkew:   spread.MaxSkew,
+					Skew:      skew,
+					Counts:    counts,
+				})
+		}
+	}
+	return nil
+}
+
 // downgradedJobForPlacement returns the previous stable version of the job for
 // downgrading a placement for non-canaries
 func (s *GenericScheduler) downgradedJobForPlacement(p reconciler.PlacementResult) (string, *structs.Job, error) {
@@ -532,6 +846,12 @@ func (s *GenericScheduler) computePlacements(
 				}
 			}
 
+			// Place nothing for a gang that can't be placed in full
+			if _, ok := s.unsatisfiedGangs[tg.GangName()]; ok {
+				s.failGangPlacement(tg, missing)
+				continue
+			}
+
 			// Check if this task group has already failed
 			if metric, ok := s.failedTGAllocs[tg.Name]; ok {
 				metric.CoalescedFailures += 1
@@ -816,6 +1136,56 @@ func getSelectOptions(prevAllocation *structs.Allocation, preferredNode *structs
 	return selectOptions
 }
 
+// limitIndexedBatchPlacements removes the placements of indexed batch task
+// groups that would run more allocations than their parallelism allows. The
+// failed allocations whose replacement is held aren't stopped, so that they
+// are rescheduled by the evaluation created when a running allocation of the
+// task group finishes.
+func limitIndexedBatchPlacements(allocs []*structs.Allocation, result *reconciler.ReconcileResults) {
+	stopping := make(map[string]struct{}, len(result.Stop))
+	for _, stop := range result.Stop {
+		stopping[stop.Alloc.ID] = struct{}{}
+	}
+	running := make(map[string]int)
+	for _, alloc := range allocs {
+		if _, ok := stopping[alloc.ID]; !ok && !alloc.TerminalStatus() {
+			running[alloc.TaskGroup]++
+		}
+	}
+
+	held := make(map[string]struct{})
+	result.Place = slices.DeleteFunc(result.Place, func(p reconciler.AllocPlaceResult) bool {
+		tg := p.TaskGroup()
+		if tg.IndexedBatch == nil {
+			return false
+		}
+		if running[tg.Name] < tg.IndexedBatch.Parallelism {
+			running[tg.Name]++
+			return false
+		}
+		if prev := p.PreviousAllocation(); prev != nil && p.IsRescheduling() {
+			held[prev.ID] = struct{}{}
+		}
+		if desired := result.DesiredTGUpdates[tg.Name]; desired != nil && desired.Place > 0 {
+			desired.Place--
+		}
+		return true
+	})
+	if len(held) == 0 {
+		return
+	}
+
+	result.Stop = slices.DeleteFunc(result.Stop, func(stop reconciler.AllocStopResult) bool {
+		if _, ok := held[stop.Alloc.ID]; !ok {
+			return false
+		}
+		if desired := result.DesiredTGUpdates[stop.Alloc.TaskGroup]; desired != nil && desired.Stop > 0 {
+			desired.Stop--
+		}
+		return true
+	})
+}
+
 // annotateRescheduleTracker adds a note about the last reschedule attempt. This
 // mutates the allocation, which should be a copy.
 func annotateRescheduleTracker(prev *structs.Allocation, note structs.RescheduleTrackerAnnotation) {
@@ -898,6 +1268,19 @@ func (s *GenericScheduler) findPreferredNode(place reconciler.PlacementResult) (
 		}
 	}
 
+	// Otherwise prefer the node the rebalancer computed for the migration, so
+	// the allocation packs onto the node the rebalancer reported.
+	if target := prev.DesiredTransition.RebalanceTargetNodeID; target != "" &&
+		prev.DesiredTransition.ShouldRebalance() {
+		node, err := s.state.NodeByID(nil, target)
+		if err != nil {
+			return nil, err
+		}
+		if node != nil && node.Ready() {
+			return node, nil
+		}
+	}
+
 	return nil, nil
 }
 
@@ -914,7 +1297,7 @@ func (s *GenericScheduler) selectNextOption(tg *structs.TaskGroup, selectOptions
 	// the stack by calling SetSchedulerConfiguration().
 	enablePreemption := true
 	if schedConfig != nil {
-		if s.job.Type == structs.JobTypeBatch {
+		if s.batch {
 			enablePreemption = schedConfig.PreemptionConfig.BatchSchedulerEnabled
 		} else {
 			enablePreemption = schedConfig.PreemptionConfig.ServiceSchedulerEnabled
@@ -553,6 +553,206 @@ func TestServiceSched_JobRegister_DiskConstraints(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_JobRegister_NodeTaints(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+
+	// Create a node with a NoSchedule taint
+	node := mock.Node()
+	node.Taints = []*structs.NodeTaint{
+		{Key: "dedicated", Value: "gpu", Effect: structs.TaintEffectNoSchedule},
+	}
+	must.NoError(t, node.ComputeClass())
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// A job without tolerations can't be placed on the node
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	must.Len(t, 0, h.Plans)
+	must.Len(t, 1, h.Evals)
+	metrics := h.Evals[0].FailedTGAllocs[job.TaskGroups[0].Name]
+	must.NotNil(t, metrics)
+	must.Eq(t, 1, metrics.NodesFiltered)
+
+	// Once the job tolerates the taint it is placed on the node
+	job = job.Copy()
+	job.Tolerations = []*structs.Toleration{
+		{Key: "dedicated", Operator: structs.TolerationOperatorEqual, Value: "gpu"},
+	}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+	eval = eval.Copy()
+	eval.ID = uuid.Generate()
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 1, h.Plans[0].NodeAllocation[node.ID])
+}
+
+func TestServiceSched_JobRegister_CustomResources(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+
+	// Create a node with two license seats
+	node := mock.Node()
+	node.NodeResources.CustomResources = []*structs.NodeCustomResource{
+		{Name: "license_seat", Count: 2},
+	}
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Each allocation of the job requires a license seat
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].Tasks[0].Resources.CustomResources = structs.ResourceCustomResources{
+		{Name: "license_seat", Count: 1},
+	}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Only two allocations fit on the node
+	must.Len(t, 1, h.Plans)
+	placed := h.Plans[0].NodeAllocation[node.ID]
+	must.Len(t, 2, placed)
+	for _, alloc := range placed {
+		must.Eq(t, map[string]uint64{"license_seat": 1},
+			alloc.AllocatedResources.Tasks["web"].CustomResources)
+	}
+
+	metrics := h.Evals[0].FailedTGAllocs[job.TaskGroups[0].Name]
+	must.NotNil(t, metrics)
+	must.Eq(t, 1, metrics.DimensionExhausted["custom resource: license_seat"])
+}
+
+func TestServiceSched_JobRegister_Reservation(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+
+	// Create a node with 13900 MHz of cpu free, and hold most of it for the
+	// consumers of a reservation
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	consumer := mock.Job()
+	consumer.TaskGroups[0].Count = 3
+	res := mock.Reservation()
+	res.Consumers.Jobs = []string{consumer.ID}
+	res.ExpiresAt = time.Now().Add(time.Hour)
+	res.Placements = map[string]*structs.ReservationPlacement{
+		node.ID: {CPU: 13000, MemoryMB: 1024},
+	}
+	must.NoError(t, h.State.UpsertReservations(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.Reservation{res}))
+
+	register := func(job *structs.Job) {
+		must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: structs.EvalTriggerJobRegister,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		must.NoError(t, h.Process(NewServiceScheduler, eval))
+	}
+
+	// Only one allocation of a job that isn't a consumer fits around the
+	// reserved capacity
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	register(job)
+
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 1, h.Plans[0].NodeAllocation[node.ID])
+
+	metrics := h.Evals[0].FailedTGAllocs[job.TaskGroups[0].Name]
+	must.NotNil(t, metrics)
+	must.Eq(t, 1, metrics.DimensionExhausted["reserved cpu"])
+
+	// Consumers can use the reserved capacity
+	register(consumer)
+
+	must.Len(t, 2, h.Plans)
+	must.Len(t, 3, h.Plans[1].NodeAllocation[node.ID])
+	must.MapEmpty(t, h.Evals[1].FailedTGAllocs)
+}
+
+func TestServiceSched_JobRegister_JobAffinity(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, anti := range []bool{false, true} {
+		t.Run(fmt.Sprintf("anti=%v", anti), func(t *testing.T) {
+			h := tests.NewHarness(t)
+
+			// Create two nodes, the first one running an allocation of another job
+			nodes := []*structs.Node{mock.Node(), mock.Node()}
+			for _, node := range nodes {
+				must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+			}
+			other := mock.Job()
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, other))
+			alloc := mock.AllocForNode(nodes[0])
+			alloc.Job, alloc.JobID = other, other.ID
+			must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+			job := mock.Job()
+			job.TaskGroups[0].Count = 3
+			job.TaskGroups[0].JobAffinities = []*structs.JobAffinity{
+				{JobID: other.ID, Anti: anti, Required: true},
+			}
+			job.Canonicalize()
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			eval := &structs.Evaluation{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    job.Priority,
+				TriggeredBy: structs.EvalTriggerJobRegister,
+				JobID:       job.ID,
+				Status:      structs.EvalStatusPending,
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+			must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+			// Every allocation is placed with the other job, or away from it
+			expected := nodes[0].ID
+			if anti {
+				expected = nodes[1].ID
+			}
+			must.Len(t, 1, h.Plans)
+			must.MapLen(t, 1, h.Plans[0].NodeAllocation)
+			must.Len(t, 3, h.Plans[0].NodeAllocation[expected])
+		})
+	}
+}
+
 func TestServiceSched_JobRegister_DistinctHosts(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4205,6 +4405,73 @@ func TestServiceSched_NodeDrain(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_NodeDrain_SpreadViolation(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+
+	// One node per rack, with the rack "r1" node draining
+	var nodes []*structs.Node
+	for _, rack := range []string{"r1", "r2", "r3"} {
+		node := mock.Node()
+		if rack == "r1" {
+			node = mock.DrainNode()
+		}
+		node.Meta["rack"] = rack
+		node.ComputeClass()
+		nodes = append(nodes, node)
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.Spreads = []*structs.Spread{{
+		Attribute:         "${meta.rack}",
+		Weight:            100,
+		MaxSkew:           1,
+		WhenUnsatisfiable: structs.SpreadWhenUnsatisfiableBlock,
+	}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	var allocs []*structs.Allocation
+	for i, node := range nodes {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		if node.DrainStrategy != nil {
+			alloc.DesiredTransition.Migrate = pointer.Of(true)
+		}
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerNodeUpdate,
+		JobID:       job.ID,
+		NodeID:      nodes[0].ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The migrated allocation can only land on r2 or r3, leaving r1 empty
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 1, h.Evals)
+	must.NotNil(t, h.Evals[0].PlanAnnotations)
+
+	violations := h.Evals[0].PlanAnnotations.SpreadViolations
+	must.Len(t, 1, violations)
+	must.Eq(t, "web", violations[0].TaskGroup)
+	must.Eq(t, 1, violations[0].MaxSkew)
+	must.Eq(t, 2, violations[0].Skew)
+	must.Eq(t, 0, violations[0].Counts["r1"])
+}
+
 func TestServiceSched_NodeDrain_Down(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5396,6 +5663,385 @@ func TestDeployment_FailedAllocs_Reschedule(t *testing.T) {
 	}
 }
 
+func TestBatchSched_Gang_Unsatisfiable(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Two groups in the same gang, where the "ps" group can't fit
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Gang = &structs.Gang{Name: "train"}
+	ps := job.TaskGroups[0].Copy()
+	ps.Name = "ps"
+	ps.Count = 1
+	ps.Tasks[0].Resources.CPU = 1000000
+	job.TaskGroups = append(job.TaskGroups, ps)
+	job.Canonicalize()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewBatchScheduler, eval))
+
+	// Nothing is placed, even though the first group fits
+	must.SliceEmpty(t, h.Plans)
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+
+	failed := h.Evals[0].FailedTGAllocs
+	must.MapLen(t, 2, failed)
+	must.Eq(t, "train", failed["web"].GangUnsatisfiable)
+	must.Eq(t, 1, failed["web"].CoalescedFailures)
+	must.Eq(t, "train", failed["ps"].GangUnsatisfiable)
+	must.Positive(t, failed["ps"].NodesExhausted)
+	must.Eq(t, 2, h.Evals[0].QueuedAllocations["web"])
+	must.Eq(t, 1, h.Evals[0].QueuedAllocations["ps"])
+
+	// A blocked eval is created to place the gang later, without a timeout
+	// eval since the gang has no timeout
+	must.Len(t, 1, h.CreateEvals)
+	must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+}
+
+func TestBatchSched_Gang_Satisfiable(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// A gang with a group that fits is placed along with a group that isn't
+	// part of the gang and can't fit
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Gang = &structs.Gang{}
+	other := job.TaskGroups[0].Copy()
+	other.Name = "other"
+	other.Count = 1
+	other.Gang = nil
+	other.Tasks[0].Resources.CPU = 1000000
+	job.TaskGroups = append(job.TaskGroups, other)
+	job.Canonicalize()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewBatchScheduler, eval))
+
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 2, h.Plans[0].NodeAllocation[node.ID])
+
+	failed := h.Evals[0].FailedTGAllocs
+	must.MapLen(t, 1, failed)
+	must.Eq(t, "", failed["other"].GangUnsatisfiable)
+}
+
+func TestServiceSched_Gang_Timeout(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Gang = &structs.Gang{Timeout: time.Minute}
+	job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job.TaskGroups[0].Tasks[0].Resources.CPU = 8000
+	job.Canonicalize()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+	job, err := h.State.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+
+	eval := &structs.Evaluation{
+		Namespace:      structs.DefaultNamespace,
+		ID:             uuid.Generate(),
+		Priority:       job.Priority,
+		TriggeredBy:    structs.EvalTriggerJobRegister,
+		JobID:          job.ID,
+		JobModifyIndex: job.ModifyIndex,
+		Status:         structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Only one of the two allocations fits, so none is placed and both a
+	// blocked eval and a delayed timeout eval are created
+	must.Len(t, 1, h.Plans)
+	must.MapEmpty(t, h.Plans[0].NodeAllocation)
+	must.NotNil(t, h.Plans[0].Deployment)
+	must.Len(t, 2, h.CreateEvals)
+	must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+
+	timeoutEval := h.CreateEvals[1]
+	must.Eq(t, structs.EvalTriggerGangTimeout, timeoutEval.TriggeredBy)
+	must.Eq(t, job.ModifyIndex, timeoutEval.JobModifyIndex)
+	must.True(t, timeoutEval.WaitUntil.After(time.Now()))
+
+	// Apply the deployment and process the timeout eval, which fails both
+	// the evaluation and the deployment
+	deployment := h.Plans[0].Deployment
+	must.NoError(t, h.State.UpsertDeployment(h.NextIndex(), deployment))
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{timeoutEval}))
+
+	h = tests.NewHarnessWithState(t, h.State)
+	must.NoError(t, h.Process(NewServiceScheduler, timeoutEval))
+
+	h.AssertEvalStatus(t, structs.EvalStatusFailed)
+	must.StrContains(t, h.Evals[0].StatusDescription, "not satisfiable before timeout")
+	must.SliceEmpty(t, h.CreateEvals)
+
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 1, h.Plans[0].DeploymentUpdates)
+	update := h.Plans[0].DeploymentUpdates[0]
+	must.Eq(t, deployment.ID, update.DeploymentID)
+	must.Eq(t, structs.DeploymentStatusFailed, update.Status)
+	must.Eq(t, structs.DeploymentStatusDescriptionGangTimeout, update.StatusDescription)
+}
+
+func TestBatchSched_ScheduleWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+	for range 2 {
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), mock.Node()))
+	}
+
+	// Create a job whose window doesn't open until 2099
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Count = 2
+	job.ScheduleWindow = &structs.ScheduleWindow{
+		Cron:     "0 0 0 1 1 * 2099",
+		Duration: time.Hour,
+	}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	register := func() *structs.Evaluation {
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: structs.EvalTriggerJobRegister,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		must.NoError(t, h.Process(NewBatchScheduler, eval))
+		return eval
+	}
+
+	// The placements are deferred to an eval that waits for the window
+	register()
+	must.SliceEmpty(t, h.Plans)
+	must.Len(t, 1, h.CreateEvals)
+	windowEval := h.CreateEvals[0]
+	must.Eq(t, structs.EvalTriggerScheduleWindow, windowEval.TriggeredBy)
+	must.Eq(t, sstructs.DescScheduleWindowFollowupEval, windowEval.StatusDescription)
+	must.Eq(t, 2099, windowEval.WaitUntil.UTC().Year())
+
+	must.Len(t, 1, h.Evals)
+	must.Eq(t, structs.EvalStatusComplete, h.Evals[0].Status)
+	must.Eq(t, windowEval.ID, h.Evals[0].NextEval)
+	must.Eq(t, 2, h.Evals[0].QueuedAllocations["web"])
+
+	// A later eval reuses the pending window eval
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{windowEval}))
+	register()
+	must.SliceEmpty(t, h.Plans)
+	must.Len(t, 1, h.CreateEvals)
+	must.Eq(t, windowEval.ID, h.Evals[1].NextEval)
+
+	// The allocations are placed once the window is open
+	job = job.Copy()
+	job.ScheduleWindow.Cron = "* * * * * * *"
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+	register()
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 1, h.CreateEvals)
+
+	out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	must.Len(t, 2, out)
+}
+
+func TestBatchSched_JobDependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+	for range 2 {
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), mock.Node()))
+	}
+
+	// Create an upstream job with a running allocation
+	upstream := mock.Job()
+	upstream.Type = structs.JobTypeBatch
+	upstream.TaskGroups[0].Count = 1
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, upstream))
+	upstreamAlloc := mock.MinAllocForJob(upstream)
+	upstreamAlloc.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.Allocation{upstreamAlloc}))
+
+	// Create a job that depends on the upstream job completing
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].ReschedulePolicy = &structs.ReschedulePolicy{
+		Attempts:      1,
+		Interval:      time.Hour,
+		DelayFunction: "constant",
+	}
+	job.DependsOn = []*structs.JobDependency{
+		{JobID: upstream.ID, Status: structs.JobDependencyStatusComplete},
+	}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	process := func(trigger string) {
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: trigger,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		must.NoError(t, h.Process(NewBatchScheduler, eval))
+	}
+
+	// The eval is held until the upstream job completes
+	process(structs.EvalTriggerJobRegister)
+	must.SliceEmpty(t, h.Plans)
+	must.Len(t, 1, h.Evals)
+	must.Eq(t, structs.EvalStatusDependencyBlocked, h.Evals[0].Status)
+	must.StrContains(t, h.Evals[0].StatusDescription, "currently running")
+	must.Eq(t, 2, h.Evals[0].QueuedAllocations["web"])
+
+	// The allocations are placed once the upstream job completed
+	upstreamAlloc = upstreamAlloc.Copy()
+	upstreamAlloc.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, h.State.UpdateAllocsFromClient(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.Allocation{upstreamAlloc}))
+	upstreamEval := mock.Eval()
+	upstreamEval.JobID = upstream.ID
+	upstreamEval.Status = structs.EvalStatusComplete
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.Evaluation{upstreamEval}))
+
+	process(structs.EvalTriggerJobDependency)
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 2, h.Evals)
+	must.Eq(t, structs.EvalStatusComplete, h.Evals[1].Status)
+
+	out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	must.Len(t, 2, out)
+
+	// Replacements aren't held back by the dependencies once the job runs
+	upstream = upstream.Copy()
+	upstream.Stop = true
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, upstream))
+	failed := out[0].Copy()
+	failed.ClientStatus = structs.AllocClientStatusFailed
+	must.NoError(t, h.State.UpdateAllocsFromClient(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.Allocation{failed}))
+
+	process(structs.EvalTriggerRetryFailedAlloc)
+	must.Len(t, 2, h.Plans)
+	must.Len(t, 3, h.Evals)
+	must.Eq(t, structs.EvalStatusComplete, h.Evals[2].Status)
+}
+
+func TestWorkflowSched_Steps(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+	for range 2 {
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), mock.Node()))
+	}
+
+	// Create a workflow job whose "load" step runs after the "web" step
+	job := mock.Job()
+	job.Type = structs.JobTypeWorkflow
+	job.TaskGroups[0].Count = 2
+	load := job.TaskGroups[0].Copy()
+	load.Name = "load"
+	load.Count = 1
+	load.After = []string{"web"}
+	job.TaskGroups = append(job.TaskGroups, load)
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	process := func(trigger string) {
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: trigger,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		must.NoError(t, h.Process(NewWorkflowScheduler, eval))
+	}
+	allocsByGroup := func() map[string][]*structs.Allocation {
+		out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+		must.NoError(t, err)
+		groups := make(map[string][]*structs.Allocation)
+		for _, alloc := range out {
+			groups[alloc.TaskGroup] = append(groups[alloc.TaskGroup], alloc)
+		}
+		return groups
+	}
+
+	// Only the first step is placed
+	process(structs.EvalTriggerJobRegister)
+	must.Len(t, 1, h.Plans)
+	must.Eq(t, structs.EvalStatusComplete, h.Evals[0].Status)
+	must.NotNil(t, h.Evals[0].PlanAnnotations)
+	desired := h.Evals[0].PlanAnnotations.DesiredTGUpdates
+	must.Eq(t, 2, desired["web"].Place)
+	must.Eq(t, 0, desired["load"].Place)
+	groups := allocsByGroup()
+	must.Len(t, 2, groups["web"])
+	must.SliceEmpty(t, groups["load"])
+
+	// The second step is still held while the first step runs
+	running := groups["web"][0].Copy()
+	running.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, h.State.UpdateAllocsFromClient(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.Allocation{running}))
+	process(structs.EvalTriggerWorkflowStep)
+	must.SliceEmpty(t, allocsByGroup()["load"])
+
+	// The second step is placed once every allocation of the first step
+	// completed
+	complete := groups["web"][1].Copy()
+	complete.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, h.State.UpdateAllocsFromClient(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.Allocation{complete}))
+	process(structs.EvalTriggerWorkflowStep)
+	groups = allocsByGroup()
+	must.Len(t, 2, groups["web"])
+	must.Len(t, 1, groups["load"])
+}
+
 func TestBatchSched_Run_CompleteAlloc(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5527,6 +6173,105 @@ func TestBatchSched_Run_FailedAlloc(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestBatchSched_IndexedBatch(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create an indexed batch job with 4 completions, 2 at a time, and a
+	// single retry per index
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Count = 4
+	job.TaskGroups[0].ReschedulePolicy.Attempts = 1
+	job.TaskGroups[0].IndexedBatch = &structs.IndexedBatch{
+		Completions:          4,
+		Parallelism:          2,
+		BackoffLimitPerIndex: 1,
+	}
+	job.Canonicalize()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+	job, _ = h.State.JobByID(nil, job.Namespace, job.ID)
+
+	process := func(trigger string) []*structs.Allocation {
+		t.Helper()
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: trigger,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		plans := len(h.Plans)
+		must.NoError(t, h.Process(NewBatchScheduler, eval))
+		if len(h.Plans) == plans {
+			return nil
+		}
+		return h.Plans[len(h.Plans)-1].NodeAllocation[node.ID]
+	}
+	indexes := func(allocs []*structs.Allocation) []uint {
+		var out []uint
+		for _, alloc := range allocs {
+			if alloc.ClientStatus == structs.AllocClientStatusPending {
+				out = append(out, alloc.Index())
+			}
+		}
+		slices.Sort(out)
+		return out
+	}
+	finish := func(alloc *structs.Allocation, clientStatus string) {
+		t.Helper()
+		alloc, err := h.State.AllocByID(nil, alloc.ID)
+		must.NoError(t, err)
+		alloc = alloc.Copy()
+		alloc.ClientStatus = clientStatus
+		alloc.TaskStates = map[string]*structs.TaskState{"web": {
+			State:      structs.TaskStateDead,
+			Failed:     clientStatus == structs.AllocClientStatusFailed,
+			StartedAt:  time.Now().Add(-time.Hour),
+			FinishedAt: time.Now().Add(-time.Minute),
+		}}
+		must.NoError(t, h.State.UpdateAllocsFromClient(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+	}
+
+	// Only the first two indexes are placed
+	placed := process(structs.EvalTriggerJobRegister)
+	must.Eq(t, []uint{0, 1}, indexes(placed))
+	first := map[uint]*structs.Allocation{placed[0].Index(): placed[0], placed[1].Index(): placed[1]}
+
+	// Once index 0 completes and index 1 fails, index 1 is rescheduled and
+	// index 2 placed
+	finish(first[0], structs.AllocClientStatusComplete)
+	finish(first[1], structs.AllocClientStatusFailed)
+	placed = process(structs.EvalTriggerIndexedBatch)
+	must.Eq(t, []uint{1, 2}, indexes(placed))
+
+	// Once the replacement of index 1 fails too, it has reached its backoff
+	// limit and only index 3 is placed
+	for _, alloc := range placed {
+		if alloc.Index() == 1 {
+			must.NotNil(t, alloc.RescheduleTracker)
+			finish(alloc, structs.AllocClientStatusFailed)
+		}
+	}
+	placed = process(structs.EvalTriggerIndexedBatch)
+	must.Eq(t, []uint{3}, indexes(placed))
+
+	allocs, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	statuses := job.IndexedBatchStatuses(job.TaskGroups[0], allocs)
+	must.Eq(t, structs.IndexedBatchIndexComplete, statuses[0].Status)
+	must.Eq(t, structs.IndexedBatchIndexFailed, statuses[1].Status)
+	must.Eq(t, 2, statuses[1].Failures)
+	must.Eq(t, structs.IndexedBatchIndexRunning, statuses[2].Status)
+	must.Eq(t, structs.IndexedBatchIndexRunning, statuses[3].Status)
+}
+
 func TestBatchSched_Run_LostAlloc(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6965,6 +7710,91 @@ func TestServiceSched_Preemption(t *testing.T) {
 	must.Eq(t, expectedPreemptedAllocs, actualPreemptedAllocs)
 }
 
+// TestServiceSched_Preemption_Reservation asserts that preemption does not
+// free capacity held by a reservation for another job
+func TestServiceSched_Preemption_Reservation(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		cpu    int
+		placed bool
+	}{
+		{name: "fits around reservation", cpu: 400, placed: true},
+		{name: "needs reserved capacity", cpu: 600, placed: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := tests.NewHarness(t)
+
+			// Create a node with 950 MHz of cpu free and hold 500 MHz of it for
+			// the consumers of a reservation
+			legacyCpuResources, processorResources := tests.CpuResources(1000)
+			node := mock.Node()
+			node.NodeResources.Processors = processorResources
+			node.NodeResources.Cpu = legacyCpuResources
+			node.ReservedResources.Cpu.CpuShares = 50
+			must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+			res := mock.Reservation()
+			res.Consumers.Jobs = []string{"consumer"}
+			res.ExpiresAt = time.Now().Add(time.Hour)
+			res.Placements = map[string]*structs.ReservationPlacement{
+				node.ID: {CPU: 500},
+			}
+			must.NoError(t, h.State.UpsertReservations(structs.MsgTypeTestSetup, h.NextIndex(),
+				[]*structs.Reservation{res}))
+
+			register := func(priority, cpu int) *structs.Job {
+				job := mock.Job()
+				job.Priority = priority
+				job.TaskGroups[0].Count = 1
+				job.TaskGroups[0].Networks = nil
+				job.TaskGroups[0].Tasks[0].Resources.CPU = cpu
+				job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 64
+				must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+				eval := &structs.Evaluation{
+					Namespace:   structs.DefaultNamespace,
+					ID:          uuid.Generate(),
+					Priority:    job.Priority,
+					TriggeredBy: structs.EvalTriggerJobRegister,
+					JobID:       job.ID,
+					Status:      structs.EvalStatusPending,
+				}
+				must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+				must.NoError(t, h.Process(NewServiceScheduler, eval))
+				return job
+			}
+
+			// A low priority job uses 400 MHz, leaving only 50 MHz free around
+			// the reserved capacity
+			register(30, 400)
+			must.Len(t, 1, h.Plans)
+			must.Len(t, 1, h.Plans[0].NodeAllocation[node.ID])
+			low := h.Plans[0].NodeAllocation[node.ID][0]
+
+			// A high priority job can only preempt the low priority allocation,
+			// never the capacity held by the reservation
+			job := register(100, tc.cpu)
+
+			out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+			must.NoError(t, err)
+			if !tc.placed {
+				must.Len(t, 0, out)
+				metrics := h.Evals[1].FailedTGAllocs[job.TaskGroups[0].Name]
+				must.NotNil(t, metrics)
+				must.Eq(t, 1, metrics.DimensionExhausted["cpu"])
+				return
+			}
+
+			must.Len(t, 1, out)
+			must.Eq(t, []string{low.ID}, out[0].PreemptedAllocations)
+			must.MapEmpty(t, h.Evals[1].FailedTGAllocs)
+		})
+	}
+}
+
 // TestServiceSched_Migrate_NonCanary asserts that when rescheduling
 // non-canary allocations, a single allocation is migrated
 func TestServiceSched_Migrate_NonCanary(t *testing.T) {
@@ -7035,6 +7865,98 @@ func TestServiceSched_Migrate_NonCanary(t *testing.T) {
 
 }
 
+// TestServiceSched_Migrate_RebalanceTarget asserts that an allocation migrated
+// by the rebalancer is placed on the target node it computed, unless that node
+// can no longer receive it.
+func TestServiceSched_Migrate_RebalanceTarget(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name         string
+		targetReady  bool
+		sticky       bool
+		expectTarget bool
+	}{
+		{name: "target ready", targetReady: true, expectTarget: true},
+		{name: "target ineligible", targetReady: false, expectTarget: false},
+		{name: "sticky disk", targetReady: true, sticky: true, expectTarget: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := tests.NewHarness(t)
+
+			source, busy, target := mock.Node(), mock.Node(), mock.Node()
+			if !tc.targetReady {
+				target.SchedulingEligibility = structs.NodeSchedulingIneligible
+			}
+			for _, node := range []*structs.Node{source, busy, target} {
+				must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+			}
+
+			// Load the busy node so bin packing alone would choose it.
+			filler := mock.Job()
+			filler.Type = structs.JobTypeBatch
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, filler))
+			var allocs []*structs.Allocation
+			for i := range 4 {
+				alloc := mock.AllocForNode(busy)
+				alloc.Job = filler
+				alloc.JobID = filler.ID
+				alloc.Name = fmt.Sprintf("filler.web[%d]", i)
+				alloc.ClientStatus = structs.AllocClientStatusRunning
+				allocs = append(allocs, alloc)
+			}
+
+			job := mock.Job()
+			job.TaskGroups[0].Count = 1
+			job.TaskGroups[0].EphemeralDisk.Sticky = tc.sticky
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			alloc := mock.AllocForNode(source)
+			alloc.Job = job
+			alloc.JobID = job.ID
+			alloc.Name = "my-job.web[0]"
+			alloc.ClientStatus = structs.AllocClientStatusRunning
+			alloc.DesiredTransition = structs.DesiredTransition{
+				Migrate:               pointer.Of(true),
+				Rebalance:             pointer.Of(true),
+				RebalanceTargetNodeID: target.ID,
+			}
+			allocs = append(allocs, alloc)
+			must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+			eval := &structs.Evaluation{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    50,
+				TriggeredBy: structs.EvalTriggerRebalance,
+				JobID:       job.ID,
+				Status:      structs.EvalStatusPending,
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+			must.NoError(t, h.Process(NewServiceScheduler, eval))
+			must.Len(t, 1, h.Plans)
+			plan := h.Plans[0]
+
+			var placed []*structs.Allocation
+			for _, allocs := range plan.NodeAllocation {
+				placed = append(placed, allocs...)
+			}
+			must.Len(t, 1, placed)
+			if tc.expectTarget {
+				must.Eq(t, target.ID, placed[0].NodeID)
+			} else {
+				must.NotEq(t, target.ID, placed[0].NodeID)
+			}
+			if tc.sticky {
+				must.Eq(t, source.ID, placed[0].NodeID)
+			}
+		})
+	}
+}
+
 // TestServiceSched_Migrate_CanaryStatus asserts that migrations/rescheduling
 // of allocations use the proper versions of allocs rather than latest:
 // Canaries should be replaced by canaries, and non-canaries should be replaced
@@ -7873,3 +8795,58 @@ func initNodeAndAllocs(t *testing.T, h *tests.Harness, job *structs.Job,
 	return node, job, allocs
 
 }
+
+func TestServiceSched_JobRegister_QuotaExhausted(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+
+	// Create a quota that only fits four of the job's allocations.
+	spec := mock.QuotaSpec()
+	spec.Limits[0].RegionLimit.CPU = 2000
+	must.NoError(t, h.State.UpsertQuotaSpecs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, h.State.UpsertNamespaces(h.NextIndex(), []*structs.Namespace{ns}))
+
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	job := mock.Job()
+	job.Namespace = ns.Name
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   ns.Name,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Only the allocations that fit in the quota are placed.
+	must.Len(t, 1, h.Plans)
+	var placed int
+	for _, allocs := range h.Plans[0].NodeAllocation {
+		placed += len(allocs)
+	}
+	must.Eq(t, 4, placed)
+
+	// The rest wait on a blocked eval for the quota.
+	must.Len(t, 1, h.CreateEvals)
+	blocked := h.CreateEvals[0]
+	must.Eq(t, structs.EvalStatusBlocked, blocked.Status)
+	must.Eq(t, spec.Name, blocked.QuotaLimitReached)
+
+	must.Len(t, 1, h.Evals)
+	metrics := h.Evals[0].FailedTGAllocs[job.TaskGroups[0].Name]
+	must.NotNil(t, metrics)
+	must.SliceNotEmpty(t, metrics.QuotaExhausted)
+}
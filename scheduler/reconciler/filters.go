@@ -43,7 +43,7 @@ func (set allocSet) filterServerTerminalAllocs() (remaining allocSet) {
 	for id, alloc := range set {
 		// check if the allocation is non-server-terminal or if it is a
 		// batch job allocation that has not been marked for rescheduling.
-		if !alloc.ServerTerminalStatus() || (alloc.Job.Type == structs.JobTypeBatch && !alloc.DesiredTransition.ShouldReschedule()) {
+		if !alloc.ServerTerminalStatus() || ((alloc.Job.Type == structs.JobTypeBatch || alloc.Job.Type == structs.JobTypeWorkflow) && !alloc.DesiredTransition.ShouldReschedule()) {
 			remaining[id] = alloc
 		}
 	}
@@ -1016,7 +1016,7 @@ func (a *AllocReconciler) computeMigrations(migrate allocSet, isCanarying bool,
 		// by the `alloc stop` command). If the allocation should disable
 		// migration placement, then placment should not be done here (used
 		// when draining batch allocations).
-		if alloc.Job.Type == structs.JobTypeBatch && (alloc.DesiredTransition.ShouldReschedule() || alloc.DesiredTransition.ShouldDisableMigrationPlacement()) {
+		if (alloc.Job.Type == structs.JobTypeBatch || alloc.Job.Type == structs.JobTypeWorkflow) && (alloc.DesiredTransition.ShouldReschedule() || alloc.DesiredTransition.ShouldDisableMigrationPlacement()) {
 			continue
 		}
 
@@ -25,6 +25,7 @@ var BuiltinSchedulers = map[string]structs.Factory{
 	"batch":    NewBatchScheduler,
 	"system":   NewSystemScheduler,
 	"sysbatch": NewSysBatchScheduler,
+	"workflow": NewWorkflowScheduler,
 }
 
 // NewScheduler is used to instantiate and return a new scheduler
@@ -6,6 +6,7 @@ package scheduler
 import (
 	"fmt"
 	"runtime/debug"
+	"slices"
 
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
@@ -45,6 +46,14 @@ type SysBatchScheduler struct {
 
 	limitReached bool
 
+	// windowEval is the eval that makes the placements deferred until the
+	// job's schedule window opens
+	windowEval *structs.Evaluation
+
+	// dependencyBlocked is the reason the placements are held until the
+	// jobs the job depends on reach their required status
+	dependencyBlocked string
+
 	failedTGAllocs  map[string]*structs.AllocMetric
 	queuedAllocs    map[string]int
 	planAnnotations *structs.PlanAnnotations
@@ -96,8 +105,15 @@ func (s *SysBatchScheduler) Process(eval *structs.Evaluation) (err error) {
 		return err
 	}
 
+	// Hold the evaluation until the job's dependencies are met
+	if s.dependencyBlocked != "" {
+		return setStatus(s.logger, s.planner, s.eval, nil, nil,
+			s.failedTGAllocs, s.planAnnotations, structs.EvalStatusDependencyBlocked, s.dependencyBlocked,
+			s.queuedAllocs, "")
+	}
+
 	// Update the status to complete
-	return setStatus(s.logger, s.planner, s.eval, nil, nil,
+	return setStatus(s.logger, s.planner, s.eval, s.windowEval, nil,
 		s.failedTGAllocs, s.planAnnotations, structs.EvalStatusComplete, "",
 		s.queuedAllocs, "")
 }
@@ -133,12 +149,15 @@ func (s *SysBatchScheduler) process() (bool, error) {
 
 	// Reset the failed allocations
 	s.failedTGAllocs = nil
+	s.dependencyBlocked = ""
 
 	// Create an evaluation context
 	s.ctx = feasible.NewEvalContext(s.eventsCh, s.state, s.plan, s.logger)
+	s.ctx.SetExplain(s.eval.ExplainPlan)
 
 	// Construct the placement stack
 	s.stack = feasible.NewSystemStack(true, s.ctx)
+	s.stack.SetNodeScorers(s.planner.NodeScorers())
 	if !s.job.Stopped() {
 		s.setJob(s.job)
 	}
@@ -275,6 +294,34 @@ func (s *SysBatchScheduler) computeJobAllocs() error {
 		DesiredTGUpdates: desiredUpdates(r, inplaceUpdates, destructiveUpdates),
 	}
 
+	// Leave the placements and destructive updates queued until the job's
+	// dependencies are met and its schedule window opens
+	if len(r.Place)+len(r.Update) > 0 {
+		met, reason, err := jobDependenciesMet(s.state, s.job)
+		if err != nil {
+			return err
+		}
+		if !met {
+			s.dependencyBlocked = reason
+			for _, tuple := range slices.Concat(r.Place, r.Update) {
+				s.queuedAllocs[tuple.TaskGroup.Name] += 1
+			}
+			return nil
+		}
+
+		windowEval, err := scheduleWindowEval(s.logger, s.state, s.planner, s.eval, s.job, s.windowEval)
+		if err != nil {
+			return err
+		}
+		if windowEval != nil {
+			s.windowEval = windowEval
+			for _, tuple := range slices.Concat(r.Place, r.Update) {
+				s.queuedAllocs[tuple.TaskGroup.Name] += 1
+			}
+			return nil
+		}
+	}
+
 	// Treat non in-place updates as an eviction and new placement, which will
 	// be limited by max_parallel
 	s.limitReached = evictAndPlace(s.ctx, s.job, r, sstructs.StatusAllocUpdating)
@@ -503,6 +550,8 @@ func (s *SysBatchScheduler) canHandle(trigger string) bool {
 	case structs.EvalTriggerQueuedAllocs:
 	case structs.EvalTriggerScaling:
 	case structs.EvalTriggerReconnect:
+	case structs.EvalTriggerScheduleWindow:
+	case structs.EvalTriggerJobDependency:
 	default:
 		return trigger == structs.EvalTriggerPeriodicJob
 	}
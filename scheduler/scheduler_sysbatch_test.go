@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/nomad/ci"
@@ -98,6 +99,55 @@ func TestSysBatch_JobRegister(t *testing.T) {
 	must.Len(t, 0, deployments)
 }
 
+func TestSysBatch_JobRegister_ScheduleWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+	_ = createNodes(t, h, 3)
+
+	// Create a job whose window doesn't open until 2099
+	job := mock.SystemBatchJob()
+	job.ScheduleWindow = &structs.ScheduleWindow{
+		Cron:     "0 0 0 1 1 * 2099",
+		Duration: time.Hour,
+	}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewSysBatchScheduler, eval))
+
+	// The placements are deferred to an eval that waits for the window
+	must.SliceEmpty(t, h.Plans)
+	must.Len(t, 1, h.CreateEvals)
+	windowEval := h.CreateEvals[0]
+	must.Eq(t, structs.EvalTriggerScheduleWindow, windowEval.TriggeredBy)
+	must.Eq(t, 2099, windowEval.WaitUntil.UTC().Year())
+
+	must.Len(t, 1, h.Evals)
+	must.Eq(t, windowEval.ID, h.Evals[0].NextEval)
+	must.Eq(t, 3, h.Evals[0].QueuedAllocations["pinger"])
+
+	// The window eval places the allocations once the window is open
+	job = job.Copy()
+	job.ScheduleWindow.Cron = "* * * * * * *"
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{windowEval}))
+	must.NoError(t, h.Process(NewSysBatchScheduler, windowEval))
+
+	must.Len(t, 1, h.Plans)
+	out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	must.Len(t, 3, out)
+}
+
 func TestSysBatch_JobRegister_AddNode_Running(t *testing.T) {
 	ci.Parallel(t)
 
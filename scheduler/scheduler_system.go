@@ -47,6 +47,10 @@ type SystemScheduler struct {
 	notReadyNodes map[string]struct{}
 	nodesByDC     map[string]int
 
+	// nodeScoped is set when the evaluation only reconciles the allocations
+	// of the node that triggered it, instead of every node of the job
+	nodeScoped bool
+
 	deployment      *structs.Deployment
 	failedTGAllocs  map[string]*structs.AllocMetric
 	queuedAllocs    map[string]int
@@ -128,15 +132,6 @@ func (s *SystemScheduler) process() (bool, error) {
 	}
 	s.queuedAllocs = make(map[string]int, numTaskGroups)
 
-	// Get the ready nodes in the required datacenters
-	if !s.job.Stopped() {
-		s.nodes, s.notReadyNodes, s.nodesByDC, err = readyNodesInDCsAndPool(
-			s.state, s.job.Datacenters, s.job.NodePool)
-		if err != nil {
-			return false, fmt.Errorf("failed to get ready nodes: %v", err)
-		}
-	}
-
 	s.deployment, err = s.state.LatestDeploymentByJobID(ws, s.eval.Namespace, s.eval.JobID)
 	if err != nil {
 		return false, fmt.Errorf("failed to get deployment for job %q: %w", s.eval.JobID, err)
@@ -145,6 +140,12 @@ func (s *SystemScheduler) process() (bool, error) {
 	// count can change between evaluations
 	s.deployment = s.deployment.Copy()
 
+	// Get the ready nodes in the required datacenters
+	s.nodeScoped = s.canScheduleNode()
+	if err := s.setNodes(); err != nil {
+		return false, err
+	}
+
 	// Create a plan
 	s.plan = s.eval.MakePlan(s.job)
 
@@ -153,9 +154,11 @@ func (s *SystemScheduler) process() (bool, error) {
 
 	// Create an evaluation context
 	s.ctx = feasible.NewEvalContext(s.eventsCh, s.state, s.plan, s.logger)
+	s.ctx.SetExplain(s.eval.ExplainPlan)
 
 	// Construct the placement stack
 	s.stack = feasible.NewSystemStack(false, s.ctx)
+	s.stack.SetNodeScorers(s.planner.NodeScorers())
 	if !s.job.Stopped() {
 		s.setJob(s.job)
 	}
@@ -224,12 +227,64 @@ func (s *SystemScheduler) setJob(job *structs.Job) error {
 	return nil
 }
 
+// canScheduleNode returns true if the evaluation can only reconcile the
+// allocations of the node that triggered it. A node event cannot change the
+// allocations the job wants on other nodes, so this avoids scanning every node
+// of large clusters on each node update. Changes to the job, and evaluations
+// for jobs with an active deployment, reconcile every node.
+func (s *SystemScheduler) canScheduleNode() bool {
+	if s.eval.NodeID == "" || s.job.Stopped() {
+		return false
+	}
+	switch s.eval.TriggeredBy {
+	case structs.EvalTriggerNodeUpdate, structs.EvalTriggerQueuedAllocs:
+	default:
+		return false
+	}
+	return s.deployment == nil || !s.deployment.Active()
+}
+
+// setNodes looks up the ready and not ready nodes the evaluation reconciles.
+func (s *SystemScheduler) setNodes() error {
+	var err error
+	switch {
+	case s.job.Stopped():
+		return nil
+	case s.nodeScoped:
+		s.nodes, s.notReadyNodes, s.nodesByDC, err = readyNodeInDCsAndPool(
+			s.state, s.job.Datacenters, s.job.NodePool, s.eval.NodeID)
+	default:
+		s.nodes, s.notReadyNodes, s.nodesByDC, err = readyNodesInDCsAndPool(
+			s.state, s.job.Datacenters, s.job.NodePool)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get ready nodes: %v", err)
+	}
+	return nil
+}
+
+// jobAllocs returns the allocations of the job on the nodes the evaluation
+// reconciles.
+func (s *SystemScheduler) jobAllocs() ([]*structs.Allocation, error) {
+	ws := memdb.NewWatchSet()
+	if !s.nodeScoped {
+		return s.state.AllocsByJob(ws, s.eval.Namespace, s.eval.JobID, true)
+	}
+
+	allocs, err := s.state.AllocsByNode(ws, s.eval.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(allocs, func(alloc *structs.Allocation) bool {
+		return alloc.Namespace != s.eval.Namespace || alloc.JobID != s.eval.JobID
+	}), nil
+}
+
 // computeJobAllocs is used to reconcile differences between the job,
 // existing allocations and node status to update the allocations.
 func (s *SystemScheduler) computeJobAllocs() error {
 	// Lookup the allocations by JobID
-	ws := memdb.NewWatchSet()
-	allocs, err := s.state.AllocsByJob(ws, s.eval.Namespace, s.eval.JobID, true)
+	allocs, err := s.jobAllocs()
 	if err != nil {
 		return fmt.Errorf("failed to get allocs for job '%s': %v", s.eval.JobID, err)
 	}
@@ -240,10 +295,6 @@ func (s *SystemScheduler) computeJobAllocs() error {
 		return fmt.Errorf("failed to get tainted nodes for job '%s': %v", s.eval.JobID, err)
 	}
 
-	// Update the allocations which are in pending/running state on tainted
-	// nodes to lost.
-	updateNonTerminalAllocsToLost(s.plan, tainted, allocs)
-
 	// Split out terminal allocations
 	live, term := structs.SplitTerminalAllocs(allocs)
 
@@ -251,10 +302,27 @@ func (s *SystemScheduler) computeJobAllocs() error {
 	nr := reconciler.NewNodeReconciler(s.deployment)
 	reconciliationResult := nr.Compute(s.job, s.nodes, s.notReadyNodes, tainted,
 		live, term)
+
+	// A deployment tracks the allocations of every node, so if the node
+	// requires a new deployment fall back to reconciling every node.
+	if s.nodeScoped && nr.DeploymentCurrent != nil && nr.DeploymentCurrent.Active() {
+		s.logger.Debug("node requires a deployment, reconciling all nodes")
+		s.nodeScoped = false
+		if err := s.setNodes(); err != nil {
+			return err
+		}
+		return s.computeJobAllocs()
+	}
+
 	if s.logger.IsDebug() {
-		s.logger.Debug("reconciled current state with desired state", reconciliationResult.Fields()...)
+		s.logger.Debug("reconciled current state with desired state",
+			append(reconciliationResult.Fields(), "node_scoped", s.nodeScoped)...)
 	}
 
+	// Update the allocations which are in pending/running state on tainted
+	// nodes to lost.
+	updateNonTerminalAllocsToLost(s.plan, tainted, allocs)
+
 	// Update the stored deployment
 	if nr.DeploymentCurrent != nil {
 		s.deployment = nr.DeploymentCurrent
@@ -371,8 +439,10 @@ func (s *SystemScheduler) computeJobAllocs() error {
 		s.planAnnotations.DesiredTGUpdates[tgName].Place = uint64(s.tgCandidateNodeCounts[tgName])
 	}
 
-	// if there is no deployment we're done at this point
-	if s.deployment == nil {
+	// if there is no deployment we're done at this point. Node scoped
+	// evaluations only see the allocations of a single node, so they leave
+	// the counts of inactive deployments untouched.
+	if s.deployment == nil || s.nodeScoped {
 		return nil
 	}
 
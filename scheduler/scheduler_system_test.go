@@ -485,6 +485,65 @@ func TestSystemSched_JobRegister_AddNode(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestSystemSched_NodeUpdate_NodeScoped(t *testing.T) {
+	ci.Parallel(t)
+
+	h := tests.NewHarness(t)
+
+	// Create some nodes
+	nodes := createNodes(t, h, 10)
+
+	// Generate a fake job with allocations on all but the first node
+	job := mock.SystemJob()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	var allocs []*structs.Allocation
+	for _, node := range nodes[1:] {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = "my-job.web[0]"
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Add a new node.
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	process := func(nodeID string) *structs.Plan {
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    50,
+			TriggeredBy: structs.EvalTriggerNodeUpdate,
+			JobID:       job.ID,
+			NodeID:      nodeID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		must.NoError(t, h.Process(NewSystemScheduler, eval))
+		return h.Plans[len(h.Plans)-1]
+	}
+
+	// The evaluation for the new node only places on the new node.
+	plan := process(node.ID)
+	must.MapLen(t, 1, plan.NodeAllocation)
+	must.Len(t, 1, plan.NodeAllocation[node.ID])
+	must.MapEmpty(t, plan.NodeUpdate)
+
+	// An evaluation for the whole job places on the remaining node.
+	plan = process("")
+	must.MapLen(t, 1, plan.NodeAllocation)
+	must.Len(t, 1, plan.NodeAllocation[nodes[0].ID])
+	must.MapEmpty(t, plan.NodeUpdate)
+
+	for _, eval := range h.Evals {
+		must.Eq(t, structs.EvalStatusComplete, eval.Status)
+	}
+}
+
 func TestSystemSched_JobRegister_AllocFail(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,8 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package simulation runs the schedulers offline against a copy of the
+// cluster state to answer "what-if" questions: what would happen if a set of
+// jobs were registered or stopped and a set of nodes were drained. Plans are
+// applied to the in-memory state store only and never reach raft.
+package simulation
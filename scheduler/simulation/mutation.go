@@ -0,0 +1,219 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package simulation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// MutationJobRegister registers a new job or a new version of an
+	// existing job.
+	MutationJobRegister = "job-register"
+
+	// MutationJobStop stops a running job without purging it.
+	MutationJobStop = "job-stop"
+
+	// MutationNodeDrain starts draining a node, marking it ineligible and
+	// migrating its allocations.
+	MutationNodeDrain = "node-drain"
+)
+
+// Mutation is a single change applied to the simulated state before the
+// schedulers are run. Mutations are applied in the order they are given to
+// the Simulator.
+type Mutation struct {
+	// Type is one of the Mutation* constants.
+	Type string
+
+	// Job is the job to register for MutationJobRegister.
+	Job *structs.Job `json:",omitempty"`
+
+	// Namespace and JobID identify the job for MutationJobStop.
+	Namespace string `json:",omitempty"`
+	JobID     string `json:",omitempty"`
+
+	// NodeID identifies the node for MutationNodeDrain. A unique prefix of
+	// the ID is accepted.
+	NodeID string `json:",omitempty"`
+}
+
+// String returns a human readable description of the mutation, used in error
+// messages and in the report.
+func (m *Mutation) String() string {
+	switch m.Type {
+	case MutationJobRegister:
+		if m.Job == nil {
+			return m.Type
+		}
+		return fmt.Sprintf("%s %s/%s", m.Type, m.Job.Namespace, m.Job.ID)
+	case MutationJobStop:
+		return fmt.Sprintf("%s %s/%s", m.Type, m.Namespace, m.JobID)
+	case MutationNodeDrain:
+		return fmt.Sprintf("%s %s", m.Type, m.NodeID)
+	default:
+		return m.Type
+	}
+}
+
+// apply writes the mutation into the simulated state and returns the
+// evaluations the servers would have created in response to it.
+func (m *Mutation) apply(s *Simulator) ([]*structs.Evaluation, error) {
+	switch m.Type {
+	case MutationJobRegister:
+		return s.registerJob(m.Job)
+	case MutationJobStop:
+		return s.stopJob(m.Namespace, m.JobID)
+	case MutationNodeDrain:
+		return s.drainNode(m.NodeID)
+	default:
+		return nil, fmt.Errorf("unknown mutation type %q", m.Type)
+	}
+}
+
+func (s *Simulator) registerJob(job *structs.Job) ([]*structs.Evaluation, error) {
+	if job == nil {
+		return nil, fmt.Errorf("missing job")
+	}
+	job = job.Copy()
+	job.Canonicalize()
+	if err := job.Validate(); err != nil {
+		return nil, fmt.Errorf("job %q is invalid: %w", job.ID, err)
+	}
+
+	index := s.planner.NextIndex()
+	if err := s.state.UpsertJob(structs.MsgTypeTestSetup, index, nil, job); err != nil {
+		return nil, err
+	}
+
+	// Parent periodic and parameterized jobs are never scheduled directly.
+	if job.IsPeriodic() || job.IsParameterized() {
+		return nil, nil
+	}
+
+	return []*structs.Evaluation{s.newEval(job, structs.EvalTriggerJobRegister)}, nil
+}
+
+func (s *Simulator) stopJob(namespace, jobID string) ([]*structs.Evaluation, error) {
+	if namespace == "" {
+		namespace = structs.DefaultNamespace
+	}
+	existing, err := s.state.JobByID(nil, namespace, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("job %q not found in namespace %q", jobID, namespace)
+	}
+
+	job := existing.Copy()
+	job.Stop = true
+
+	index := s.planner.NextIndex()
+	if err := s.state.UpsertJob(structs.MsgTypeTestSetup, index, nil, job); err != nil {
+		return nil, err
+	}
+	return []*structs.Evaluation{s.newEval(job, structs.EvalTriggerJobDeregister)}, nil
+}
+
+func (s *Simulator) drainNode(prefix string) ([]*structs.Evaluation, error) {
+	node, err := s.nodeByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	drain := &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline:         -1 * time.Second,
+			IgnoreSystemJobs: true,
+		},
+		ForceDeadline: now,
+		StartedAt:     now,
+	}
+	index := s.planner.NextIndex()
+	if err := s.state.UpdateNodeDrain(structs.MsgTypeTestSetup, index, node.ID,
+		drain, false, now.Unix(), nil, nil, ""); err != nil {
+		return nil, err
+	}
+
+	allocs, err := s.state.AllocsByNode(nil, node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mirror a forced node drain: every non-terminal allocation is marked for
+	// migration at once, batch allocations are stopped without being
+	// replaced, and one evaluation is created per affected job. System jobs
+	// are ignored, the same as "nomad node drain -ignore-system".
+	jobs := map[structs.NamespacedID]*structs.Job{}
+	transitions := map[string]*structs.DesiredTransition{}
+	for _, alloc := range allocs {
+		if alloc.TerminalStatus() || alloc.Job == nil {
+			continue
+		}
+		if alloc.Job.Type == structs.JobTypeSystem || alloc.Job.Type == structs.JobTypeSysBatch {
+			continue
+		}
+		transitions[alloc.ID] = &structs.DesiredTransition{Migrate: pointer.Of(true)}
+		if alloc.Job.Type == structs.JobTypeBatch {
+			transitions[alloc.ID].MigrateDisablePlacement = pointer.Of(true)
+		}
+		jobs[alloc.JobNamespacedID()] = alloc.Job
+	}
+	if len(transitions) == 0 {
+		return nil, nil
+	}
+
+	index = s.planner.NextIndex()
+	evals := make([]*structs.Evaluation, 0, len(jobs))
+	for _, job := range jobs {
+		evals = append(evals, s.newEval(job, structs.EvalTriggerNodeDrain))
+	}
+	if err := s.state.UpdateAllocsDesiredTransitions(
+		structs.MsgTypeTestSetup, index, transitions, nil); err != nil {
+		return nil, err
+	}
+	return evals, nil
+}
+
+func (s *Simulator) nodeByPrefix(prefix string) (*structs.Node, error) {
+	iter, err := s.state.NodesByIDPrefix(nil, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var node *structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if node != nil {
+			return nil, fmt.Errorf("node prefix %q matched multiple nodes", prefix)
+		}
+		node = raw.(*structs.Node)
+	}
+	if node == nil {
+		return nil, fmt.Errorf("no node matches prefix %q", prefix)
+	}
+	return node, nil
+}
+
+func (s *Simulator) newEval(job *structs.Job, triggeredBy string) *structs.Evaluation {
+	now := time.Now().UTC().UnixNano()
+	return &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    triggeredBy,
+		JobID:          job.ID,
+		JobModifyIndex: job.JobModifyIndex,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+}
@@ -0,0 +1,405 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package simulation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+	sstructs "github.com/hashicorp/nomad/scheduler/structs"
+)
+
+// DefaultMaxEvals bounds the number of evaluations a simulation processes
+// before giving up on convergence.
+const DefaultMaxEvals = 10_000
+
+// Simulator applies mutations to a private copy of the cluster state and runs
+// the schedulers against it until no more work is left. The state store is
+// modified in place, so callers must not share it with a live server.
+type Simulator struct {
+	logger log.Logger
+	state  *state.StateStore
+
+	// planner applies plans directly to the state store, without the
+	// verification done by the plan applier on the leader.
+	planner *sstructs.PlanBuilder
+
+	// baseIndex is the latest index of the state before the simulation
+	// started. Anything written above it was written by the simulation.
+	baseIndex uint64
+
+	// MaxEvals is the maximum number of evaluations processed by Run.
+	MaxEvals int
+
+	queue     []*structs.Evaluation
+	blocked   map[structs.NamespacedID]*structs.Evaluation
+	failures  map[structs.NamespacedID]*structs.Evaluation
+	processed int
+	deferred  int
+
+	// capacityFreed is set whenever a plan stops or preempts allocations, so
+	// blocked evaluations are worth retrying.
+	capacityFreed bool
+}
+
+// NewSimulator returns a Simulator that mutates the given state store.
+func NewSimulator(logger log.Logger, store *state.StateStore) (*Simulator, error) {
+	index, err := store.LatestIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state index: %w", err)
+	}
+
+	return &Simulator{
+		logger:    logger.Named("simulation"),
+		state:     store,
+		planner:   sstructs.NewPlanWithStateAndIndex(store, index+1, true),
+		baseIndex: index,
+		MaxEvals:  DefaultMaxEvals,
+		blocked:   map[structs.NamespacedID]*structs.Evaluation{},
+		failures:  map[structs.NamespacedID]*structs.Evaluation{},
+	}, nil
+}
+
+// Run applies each mutation in order and runs the schedulers to convergence
+// after each one, the same way the servers would react to the changes being
+// submitted one after the other. It returns a report of the resulting
+// placements, failed placements and preemptions.
+func (s *Simulator) Run(mutations []*Mutation) (*Report, error) {
+	for _, m := range mutations {
+		evals, err := m.apply(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply %s: %w", m, err)
+		}
+		s.queue = append(s.queue, evals...)
+
+		if err := s.converge(); err != nil {
+			return nil, err
+		}
+	}
+	return s.report()
+}
+
+// converge processes evaluations until the queue is empty and no blocked
+// evaluation can make progress anymore.
+func (s *Simulator) converge() error {
+	for {
+		for len(s.queue) > 0 {
+			if s.processed >= s.MaxEvals {
+				return fmt.Errorf("simulation did not converge after %d evaluations", s.processed)
+			}
+			eval := s.queue[0]
+			s.queue = s.queue[1:]
+			if err := s.process(eval); err != nil {
+				return err
+			}
+		}
+
+		// Similar to the blocked evals tracker, retry blocked evaluations
+		// only when capacity has been freed since they were last processed.
+		if !s.capacityFreed || len(s.blocked) == 0 {
+			return nil
+		}
+		s.capacityFreed = false
+		for _, eval := range s.blocked {
+			s.queue = append(s.queue, eval)
+		}
+		clear(s.blocked)
+	}
+}
+
+func (s *Simulator) process(eval *structs.Evaluation) error {
+	s.processed++
+
+	if err := s.state.UpsertEvals(structs.MsgTypeTestSetup, s.planner.NextIndex(),
+		[]*structs.Evaluation{eval}); err != nil {
+		return err
+	}
+
+	snap, err := s.state.Snapshot()
+	if err != nil {
+		return err
+	}
+	sched, err := scheduler.NewScheduler(eval.Type, s.logger, nil, snap, s.planner)
+	if err != nil {
+		return err
+	}
+
+	numPlans := len(s.planner.Plans)
+	numUpdates := len(s.planner.Evals)
+	numCreated := len(s.planner.CreateEvals)
+	numReblocked := len(s.planner.ReblockEvals)
+
+	if err := sched.Process(eval); err != nil {
+		return fmt.Errorf("failed to process evaluation %s for job %q: %w", eval.ID, eval.JobID, err)
+	}
+
+	for _, plan := range s.planner.Plans[numPlans:] {
+		if err := s.applyClientUpdates(plan); err != nil {
+			return err
+		}
+	}
+
+	// The last update of the evaluation holds the final placement failures
+	// for its job, replacing the result of any previous evaluation.
+	key := structs.NewNamespacedID(eval.JobID, eval.Namespace)
+	if updates := s.planner.Evals[numUpdates:]; len(updates) > 0 {
+		s.failures[key] = updates[len(updates)-1]
+	}
+
+	for _, created := range s.planner.CreateEvals[numCreated:] {
+		switch {
+		case created.Status == structs.EvalStatusBlocked:
+			if err := s.state.UpsertEvals(structs.MsgTypeTestSetup, s.planner.NextIndex(),
+				[]*structs.Evaluation{created}); err != nil {
+				return err
+			}
+			s.blocked[structs.NewNamespacedID(created.JobID, created.Namespace)] = created
+		case !created.WaitUntil.IsZero():
+			// Delayed evaluations, such as reschedules, happen outside of
+			// the simulated window.
+			s.deferred++
+		default:
+			s.queue = append(s.queue, created)
+		}
+	}
+	for _, reblocked := range s.planner.ReblockEvals[numReblocked:] {
+		s.blocked[structs.NewNamespacedID(reblocked.JobID, reblocked.Namespace)] = reblocked
+	}
+	return nil
+}
+
+// applyClientUpdates stands in for the clients. Without it, stopped and
+// preempted allocations would keep holding their resources because no client
+// ever reports them as complete.
+func (s *Simulator) applyClientUpdates(plan *structs.Plan) error {
+	var updates []*structs.Allocation
+	for _, stopped := range []map[string][]*structs.Allocation{plan.NodeUpdate, plan.NodePreemptions} {
+		for _, nodeAllocs := range stopped {
+			for _, alloc := range nodeAllocs {
+				updates = append(updates, &structs.Allocation{
+					ID:           alloc.ID,
+					NodeID:       alloc.NodeID,
+					ClientStatus: structs.AllocClientStatusComplete,
+				})
+			}
+		}
+	}
+	for _, nodeAllocs := range plan.NodeAllocation {
+		for _, alloc := range nodeAllocs {
+			if alloc.ClientStatus != structs.AllocClientStatusPending {
+				continue
+			}
+			updates = append(updates, &structs.Allocation{
+				ID:           alloc.ID,
+				NodeID:       alloc.NodeID,
+				ClientStatus: structs.AllocClientStatusRunning,
+			})
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if len(plan.NodeUpdate) > 0 || len(plan.NodePreemptions) > 0 {
+		s.capacityFreed = true
+	}
+	return s.state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, s.planner.NextIndex(), updates)
+}
+
+// Report is the result of a simulation.
+type Report struct {
+	// SnapshotIndex is the raft index of the state the simulation started
+	// from.
+	SnapshotIndex uint64
+
+	// Evaluations is the number of evaluations processed.
+	Evaluations int
+
+	// DeferredEvaluations is the number of delayed evaluations, such as
+	// reschedules, that were created but not processed.
+	DeferredEvaluations int
+
+	Placements       []*Placement
+	Stops            []*Stop
+	FailedPlacements []*FailedPlacement
+	Preemptions      []*Preemption
+}
+
+// Placement is an allocation placed during the simulation.
+type Placement struct {
+	AllocID            string
+	AllocName          string
+	Namespace          string
+	JobID              string
+	TaskGroup          string
+	NodeID             string
+	NodeName           string
+	PreviousAllocation string `json:",omitempty"`
+}
+
+// Stop is a pre-existing allocation stopped during the simulation, for
+// example because its job was stopped or its node drained.
+type Stop struct {
+	AllocID            string
+	AllocName          string
+	Namespace          string
+	JobID              string
+	TaskGroup          string
+	NodeID             string
+	DesiredStatus      string
+	DesiredDescription string
+}
+
+// FailedPlacement describes a task group whose allocations could not all be
+// placed.
+type FailedPlacement struct {
+	Namespace string
+	JobID     string
+	TaskGroup string
+	EvalID    string
+
+	// Blocked is true if the job was left with a blocked evaluation at the
+	// end of the simulation.
+	Blocked bool
+
+	Metrics *structs.AllocMetric
+}
+
+// Preemption is an allocation evicted to make room for a higher priority
+// placement.
+type Preemption struct {
+	AllocID     string
+	AllocName   string
+	Namespace   string
+	JobID       string
+	TaskGroup   string
+	NodeID      string
+	PreemptedBy string
+}
+
+func (s *Simulator) report() (*Report, error) {
+	r := &Report{
+		SnapshotIndex:       s.baseIndex,
+		Evaluations:         s.processed,
+		DeferredEvaluations: s.deferred,
+		Placements:          []*Placement{},
+		Stops:               []*Stop{},
+		FailedPlacements:    []*FailedPlacement{},
+		Preemptions:         []*Preemption{},
+	}
+
+	preempted := map[string]struct{}{}
+	for _, plan := range s.planner.Plans {
+		for _, allocs := range plan.NodePreemptions {
+			for _, alloc := range allocs {
+				preempted[alloc.ID] = struct{}{}
+			}
+		}
+	}
+
+	iter, err := s.state.Allocs(nil, state.SortDefault)
+	if err != nil {
+		return nil, err
+	}
+	nodeNames := map[string]string{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		alloc := raw.(*structs.Allocation)
+		if alloc.ModifyIndex <= s.baseIndex {
+			continue
+		}
+
+		switch {
+		case alloc.CreateIndex > s.baseIndex:
+			if alloc.TerminalStatus() {
+				// Placed and stopped again by a later mutation.
+				continue
+			}
+			name, err := s.nodeName(nodeNames, alloc.NodeID)
+			if err != nil {
+				return nil, err
+			}
+			r.Placements = append(r.Placements, &Placement{
+				AllocID:            alloc.ID,
+				AllocName:          alloc.Name,
+				Namespace:          alloc.Namespace,
+				JobID:              alloc.JobID,
+				TaskGroup:          alloc.TaskGroup,
+				NodeID:             alloc.NodeID,
+				NodeName:           name,
+				PreviousAllocation: alloc.PreviousAllocation,
+			})
+
+		case alloc.DesiredStatus == structs.AllocDesiredStatusRun:
+			continue
+
+		default:
+			if _, ok := preempted[alloc.ID]; ok {
+				r.Preemptions = append(r.Preemptions, &Preemption{
+					AllocID:     alloc.ID,
+					AllocName:   alloc.Name,
+					Namespace:   alloc.Namespace,
+					JobID:       alloc.JobID,
+					TaskGroup:   alloc.TaskGroup,
+					NodeID:      alloc.NodeID,
+					PreemptedBy: alloc.PreemptedByAllocation,
+				})
+				continue
+			}
+			r.Stops = append(r.Stops, &Stop{
+				AllocID:            alloc.ID,
+				AllocName:          alloc.Name,
+				Namespace:          alloc.Namespace,
+				JobID:              alloc.JobID,
+				TaskGroup:          alloc.TaskGroup,
+				NodeID:             alloc.NodeID,
+				DesiredStatus:      alloc.DesiredStatus,
+				DesiredDescription: alloc.DesiredDescription,
+			})
+		}
+	}
+
+	for key, eval := range s.failures {
+		_, blocked := s.blocked[key]
+		for tg, metrics := range eval.FailedTGAllocs {
+			r.FailedPlacements = append(r.FailedPlacements, &FailedPlacement{
+				Namespace: eval.Namespace,
+				JobID:     eval.JobID,
+				TaskGroup: tg,
+				EvalID:    eval.ID,
+				Blocked:   blocked,
+				Metrics:   metrics,
+			})
+		}
+	}
+	slices.SortFunc(r.FailedPlacements, func(a, b *FailedPlacement) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.JobID, b.JobID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.TaskGroup, b.TaskGroup)
+	})
+
+	return r, nil
+}
+
+func (s *Simulator) nodeName(cache map[string]string, nodeID string) (string, error) {
+	if name, ok := cache[nodeID]; ok {
+		return name, nil
+	}
+	node, err := s.state.NodeByID(nil, nodeID)
+	if err != nil {
+		return "", err
+	}
+	if node != nil {
+		cache[nodeID] = node.Name
+	}
+	return cache[nodeID], nil
+}
@@ -0,0 +1,135 @@
+// Copyright IBM Corp. 2015, 2025
+// SPDX-License-Identifier: BUSL-1.1
+
+package simulation
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func testStateWithNodes(t *testing.T, count int) (*state.StateStore, []*structs.Node) {
+	store := state.TestStateStore(t)
+	nodes := make([]*structs.Node, 0, count)
+	for i := range count {
+		node := mock.Node()
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, node)
+	}
+	return store, nodes
+}
+
+func TestSimulator_RegisterAndDrain(t *testing.T) {
+	ci.Parallel(t)
+
+	store, nodes := testStateWithNodes(t, 2)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 4
+
+	sim, err := NewSimulator(testlog.HCLogger(t), store)
+	must.NoError(t, err)
+	report, err := sim.Run([]*Mutation{{Type: MutationJobRegister, Job: job}})
+	must.NoError(t, err)
+	must.Len(t, 4, report.Placements)
+	must.SliceEmpty(t, report.FailedPlacements)
+	must.SliceEmpty(t, report.Stops)
+
+	// Drain the first node in a second simulation over the resulting state
+	// so that the migrated allocations are reported as stops.
+	onFirst := 0
+	for _, p := range report.Placements {
+		if p.NodeID == nodes[0].ID {
+			onFirst++
+		}
+	}
+
+	sim, err = NewSimulator(testlog.HCLogger(t), store)
+	must.NoError(t, err)
+	report, err = sim.Run([]*Mutation{{Type: MutationNodeDrain, NodeID: nodes[0].ID[:8]}})
+	must.NoError(t, err)
+	must.Len(t, onFirst, report.Stops)
+	must.Len(t, onFirst, report.Placements)
+	for _, p := range report.Placements {
+		must.Eq(t, nodes[1].ID, p.NodeID)
+		must.NotEq(t, "", p.PreviousAllocation)
+	}
+
+	node, err := store.NodeByID(nil, nodes[0].ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.NodeSchedulingIneligible, node.SchedulingEligibility)
+}
+
+func TestSimulator_FailedPlacement(t *testing.T) {
+	ci.Parallel(t)
+
+	store, _ := testStateWithNodes(t, 2)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 64 * 1024
+
+	sim, err := NewSimulator(testlog.HCLogger(t), store)
+	must.NoError(t, err)
+	report, err := sim.Run([]*Mutation{{Type: MutationJobRegister, Job: job}})
+	must.NoError(t, err)
+	must.SliceEmpty(t, report.Placements)
+	must.Len(t, 1, report.FailedPlacements)
+
+	failed := report.FailedPlacements[0]
+	must.Eq(t, job.ID, failed.JobID)
+	must.Eq(t, "web", failed.TaskGroup)
+	must.True(t, failed.Blocked)
+	must.Eq(t, 2, failed.Metrics.NodesEvaluated)
+	must.Eq(t, 2, failed.Metrics.DimensionExhausted["memory"])
+}
+
+func TestSimulator_StopUnblocks(t *testing.T) {
+	ci.Parallel(t)
+
+	store, _ := testStateWithNodes(t, 1)
+
+	big := mock.Job()
+	big.TaskGroups[0].Count = 1
+	big.TaskGroups[0].Tasks[0].Resources.MemoryMB = 6 * 1024
+
+	blocked := mock.Job()
+	blocked.TaskGroups[0].Count = 1
+	blocked.TaskGroups[0].Tasks[0].Resources.MemoryMB = 6 * 1024
+
+	sim, err := NewSimulator(testlog.HCLogger(t), store)
+	must.NoError(t, err)
+	report, err := sim.Run([]*Mutation{
+		{Type: MutationJobRegister, Job: big},
+		{Type: MutationJobRegister, Job: blocked},
+		{Type: MutationJobStop, Namespace: big.Namespace, JobID: big.ID},
+	})
+	must.NoError(t, err)
+
+	// The stopped job frees the capacity so the blocked evaluation is
+	// retried and the second job is placed.
+	must.Len(t, 1, report.Placements)
+	must.Eq(t, blocked.ID, report.Placements[0].JobID)
+	must.SliceEmpty(t, report.FailedPlacements)
+}
+
+func TestSimulator_InvalidMutation(t *testing.T) {
+	ci.Parallel(t)
+
+	store, _ := testStateWithNodes(t, 1)
+
+	sim, err := NewSimulator(testlog.HCLogger(t), store)
+	must.NoError(t, err)
+
+	_, err = sim.Run([]*Mutation{{Type: MutationNodeDrain, NodeID: "ffffffff"}})
+	must.ErrorContains(t, err, "no node matches prefix")
+
+	_, err = sim.Run([]*Mutation{{Type: MutationJobStop, JobID: "missing"}})
+	must.ErrorContains(t, err, `job "missing" not found`)
+}
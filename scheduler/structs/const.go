@@ -51,4 +51,13 @@ const (
 	// up evals for allocations that be should be stopped after its disconnect
 	// timeout has passed.
 	DescDisconnectTimeoutFollowupEval = "created for delayed disconnect timeout"
+
+	// DescGangTimeoutFollowupEval is the description used when creating follow
+	// up evals that fail a gang still unsatisfiable after its timeout.
+	DescGangTimeoutFollowupEval = "created for delayed gang timeout"
+
+	// DescScheduleWindowFollowupEval is the description used when creating
+	// follow up evals that place allocations once the job's schedule window
+	// opens.
+	DescScheduleWindowFollowupEval = "pending window"
 )
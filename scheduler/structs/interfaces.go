@@ -52,6 +52,20 @@ type State interface {
 	// NodePoolByName is used to lookup a node by ID.
 	NodePoolByName(ws memdb.WatchSet, poolName string) (*structs.NodePool, error)
 
+	// NamespaceByName is used to lookup a namespace by name.
+	NamespaceByName(ws memdb.WatchSet, name string) (*structs.Namespace, error)
+
+	// QuotaSpecByName is used to lookup a quota specification by name.
+	QuotaSpecByName(ws memdb.WatchSet, name string) (*structs.QuotaSpec, error)
+
+	// QuotaUsageByName is used to lookup the usage of a quota specification
+	// by name.
+	QuotaUsageByName(ws memdb.WatchSet, name string) (*structs.QuotaUsage, error)
+
+	// Reservations returns an iterator over all capacity reservations.
+	// The type of each result is *structs.Reservation
+	Reservations(ws memdb.WatchSet) (memdb.ResultIterator, error)
+
 	// AllocsByJob returns the allocations by JobID
 	AllocsByJob(ws memdb.WatchSet, namespace, jobID string, all bool) ([]*structs.Allocation, error)
 
@@ -76,6 +90,9 @@ type State interface {
 	// JobByIDAndVersion returns the job associated with id and specific version
 	JobByIDAndVersion(ws memdb.WatchSet, namespace, id string, version uint64) (*structs.Job, error)
 
+	// EvalsByJob returns the evaluations of the job
+	EvalsByJob(ws memdb.WatchSet, namespace, jobID string) ([]*structs.Evaluation, error)
+
 	// LatestDeploymentByJobID returns the latest deployment matching the given
 	// job ID
 	LatestDeploymentByJobID(ws memdb.WatchSet, namespace, jobID string) (*structs.Deployment, error)
@@ -130,4 +147,35 @@ type Planner interface {
 	// checkFailedServers parameter specifies whether version for the failed
 	// servers should be verified.
 	ServersMeetMinimumVersion(minVersion *version.Version, checkFailedServers bool) bool
+
+	// NodeScorers returns the external scorers that contribute to the ranking
+	// of candidate nodes, such as scoring plugins loaded by the server.
+	NodeScorers() []NodeScorer
+}
+
+// NodeScorer is an external source of node scores. Scorers are called for
+// every feasible node ranked for a placement, after the built-in scorers and
+// before the scores are normalized.
+type NodeScorer interface {
+	// Name is used to identify the scorer in the allocation metrics.
+	Name() string
+
+	// ScoreNode returns the score of placing the task group on the node,
+	// between -1 and 1, along with a short explanation of the score. A score
+	// of 0 does not count towards the normalized score of the node.
+	ScoreNode(*NodeScoreRequest) (float64, string, error)
+}
+
+// NodeScoreRequest describes a candidate placement given to a NodeScorer.
+type NodeScoreRequest struct {
+	Job       *structs.Job
+	TaskGroup *structs.TaskGroup
+	Node      *structs.Node
+
+	// Scores are the scores given to the node so far in the ranking pipeline.
+	Scores []float64
+
+	// ProposedAllocs is the number of allocations the node would be running
+	// if the placement is made, not counting the placement itself.
+	ProposedAllocs int
 }
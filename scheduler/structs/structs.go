@@ -81,6 +81,9 @@ type PlanBuilder struct {
 	CreateEvals  []*structs.Evaluation
 	ReblockEvals []*structs.Evaluation
 
+	// Scorers are returned by NodeScorers.
+	Scorers []NodeScorer
+
 	nextIndex     uint64
 	nextIndexLock sync.Mutex
 
@@ -237,6 +240,10 @@ func (p *PlanBuilder) ServersMeetMinimumVersion(_ *version.Version, _ bool) bool
 	return p.serversMeetMinimumVersion
 }
 
+func (p *PlanBuilder) NodeScorers() []NodeScorer {
+	return p.Scorers
+}
+
 // NextIndex returns the next index
 func (p *PlanBuilder) NextIndex() uint64 {
 	p.nextIndexLock.Lock()
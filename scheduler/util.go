@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/scheduler/feasible"
 	"github.com/hashicorp/nomad/scheduler/reconciler"
@@ -60,6 +62,37 @@ func readyNodesInDCsAndPool(state sstructs.State, dcs []string, pool string) ([]
 	return out, notReady, dcMap, nil
 }
 
+// readyNodeInDCsAndPool is like readyNodesInDCsAndPool but only considers the
+// node with the given ID, so evaluations scoped to a node don't have to scan
+// the whole node pool.
+func readyNodeInDCsAndPool(state sstructs.State, dcs []string, pool, nodeID string) ([]*structs.Node, map[string]struct{}, map[string]int, error) {
+	dcMap := make(map[string]int)
+	notReady := map[string]struct{}{}
+
+	ws := memdb.NewWatchSet()
+	node, err := state.NodeByID(ws, nodeID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Filter on node pool, datacenter and status
+	if node == nil {
+		return nil, notReady, dcMap, nil
+	}
+	if pool != structs.NodePoolAll && pool != "" && node.NodePool != pool {
+		return nil, notReady, dcMap, nil
+	}
+	if !node.Ready() {
+		notReady[node.ID] = struct{}{}
+		return nil, notReady, dcMap, nil
+	}
+	if !node.IsInAnyDC(dcs) {
+		return nil, notReady, dcMap, nil
+	}
+	dcMap[node.Datacenter]++
+	return []*structs.Node{node}, notReady, dcMap, nil
+}
+
 // retryMax is used to retry a callback until it returns success or
 // a maximum number of attempts is reached. An optional reset function may be
 // passed which is called after each failed iteration. If the reset function is
@@ -313,6 +346,8 @@ func nonNetworkResourcesUpdated(a, b *structs.Resources) comparison {
 		return difference("numa", a.NUMA, b.NUMA)
 	case a.SecretsMB != b.SecretsMB:
 		return difference("task secrets", a.SecretsMB, b.SecretsMB)
+	case !a.CustomResources.Equal(b.CustomResources):
+		return difference("task custom resources", a.CustomResources, b.CustomResources)
 	}
 	return same
 }
@@ -946,6 +981,102 @@ func mergeNodeFiltered(acc, curr *structs.AllocMetric) *structs.AllocMetric {
 	for k, v := range curr.ConstraintFiltered {
 		acc.ConstraintFiltered[k] += v
 	}
+	for id, exp := range curr.NodeExplanations {
+		acc.NodeExplanations[id] = exp.Copy()
+	}
 	acc.AllocationTime += curr.AllocationTime
 	return acc
 }
+
+// scheduleWindowEval returns the evaluation that makes the placements of the
+// job once its schedule window opens, or nil if the job has no schedule window
+// or the window is open. The evaluation is created unless it's the given
+// evaluation, made by an earlier attempt, or one pending in the state store.
+func scheduleWindowEval(logger log.Logger, state sstructs.State, planner sstructs.Planner,
+	eval *structs.Evaluation, job *structs.Job, existing *structs.Evaluation) (*structs.Evaluation, error) {
+
+	if job == nil || job.Stopped() || job.ScheduleWindow == nil {
+		return nil, nil
+	}
+
+	open, opens, err := job.ScheduleWindow.IsOpen(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute schedule window of job %q: %w", job.ID, err)
+	}
+	if open {
+		return nil, nil
+	}
+
+	if existing != nil && existing.WaitUntil.Equal(opens) {
+		return existing, nil
+	}
+	evals, err := state.EvalsByJob(nil, job.Namespace, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get evals for job %q: %w", job.ID, err)
+	}
+	for _, e := range evals {
+		if e.ID != eval.ID && e.JobID == job.ID &&
+			e.TriggeredBy == structs.EvalTriggerScheduleWindow &&
+			e.Status == structs.EvalStatusPending && e.WaitUntil.Equal(opens) {
+			return e, nil
+		}
+	}
+
+	followup := &structs.Evaluation{
+		ID:                uuid.Generate(),
+		Namespace:         job.Namespace,
+		Priority:          eval.Priority,
+		Type:              job.Type,
+		TriggeredBy:       structs.EvalTriggerScheduleWindow,
+		JobID:             job.ID,
+		JobModifyIndex:    job.ModifyIndex,
+		Status:            structs.EvalStatusPending,
+		StatusDescription: sstructs.DescScheduleWindowFollowupEval,
+		WaitUntil:         opens,
+		PreviousEval:      eval.ID,
+	}
+	if err := planner.CreateEval(followup); err != nil {
+		return nil, fmt.Errorf("failed to make schedule window eval: %w", err)
+	}
+	logger.Debug("job outside its schedule window, followup eval created",
+		"followup_eval_id", followup.ID, "wait_until", opens)
+	return followup, nil
+}
+
+// jobDependenciesMet returns whether the jobs the given job depends on reached
+// their required status, along with the reason the job is waiting if they
+// didn't. Dependencies only hold back the first placements of a version of
+// the job, so replacements of its allocations aren't delayed.
+func jobDependenciesMet(state sstructs.State, job *structs.Job) (bool, string, error) {
+	if job == nil || job.Stopped() || len(job.DependsOn) == 0 {
+		return true, "", nil
+	}
+
+	allocs, err := state.AllocsByJob(nil, job.Namespace, job.ID, false)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get allocs for job %q: %w", job.ID, err)
+	}
+	for _, alloc := range allocs {
+		if alloc.Job != nil && alloc.Job.Version == job.Version {
+			return true, "", nil
+		}
+	}
+
+	for _, dep := range job.DependsOn {
+		upstream, err := state.JobByID(nil, job.Namespace, dep.JobID)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to get job %q: %w", dep.JobID, err)
+		}
+		var upstreamAllocs []*structs.Allocation
+		if upstream != nil {
+			upstreamAllocs, err = state.AllocsByJob(nil, job.Namespace, dep.JobID, false)
+			if err != nil {
+				return false, "", fmt.Errorf("failed to get allocs for job %q: %w", dep.JobID, err)
+			}
+		}
+		if ok, reason := dep.Satisfied(upstream, upstreamAllocs); !ok {
+			return false, reason, nil
+		}
+	}
+	return true, "", nil
+}